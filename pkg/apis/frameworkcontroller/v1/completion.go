@@ -23,11 +23,13 @@
 package v1
 
 import (
+	"encoding/json"
 	"fmt"
 	"github.com/microsoft/frameworkcontroller/pkg/common"
 	core "k8s.io/api/core/v1"
 	"reflect"
 	"regexp"
+	"strings"
 	"time"
 )
 
@@ -63,17 +65,37 @@ const (
 
 	// [-999, -1]: Predefined Framework Error
 	// -1XX: Transient Error
-	CompletionCodeConfigMapExternalDeleted CompletionCode = -100
-	CompletionCodePodExternalDeleted       CompletionCode = -101
-	CompletionCodeConfigMapCreationTimeout CompletionCode = -110
-	CompletionCodePodCreationTimeout       CompletionCode = -111
+	CompletionCodeConfigMapExternalDeleted   CompletionCode = -100
+	CompletionCodePodExternalDeleted         CompletionCode = -101
+	CompletionCodePodVolcanoEvicted          CompletionCode = -102
+	CompletionCodePodNodeNotReady            CompletionCode = -103
+	CompletionCodePodSpotInterrupted         CompletionCode = -104
+	CompletionCodePodHiveDPreempted          CompletionCode = -105
+	CompletionCodeConfigMapCreationTimeout   CompletionCode = -110
+	CompletionCodePodCreationTimeout         CompletionCode = -111
+	CompletionCodeFrameworkPreempted         CompletionCode = -120
+	CompletionCodeFrameworkKueueEvicted      CompletionCode = -121
+	CompletionCodeFrameworkSchedulingTimeout CompletionCode = -122
+	CompletionCodeMinTaskNumberUnsatisfied   CompletionCode = -123
+	CompletionCodeTaskAttemptTimeExceeded    CompletionCode = -130
+	CompletionCodeTaskHeartbeatTimeout       CompletionCode = -131
+	CompletionCodeRestartTaskRequested       CompletionCode = -140
+	CompletionCodeRestartFrameworkRequested  CompletionCode = -141
+	CompletionCodePodTemplateUpdated         CompletionCode = -142
 	// -2XX: Permanent Error
-	CompletionCodePodSpecPermanentError      CompletionCode = -200
-	CompletionCodeStopFrameworkRequested     CompletionCode = -210
-	CompletionCodeFrameworkAttemptCompletion CompletionCode = -220
-	CompletionCodeDeleteTaskRequested        CompletionCode = -230
+	CompletionCodePodSpecPermanentError         CompletionCode = -200
+	CompletionCodePodNodeUnmatched              CompletionCode = -201
+	CompletionCodeStopFrameworkRequested        CompletionCode = -210
+	CompletionCodeSuspendFrameworkRequested     CompletionCode = -211
+	CompletionCodeFrameworkAttemptCompletion    CompletionCode = -220
+	CompletionCodeDeleteTaskRequested           CompletionCode = -230
+	CompletionCodeStopTaskRequested             CompletionCode = -231
+	CompletionCodeFrameworkRetryBudgetExceeded  CompletionCode = -240
+	CompletionCodePodFailurePolicyFailFramework CompletionCode = -250
 	// -3XX: Unknown Error
 	CompletionCodePodFailedWithoutFailedContainer CompletionCode = -300
+	CompletionCodeFrameworkPreAttemptHookFailed   CompletionCode = -310
+	CompletionCodeFrameworkPostAttemptHookFailed  CompletionCode = -311
 )
 
 var completionCodeInfoList = []*CompletionCodeInfo{}
@@ -151,6 +173,39 @@ func initCompletionCodeInfos() {
 			Type: CompletionType{CompletionTypeNameFailed,
 				[]CompletionTypeAttribute{CompletionTypeAttributeTransient}},
 		},
+		{
+			// The Pod is evicted by Volcano, such as for preemption and reclaim.
+			Code:   CompletionCodePodVolcanoEvicted.Ptr(),
+			Phrase: "PodVolcanoEvicted",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributeTransient,
+					CompletionTypeAttributeConflict}},
+		},
+		{
+			// The Pod's node has been NotReady for too long.
+			// See NodeLostConfig.
+			Code:   CompletionCodePodNodeNotReady.Ptr(),
+			Phrase: "PodNodeNotReady",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributeTransient}},
+		},
+		{
+			// The Pod's spot node is interrupted, such as reclaimed by the cloud.
+			// See SpotInterruptionConfig.
+			Code:   CompletionCodePodSpotInterrupted.Ptr(),
+			Phrase: "PodSpotInterrupted",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributeTransient}},
+		},
+		{
+			// The HiveD scheduled Pod is preempted by a higher priority Pod.
+			// See TaskRoleSpec.HiveD.
+			Code:   CompletionCodePodHiveDPreempted.Ptr(),
+			Phrase: "PodHiveDPreempted",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributeTransient,
+					CompletionTypeAttributeConflict}},
+		},
 		{
 			Code:   CompletionCodeConfigMapCreationTimeout.Ptr(),
 			Phrase: "ConfigMapCreationTimeout",
@@ -163,18 +218,100 @@ func initCompletionCodeInfos() {
 			Type: CompletionType{CompletionTypeNameFailed,
 				[]CompletionTypeAttribute{CompletionTypeAttributeTransient}},
 		},
+		{
+			// The FrameworkAttempt is preempted by a higher priority Framework in
+			// the same Queue, and the Framework will be requeued.
+			Code:   CompletionCodeFrameworkPreempted.Ptr(),
+			Phrase: "FrameworkPreempted",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributeTransient,
+					CompletionTypeAttributeConflict}},
+		},
+		{
+			// The FrameworkAttempt is evicted by Kueue, such as for preemption or
+			// the admission check is rejected.
+			Code:   CompletionCodeFrameworkKueueEvicted.Ptr(),
+			Phrase: "FrameworkKueueEvicted",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributeTransient,
+					CompletionTypeAttributeConflict}},
+		},
+		{
+			// Some Pod of the FrameworkAttempt has been unschedulable longer than the
+			// AttemptSchedulingTimeoutSec.
+			Code:   CompletionCodeFrameworkSchedulingTimeout.Ptr(),
+			Phrase: "FrameworkSchedulingTimeout",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributeTransient,
+					CompletionTypeAttributeConflict}},
+		},
+		{
+			// The available Tasks of a TaskRole dropped below its MinTaskNumber
+			// after the FrameworkAttempt is Running.
+			Code:   CompletionCodeMinTaskNumberUnsatisfied.Ptr(),
+			Phrase: "MinTaskNumberUnsatisfied",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributeTransient}},
+		},
+		{
+			// The TaskAttempt has been running longer than the
+			// AttemptMaxRunDurationSec.
+			Code:   CompletionCodeTaskAttemptTimeExceeded.Ptr(),
+			Phrase: "TaskAttemptTimeExceeded",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributeTransient}},
+		},
+		{
+			// The TaskAttempt has not reported its heartbeat longer than the
+			// HeartbeatTimeoutSec.
+			Code:   CompletionCodeTaskHeartbeatTimeout.Ptr(),
+			Phrase: "TaskHeartbeatTimeout",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributeTransient}},
+		},
+		{
+			Code:   CompletionCodeRestartTaskRequested.Ptr(),
+			Phrase: "RestartTaskRequested",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributeTransient}},
+		},
+		{
+			Code:   CompletionCodeRestartFrameworkRequested.Ptr(),
+			Phrase: "RestartFrameworkRequested",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributeTransient}},
+		},
+		{
+			Code:   CompletionCodePodTemplateUpdated.Ptr(),
+			Phrase: "PodTemplateUpdated",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributeTransient}},
+		},
 		{
 			Code:   CompletionCodePodSpecPermanentError.Ptr(),
 			Phrase: "PodSpecPermanentError",
 			Type: CompletionType{CompletionTypeNameFailed,
 				[]CompletionTypeAttribute{CompletionTypeAttributePermanent}},
 		},
+		{
+			// No node matches the Pod's OS and architecture NodeSelector.
+			Code:   CompletionCodePodNodeUnmatched.Ptr(),
+			Phrase: "PodNodeUnmatched",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributePermanent}},
+		},
 		{
 			Code:   CompletionCodeStopFrameworkRequested.Ptr(),
 			Phrase: "StopFrameworkRequested",
 			Type: CompletionType{CompletionTypeNameFailed,
 				[]CompletionTypeAttribute{CompletionTypeAttributePermanent}},
 		},
+		{
+			Code:   CompletionCodeSuspendFrameworkRequested.Ptr(),
+			Phrase: "SuspendFrameworkRequested",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributePermanent}},
+		},
 		{
 			Code:   CompletionCodeFrameworkAttemptCompletion.Ptr(),
 			Phrase: "FrameworkAttemptCompletion",
@@ -187,12 +324,46 @@ func initCompletionCodeInfos() {
 			Type: CompletionType{CompletionTypeNameFailed,
 				[]CompletionTypeAttribute{CompletionTypeAttributePermanent}},
 		},
+		{
+			Code:   CompletionCodeStopTaskRequested.Ptr(),
+			Phrase: "StopTaskRequested",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributePermanent}},
+		},
+		{
+			// The Task retries in the FrameworkAttempt exceed the RetryBudget.
+			Code:   CompletionCodeFrameworkRetryBudgetExceeded.Ptr(),
+			Phrase: "FrameworkRetryBudgetExceeded",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributePermanent}},
+		},
+		{
+			// The TaskAttempt matches the FailFramework PodFailurePolicy Rule.
+			Code:   CompletionCodePodFailurePolicyFailFramework.Ptr(),
+			Phrase: "PodFailurePolicyFailFramework",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{CompletionTypeAttributePermanent}},
+		},
 		{
 			Code:   CompletionCodePodFailedWithoutFailedContainer.Ptr(),
 			Phrase: "PodFailedWithoutFailedContainer",
 			Type: CompletionType{CompletionTypeNameFailed,
 				[]CompletionTypeAttribute{}},
 		},
+		{
+			// The Framework PreAttempt hook failed with HookFailurePolicyFail.
+			Code:   CompletionCodeFrameworkPreAttemptHookFailed.Ptr(),
+			Phrase: "FrameworkPreAttemptHookFailed",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{}},
+		},
+		{
+			// The Framework PostAttempt hook failed with HookFailurePolicyFail.
+			Code:   CompletionCodeFrameworkPostAttemptHookFailed.Ptr(),
+			Phrase: "FrameworkPostAttemptHookFailed",
+			Type: CompletionType{CompletionTypeNameFailed,
+				[]CompletionTypeAttribute{}},
+		},
 	})
 }
 
@@ -222,10 +393,11 @@ type PodMatchResult struct {
 
 // Field name should be consistent with PodCompletionStatus
 type MatchedPod struct {
-	Name       *string             `json:"name,omitempty"`
-	Reason     string              `json:"reason,omitempty"`
-	Message    string              `json:"message,omitempty"`
-	Containers []*MatchedContainer `json:"containers,omitempty"`
+	Name             *string             `json:"name,omitempty"`
+	Reason           string              `json:"reason,omitempty"`
+	Message          string              `json:"message,omitempty"`
+	DisruptionReason string              `json:"disruptionReason,omitempty"`
+	Containers       []*MatchedContainer `json:"containers,omitempty"`
 }
 
 // Field name should be consistent with ContainerCompletionStatus
@@ -255,6 +427,30 @@ func MatchCompletionCodeInfos(pod *core.Pod) PodMatchResult {
 	return generatePodUnmatchedResult(pod)
 }
 
+// Match ALL CompletionCodeInfos, and return the results in the same order as
+// the completionCodeInfoList, so the first one is the same as the result of
+// MatchCompletionCodeInfos.
+func MatchAllCompletionCodeInfos(pod *core.Pod) []PodMatchResult {
+	results := []PodMatchResult{}
+	for _, codeInfo := range completionCodeInfoList {
+		for _, podPattern := range codeInfo.PodPatterns {
+			if matchedPod := matchPodPattern(pod, podPattern); matchedPod != nil {
+				diag := fmt.Sprintf("PodPattern matched: %v", common.ToJson(matchedPod))
+				results = append(results, PodMatchResult{
+					CodeInfo:    codeInfo,
+					Diagnostics: diag,
+				})
+				break
+			}
+		}
+	}
+
+	if len(results) == 0 {
+		results = append(results, generatePodUnmatchedResult(pod))
+	}
+	return results
+}
+
 // Match ENTIRE PodPattern
 func matchPodPattern(pod *core.Pod, podPattern *PodPattern) *MatchedPod {
 	matchedPod := &MatchedPod{}
@@ -280,6 +476,17 @@ func matchPodPattern(pod *core.Pod, podPattern *PodPattern) *MatchedPod {
 			return nil
 		}
 	}
+	if !podPattern.DisruptionReasonRegex.IsZero() {
+		cond := GetPodDisruptionCondition(pod)
+		if cond == nil {
+			return nil
+		}
+		if ms := podPattern.DisruptionReasonRegex.FindString(cond.Reason); ms != nil {
+			matchedPod.DisruptionReason = *ms
+		} else {
+			return nil
+		}
+	}
 
 	if len(podPattern.Containers) > 0 {
 		containers := GetAllContainerStatuses(pod)
@@ -369,6 +576,67 @@ func matchContainerPattern(
 	return matchedContainer
 }
 
+// TerminationMessageCompletion can be written by the application as the
+// container termination message to report its precise failure semantics.
+type TerminationMessageCompletion struct {
+	// It must be positive.
+	Code *CompletionCode `json:"code"`
+	// Default to the Phrase of the CompletionCodeInfo with the same Code if
+	// exists, otherwise ContainerUnrecognizedFailed.
+	Phrase CompletionPhrase `json:"phrase,omitempty"`
+}
+
+// Match the TerminationMessageCompletion of the last failed Container.
+// Return nil if the last failed Container does not report a valid one.
+func MatchTerminationMessageCompletion(pod *core.Pod) *PodMatchResult {
+	var lastTerm *core.ContainerStateTerminated
+	lastContainerName := ""
+	for _, container := range GetAllContainerStatuses(pod) {
+		term := container.State.Terminated
+		if term != nil && term.ExitCode != 0 {
+			if lastTerm == nil || lastTerm.FinishedAt.Time.Before(term.FinishedAt.Time) {
+				lastTerm = term
+				lastContainerName = container.Name
+			}
+		}
+	}
+	if lastTerm == nil || strings.TrimSpace(lastTerm.Message) == "" {
+		return nil
+	}
+
+	tmc := &TerminationMessageCompletion{}
+	if err := json.Unmarshal([]byte(lastTerm.Message), tmc); err != nil ||
+		tmc.Code == nil || *tmc.Code <= 0 {
+		return nil
+	}
+
+	codeInfo := &CompletionCodeInfo{
+		Code:   tmc.Code,
+		Phrase: completionCodeInfoContainerUnrecognizedFailed.Phrase,
+		Type:   completionCodeInfoContainerUnrecognizedFailed.Type,
+	}
+	if existingCodeInfo, ok := completionCodeInfoMap[*tmc.Code]; ok {
+		codeInfo.Phrase = existingCodeInfo.Phrase
+		codeInfo.Type = existingCodeInfo.Type
+	}
+	if tmc.Phrase != "" {
+		codeInfo.Phrase = tmc.Phrase
+	}
+
+	diag := fmt.Sprintf("TerminationMessage reported: %v",
+		common.ToJson(&MatchedContainer{
+			Name:    &lastContainerName,
+			Reason:  lastTerm.Reason,
+			Message: lastTerm.Message,
+			Signal:  lastTerm.Signal,
+			Code:    &lastTerm.ExitCode,
+		}))
+	return &PodMatchResult{
+		CodeInfo:    codeInfo,
+		Diagnostics: diag,
+	}
+}
+
 func generatePodUnmatchedResult(pod *core.Pod) PodMatchResult {
 	// Take the last failed Container ExitCode as CompletionCode and full failure
 	// info as Diagnostics.
@@ -407,6 +675,16 @@ func generatePodUnmatchedResult(pod *core.Pod) PodMatchResult {
 ///////////////////////////////////////////////////////////////////////////////////////
 // Completion Utils
 ///////////////////////////////////////////////////////////////////////////////////////
+// The Transient Failed CompletionStatus issued by K8S or the container runtime,
+// which is likely due to the node instead of the Pod itself.
+// The Conflict one, such as the scheduler preemption, is due to the contention
+// with other Pods, so it is excluded.
+func (cs *CompletionStatus) IsInfrastructureFailed() bool {
+	return cs.Code <= -1000 && cs.Type.IsFailed() &&
+		cs.Type.ContainsAttribute(CompletionTypeAttributeTransient) &&
+		!cs.Type.ContainsAttribute(CompletionTypeAttributeConflict)
+}
+
 func (ct CompletionType) IsSucceeded() bool {
 	return ct.Name == CompletionTypeNameSucceeded
 }
@@ -548,10 +826,16 @@ func ExtractPodCompletionStatus(pod *core.Pod) *PodCompletionStatus {
 		Reason:  pod.Status.Reason,
 		Message: pod.Status.Message,
 	}
+	if cond := GetPodDisruptionCondition(pod); cond != nil {
+		pcs.DisruptionReason = cond.Reason
+	}
 
-	for _, container := range GetAllContainerStatuses(pod) {
+	initContainerCount := len(pod.Status.InitContainerStatuses)
+	for i, container := range GetAllContainerStatuses(pod) {
 		ccs := &ContainerCompletionStatus{
-			Name: container.Name,
+			Name:         container.Name,
+			Init:         i < initContainerCount,
+			RestartCount: container.RestartCount,
 		}
 		term := container.State.Terminated
 		if term != nil {
@@ -559,6 +843,14 @@ func ExtractPodCompletionStatus(pod *core.Pod) *PodCompletionStatus {
 			ccs.Message = term.Message
 			ccs.Signal = term.Signal
 			ccs.Code = term.ExitCode
+		} else if lastTerm := container.LastTerminationState.Terminated; lastTerm != nil {
+			ccs.Reason = lastTerm.Reason
+			ccs.Message = lastTerm.Message
+			ccs.Signal = lastTerm.Signal
+			ccs.Code = lastTerm.ExitCode
+		} else if wait := container.State.Waiting; wait != nil {
+			ccs.Reason = wait.Reason
+			ccs.Message = wait.Message
 		}
 		pcs.Containers = append(pcs.Containers, ccs)
 	}
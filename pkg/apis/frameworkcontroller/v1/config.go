@@ -26,6 +26,7 @@ import (
 	"fmt"
 	"github.com/microsoft/frameworkcontroller/pkg/common"
 	"io/ioutil"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/clientcmd"
 	"os"
@@ -59,6 +60,33 @@ type Config struct {
 	// Number of concurrent workers to process each different Frameworks
 	WorkerNumber *int32 `yaml:"workerNumber"`
 
+	// Interval to check whether the config file is changed, and if changed, hot
+	// reload the changed Config without restarting FrameworkController.
+	// Only below fields can be hot reloaded, other changed fields are ignored
+	// until FrameworkController restart:
+	// WorkerNumber (can only be increased), ShutdownDrainTimeoutSec,
+	// LargeFrameworkCompression, ObjectLocalCacheCreationTimeoutSec,
+	// FrameworkCompletedRetainSec, FrameworkSucceededRetainSec, FrameworkFailedRetainSec,
+	// FrameworkMinRetryDelaySecForTransientConflictFailed,
+	// FrameworkMaxRetryDelaySecForTransientConflictFailed, LogObjectSnapshot.
+	// If the changed config file is invalid, it is ignored and the current Config
+	// is still effective.
+	// Non-positive value disables the hot reload.
+	// Default to 0, i.e. the hot reload is disabled.
+	ConfigReloadIntervalSec *int64 `yaml:"configReloadIntervalSec"`
+
+	// Timeout to drain the in-flight syncs after the shutdown signal is received.
+	// During the drain, no new Framework will be started to sync, and the running
+	// syncs are waited to finish within this timeout.
+	// If all running syncs are finished within the timeout, the expected
+	// Framework.Status which is not yet persisted will also be flushed to remote,
+	// otherwise, it will be recovered after FrameworkController restart.
+	ShutdownDrainTimeoutSec *int64 `yaml:"shutdownDrainTimeoutSec"`
+
+	// Specify how to partition Frameworks across multiple FrameworkController
+	// instances, so that each instance only syncs the Frameworks in its own shard.
+	Sharding ShardingConfig `yaml:"sharding"`
+
 	// Specify whether to compress some fields in the Framework object if they are too large.
 	//
 	// Currently, due to the etcd limitation, the max size of any object on ApiServer is 1.5 MB:
@@ -80,6 +108,140 @@ type Config struct {
 	// 3. Currently, only field TaskRoleStatuses will be compressed if it is too large.
 	LargeFrameworkCompression *bool `yaml:"largeFrameworkCompression"`
 
+	// Specify whether to strip the unused fields of Pods and ConfigMaps before
+	// storing them in the local cache of the Controller's Informer, to reduce
+	// the memory usage.
+	// The stripped fields include the ManagedFields, large Annotations, the
+	// ConfigMap data and some ContainerStatus details, so they will also be
+	// absent in the logged ObjectSnapshot of Pods, and any code reading them from
+	// the local cache will see empty values.
+	// The large Annotations consumed by FrameworkController are never stripped,
+	// such as the ones prefixed with FC_ and the PodDeviceIDAnnotationKeys, and
+	// each stripped Annotation is logged.
+	// Default to false.
+	LocalCacheObjectTransform *bool `yaml:"localCacheObjectTransform"`
+
+	// Specify whether to run in the dry-run mode, such as to safely validate the
+	// controller upgrades and config changes against the Frameworks in the
+	// production cluster.
+	// In the dry-run mode, the controller still executes the full sync logic, but
+	// all its writes to the ApiServer are logged and sent as server side dry-run
+	// requests, so they are validated and responded by the ApiServer but never
+	// persisted. And the writes to the external systems, such as the EventSink
+	// and FrameworkArchive, are disabled.
+	// Note:
+	// 1. The CRDs should already exist, as they cannot be created in the dry-run
+	//    mode.
+	// 2. As the writes are never persisted, the same writes may be logged again
+	//    and again for a Framework, until the real controller makes progress on it.
+	// 3. The Sharding.MembershipEnabled should be false, as the membership Leases
+	//    cannot be persisted either.
+	DryRunEnabled *bool `yaml:"dryRunEnabled"`
+
+	// Specify whether to record each completed FrameworkAttempt as a
+	// FrameworkAttemptHistory object, so that previous FrameworkAttempts can
+	// still be inspected after the Framework is retried.
+	// Default to false, since it needs the FrameworkAttemptHistory CRD and the
+	// permissions to manage it, and it writes an extra object per FrameworkAttempt.
+	// See FrameworkAttemptHistory.
+	FrameworkAttemptHistoryEnabled *bool `yaml:"frameworkAttemptHistoryEnabled"`
+
+	// Specify whether to manage ScheduledFrameworks, and the number of concurrent
+	// workers to process each different ScheduledFrameworks.
+	// Default to false, since it needs the ScheduledFramework CRD and the
+	// permissions to manage it.
+	// See ScheduledFramework.
+	ScheduledFrameworkEnabled      *bool  `yaml:"scheduledFrameworkEnabled"`
+	ScheduledFrameworkWorkerNumber *int32 `yaml:"scheduledFrameworkWorkerNumber"`
+
+	// Specify whether to manage FrameworkGroups, and the number of concurrent
+	// workers to process each different FrameworkGroups.
+	// Default to false, since it needs the FrameworkGroup CRD and the permissions
+	// to manage it.
+	// See FrameworkGroup.
+	FrameworkGroupEnabled      *bool  `yaml:"frameworkGroupEnabled"`
+	FrameworkGroupWorkerNumber *int32 `yaml:"frameworkGroupWorkerNumber"`
+
+	// Specify whether to manage Queues, and the number of concurrent workers to
+	// process each different Queues.
+	// If it is disabled, all the Frameworks are admitted immediately, no matter
+	// which Queue they reference.
+	// Default to false, since it needs the cluster scoped Queue CRD and the
+	// permissions to manage it.
+	// See Queue.
+	QueueEnabled      *bool  `yaml:"queueEnabled"`
+	QueueWorkerNumber *int32 `yaml:"queueWorkerNumber"`
+
+	// Specify whether to manage FrameworkQuotas, and the number of concurrent
+	// workers to process each different namespaces with FrameworkQuotas.
+	// If it is disabled, all the Frameworks are admitted immediately, no matter
+	// whether there are FrameworkQuotas in their namespaces.
+	// Default to false, since it needs the FrameworkQuota CRD and the permissions
+	// to manage it.
+	// See FrameworkQuota.
+	FrameworkQuotaEnabled      *bool  `yaml:"frameworkQuotaEnabled"`
+	FrameworkQuotaWorkerNumber *int32 `yaml:"frameworkQuotaWorkerNumber"`
+
+	// Specify whether to manage TaskRoleScales, and the number of concurrent
+	// workers to process each different TaskRoleScales.
+	// Default to false, since it needs the TaskRoleScale CRD and the permissions
+	// to manage it.
+	// See TaskRoleScale.
+	TaskRoleScaleEnabled      *bool  `yaml:"taskRoleScaleEnabled"`
+	TaskRoleScaleWorkerNumber *int32 `yaml:"taskRoleScaleWorkerNumber"`
+
+	// Specify whether to materialize the Kubeflow TFJobs and PyTorchJobs as
+	// Frameworks and report their Status back, and the number of concurrent
+	// workers to process each different Kubeflow Jobs.
+	// The Kubeflow CRDs should be installed, and the Kubeflow training operator
+	// should not manage the same Kubeflow Jobs at the same time.
+	// The TaskRoleHeadlessServiceEnabled and TaskHostnameEnabled should also be
+	// true, so that the injected TF_CONFIG and MASTER_ADDR are resolvable.
+	KubeflowJobEnabled      *bool  `yaml:"kubeflowJobEnabled"`
+	KubeflowJobWorkerNumber *int32 `yaml:"kubeflowJobWorkerNumber"`
+
+	// Specify whether to create a headless Service for each TaskRole of each
+	// FrameworkAttempt, so that its Tasks can resolve each other by stable DNS
+	// names instead of waiting for the PodIPs, such as by the FrameworkBarrier.
+	// The Service selects all Pods of the TaskRole, including the not ready ones,
+	// and it is controlled by the ConfigMap of the FrameworkAttempt.
+	// ServiceName = {FrameworkName}-{TaskRoleName}
+	//
+	// If TaskHostnameEnabled is also true, the Pod Hostname and Subdomain will be
+	// set if they are not specified in the Pod template, so that each Task can be
+	// resolved by the stable DNS name across TaskAttempts, i.e. the PodFQDN in
+	// the TaskAttemptStatus:
+	// {TaskRoleName}-{TaskIndex}.{ServiceName}.{FrameworkNamespace}.svc.{ClusterDomain}
+	//
+	// Note, the ServiceName and the Hostname should not be longer than 63
+	// characters, otherwise the FrameworkAttempt or the TaskAttempt will be
+	// completed with CompletionCodePodSpecPermanentError.
+	TaskRoleHeadlessServiceEnabled *bool `yaml:"taskRoleHeadlessServiceEnabled"`
+	TaskHostnameEnabled            *bool `yaml:"taskHostnameEnabled"`
+
+	// Specify whether to add the PodConditionTypeFrameworkAttemptReady readiness
+	// gate to each created Pod, and set the condition of all Pods of the
+	// FrameworkAttempt to True once all its Tasks are assigned PodIPs, or False
+	// once any of them is not.
+	// So the Pod is only considered ready by the Services and meshes after the
+	// whole gang is ready.
+	PodReadinessGateEnabled *bool `yaml:"podReadinessGateEnabled"`
+
+	// The DNS domain of the cluster, which is used to expose the PodFQDN.
+	// It should be the same as the kubelet clusterDomain.
+	ClusterDomain *string `yaml:"clusterDomain"`
+
+	// The host port range to allocate the ports for the Tasks whose TaskRole
+	// PortNumber is positive.
+	// The allocated ports are unique among all the running Tasks managed by
+	// current FrameworkController instance, and they are also declared as the
+	// HostPorts of the HostNetwork Pods, so that the Pods with colliding ports
+	// will not be scheduled to the same node, even if they are managed by other
+	// FrameworkController instances.
+	// Default to [20000, 29999], which should not overlap with the NodePort range
+	// and the ephemeral port range of the nodes.
+	PortAllocationRange Int32Range `yaml:"portAllocationRange"`
+
 	// Check interval and timeout to expect the created CRD to be in Established condition.
 	CRDEstablishedCheckIntervalSec *int64 `yaml:"crdEstablishedCheckIntervalSec"`
 	CRDEstablishedCheckTimeoutSec  *int64 `yaml:"crdEstablishedCheckTimeoutSec"`
@@ -90,11 +252,77 @@ type Config struct {
 	// it is considered as deleted.
 	ObjectLocalCacheCreationTimeoutSec *int64 `yaml:"objectLocalCacheCreationTimeoutSec"`
 
+	// Timeout to wait for a node matching the TaskRole OS and Arch, such as the
+	// cluster autoscaler provisions it, after the Pod is found unschedulable
+	// since no node matches its NodeSelector.
+	// After the timeout, the TaskAttempt is completed with
+	// CompletionCodePodNodeUnmatched instead of keeping the Pod pending forever.
+	PodNodeUnmatchedTimeoutSec *int64 `yaml:"podNodeUnmatchedTimeoutSec"`
+
+	// If enabled, when a TaskAttempt is completed as failed, the recent Warning
+	// Events of its Pod, such as FailedScheduling, image pull BackOff and Evicted,
+	// are listed and appended to its CompletionStatus.Diagnostics, so that they
+	// are still visible after the Events are expired or the Pod is deleted.
+	// At most the latest PodEventDiagnosticsMaxCount Events are appended, and the
+	// appended summary is truncated to PodEventDiagnosticsMaxBytes.
+	// Note, the Events are listed from ApiServer synchronously within the sync of
+	// the Framework, since they are not cached by the informers, so it costs one
+	// extra List call per failed TaskAttempt and it is disabled by default.
+	// Set PodEventDiagnosticsMaxCount to 0 to disable it.
+	PodEventDiagnosticsMaxCount *int32 `yaml:"podEventDiagnosticsMaxCount"`
+	PodEventDiagnosticsMaxBytes *int32 `yaml:"podEventDiagnosticsMaxBytes"`
+
+	// When a TaskAttempt is completed as failed, the last
+	// FailedContainerLogTailLines lines of each failed container's logs are
+	// fetched and stored in its ContainerCompletionStatus.LogTail, since the Pod
+	// and its logs will be deleted shortly after.
+	// The LogTail of each container is truncated to its last
+	// FailedContainerLogTailMaxBytes.
+	// All the fetches of a failed TaskAttempt share the total timeout
+	// FailedContainerLogTailTimeoutSec, and the containers not fetched within it
+	// are left without LogTail.
+	// Note, the logs are fetched from the kubelets synchronously within the sync
+	// of the Framework, so enabling it adds up to FailedContainerLogTailTimeoutSec
+	// to the sync of each failed TaskAttempt, during which the worker is blocked
+	// by a slow or unreachable kubelet.
+	// Set FailedContainerLogTailLines to 0 to disable it.
+	FailedContainerLogTailLines      *int64 `yaml:"failedContainerLogTailLines"`
+	FailedContainerLogTailMaxBytes   *int32 `yaml:"failedContainerLogTailMaxBytes"`
+	FailedContainerLogTailTimeoutSec *int64 `yaml:"failedContainerLogTailTimeoutSec"`
+
 	// A Framework will only be retained within recent FrameworkCompletedRetainSec
 	// after it is completed, i.e. it will be automatically deleted after
 	// f.Status.CompletionTime + FrameworkCompletedRetainSec.
 	FrameworkCompletedRetainSec *int64 `yaml:"frameworkCompletedRetainSec"`
 
+	// Override the FrameworkCompletedRetainSec for the Succeeded and Failed
+	// Frameworks respectively, such as to retain the Failed Frameworks much longer
+	// for debugging.
+	// Default to FrameworkCompletedRetainSec.
+	// They can be further overridden by the Framework SucceededRetainSec and
+	// FailedRetainSec.
+	FrameworkSucceededRetainSec *int64 `yaml:"frameworkSucceededRetainSec"`
+	FrameworkFailedRetainSec    *int64 `yaml:"frameworkFailedRetainSec"`
+
+	// The PropagationPolicy to delete the Framework, such as after
+	// FrameworkCompletedRetainSec, and to delete the ConfigMap of the
+	// FrameworkAttempt respectively, which should be Foreground, Background,
+	// Orphan or empty, i.e. the K8S default PropagationPolicy of the object.
+	// For example, with Orphan, the ConfigMap and the Pods can outlive the deleted
+	// Framework, such as for the external log collectors to finish, but they are
+	// leaked and should be deleted externally.
+	// Note, only Foreground can achieve all the Framework ConsistencyGuarantees.
+	// Default to Foreground for the Framework, and empty for the ConfigMap.
+	// They can be further overridden by the Framework
+	// FrameworkDeletionPropagationPolicy and ConfigMapDeletionPropagationPolicy.
+	FrameworkDeletionPropagationPolicy *meta.DeletionPropagation `yaml:"frameworkDeletionPropagationPolicy"`
+	ConfigMapDeletionPropagationPolicy *meta.DeletionPropagation `yaml:"configMapDeletionPropagationPolicy"`
+
+	// Specify how to archive the final snapshot of a completed Framework to
+	// external storage before it is deleted due to FrameworkCompletedRetainSec,
+	// so that its history survives the automatic deletion.
+	FrameworkArchive FrameworkArchiveConfig `yaml:"frameworkArchive"`
+
 	// If the Framework FancyRetryPolicy is enabled and its FrameworkAttempt is
 	// completed with Transient Conflict Failed CompletionType, it will be retried
 	// after a random delay within this range.
@@ -104,6 +332,101 @@ type Config struct {
 	FrameworkMinRetryDelaySecForTransientConflictFailed *int64 `yaml:"frameworkMinRetryDelaySecForTransientConflictFailed"`
 	FrameworkMaxRetryDelaySecForTransientConflictFailed *int64 `yaml:"frameworkMaxRetryDelaySecForTransientConflictFailed"`
 
+	// The name of the RetryDecider to decide whether and how to retry the
+	// completed FrameworkAttempts and TaskAttempts.
+	// Default to DefaultRetryDeciderName, i.e. apply the RetryPolicySpec as is.
+	// Other RetryDeciders, such as cost-aware or time-of-day-aware, can be
+	// compiled in by controller.RegisterRetryDecider.
+	RetryDecider *string `yaml:"retryDecider"`
+
+	// Specify the TaskRoleAutoscaler to periodically adjust the TaskNumber of the
+	// TaskRoles of the Preparing or Running FrameworkAttempts.
+	TaskRoleAutoscaler TaskRoleAutoscalerConfig `yaml:"taskRoleAutoscaler"`
+
+	// Specify whether and how to gang schedule each FrameworkAttempt, i.e. all
+	// its Tasks start together or not at all.
+	GangScheduling GangSchedulingConfig `yaml:"gangScheduling"`
+
+	// Specify whether and how to admit each FrameworkAttempt by Kueue, so that
+	// Frameworks can share the cluster quota with other Jobs managed by Kueue.
+	Kueue KueueConfig `yaml:"kueue"`
+
+	// Specify whether and how to proactively complete the TaskAttempts whose Pods
+	// are on the NotReady nodes, instead of waiting for them indefinitely.
+	NodeLost NodeLostConfig `yaml:"nodeLost"`
+
+	// Specify whether and how to recognize the spot node interruptions, so that
+	// the TaskAttempts interrupted by them can be distinguished from the
+	// application failures.
+	SpotInterruption SpotInterruptionConfig `yaml:"spotInterruption"`
+
+	// Specify whether and how to check the schedulable capacity of the cluster
+	// before creating each FrameworkAttempt, so that the FrameworkAttempt which
+	// cannot possibly fit is not created to flood the cluster with Pending Pods.
+	CapacityCheck CapacityCheckConfig `yaml:"capacityCheck"`
+
+	// Specify whether and how to dispatch the Frameworks waiting for the
+	// schedulable capacity of the cluster in the weighted fair share order of
+	// their groups, instead of the order in which they happen to be synced.
+	FairShare FairShareConfig `yaml:"fairShare"`
+
+	// Specify where to publish the Framework and Task state transitions as
+	// CloudEvents, so that external systems can be driven by the Framework
+	// lifecycle without polling the ApiServer.
+	EventSink EventSinkConfig `yaml:"eventSink"`
+
+	// Specify where to export the OpenTelemetry traces of the Framework sync
+	// pipeline, i.e. the spans of syncFramework, syncTaskState and all the
+	// remote ApiServer calls, keyed by the Framework key and FrameworkAttemptID.
+	Tracing TracingConfig `yaml:"tracing"`
+
+	// Specify the defaults applied to all created Pods if their Pod templates
+	// omit them, so that the platform can enforce the sandboxed runtime, such as
+	// gVisor or Kata, or the restricted Pod Security Standard without modifying
+	// every Framework Spec.
+	PodDefaults PodDefaultsConfig `yaml:"podDefaults"`
+
+	// The names of the PodDecorators to mutate every created Pod in order, after
+	// the PodDefaults and all other built-in mutations are applied.
+	// Default to [SecretRefPodDecoratorName], i.e. only inject the TaskRole
+	// SecretRefs, so it should also be included if it is overridden.
+	// Other PodDecorators can be compiled in by controller.RegisterPodDecorator.
+	PodDecorators []string `yaml:"podDecorators"`
+
+	// Specify whether and how to avoid the nodes on which the Framework's
+	// TaskAttempts failed due to the infrastructure, so that the retried Pods are
+	// not attracted back to the bad nodes, such as by the image locality.
+	NodeBlacklist NodeBlacklistConfig `yaml:"nodeBlacklist"`
+
+	// Specify the external webhook to classify the failed Pods, so that the
+	// platform can plug in custom classifiers, such as by scraping the logs or
+	// the node telemetry, to override the CompletionCode and CompletionType
+	// matched by the PodFailureSpec before the RetryPolicy is applied.
+	FailureClassifier FailureClassifierConfig `yaml:"failureClassifier"`
+
+	// Specify whether the failed container can report its precise failure
+	// semantics by writing a TerminationMessageCompletion JSON, such as
+	// {"code": 1001, "phrase": "DatasetCorrupted"}, as its termination message,
+	// see the container TerminationMessagePath and TerminationMessagePolicy.
+	// If enabled, the TerminationMessageCompletion of the last failed container
+	// overrides the CompletionCode matched by the PodFailureSpec, but it can still
+	// be overridden by the FailureClassifier.
+	TerminationMessageCompletionEnabled *bool `yaml:"terminationMessageCompletionEnabled"`
+
+	// The Pod annotation keys in which the device plugin or scheduler exposes
+	// the comma separated IDs of the devices allocated to the Pod, such as
+	// volcano.sh/gpu-index.
+	// They are recorded in the TaskAttemptStatus.PodAllocatedDevices together with
+	// the allocated extended resources once the Pod is running.
+	PodDeviceIDAnnotationKeys []string `yaml:"podDeviceIDAnnotationKeys"`
+
+	// Specify whether and how to inject the faults into the controller, such as
+	// the delayed informer events, the failed writes and the dropped local cache
+	// entries, so that the monotonic Framework Status and the expected status
+	// machinery can be stress tested continuously.
+	// It should never be enabled in the production cluster.
+	FaultInjection FaultInjectionConfig `yaml:"faultInjection"`
+
 	// Specify when to log the snapshot of which managed object.
 	// This enables external systems to collect and process the history snapshots,
 	// such as persistence, metrics conversion, visualization, alerting, acting,
@@ -140,6 +463,440 @@ type Config struct {
 	PodFailureSpec []*CompletionCodeInfo `yaml:"podFailureSpec"`
 }
 
+type ShardingConfig struct {
+	// Static sharding:
+	// The total number of shards and the shard owned by current instance.
+	// The ShardIndex should be within [0, ShardNumber).
+	// Default to a single shard which owns all Frameworks.
+	ShardNumber *int32 `yaml:"shardNumber"`
+	ShardIndex  *int32 `yaml:"shardIndex"`
+
+	// Dynamic sharding:
+	// If it is enabled, the static ShardNumber and ShardIndex are ignored, and
+	// they are derived from the live members instead:
+	// 1. Each instance heartbeats its own member Lease within MembershipNamespace.
+	// 2. A member is live if its Lease is renewed within MemberLeaseDurationSec.
+	// 3. ShardNumber is the live member number and ShardIndex is the position of
+	//    current MemberID in all sorted live MemberIDs.
+	// Once the membership is changed, the shards are rebalanced, i.e. all
+	// Frameworks are enqueued to sync, so the newly owned ones are recovered from
+	// the remote Framework.Status, as if current instance was just restarted.
+	// However, the newly owned Frameworks are only synced after
+	// MemberLeaseDurationSec since the rebalance, so that their previous owners
+	// must have observed the new membership or become stale and stopped syncing
+	// them. The same fence also applies after joined or recovered from stale.
+	// If current instance cannot renew its Lease within MemberLeaseDurationSec,
+	// it will not sync any Framework, since its shard may have been taken over.
+	MembershipEnabled      *bool   `yaml:"membershipEnabled"`
+	MembershipNamespace    *string `yaml:"membershipNamespace"`
+	MemberID               *string `yaml:"memberID"`
+	MemberLeaseDurationSec *int64  `yaml:"memberLeaseDurationSec"`
+	MemberRenewIntervalSec *int64  `yaml:"memberRenewIntervalSec"`
+
+	// If it is not empty and a Framework has this label with non-negative integer
+	// value, the Framework belongs to the shard {LabelValue} % {ShardNumber}.
+	// Otherwise, the Framework belongs to the shard
+	// {Hash({FrameworkNamespace}/{FrameworkName})} % {ShardNumber}.
+	ShardLabelKey *string `yaml:"shardLabelKey"`
+}
+
+type FrameworkArchiveType string
+
+const (
+	FrameworkArchiveNone FrameworkArchiveType = "None"
+	// Write to {FileDir}/{FrameworkNamespace}/{FrameworkName}-{FrameworkUID}.json
+	FrameworkArchiveFile FrameworkArchiveType = "File"
+	// POST to {HTTPURL} with the snapshot as the JSON body.
+	FrameworkArchiveHTTP FrameworkArchiveType = "HTTP"
+	// PUT to the Block Blob
+	// {FrameworkNamespace}/{FrameworkName}-{FrameworkUID}.json
+	// within the container of {AzureBlobContainerSASURL}.
+	FrameworkArchiveAzureBlob FrameworkArchiveType = "AzureBlob"
+)
+
+type FrameworkArchiveConfig struct {
+	// Default to FrameworkArchiveNone, i.e. the Framework is deleted without
+	// any archive.
+	// Other storages, such as S3, can be archived by the HTTP archiver through
+	// a gateway.
+	Type *FrameworkArchiveType `yaml:"type"`
+
+	FileDir                  *string `yaml:"fileDir"`
+	HTTPURL                  *string `yaml:"httpURL"`
+	AzureBlobContainerSASURL *string `yaml:"azureBlobContainerSASURL"`
+
+	// Timeout for a single archive request.
+	// If the archive failed, the deletion of the Framework will be retried later,
+	// so the Framework will not be deleted until it is archived successfully.
+	TimeoutSec *int64 `yaml:"timeoutSec"`
+}
+
+type EventSinkType string
+
+const (
+	EventSinkNone EventSinkType = "None"
+	// Publish to the {NATSSubject} of the NATS server {NATSAddress}.
+	EventSinkNATS EventSinkType = "NATS"
+	// Produce to the {KafkaTopic} through the Kafka REST Proxy {KafkaRESTProxyURL}.
+	EventSinkKafkaRESTProxy EventSinkType = "KafkaRESTProxy"
+)
+
+// The events are published in the CloudEvents JSON format, with the event type
+// com.microsoft.frameworkcontroller.framework.transitioned or
+// com.microsoft.frameworkcontroller.task.transitioned.
+// Notes:
+// 1. A state transition is only published after it has been persisted, so a
+//    published state will never be rolled back.
+// 2. The publish is best effort, i.e. the events may be dropped if the sink is
+//    unavailable or the buffer is full, and the events during the
+//    FrameworkController downtime may be missed.
+// 3. The same event may be published more than once in some rare cases, so
+//    external systems may need to deduplicate them by the event id.
+type GangSchedulingConfig struct {
+	// Specify whether to create a PodGroup of the coscheduling plugin in
+	// [scheduler-plugins](https://github.com/kubernetes-sigs/scheduler-plugins)
+	// for each FrameworkAttempt, and associate all its Pods with the PodGroup.
+	// The PodGroup MinMember is the total TaskNumber of all TaskRoles, and the
+	// PodGroup MinResources is the total resource requests of all Tasks.
+	// Default to false.
+	// Notes:
+	// 1. The PodGroup CRD should be installed, and the Pods should be scheduled
+	//    by a scheduler with the coscheduling plugin enabled, see SchedulerName.
+	// 2. The PodGroup is controlled by the ConfigMap of the FrameworkAttempt, so
+	//    it will be garbage collected together with the FrameworkAttempt.
+	PodGroupEnabled *bool `yaml:"podGroupEnabled"`
+
+	// Override the schedulerName of all created Pods if it is not empty.
+	// Default to empty, i.e. the schedulerName in the Pod template is used.
+	SchedulerName *string `yaml:"schedulerName"`
+
+	// The PodGroup ScheduleTimeoutSeconds.
+	// Default to nil, i.e. the coscheduling plugin default is used.
+	ScheduleTimeoutSec *int64 `yaml:"scheduleTimeoutSec"`
+
+	// Specify whether to integrate with [Volcano](https://volcano.sh) for the
+	// Framework whose any Pod is scheduled by the VolcanoSchedulerName, i.e.
+	// create a Volcano PodGroup for each FrameworkAttempt instead of the
+	// coscheduling PodGroup, and associate all its Pods with the PodGroup.
+	// The Volcano PodGroup MinMember and MinResources are the same as the
+	// coscheduling PodGroup, and its Queue is from the Framework annotation
+	// scheduling.volcano.sh/queue-name and its PriorityClassName is from the
+	// first not empty PriorityClassName of the Pod templates.
+	// The Pod evicted by Volcano, such as for preemption and reclaim, is
+	// completed with CompletionCodePodVolcanoEvicted, which is Transient Conflict
+	// Failed.
+	// Default to true and volcano.
+	VolcanoEnabled       *bool   `yaml:"volcanoEnabled"`
+	VolcanoSchedulerName *string `yaml:"volcanoSchedulerName"`
+}
+
+type KueueConfig struct {
+	// Specify whether to create a Kueue Workload for each FrameworkAttempt of
+	// the Framework with the kueue.x-k8s.io/queue-name label, and hold its Pods
+	// creation until the Workload is admitted by Kueue.
+	// The Workload has a PodSet for each TaskRole, whose Count is the TaskNumber
+	// and whose Template is the Pod template, and its PriorityClassName is from
+	// the first not empty PriorityClassName of the Pod templates.
+	// The FrameworkAttempt evicted by Kueue is completed with
+	// CompletionCodeFrameworkKueueEvicted, which is Transient Conflict Failed.
+	// Default to false.
+	// Notes:
+	// 1. Kueue should be installed before enable it.
+	// 2. The Workload is controlled by the ConfigMap of the FrameworkAttempt, so
+	//    it will be garbage collected together with the FrameworkAttempt, and
+	//    then its quota will be released.
+	// 3. The node affinity of the admitted ResourceFlavors is not injected into
+	//    the Pods, so the Pod template should specify it if needed.
+	Enabled *bool `yaml:"enabled"`
+}
+
+type NodeLostConfig struct {
+	// Specify whether to watch the Nodes, and once the node of a not completed
+	// Pod has been NotReady for more than NotReadyGraceSec, complete its
+	// TaskAttempt with CompletionCodePodNodeNotReady, which is Transient Failed,
+	// instead of waiting for the node to come back or the Pod to be evicted.
+	// Default to false.
+	// Note, the Nodes list and watch permissions are needed to enable it.
+	Enabled *bool `yaml:"enabled"`
+
+	// Default to 300, i.e. the same as the default pod-eviction-timeout.
+	NotReadyGraceSec *int64 `yaml:"notReadyGraceSec"`
+
+	// Specify whether to force delete the Pod on the NotReady node, so that the
+	// TaskAttempt can be retried immediately, instead of waiting for the kubelet
+	// to confirm the Pod deletion, which will never happen if the node is lost.
+	// Default to false.
+	// Note, if the node is only partitioned from the ApiServer, the containers
+	// of the force deleted Pod may still be running on it, so at most one Pod
+	// for each Task is no longer guaranteed.
+	ForceDeletePod *bool `yaml:"forceDeletePod"`
+}
+
+type CapacityCheckConfig struct {
+	// Specify whether to watch the Nodes, and only create the FrameworkAttempt
+	// after its total resource requests fit into the schedulable capacity of the
+	// cluster, and each of its Pods fits into at least one schedulable node.
+	// Until then, the Framework is kept in FrameworkAttemptCreationPending state
+	// with the FrameworkCapacitySufficient Condition False.
+	// The schedulable capacity of a node is its allocatable resources minus the
+	// resource requests of all its not completed Pods, and only the Ready and not
+	// Unschedulable nodes are counted.
+	// Default to false.
+	// Notes:
+	// 1. The Nodes list and watch permissions are needed to enable it.
+	// 2. It is only a necessary check, i.e. the node selector, affinity and
+	//    taints are not considered, so the created Pods may still be Pending.
+	// 3. The resource requests of a Framework is the same as the one of the
+	//    Queue, except that the AutoscaledTaskNumber is respected.
+	Enabled *bool `yaml:"enabled"`
+
+	// The interval to check again for the Framework which cannot fit, since the
+	// capacity changes are not watched for each Framework.
+	RecheckIntervalSec *int64 `yaml:"recheckIntervalSec"`
+}
+
+type FairShareGroupBy string
+
+const (
+	// Group the Frameworks by their namespaces.
+	FairShareGroupByNamespace FairShareGroupBy = "Namespace"
+	// Group the Frameworks by their Queues, and the Frameworks without Queue
+	// are in the group with empty name.
+	FairShareGroupByQueue FairShareGroupBy = "Queue"
+)
+
+type FairShareConfig struct {
+	// Specify whether to dispatch the Frameworks waiting for the schedulable
+	// capacity of the cluster in the weighted Dominant Resource Fairness order,
+	// i.e. only the earliest created waiting Framework in the group with the
+	// lowest weighted dominant share can create its FrameworkAttempt, and the
+	// others are kept in FrameworkAttemptCreationPending state with the
+	// FrameworkCapacitySufficient Condition False.
+	// The dominant share of a group is the max ratio of the total resource
+	// requests of its started and not completed Frameworks to the allocatable
+	// resources of the schedulable nodes, divided by its weight.
+	// Default to false.
+	// Notes:
+	// 1. It requires the CapacityCheck to be enabled, and a Framework is waiting
+	//    only after it is ever found to be unable to fit by the CapacityCheck or
+	//    to be behind others by the FairShare, so it does not delay the
+	//    Frameworks if no Framework is waiting.
+	// 2. The waiting Framework which can never fit into the whole cluster is
+	//    ignored, so that it does not block the others.
+	Enabled *bool `yaml:"enabled"`
+
+	// Default to FairShareGroupByNamespace.
+	GroupBy *FairShareGroupBy `yaml:"groupBy"`
+
+	// The weight of each group by its name, i.e. the namespace or Queue name.
+	// The group which is not specified has weight 1.
+	Weights map[string]int32 `yaml:"weights"`
+}
+
+type SpotInterruptionConfig struct {
+	// Specify whether to watch the Nodes, and once a TaskAttempt is failed or
+	// deleted while its node has any interruption signal below, complete it with
+	// CompletionCodePodSpotInterrupted, which is Transient Failed, instead of the
+	// failures of its killed Containers.
+	// Besides, the TaskAttempt can be drained in advance once the signal appears,
+	// see TaskRoleSpec SpotInterruption.
+	// Default to false.
+	// Notes:
+	// 1. The Nodes list and watch permissions are needed to enable it.
+	// 2. If the node is already deleted when the TaskAttempt is completed, the
+	//    interruption cannot be recognized.
+	Enabled *bool `yaml:"enabled"`
+
+	// The node taint keys which indicate the node is being interrupted, such as
+	// added by the cloud provider or the node termination handler.
+	// Default to the known ones of GKE and AWS.
+	NodeTaintKeys []string `yaml:"nodeTaintKeys"`
+
+	// The node condition types which indicate the node is being interrupted once
+	// they are True, such as reported by the node problem detector.
+	NodeConditionTypes []string `yaml:"nodeConditionTypes"`
+
+	// The node annotation keys which indicate the node is being interrupted once
+	// they exist.
+	NodeAnnotationKeys []string `yaml:"nodeAnnotationKeys"`
+}
+
+type EventSinkConfig struct {
+	// Default to EventSinkNone, i.e. the events are not published.
+	Type *EventSinkType `yaml:"type"`
+
+	// Host:Port of the NATS server.
+	NATSAddress *string `yaml:"natsAddress"`
+	NATSSubject *string `yaml:"natsSubject"`
+
+	KafkaRESTProxyURL *string `yaml:"kafkaRESTProxyURL"`
+	KafkaTopic        *string `yaml:"kafkaTopic"`
+
+	// The max number of events waiting to be published.
+	BufferSize *int32 `yaml:"bufferSize"`
+	// Timeout for a single publish request.
+	TimeoutSec *int64 `yaml:"timeoutSec"`
+}
+
+type TracingConfig struct {
+	// The OTLP/HTTP endpoint of the OpenTelemetry collector, such as
+	// http://otel-collector:4318, and the spans are exported to
+	// {OTLPEndpoint}/v1/traces in the OTLP JSON encoding.
+	// Default to empty, i.e. the tracing is disabled.
+	OTLPEndpoint *string `yaml:"otlpEndpoint"`
+	// The service.name resource attribute of the exported spans.
+	ServiceName *string `yaml:"serviceName"`
+
+	// The max number of ended spans waiting to be exported, and the spans are
+	// dropped if it is exceeded.
+	BufferSize *int32 `yaml:"bufferSize"`
+	// Interval and timeout to export the ended spans in batch.
+	ExportIntervalSec *int64 `yaml:"exportIntervalSec"`
+	ExportTimeoutSec  *int64 `yaml:"exportTimeoutSec"`
+}
+
+type PodDefaultsConfig struct {
+	// The SchedulerName applied to the Pod without SchedulerName, after the
+	// TaskRole SchedulerName is applied, such as to route all Pods to volcano or
+	// hivedscheduler.
+	// Default to empty, i.e. the default scheduler of K8S.
+	SchedulerName *string `yaml:"schedulerName"`
+
+	// The RuntimeClassName applied to the Pod without RuntimeClassName.
+	// Default to empty, i.e. no default RuntimeClassName.
+	RuntimeClassName *string `yaml:"runtimeClassName"`
+
+	// The seccomp profile applied to the Pod without the seccomp annotation,
+	// such as runtime/default.
+	// It is applied by the seccomp.security.alpha.kubernetes.io/pod annotation.
+	// Default to empty, i.e. no default seccomp profile.
+	SeccompProfile *string `yaml:"seccompProfile"`
+
+	// The PodSecurityContext fields applied to the Pod whose PodSecurityContext
+	// omits them.
+	// Default to nil, i.e. no default for the field.
+	SecurityContext PodSecurityContextConfig `yaml:"securityContext"`
+
+	// The names of the Secrets in the Framework namespace appended to the
+	// ImagePullSecrets of the Pod which does not refer to them yet, such as the
+	// credential of the site registry.
+	// Default to empty, i.e. no default ImagePullSecrets.
+	ImagePullSecrets []string `yaml:"imagePullSecrets"`
+
+	// The rules to rewrite the registry of the Container images, such as to pull
+	// the images from the registry mirror in the air-gapped cluster.
+	// Only the first matched rule is applied to each image.
+	// Default to empty, i.e. no rewrite.
+	ImageRegistryRewrites []ImageRegistryRewriteConfig `yaml:"imageRegistryRewrites"`
+}
+
+type ImageRegistryRewriteConfig struct {
+	// The registry, optionally followed by the repository path, to be matched,
+	// such as docker.io or nvcr.io/nvidia.
+	// The image without the registry is matched as it is in docker.io, and the
+	// image without the repository path in docker.io is matched as it is in
+	// docker.io/library, i.e. ubuntu is matched as docker.io/library/ubuntu.
+	From string `yaml:"from"`
+	// The registry, optionally followed by the repository path, to replace the
+	// matched From, such as mirror.local:5000/docker.io.
+	To string `yaml:"to"`
+}
+
+type NodeBlacklistConfig struct {
+	// Specify whether to record the node of the TaskAttempt which failed with
+	// the infrastructure CompletionCode into the Framework BlacklistedNodes, and
+	// inject the NodeAffinity into all later created Pods of the Framework to
+	// avoid the BlacklistedNodes.
+	// The infrastructure CompletionCode is the Transient Failed one issued by
+	// K8S or the container runtime, i.e. CompletionCode <= -1000, such as the
+	// PodNodeLost.
+	// Default to false.
+	Enabled *bool `yaml:"enabled"`
+	// The max number of BlacklistedNodes of a Framework, and the oldest ones are
+	// dropped if it is exceeded, so that the Framework will not be
+	// unschedulable on a small cluster.
+	MaxNodeCount *int32 `yaml:"maxNodeCount"`
+	// The BlacklistedNode is expired after TTLSec since its last failure.
+	TTLSec *int64 `yaml:"ttlSec"`
+}
+
+type FailureClassifierFailurePolicy string
+
+const (
+	// Fall back to the CompletionStatus matched by the PodFailureSpec.
+	FailureClassifierFailurePolicyIgnore FailureClassifierFailurePolicy = "Ignore"
+	// Retry the classification later, i.e. the TaskAttempt will not be completed
+	// until the webhook succeeded.
+	FailureClassifierFailurePolicyFail FailureClassifierFailurePolicy = "Fail"
+)
+
+type FailureClassifierConfig struct {
+	// Default to empty, i.e. the webhook is disabled.
+	// POST to {URL} with the FailureClassificationRequest as the JSON body, and
+	// expect the FailureClassificationResponse as the JSON body.
+	URL *string `yaml:"url"`
+
+	// Default to FailureClassifierFailurePolicyIgnore.
+	// How to handle the webhook failure, such as timeout or invalid response.
+	FailurePolicy *FailureClassifierFailurePolicy `yaml:"failurePolicy"`
+
+	// Timeout for a single classification request.
+	// The webhook is called synchronously on the sync worker for each failed Pod,
+	// so it should be well below the resync interval, and it cannot exceed 10.
+	TimeoutSec *int64 `yaml:"timeoutSec"`
+}
+
+type FaultInjectionConfig struct {
+	// Default to false, i.e. no fault is injected.
+	Enabled *bool `yaml:"enabled"`
+
+	// The percentage of the writes to the ApiServer which are failed with a
+	// transient error.
+	// Half of the failed writes are failed before they are sent, and the other
+	// half are failed after they are sent, i.e. the writes may have been
+	// persisted but their responses are lost.
+	WriteFailurePercent *int32 `yaml:"writeFailurePercent"`
+
+	// The max random delay before each chunk of the watch responses from the
+	// ApiServer is delivered, so that the informer events are delayed and the
+	// local cache is stale.
+	WatchEventMaxDelayMs *int64 `yaml:"watchEventMaxDelayMs"`
+
+	// The percentage of the Pods and ConfigMaps in the local cache which are
+	// dropped every CacheDropIntervalSec, and the dropped objects are restored
+	// from the ApiServer after another CacheDropIntervalSec if they are still
+	// absent.
+	// The Frameworks are never dropped, as a Framework absent in the local cache
+	// is treated as deleted.
+	CacheDropPercent     *int32 `yaml:"cacheDropPercent"`
+	CacheDropIntervalSec *int64 `yaml:"cacheDropIntervalSec"`
+}
+
+type TaskRoleAutoscalerConfig struct {
+	// The name of the TaskRoleAutoscaler, which can be compiled in by
+	// controller.RegisterTaskRoleAutoscaler, or WebhookTaskRoleAutoscalerName to
+	// call the external webhook at URL.
+	// Default to empty, i.e. the TaskRoleAutoscaler is disabled.
+	Name *string `yaml:"name"`
+
+	// The interval to consult the TaskRoleAutoscaler for each FrameworkAttempt.
+	IntervalSec *int64 `yaml:"intervalSec"`
+
+	// Only used by WebhookTaskRoleAutoscalerName.
+	// POST to {URL} with the TaskRoleAutoscaleRequest as the JSON body, and
+	// expect the TaskRoleAutoscaleResponse as the JSON body.
+	URL *string `yaml:"url"`
+	// Timeout for a single autoscale request.
+	TimeoutSec *int64 `yaml:"timeoutSec"`
+}
+
+type PodSecurityContextConfig struct {
+	RunAsNonRoot *bool  `yaml:"runAsNonRoot"`
+	RunAsUser    *int64 `yaml:"runAsUser"`
+	RunAsGroup   *int64 `yaml:"runAsGroup"`
+	FSGroup      *int64 `yaml:"fsGroup"`
+}
+
 type LogObjectSnapshot struct {
 	Framework LogFrameworkSnapshot `yaml:"framework"`
 	Pod       LogPodSnapshot       `yaml:"pod"`
@@ -178,10 +935,12 @@ type CompletionCodeInfo struct {
 // ALL its fields are optional and default to match ANY.
 // It is matched if and only if ALL its fields are matched.
 type PodPattern struct {
-	NameRegex    Regex               `yaml:"nameRegex,omitempty"`
-	ReasonRegex  Regex               `yaml:"reasonRegex,omitempty"`
-	MessageRegex Regex               `yaml:"messageRegex,omitempty"`
-	Containers   []*ContainerPattern `yaml:"containers,omitempty"`
+	NameRegex    Regex `yaml:"nameRegex,omitempty"`
+	ReasonRegex  Regex `yaml:"reasonRegex,omitempty"`
+	MessageRegex Regex `yaml:"messageRegex,omitempty"`
+	// It is the regex of the reason of the True DisruptionTarget Pod condition.
+	DisruptionReasonRegex Regex               `yaml:"disruptionReasonRegex,omitempty"`
+	Containers            []*ContainerPattern `yaml:"containers,omitempty"`
 }
 
 type ContainerPattern struct {
@@ -206,8 +965,19 @@ type Int32Range struct {
 }
 
 func NewConfig() *Config {
-	c := initConfig()
+	return completeConfig(initConfig())
+}
 
+// Same as NewConfig, but the config is parsed from the given yaml instead of
+// the config file, such as to construct the Config in tests.
+func NewConfigFromYaml(yamlStr string) *Config {
+	c := Config{}
+	common.FromYaml(yamlStr, &c)
+	return completeConfig(&c)
+}
+
+// Default and validate the given Config in place.
+func completeConfig(c *Config) *Config {
 	// Defaulting
 	if c.KubeApiServerAddress == nil {
 		c.KubeApiServerAddress = common.PtrString(EnvValueKubeApiServerAddress)
@@ -218,9 +988,102 @@ func NewConfig() *Config {
 	if c.WorkerNumber == nil {
 		c.WorkerNumber = common.PtrInt32(10)
 	}
+	if c.ConfigReloadIntervalSec == nil {
+		c.ConfigReloadIntervalSec = common.PtrInt64(0)
+	}
+	if c.ShutdownDrainTimeoutSec == nil {
+		c.ShutdownDrainTimeoutSec = common.PtrInt64(60)
+	}
+	if c.Sharding.ShardNumber == nil {
+		c.Sharding.ShardNumber = common.PtrInt32(1)
+	}
+	if c.Sharding.ShardIndex == nil {
+		c.Sharding.ShardIndex = common.PtrInt32(0)
+	}
+	if c.Sharding.MembershipEnabled == nil {
+		c.Sharding.MembershipEnabled = common.PtrBool(false)
+	}
+	if c.Sharding.MembershipNamespace == nil {
+		c.Sharding.MembershipNamespace = common.PtrString(meta.NamespaceDefault)
+	}
+	if c.Sharding.MemberID == nil {
+		c.Sharding.MemberID = defaultMemberID()
+	}
+	if c.Sharding.MemberLeaseDurationSec == nil {
+		c.Sharding.MemberLeaseDurationSec = common.PtrInt64(30)
+	}
+	if c.Sharding.MemberRenewIntervalSec == nil {
+		c.Sharding.MemberRenewIntervalSec = common.PtrInt64(10)
+	}
+	if c.Sharding.ShardLabelKey == nil {
+		c.Sharding.ShardLabelKey = common.PtrString("")
+	}
 	if c.LargeFrameworkCompression == nil {
 		c.LargeFrameworkCompression = common.PtrBool(false)
 	}
+	if c.LocalCacheObjectTransform == nil {
+		c.LocalCacheObjectTransform = common.PtrBool(false)
+	}
+	if c.DryRunEnabled == nil {
+		c.DryRunEnabled = common.PtrBool(false)
+	}
+	if c.FrameworkAttemptHistoryEnabled == nil {
+		c.FrameworkAttemptHistoryEnabled = common.PtrBool(false)
+	}
+	if c.ScheduledFrameworkEnabled == nil {
+		c.ScheduledFrameworkEnabled = common.PtrBool(false)
+	}
+	if c.ScheduledFrameworkWorkerNumber == nil {
+		c.ScheduledFrameworkWorkerNumber = common.PtrInt32(2)
+	}
+	if c.FrameworkGroupEnabled == nil {
+		c.FrameworkGroupEnabled = common.PtrBool(false)
+	}
+	if c.FrameworkGroupWorkerNumber == nil {
+		c.FrameworkGroupWorkerNumber = common.PtrInt32(2)
+	}
+	if c.QueueEnabled == nil {
+		c.QueueEnabled = common.PtrBool(false)
+	}
+	if c.QueueWorkerNumber == nil {
+		c.QueueWorkerNumber = common.PtrInt32(2)
+	}
+	if c.FrameworkQuotaEnabled == nil {
+		c.FrameworkQuotaEnabled = common.PtrBool(false)
+	}
+	if c.FrameworkQuotaWorkerNumber == nil {
+		c.FrameworkQuotaWorkerNumber = common.PtrInt32(2)
+	}
+	if c.TaskRoleScaleEnabled == nil {
+		c.TaskRoleScaleEnabled = common.PtrBool(false)
+	}
+	if c.TaskRoleScaleWorkerNumber == nil {
+		c.TaskRoleScaleWorkerNumber = common.PtrInt32(2)
+	}
+	if c.KubeflowJobEnabled == nil {
+		c.KubeflowJobEnabled = common.PtrBool(false)
+	}
+	if c.KubeflowJobWorkerNumber == nil {
+		c.KubeflowJobWorkerNumber = common.PtrInt32(2)
+	}
+	if c.TaskRoleHeadlessServiceEnabled == nil {
+		c.TaskRoleHeadlessServiceEnabled = common.PtrBool(false)
+	}
+	if c.TaskHostnameEnabled == nil {
+		c.TaskHostnameEnabled = common.PtrBool(true)
+	}
+	if c.PodReadinessGateEnabled == nil {
+		c.PodReadinessGateEnabled = common.PtrBool(false)
+	}
+	if c.ClusterDomain == nil {
+		c.ClusterDomain = common.PtrString("cluster.local")
+	}
+	if c.PortAllocationRange.Min == nil {
+		c.PortAllocationRange.Min = common.PtrInt32(20000)
+	}
+	if c.PortAllocationRange.Max == nil {
+		c.PortAllocationRange.Max = common.PtrInt32(29999)
+	}
 	if c.CRDEstablishedCheckIntervalSec == nil {
 		c.CRDEstablishedCheckIntervalSec = common.PtrInt64(1)
 	}
@@ -231,9 +1094,203 @@ func NewConfig() *Config {
 		// Default to k8s.io/kubernetes/pkg/controller.ExpectationsTimeout
 		c.ObjectLocalCacheCreationTimeoutSec = common.PtrInt64(5 * 60)
 	}
+	if c.PodNodeUnmatchedTimeoutSec == nil {
+		c.PodNodeUnmatchedTimeoutSec = common.PtrInt64(5 * 60)
+	}
+	if c.PodEventDiagnosticsMaxCount == nil {
+		c.PodEventDiagnosticsMaxCount = common.PtrInt32(0)
+	}
+	if c.PodEventDiagnosticsMaxBytes == nil {
+		c.PodEventDiagnosticsMaxBytes = common.PtrInt32(1024)
+	}
+	if c.FailedContainerLogTailLines == nil {
+		c.FailedContainerLogTailLines = common.PtrInt64(0)
+	}
+	if c.FailedContainerLogTailMaxBytes == nil {
+		c.FailedContainerLogTailMaxBytes = common.PtrInt32(2048)
+	}
+	if c.FailedContainerLogTailTimeoutSec == nil {
+		c.FailedContainerLogTailTimeoutSec = common.PtrInt64(10)
+	}
 	if c.FrameworkCompletedRetainSec == nil {
 		c.FrameworkCompletedRetainSec = common.PtrInt64(30 * 24 * 3600)
 	}
+	if c.FrameworkSucceededRetainSec == nil {
+		c.FrameworkSucceededRetainSec = common.PtrInt64(*c.FrameworkCompletedRetainSec)
+	}
+	if c.FrameworkFailedRetainSec == nil {
+		c.FrameworkFailedRetainSec = common.PtrInt64(*c.FrameworkCompletedRetainSec)
+	}
+	if c.FrameworkDeletionPropagationPolicy == nil {
+		c.FrameworkDeletionPropagationPolicy =
+			common.PtrDeletionPropagation(meta.DeletePropagationForeground)
+	}
+	if c.ConfigMapDeletionPropagationPolicy == nil {
+		c.ConfigMapDeletionPropagationPolicy = common.PtrDeletionPropagation("")
+	}
+	if c.FrameworkArchive.Type == nil {
+		t := FrameworkArchiveNone
+		c.FrameworkArchive.Type = &t
+	}
+	if c.FrameworkArchive.FileDir == nil {
+		c.FrameworkArchive.FileDir = common.PtrString("")
+	}
+	if c.FrameworkArchive.HTTPURL == nil {
+		c.FrameworkArchive.HTTPURL = common.PtrString("")
+	}
+	if c.FrameworkArchive.AzureBlobContainerSASURL == nil {
+		c.FrameworkArchive.AzureBlobContainerSASURL = common.PtrString("")
+	}
+	if c.FrameworkArchive.TimeoutSec == nil {
+		c.FrameworkArchive.TimeoutSec = common.PtrInt64(30)
+	}
+	if c.GangScheduling.PodGroupEnabled == nil {
+		c.GangScheduling.PodGroupEnabled = common.PtrBool(false)
+	}
+	if c.GangScheduling.SchedulerName == nil {
+		c.GangScheduling.SchedulerName = common.PtrString("")
+	}
+	if c.GangScheduling.VolcanoEnabled == nil {
+		c.GangScheduling.VolcanoEnabled = common.PtrBool(true)
+	}
+	if c.GangScheduling.VolcanoSchedulerName == nil {
+		c.GangScheduling.VolcanoSchedulerName = common.PtrString("volcano")
+	}
+	if c.Kueue.Enabled == nil {
+		c.Kueue.Enabled = common.PtrBool(false)
+	}
+	if c.NodeLost.Enabled == nil {
+		c.NodeLost.Enabled = common.PtrBool(false)
+	}
+	if c.NodeLost.NotReadyGraceSec == nil {
+		c.NodeLost.NotReadyGraceSec = common.PtrInt64(300)
+	}
+	if c.NodeLost.ForceDeletePod == nil {
+		c.NodeLost.ForceDeletePod = common.PtrBool(false)
+	}
+	if c.CapacityCheck.Enabled == nil {
+		c.CapacityCheck.Enabled = common.PtrBool(false)
+	}
+	if c.CapacityCheck.RecheckIntervalSec == nil {
+		c.CapacityCheck.RecheckIntervalSec = common.PtrInt64(30)
+	}
+	if c.FairShare.Enabled == nil {
+		c.FairShare.Enabled = common.PtrBool(false)
+	}
+	if c.FairShare.GroupBy == nil {
+		g := FairShareGroupByNamespace
+		c.FairShare.GroupBy = &g
+	}
+	if c.SpotInterruption.Enabled == nil {
+		c.SpotInterruption.Enabled = common.PtrBool(false)
+	}
+	if c.SpotInterruption.NodeTaintKeys == nil {
+		c.SpotInterruption.NodeTaintKeys = []string{
+			"cloud.google.com/impending-node-termination",
+			"aws-node-termination-handler/spot-itn",
+		}
+	}
+	if c.EventSink.Type == nil {
+		t := EventSinkNone
+		c.EventSink.Type = &t
+	}
+	if c.EventSink.NATSAddress == nil {
+		c.EventSink.NATSAddress = common.PtrString("")
+	}
+	if c.EventSink.NATSSubject == nil {
+		c.EventSink.NATSSubject = common.PtrString(ComponentName + ".events")
+	}
+	if c.EventSink.KafkaRESTProxyURL == nil {
+		c.EventSink.KafkaRESTProxyURL = common.PtrString("")
+	}
+	if c.EventSink.KafkaTopic == nil {
+		c.EventSink.KafkaTopic = common.PtrString(ComponentName + "-events")
+	}
+	if c.EventSink.BufferSize == nil {
+		c.EventSink.BufferSize = common.PtrInt32(10000)
+	}
+	if c.EventSink.TimeoutSec == nil {
+		c.EventSink.TimeoutSec = common.PtrInt64(10)
+	}
+	if c.Tracing.OTLPEndpoint == nil {
+		c.Tracing.OTLPEndpoint = common.PtrString("")
+	}
+	if c.Tracing.ServiceName == nil {
+		c.Tracing.ServiceName = common.PtrString(ComponentName)
+	}
+	if c.Tracing.BufferSize == nil {
+		c.Tracing.BufferSize = common.PtrInt32(10000)
+	}
+	if c.Tracing.ExportIntervalSec == nil {
+		c.Tracing.ExportIntervalSec = common.PtrInt64(5)
+	}
+	if c.Tracing.ExportTimeoutSec == nil {
+		c.Tracing.ExportTimeoutSec = common.PtrInt64(10)
+	}
+	if c.NodeBlacklist.Enabled == nil {
+		c.NodeBlacklist.Enabled = common.PtrBool(false)
+	}
+	if c.NodeBlacklist.MaxNodeCount == nil {
+		c.NodeBlacklist.MaxNodeCount = common.PtrInt32(10)
+	}
+	if c.NodeBlacklist.TTLSec == nil {
+		c.NodeBlacklist.TTLSec = common.PtrInt64(60 * 60)
+	}
+	if c.TerminationMessageCompletionEnabled == nil {
+		c.TerminationMessageCompletionEnabled = common.PtrBool(false)
+	}
+	if c.FailureClassifier.URL == nil {
+		c.FailureClassifier.URL = common.PtrString("")
+	}
+	if c.FailureClassifier.FailurePolicy == nil {
+		fp := FailureClassifierFailurePolicyIgnore
+		c.FailureClassifier.FailurePolicy = &fp
+	}
+	if c.FailureClassifier.TimeoutSec == nil {
+		c.FailureClassifier.TimeoutSec = common.PtrInt64(5)
+	}
+	if c.FaultInjection.Enabled == nil {
+		c.FaultInjection.Enabled = common.PtrBool(false)
+	}
+	if c.FaultInjection.WriteFailurePercent == nil {
+		c.FaultInjection.WriteFailurePercent = common.PtrInt32(10)
+	}
+	if c.FaultInjection.WatchEventMaxDelayMs == nil {
+		c.FaultInjection.WatchEventMaxDelayMs = common.PtrInt64(1000)
+	}
+	if c.FaultInjection.CacheDropPercent == nil {
+		c.FaultInjection.CacheDropPercent = common.PtrInt32(1)
+	}
+	if c.FaultInjection.CacheDropIntervalSec == nil {
+		c.FaultInjection.CacheDropIntervalSec = common.PtrInt64(10)
+	}
+	if c.PodDefaults.SchedulerName == nil {
+		c.PodDefaults.SchedulerName = common.PtrString("")
+	}
+	if c.PodDefaults.RuntimeClassName == nil {
+		c.PodDefaults.RuntimeClassName = common.PtrString("")
+	}
+	if c.PodDefaults.SeccompProfile == nil {
+		c.PodDefaults.SeccompProfile = common.PtrString("")
+	}
+	if c.PodDecorators == nil {
+		c.PodDecorators = []string{SecretRefPodDecoratorName}
+	}
+	if c.RetryDecider == nil {
+		c.RetryDecider = common.PtrString(DefaultRetryDeciderName)
+	}
+	if c.TaskRoleAutoscaler.Name == nil {
+		c.TaskRoleAutoscaler.Name = common.PtrString("")
+	}
+	if c.TaskRoleAutoscaler.IntervalSec == nil {
+		c.TaskRoleAutoscaler.IntervalSec = common.PtrInt64(60)
+	}
+	if c.TaskRoleAutoscaler.URL == nil {
+		c.TaskRoleAutoscaler.URL = common.PtrString("")
+	}
+	if c.TaskRoleAutoscaler.TimeoutSec == nil {
+		c.TaskRoleAutoscaler.TimeoutSec = common.PtrInt64(5)
+	}
 	if c.FrameworkMinRetryDelaySecForTransientConflictFailed == nil {
 		c.FrameworkMinRetryDelaySecForTransientConflictFailed = common.PtrInt64(60)
 	}
@@ -263,11 +1320,97 @@ func NewConfig() *Config {
 
 	// Validation
 	errPrefix := "Config Validation Failed: "
+	if *c.PortAllocationRange.Min < 1 ||
+		*c.PortAllocationRange.Max > 65535 ||
+		*c.PortAllocationRange.Min > *c.PortAllocationRange.Max {
+		panic(fmt.Errorf(errPrefix+
+			"PortAllocationRange [%v, %v] should be within [1, 65535] and not empty",
+			*c.PortAllocationRange.Min, *c.PortAllocationRange.Max))
+	}
 	if *c.WorkerNumber <= 0 {
 		panic(fmt.Errorf(errPrefix+
 			"WorkerNumber %v should be positive",
 			*c.WorkerNumber))
 	}
+	if *c.ShutdownDrainTimeoutSec < 0 {
+		panic(fmt.Errorf(errPrefix+
+			"ShutdownDrainTimeoutSec %v should not be negative",
+			*c.ShutdownDrainTimeoutSec))
+	}
+	if *c.Sharding.ShardNumber <= 0 {
+		panic(fmt.Errorf(errPrefix+
+			"Sharding.ShardNumber %v should be positive",
+			*c.Sharding.ShardNumber))
+	}
+	if *c.Sharding.ShardIndex < 0 || *c.Sharding.ShardIndex >= *c.Sharding.ShardNumber {
+		panic(fmt.Errorf(errPrefix+
+			"Sharding.ShardIndex %v should be within [0, %v)",
+			*c.Sharding.ShardIndex, *c.Sharding.ShardNumber))
+	}
+	if *c.Sharding.MembershipEnabled {
+		if *c.Sharding.MemberID == "" {
+			panic(fmt.Errorf(errPrefix +
+				"Sharding.MemberID should not be empty if Sharding.MembershipEnabled"))
+		}
+		if *c.Sharding.MemberRenewIntervalSec < 1 {
+			panic(fmt.Errorf(errPrefix+
+				"Sharding.MemberRenewIntervalSec %v should not be less than 1",
+				*c.Sharding.MemberRenewIntervalSec))
+		}
+		if *c.Sharding.MemberLeaseDurationSec < 2*(*c.Sharding.MemberRenewIntervalSec) {
+			panic(fmt.Errorf(errPrefix+
+				"Sharding.MemberLeaseDurationSec %v should not be less than twice of "+
+				"Sharding.MemberRenewIntervalSec %v",
+				*c.Sharding.MemberLeaseDurationSec, *c.Sharding.MemberRenewIntervalSec))
+		}
+	}
+	if !IsValidDeletionPropagation(*c.FrameworkDeletionPropagationPolicy) {
+		panic(fmt.Errorf(errPrefix+
+			"FrameworkDeletionPropagationPolicy %v should be in %v",
+			*c.FrameworkDeletionPropagationPolicy, ValidDeletionPropagations))
+	}
+	if !IsValidDeletionPropagation(*c.ConfigMapDeletionPropagationPolicy) {
+		panic(fmt.Errorf(errPrefix+
+			"ConfigMapDeletionPropagationPolicy %v should be in %v",
+			*c.ConfigMapDeletionPropagationPolicy, ValidDeletionPropagations))
+	}
+	for i, rewrite := range c.PodDefaults.ImageRegistryRewrites {
+		if rewrite.From == "" || rewrite.To == "" {
+			panic(fmt.Errorf(errPrefix+
+				"PodDefaults.ImageRegistryRewrites[%v] should specify both From and To",
+				i))
+		}
+	}
+	if *c.ScheduledFrameworkWorkerNumber <= 0 {
+		panic(fmt.Errorf(errPrefix+
+			"ScheduledFrameworkWorkerNumber %v should be positive",
+			*c.ScheduledFrameworkWorkerNumber))
+	}
+	if *c.FrameworkGroupWorkerNumber <= 0 {
+		panic(fmt.Errorf(errPrefix+
+			"FrameworkGroupWorkerNumber %v should be positive",
+			*c.FrameworkGroupWorkerNumber))
+	}
+	if *c.QueueWorkerNumber <= 0 {
+		panic(fmt.Errorf(errPrefix+
+			"QueueWorkerNumber %v should be positive",
+			*c.QueueWorkerNumber))
+	}
+	if *c.FrameworkQuotaWorkerNumber <= 0 {
+		panic(fmt.Errorf(errPrefix+
+			"FrameworkQuotaWorkerNumber %v should be positive",
+			*c.FrameworkQuotaWorkerNumber))
+	}
+	if *c.KubeflowJobWorkerNumber <= 0 {
+		panic(fmt.Errorf(errPrefix+
+			"KubeflowJobWorkerNumber %v should be positive",
+			*c.KubeflowJobWorkerNumber))
+	}
+	if *c.TaskRoleScaleWorkerNumber <= 0 {
+		panic(fmt.Errorf(errPrefix+
+			"TaskRoleScaleWorkerNumber %v should be positive",
+			*c.TaskRoleScaleWorkerNumber))
+	}
 	if *c.CRDEstablishedCheckIntervalSec < 1 {
 		panic(fmt.Errorf(errPrefix+
 			"CRDEstablishedCheckIntervalSec %v should not be less than 1",
@@ -283,6 +1426,60 @@ func NewConfig() *Config {
 			"ObjectLocalCacheCreationTimeoutSec %v should not be less than 60",
 			*c.ObjectLocalCacheCreationTimeoutSec))
 	}
+	if *c.NodeLost.NotReadyGraceSec < 0 {
+		panic(fmt.Errorf(errPrefix+
+			"NodeLost.NotReadyGraceSec %v should not be negative",
+			*c.NodeLost.NotReadyGraceSec))
+	}
+	if *c.CapacityCheck.RecheckIntervalSec <= 0 {
+		panic(fmt.Errorf(errPrefix+
+			"CapacityCheck.RecheckIntervalSec %v should be positive",
+			*c.CapacityCheck.RecheckIntervalSec))
+	}
+	if *c.FairShare.Enabled && !*c.CapacityCheck.Enabled {
+		panic(fmt.Errorf(errPrefix +
+			"CapacityCheck should be enabled if FairShare is enabled"))
+	}
+	switch *c.FairShare.GroupBy {
+	case FairShareGroupByNamespace:
+	case FairShareGroupByQueue:
+	default:
+		panic(fmt.Errorf(errPrefix+
+			"FairShare.GroupBy %v is not supported",
+			*c.FairShare.GroupBy))
+	}
+	for group, weight := range c.FairShare.Weights {
+		if weight <= 0 {
+			panic(fmt.Errorf(errPrefix+
+				"FairShare.Weights[%v] %v should be positive",
+				group, weight))
+		}
+	}
+	if *c.PodEventDiagnosticsMaxCount < 0 {
+		panic(fmt.Errorf(errPrefix+
+			"PodEventDiagnosticsMaxCount %v should not be negative",
+			*c.PodEventDiagnosticsMaxCount))
+	}
+	if *c.PodEventDiagnosticsMaxBytes <= 0 {
+		panic(fmt.Errorf(errPrefix+
+			"PodEventDiagnosticsMaxBytes %v should be positive",
+			*c.PodEventDiagnosticsMaxBytes))
+	}
+	if *c.FailedContainerLogTailLines < 0 {
+		panic(fmt.Errorf(errPrefix+
+			"FailedContainerLogTailLines %v should not be negative",
+			*c.FailedContainerLogTailLines))
+	}
+	if *c.FailedContainerLogTailMaxBytes <= 0 {
+		panic(fmt.Errorf(errPrefix+
+			"FailedContainerLogTailMaxBytes %v should be positive",
+			*c.FailedContainerLogTailMaxBytes))
+	}
+	if *c.FailedContainerLogTailTimeoutSec <= 0 {
+		panic(fmt.Errorf(errPrefix+
+			"FailedContainerLogTailTimeoutSec %v should be positive",
+			*c.FailedContainerLogTailTimeoutSec))
+	}
 	if *c.FrameworkMinRetryDelaySecForTransientConflictFailed < 0 {
 		panic(fmt.Errorf(errPrefix+
 			"FrameworkMinRetryDelaySecForTransientConflictFailed %v should not be negative",
@@ -296,6 +1493,136 @@ func NewConfig() *Config {
 			*c.FrameworkMaxRetryDelaySecForTransientConflictFailed,
 			*c.FrameworkMinRetryDelaySecForTransientConflictFailed))
 	}
+	switch *c.FrameworkArchive.Type {
+	case FrameworkArchiveNone:
+	case FrameworkArchiveFile:
+		if *c.FrameworkArchive.FileDir == "" {
+			panic(fmt.Errorf(errPrefix +
+				"FrameworkArchive.FileDir should not be empty for File FrameworkArchive"))
+		}
+	case FrameworkArchiveHTTP:
+		if *c.FrameworkArchive.HTTPURL == "" {
+			panic(fmt.Errorf(errPrefix +
+				"FrameworkArchive.HTTPURL should not be empty for HTTP FrameworkArchive"))
+		}
+	case FrameworkArchiveAzureBlob:
+		if *c.FrameworkArchive.AzureBlobContainerSASURL == "" {
+			panic(fmt.Errorf(errPrefix +
+				"FrameworkArchive.AzureBlobContainerSASURL should not be empty for " +
+				"AzureBlob FrameworkArchive"))
+		}
+	default:
+		panic(fmt.Errorf(errPrefix+
+			"FrameworkArchive.Type %v is not supported",
+			*c.FrameworkArchive.Type))
+	}
+	if *c.FrameworkArchive.TimeoutSec < 1 {
+		panic(fmt.Errorf(errPrefix+
+			"FrameworkArchive.TimeoutSec %v should not be less than 1",
+			*c.FrameworkArchive.TimeoutSec))
+	}
+	if c.GangScheduling.ScheduleTimeoutSec != nil &&
+		*c.GangScheduling.ScheduleTimeoutSec < 1 {
+		panic(fmt.Errorf(errPrefix+
+			"GangScheduling.ScheduleTimeoutSec %v should not be less than 1",
+			*c.GangScheduling.ScheduleTimeoutSec))
+	}
+	switch *c.EventSink.Type {
+	case EventSinkNone:
+	case EventSinkNATS:
+		if *c.EventSink.NATSAddress == "" || *c.EventSink.NATSSubject == "" {
+			panic(fmt.Errorf(errPrefix +
+				"EventSink.NATSAddress and EventSink.NATSSubject should not be empty " +
+				"for NATS EventSink"))
+		}
+	case EventSinkKafkaRESTProxy:
+		if *c.EventSink.KafkaRESTProxyURL == "" || *c.EventSink.KafkaTopic == "" {
+			panic(fmt.Errorf(errPrefix +
+				"EventSink.KafkaRESTProxyURL and EventSink.KafkaTopic should not be " +
+				"empty for KafkaRESTProxy EventSink"))
+		}
+	default:
+		panic(fmt.Errorf(errPrefix+
+			"EventSink.Type %v is not supported",
+			*c.EventSink.Type))
+	}
+	if *c.EventSink.BufferSize < 1 {
+		panic(fmt.Errorf(errPrefix+
+			"EventSink.BufferSize %v should not be less than 1",
+			*c.EventSink.BufferSize))
+	}
+	if *c.EventSink.TimeoutSec < 1 {
+		panic(fmt.Errorf(errPrefix+
+			"EventSink.TimeoutSec %v should not be less than 1",
+			*c.EventSink.TimeoutSec))
+	}
+	if *c.Tracing.BufferSize < 1 {
+		panic(fmt.Errorf(errPrefix+
+			"Tracing.BufferSize %v should not be less than 1",
+			*c.Tracing.BufferSize))
+	}
+	if *c.Tracing.ExportIntervalSec < 1 {
+		panic(fmt.Errorf(errPrefix+
+			"Tracing.ExportIntervalSec %v should not be less than 1",
+			*c.Tracing.ExportIntervalSec))
+	}
+	if *c.Tracing.ExportTimeoutSec < 1 {
+		panic(fmt.Errorf(errPrefix+
+			"Tracing.ExportTimeoutSec %v should not be less than 1",
+			*c.Tracing.ExportTimeoutSec))
+	}
+	switch *c.FailureClassifier.FailurePolicy {
+	case FailureClassifierFailurePolicyIgnore:
+	case FailureClassifierFailurePolicyFail:
+	default:
+		panic(fmt.Errorf(errPrefix+
+			"FailureClassifier.FailurePolicy %v is not supported",
+			*c.FailureClassifier.FailurePolicy))
+	}
+	if *c.TaskRoleAutoscaler.IntervalSec < 1 {
+		panic(fmt.Errorf(errPrefix+
+			"TaskRoleAutoscaler.IntervalSec %v should not be less than 1",
+			*c.TaskRoleAutoscaler.IntervalSec))
+	}
+	if *c.TaskRoleAutoscaler.Name == WebhookTaskRoleAutoscalerName &&
+		*c.TaskRoleAutoscaler.URL == "" {
+		panic(fmt.Errorf(errPrefix+
+			"TaskRoleAutoscaler.URL should not be empty for TaskRoleAutoscaler %v",
+			WebhookTaskRoleAutoscalerName))
+	}
+	if *c.TaskRoleAutoscaler.TimeoutSec < 1 {
+		panic(fmt.Errorf(errPrefix+
+			"TaskRoleAutoscaler.TimeoutSec %v should not be less than 1",
+			*c.TaskRoleAutoscaler.TimeoutSec))
+	}
+	if *c.FailureClassifier.TimeoutSec < 1 ||
+		*c.FailureClassifier.TimeoutSec > 10 {
+		panic(fmt.Errorf(errPrefix+
+			"FailureClassifier.TimeoutSec %v should be within [1, 10]",
+			*c.FailureClassifier.TimeoutSec))
+	}
+	if *c.FaultInjection.WriteFailurePercent < 0 ||
+		*c.FaultInjection.WriteFailurePercent > 100 {
+		panic(fmt.Errorf(errPrefix+
+			"FaultInjection.WriteFailurePercent %v should be within [0, 100]",
+			*c.FaultInjection.WriteFailurePercent))
+	}
+	if *c.FaultInjection.WatchEventMaxDelayMs < 0 {
+		panic(fmt.Errorf(errPrefix+
+			"FaultInjection.WatchEventMaxDelayMs %v should not be less than 0",
+			*c.FaultInjection.WatchEventMaxDelayMs))
+	}
+	if *c.FaultInjection.CacheDropPercent < 0 ||
+		*c.FaultInjection.CacheDropPercent > 100 {
+		panic(fmt.Errorf(errPrefix+
+			"FaultInjection.CacheDropPercent %v should be within [0, 100]",
+			*c.FaultInjection.CacheDropPercent))
+	}
+	if *c.FaultInjection.CacheDropIntervalSec < 1 {
+		panic(fmt.Errorf(errPrefix+
+			"FaultInjection.CacheDropIntervalSec %v should not be less than 1",
+			*c.FaultInjection.CacheDropIntervalSec))
+	}
 	codeInfoMap := map[CompletionCode]*CompletionCodeInfo{}
 	for _, codeInfo := range c.PodFailureSpec {
 		if codeInfo.Type.Name != CompletionTypeNameFailed {
@@ -347,6 +1674,46 @@ func NewConfig() *Config {
 	return c
 }
 
+// Same as NewConfig, but return error instead of panic if the config file is
+// invalid.
+func TryNewConfig() (c *Config, err error) {
+	defer func() {
+		if r := recover(); r != nil {
+			err = fmt.Errorf("%v", r)
+		}
+	}()
+
+	return NewConfig(), nil
+}
+
+// Return a copy of current Config with the hot reloadable fields overridden by
+// the newConfig.
+// See ConfigReloadIntervalSec.
+func (c *Config) HotReload(newConfig *Config) *Config {
+	reloaded := *c
+	reloaded.WorkerNumber = common.PtrInt32(
+		common.MaxInt32(*c.WorkerNumber, *newConfig.WorkerNumber))
+	reloaded.ShutdownDrainTimeoutSec = newConfig.ShutdownDrainTimeoutSec
+	reloaded.LargeFrameworkCompression = newConfig.LargeFrameworkCompression
+	reloaded.ObjectLocalCacheCreationTimeoutSec = newConfig.ObjectLocalCacheCreationTimeoutSec
+	reloaded.PodEventDiagnosticsMaxCount = newConfig.PodEventDiagnosticsMaxCount
+	reloaded.PodEventDiagnosticsMaxBytes = newConfig.PodEventDiagnosticsMaxBytes
+	reloaded.FailedContainerLogTailLines = newConfig.FailedContainerLogTailLines
+	reloaded.FailedContainerLogTailMaxBytes = newConfig.FailedContainerLogTailMaxBytes
+	reloaded.FailedContainerLogTailTimeoutSec = newConfig.FailedContainerLogTailTimeoutSec
+	reloaded.FrameworkCompletedRetainSec = newConfig.FrameworkCompletedRetainSec
+	reloaded.FrameworkSucceededRetainSec = newConfig.FrameworkSucceededRetainSec
+	reloaded.FrameworkFailedRetainSec = newConfig.FrameworkFailedRetainSec
+	reloaded.FrameworkDeletionPropagationPolicy = newConfig.FrameworkDeletionPropagationPolicy
+	reloaded.ConfigMapDeletionPropagationPolicy = newConfig.ConfigMapDeletionPropagationPolicy
+	reloaded.FrameworkMinRetryDelaySecForTransientConflictFailed =
+		newConfig.FrameworkMinRetryDelaySecForTransientConflictFailed
+	reloaded.FrameworkMaxRetryDelaySecForTransientConflictFailed =
+		newConfig.FrameworkMaxRetryDelaySecForTransientConflictFailed
+	reloaded.LogObjectSnapshot = newConfig.LogObjectSnapshot
+	return &reloaded
+}
+
 func defaultKubeConfigFilePath() *string {
 	configPath := EnvValueKubeConfigFilePath
 	_, err := os.Stat(configPath)
@@ -364,6 +1731,14 @@ func defaultKubeConfigFilePath() *string {
 	return &configPath
 }
 
+func defaultMemberID() *string {
+	memberID, err := os.Hostname()
+	if err != nil {
+		memberID = ""
+	}
+	return &memberID
+}
+
 func initConfig() *Config {
 	c := Config{}
 
@@ -24,6 +24,8 @@ package v1
 
 import (
 	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime/schema"
 	"os"
 )
 
@@ -32,22 +34,63 @@ import (
 ///////////////////////////////////////////////////////////////////////////////////////
 const (
 	// For controller
-	ComponentName      = "frameworkcontroller"
-	GroupName          = "frameworkcontroller.microsoft.com"
-	Version            = "v1"
-	FrameworkPlural    = "frameworks"
-	FrameworkCRDName   = FrameworkPlural + "." + GroupName
-	FrameworkKind      = "Framework"
-	ConfigMapKind      = "ConfigMap"
-	PodKind            = "Pod"
-	ObjectUIDFieldPath = "metadata.uid"
+	ComponentName                  = "frameworkcontroller"
+	GroupName                      = "frameworkcontroller.microsoft.com"
+	Version                        = "v1"
+	FrameworkPlural                = "frameworks"
+	FrameworkCRDName               = FrameworkPlural + "." + GroupName
+	FrameworkKind                  = "Framework"
+	FrameworkAttemptHistoryPlural  = "frameworkattempthistories"
+	FrameworkAttemptHistoryCRDName = FrameworkAttemptHistoryPlural + "." + GroupName
+	FrameworkAttemptHistoryKind    = "FrameworkAttemptHistory"
+	ScheduledFrameworkPlural       = "scheduledframeworks"
+	ScheduledFrameworkCRDName      = ScheduledFrameworkPlural + "." + GroupName
+	ScheduledFrameworkKind         = "ScheduledFramework"
+	FrameworkGroupPlural           = "frameworkgroups"
+	FrameworkGroupCRDName          = FrameworkGroupPlural + "." + GroupName
+	FrameworkGroupKind             = "FrameworkGroup"
+	QueuePlural                    = "queues"
+	QueueCRDName                   = QueuePlural + "." + GroupName
+	QueueKind                      = "Queue"
+	TaskRoleScalePlural            = "taskrolescales"
+	TaskRoleScaleCRDName           = TaskRoleScalePlural + "." + GroupName
+	TaskRoleScaleKind              = "TaskRoleScale"
+	FrameworkQuotaPlural           = "frameworkquotas"
+	FrameworkQuotaCRDName          = FrameworkQuotaPlural + "." + GroupName
+	FrameworkQuotaKind             = "FrameworkQuota"
+	ConfigMapKind                  = "ConfigMap"
+	PodKind                        = "Pod"
+	ObjectUIDFieldPath             = "metadata.uid"
+	NodeNameFieldPath              = "metadata.name"
 
 	ConfigFilePath                    = "./frameworkcontroller.yaml"
 	UnlimitedValue                    = -1
 	ExtendedUnlimitedValue            = -2
 	LargeFrameworkCompressionMinBytes = 700 * 1024
+	SpecChangeHistoryMaxLength        = 20
+	ProgressFailedTaskSampleMaxCount  = 10
+	DefaultRetryDeciderName           = "Default"
+	WebhookTaskRoleAutoscalerName     = "Webhook"
+	SecretRefPodDecoratorName         = "SecretRef"
+	SecretStoreKubernetes             = "Kubernetes"
+
+	// For Framework
+	// The annotation set by kubectl --record.
+	AnnotationKeyChangeCause = "kubernetes.io/change-cause"
+
+	// For scheduled Framework
+	AnnotationKeyScheduledTime     = "FC_SCHEDULED_TIME"
+	LabelKeyScheduledFrameworkName = "FC_SCHEDULED_FRAMEWORK_NAME"
+
+	// For FrameworkGroup member Framework
+	AnnotationKeyFrameworkGroupMemberName = "FC_FRAMEWORK_GROUP_MEMBER_NAME"
+	LabelKeyFrameworkGroupName            = "FC_FRAMEWORK_GROUP_NAME"
 
 	// For all managed objects
+	// The prefix of all Annotations created by FrameworkController and the
+	// TaskAttempt, which are always kept in the local cache.
+	AnnotationKeyPrefix = "FC_"
+
 	// Predefined Annotations
 	AnnotationKeyFrameworkNamespace = "FC_FRAMEWORK_NAMESPACE"
 	AnnotationKeyFrameworkName      = "FC_FRAMEWORK_NAME"
@@ -61,11 +104,147 @@ const (
 	AnnotationKeyConfigMapUID                = "FC_CONFIGMAP_UID"
 	AnnotationKeyTaskAttemptID               = "FC_TASK_ATTEMPT_ID"
 
+	// For Task heartbeat
+	// The annotation updated by the Task Pod itself with the RFC3339 time of its
+	// latest heartbeat.
+	AnnotationKeyHeartbeatTime = "FC_HEARTBEAT_TIME"
+
+	// For TaskRole UpdateStrategy
+	// The annotation of the Task Pod to record the hash of the Task's Pod template
+	// which the Pod was created from.
+	AnnotationKeyPodTemplateHash = "FC_POD_TEMPLATE_HASH"
+
+	// For Indexed Job compatibility
+	// The TaskIndex is also exposed as the completion index of the Indexed Job,
+	// so that the tooling built for the Indexed Job works for the Task Pods.
+	AnnotationKeyJobCompletionIndex = "batch.kubernetes.io/job-completion-index"
+
+	// For Pod readiness gate
+	// The Pod condition type which is True once all Tasks of the Pod's
+	// FrameworkAttempt are assigned PodIPs.
+	PodConditionTypeFrameworkAttemptReady = "frameworkcontroller.microsoft.com/FrameworkAttemptReady"
+
+	// For Pod failure classification
+	// The Pod condition type which is True once the Pod is about to be terminated
+	// due to a disruption, such as the preemption, eviction or node loss, and its
+	// reason tells the disruption, such as PreemptionByScheduler.
+	PodConditionTypeDisruptionTarget = "DisruptionTarget"
+
 	// Predefined Labels
 	LabelKeyFrameworkName = AnnotationKeyFrameworkName
 	LabelKeyTaskRoleName  = AnnotationKeyTaskRoleName
 	LabelKeyTaskIndex     = AnnotationKeyTaskIndex
 
+	// For FrameworkController instances
+	// Predefined Labels
+	LabelKeyShardMember = "FC_SHARD_MEMBER"
+
+	// For the PodGroup of the coscheduling plugin in scheduler-plugins
+	PodGroupKind = "PodGroup"
+	// The label to associate the Pod with its PodGroup.
+	LabelKeyPodGroupName = "scheduling.x-k8s.io/pod-group"
+
+	// For the PodGroup of Volcano
+	// The annotation to associate the Pod with its PodGroup.
+	AnnotationKeyVolcanoPodGroupName = "scheduling.k8s.io/group-name"
+	// The annotation to specify the Volcano Queue of the Framework and its Pods.
+	AnnotationKeyVolcanoQueueName = "scheduling.volcano.sh/queue-name"
+	// The Pod condition reason set by Volcano when it evicts the Pod, such as
+	// for preemption and reclaim.
+	VolcanoEvictReason = "Evict"
+
+	// For the HiveDScheduler
+	// The annotation to specify the HiveD scheduling spec of the Pod.
+	AnnotationKeyHiveDPodSchedulingSpec = "hivedscheduler.microsoft.com/pod-scheduling-spec"
+	// The reason of the DisruptionTarget Pod condition set by the K8S scheduler
+	// when it preempts the Pod, such as for the HiveDScheduler preemption.
+	PreemptionBySchedulerReason = "PreemptionByScheduler"
+
+	// For the Workload of Kueue
+	KueueWorkloadKind = "Workload"
+	// The label to specify the Kueue LocalQueue of the Framework, and only the
+	// Framework with this label is managed by Kueue.
+	LabelKeyKueueQueueName = "kueue.x-k8s.io/queue-name"
+	// The Workload condition types set by Kueue.
+	KueueWorkloadConditionAdmitted = "Admitted"
+	KueueWorkloadConditionEvicted  = "Evicted"
+
+	// For the TFJob and PyTorchJob of Kubeflow
+	KubeflowTFJobKind      = "TFJob"
+	KubeflowPyTorchJobKind = "PyTorchJob"
+	// The Kubeflow Job condition types.
+	KubeflowJobConditionCreated   = "Created"
+	KubeflowJobConditionRunning   = "Running"
+	KubeflowJobConditionSucceeded = "Succeeded"
+	KubeflowJobConditionFailed    = "Failed"
+	// The default ports of the Kubeflow Jobs, which can be overridden by the
+	// container port with the default port name.
+	KubeflowTFJobDefaultPortName      = "tfjob-port"
+	KubeflowTFJobDefaultPort          = 2222
+	KubeflowPyTorchJobDefaultPortName = "pytorchjob-port"
+	KubeflowPyTorchJobDefaultPort     = 23456
+
+	// For the TaskRole MemoryEscalation
+	ContainerReasonOOMKilled             = "OOMKilled"
+	MemoryEscalationDefaultFactorPercent = 200
+
+	// For the node OS and architecture required by the TaskRole
+	OSLinux   = "linux"
+	OSWindows = "windows"
+	ArchAMD64 = "amd64"
+
+	// For the exposed Ingress of the TaskRole
+	IngressKind = "Ingress"
+
+	// For the PodDisruptionBudget of the TaskRole
+	PodDisruptionBudgetKind = "PodDisruptionBudget"
+
+	// For the hostfile of the FrameworkAttempt
+	// The ConfigMap keys of the hostfile and the peer list, and where they are
+	// mounted in all managed containers.
+	// The hostfile line: {Task.PodIP} slots={TaskRole.Hostfile.Slots}
+	// The peer list line: FC_{UpperCase({TaskRoleName})}_IPS={Task[0].PodIP},...
+	ConfigMapKeyHostfile = "hostfile"
+	ConfigMapKeyPeerList = "peers.env"
+	HostfileVolumeName   = "fc-hostfile"
+	HostfileMountPath    = "/etc/frameworkcontroller"
+
+	// For the SSH keypair Secret of the FrameworkAttempt
+	SecretKeySSHPrivateKey     = "id_rsa"
+	SecretKeySSHPublicKey      = "id_rsa.pub"
+	SecretKeySSHAuthorizedKeys = "authorized_keys"
+	SecretKeySSHConfig         = "config"
+	SSHVolumeName              = "fc-ssh"
+	SSHDefaultMountPath        = "/root/.ssh"
+	SSHConfig                  = "StrictHostKeyChecking no\nUserKnownHostsFile /dev/null\n"
+
+	// For the Framework Colocation
+	ColocationPreferredWeight = 100
+
+	// For the TaskRole AntiAffinity
+	AntiAffinityPreferredWeight = 100
+
+	// For the TaskRole SecretRefs, VolumeName = {Prefix}{SecretRefIndex}
+	SecretRefVolumeNamePrefix = "fc-secret-ref-"
+
+	// For the hooks of the FrameworkAttempt
+	HookNamePreAttempt           = "preattempt"
+	HookNamePostAttempt          = "postattempt"
+	HookWebhookDefaultTimeoutSec = 5
+	HookWebhookMaxTimeoutSec     = 10
+	AnnotationKeyHookName        = "FC_HOOK_NAME"
+
+	// For the main Container which stops its sidecar Containers after it exits
+	// The wrapper forwards the termination signals to the original command, and
+	// always runs the quit command after the original command exits.
+	SidecarQuitDefaultCommand = "curl -fsS -X POST http://127.0.0.1:15020/quitquitquit"
+	SidecarQuitWrapperScript  = "\"$@\" & pid=$!; " +
+		"trap 'kill -TERM $pid' TERM INT; " +
+		"wait $pid; rc=$?; " +
+		"if kill -0 $pid 2>/dev/null; then wait $pid; rc=$?; fi; " +
+		"(%v) >/dev/null 2>&1 || true; " +
+		"exit $rc"
+
 	// For all managed containers
 	// Predefined Environment Variables
 	// It can be referred by other environment variables specified in the Container Env,
@@ -85,6 +264,23 @@ const (
 	EnvNameTaskAttemptID               = AnnotationKeyTaskAttemptID
 	EnvNameTaskAttemptInstanceUID      = "FC_TASK_ATTEMPT_INSTANCE_UID"
 	EnvNamePodUID                      = "FC_POD_UID"
+	EnvNameJobCompletionIndex          = "JOB_COMPLETION_INDEX"
+	// Only for the hook Pods, and the TaskRole level ones are not available in
+	// them.
+	EnvNameHookName = AnnotationKeyHookName
+
+	// The peer addressing environment variables of all TaskRoles in the Spec:
+	// FC_{UpperCase({TaskRoleName})}_{Suffix}
+	// See GetTaskRoleEnvName.
+	EnvNameSuffixTaskNumber  = "TASK_NUMBER"
+	EnvNameSuffixServiceName = "SERVICE_NAME"
+	EnvNameSuffixHosts       = "HOSTS"
+	EnvNameSuffixIPs         = "IPS"
+	// The host of the Task 0 of the first TaskRole in the Spec.
+	EnvNameMasterHost = "FC_MASTER_HOST"
+	// The host port range allocated to the Task, see TaskRoleSpec.PortNumber.
+	EnvNameTaskPortMin = "FC_TASK_PORT_MIN"
+	EnvNameTaskPortMax = "FC_TASK_PORT_MAX"
 
 	// For Pod Spec
 	// Predefined Pod Template Placeholders
@@ -98,12 +294,43 @@ const (
 	PlaceholderTaskIndex          = AnnotationKeyTaskIndex
 	PlaceholderConfigMapName      = AnnotationKeyConfigMapName
 	PlaceholderPodName            = AnnotationKeyPodName
+
+	PlaceholderFrameworkAttemptID          = AnnotationKeyFrameworkAttemptID
+	PlaceholderFrameworkAttemptInstanceUID = AnnotationKeyFrameworkAttemptInstanceUID
+	PlaceholderConfigMapUID                = AnnotationKeyConfigMapUID
+	PlaceholderTaskAttemptID               = AnnotationKeyTaskAttemptID
 )
 
 var FrameworkGroupVersionKind = SchemeGroupVersion.WithKind(FrameworkKind)
+var FrameworkAttemptHistoryGroupVersionKind = SchemeGroupVersion.WithKind(FrameworkAttemptHistoryKind)
+var ScheduledFrameworkGroupVersionKind = SchemeGroupVersion.WithKind(ScheduledFrameworkKind)
+var FrameworkGroupGroupVersionKind = SchemeGroupVersion.WithKind(FrameworkGroupKind)
+var PodGroupGroupVersionResource = schema.GroupVersionResource{
+	Group: "scheduling.x-k8s.io", Version: "v1alpha1", Resource: "podgroups"}
+var VolcanoPodGroupGroupVersionResource = schema.GroupVersionResource{
+	Group: "scheduling.volcano.sh", Version: "v1beta1", Resource: "podgroups"}
+var KueueWorkloadGroupVersionResource = schema.GroupVersionResource{
+	Group: "kueue.x-k8s.io", Version: "v1beta1", Resource: "workloads"}
+var KubeflowTFJobGroupVersionResource = schema.GroupVersionResource{
+	Group: "kubeflow.org", Version: "v1", Resource: "tfjobs"}
+var KubeflowPyTorchJobGroupVersionResource = schema.GroupVersionResource{
+	Group: "kubeflow.org", Version: "v1", Resource: "pytorchjobs"}
+var IngressGroupVersionResource = schema.GroupVersionResource{
+	Group: "networking.k8s.io", Version: "v1", Resource: "ingresses"}
+var PodDisruptionBudgetGroupVersionResource = schema.GroupVersionResource{
+	Group: "policy", Version: "v1", Resource: "poddisruptionbudgets"}
+var PodGroupVersionResource = core.SchemeGroupVersion.WithResource("pods")
 var ConfigMapGroupVersionKind = core.SchemeGroupVersion.WithKind(ConfigMapKind)
 var PodGroupVersionKind = core.SchemeGroupVersion.WithKind(PodKind)
 
+// Empty means the K8S default PropagationPolicy of the object.
+var ValidDeletionPropagations = []meta.DeletionPropagation{
+	"",
+	meta.DeletePropagationForeground,
+	meta.DeletePropagationBackground,
+	meta.DeletePropagationOrphan,
+}
+
 var ObjectUIDEnvVarSource = &core.EnvVarSource{
 	FieldRef: &core.ObjectFieldSelector{FieldPath: ObjectUIDFieldPath},
 }
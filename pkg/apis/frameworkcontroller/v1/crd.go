@@ -24,6 +24,7 @@ package v1
 
 import (
 	"github.com/microsoft/frameworkcontroller/pkg/common"
+	core "k8s.io/api/core/v1"
 	apiExtensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 )
@@ -31,6 +32,13 @@ import (
 const (
 	// Names in CRD should be up to 63 lower case alphanumeric characters.
 	NamingConvention = "^[a-z0-9]{1,63}$"
+	// ScheduledFramework name is further limited to leave room for the
+	// scheduled time suffix of its Framework names.
+	ScheduledFrameworkNamingConvention = "^[a-z0-9]{1,52}$"
+	// FrameworkGroup name and its MemberName are limited, so that their
+	// concatenation is a valid Framework name.
+	FrameworkGroupNamingConvention       = "^[a-z0-9]{1,31}$"
+	FrameworkGroupMemberNamingConvention = "^[a-z0-9]{1,32}$"
 )
 
 func BuildFrameworkCRD() *apiExtensions.CustomResourceDefinition {
@@ -58,11 +66,150 @@ func BuildFrameworkCRD() *apiExtensions.CustomResourceDefinition {
 	return crd
 }
 
+func BuildFrameworkAttemptHistoryCRD() *apiExtensions.CustomResourceDefinition {
+	crd := &apiExtensions.CustomResourceDefinition{
+		ObjectMeta: meta.ObjectMeta{
+			Name: FrameworkAttemptHistoryCRDName,
+		},
+		Spec: apiExtensions.CustomResourceDefinitionSpec{
+			Group:   GroupName,
+			Version: SchemeGroupVersion.Version,
+			Scope:   apiExtensions.NamespaceScoped,
+			Names: apiExtensions.CustomResourceDefinitionNames{
+				Plural: FrameworkAttemptHistoryPlural,
+				Kind:   FrameworkAttemptHistoryKind,
+			},
+		},
+	}
+
+	return crd
+}
+
+func BuildScheduledFrameworkCRD() *apiExtensions.CustomResourceDefinition {
+	crd := &apiExtensions.CustomResourceDefinition{
+		ObjectMeta: meta.ObjectMeta{
+			Name: ScheduledFrameworkCRDName,
+		},
+		Spec: apiExtensions.CustomResourceDefinitionSpec{
+			Group:   GroupName,
+			Version: SchemeGroupVersion.Version,
+			Scope:   apiExtensions.NamespaceScoped,
+			Names: apiExtensions.CustomResourceDefinitionNames{
+				Plural: ScheduledFrameworkPlural,
+				Kind:   ScheduledFrameworkKind,
+			},
+			Validation: buildScheduledFrameworkValidation(),
+		},
+	}
+
+	return crd
+}
+
+func BuildFrameworkGroupCRD() *apiExtensions.CustomResourceDefinition {
+	crd := &apiExtensions.CustomResourceDefinition{
+		ObjectMeta: meta.ObjectMeta{
+			Name: FrameworkGroupCRDName,
+		},
+		Spec: apiExtensions.CustomResourceDefinitionSpec{
+			Group:   GroupName,
+			Version: SchemeGroupVersion.Version,
+			Scope:   apiExtensions.NamespaceScoped,
+			Names: apiExtensions.CustomResourceDefinitionNames{
+				Plural: FrameworkGroupPlural,
+				Kind:   FrameworkGroupKind,
+			},
+			Validation: buildFrameworkGroupValidation(),
+		},
+	}
+
+	return crd
+}
+
+func BuildQueueCRD() *apiExtensions.CustomResourceDefinition {
+	crd := &apiExtensions.CustomResourceDefinition{
+		ObjectMeta: meta.ObjectMeta{
+			Name: QueueCRDName,
+		},
+		Spec: apiExtensions.CustomResourceDefinitionSpec{
+			Group:   GroupName,
+			Version: SchemeGroupVersion.Version,
+			Scope:   apiExtensions.ClusterScoped,
+			Names: apiExtensions.CustomResourceDefinitionNames{
+				Plural: QueuePlural,
+				Kind:   QueueKind,
+			},
+			Validation: buildQueueValidation(),
+		},
+	}
+
+	return crd
+}
+
+func BuildFrameworkQuotaCRD() *apiExtensions.CustomResourceDefinition {
+	crd := &apiExtensions.CustomResourceDefinition{
+		ObjectMeta: meta.ObjectMeta{
+			Name: FrameworkQuotaCRDName,
+		},
+		Spec: apiExtensions.CustomResourceDefinitionSpec{
+			Group:   GroupName,
+			Version: SchemeGroupVersion.Version,
+			Scope:   apiExtensions.NamespaceScoped,
+			Names: apiExtensions.CustomResourceDefinitionNames{
+				Plural: FrameworkQuotaPlural,
+				Kind:   FrameworkQuotaKind,
+			},
+			Validation: buildFrameworkQuotaValidation(),
+		},
+	}
+
+	return crd
+}
+
+func BuildTaskRoleScaleCRD() *apiExtensions.CustomResourceDefinition {
+	crd := &apiExtensions.CustomResourceDefinition{
+		ObjectMeta: meta.ObjectMeta{
+			Name: TaskRoleScaleCRDName,
+		},
+		Spec: apiExtensions.CustomResourceDefinitionSpec{
+			Group:   GroupName,
+			Version: SchemeGroupVersion.Version,
+			Scope:   apiExtensions.NamespaceScoped,
+			Names: apiExtensions.CustomResourceDefinitionNames{
+				Plural: TaskRoleScalePlural,
+				Kind:   TaskRoleScaleKind,
+			},
+			Validation: buildTaskRoleScaleValidation(),
+			Subresources: &apiExtensions.CustomResourceSubresources{
+				Scale: &apiExtensions.CustomResourceSubresourceScale{
+					SpecReplicasPath:   ".spec.replicas",
+					StatusReplicasPath: ".status.replicas",
+					LabelSelectorPath:  common.PtrString(".status.selector"),
+				},
+			},
+		},
+	}
+
+	return crd
+}
+
+// The structural schema rejects invalid Frameworks at apply time, without any
+// admission webhook.
+// The vendored apiextensions does not support x-kubernetes-validations (CEL)
+// yet, so only the rules expressible by OpenAPI v3 are included, such as the
+// TaskNumber bounds, the name patterns and the lower bounds of the
+// FrameworkAttemptCompletionPolicy.
+// The cross-field rules, such as unique TaskRole names and
+// MinFailedTaskCount <= TaskNumber, are not enforced by the ApiServer or
+// FrameworkController, and are only checked by validation.Validate before the
+// Framework is submitted, such as by the FrameworkGateway and the builder.
 func buildFrameworkValidation() *apiExtensions.CustomResourceValidation {
 	return &apiExtensions.CustomResourceValidation{
 		OpenAPIV3Schema: &apiExtensions.JSONSchemaProps{
+			Type:     "object",
+			Required: []string{"spec"},
 			Properties: map[string]apiExtensions.JSONSchemaProps{
 				"metadata": {
+					Type: "object",
 					Properties: map[string]apiExtensions.JSONSchemaProps{
 						"name": {
 							Type:    "string",
@@ -70,54 +217,711 @@ func buildFrameworkValidation() *apiExtensions.CustomResourceValidation {
 						},
 					},
 				},
+				"spec": buildFrameworkSpecValidation(),
+			},
+		},
+	}
+}
+
+func buildScheduledFrameworkValidation() *apiExtensions.CustomResourceValidation {
+	return &apiExtensions.CustomResourceValidation{
+		OpenAPIV3Schema: &apiExtensions.JSONSchemaProps{
+			Type:     "object",
+			Required: []string{"spec"},
+			Properties: map[string]apiExtensions.JSONSchemaProps{
+				"metadata": {
+					Type: "object",
+					Properties: map[string]apiExtensions.JSONSchemaProps{
+						"name": {
+							Type:    "string",
+							Pattern: ScheduledFrameworkNamingConvention,
+						},
+					},
+				},
 				"spec": {
+					Type:     "object",
+					Required: []string{"schedule", "frameworkTemplate"},
 					Properties: map[string]apiExtensions.JSONSchemaProps{
-						"executionType": {
+						"schedule": {
+							Type:      "string",
+							MinLength: common.PtrInt64(1),
+						},
+						"startingDeadlineSec": {
+							Type:    "integer",
+							Minimum: common.PtrFloat64(0),
+						},
+						"concurrencyPolicy": {
+							Type: "string",
 							Enum: []apiExtensions.JSON{
-								{Raw: []byte(common.Quote(string(ExecutionStart)))},
-								{Raw: []byte(common.Quote(string(ExecutionStop)))},
+								{Raw: []byte(common.Quote(string(AllowConcurrent)))},
+								{Raw: []byte(common.Quote(string(ForbidConcurrent)))},
+								{Raw: []byte(common.Quote(string(ReplaceConcurrent)))},
 							},
 						},
-						"retryPolicy": {
+						"suspend": {
+							Type: "boolean",
+						},
+						"succeededFrameworksHistoryLimit": {
+							Type:    "integer",
+							Minimum: common.PtrFloat64(0),
+						},
+						"failedFrameworksHistoryLimit": {
+							Type:    "integer",
+							Minimum: common.PtrFloat64(0),
+						},
+						"frameworkTemplate": {
+							Type:     "object",
+							Required: []string{"spec"},
 							Properties: map[string]apiExtensions.JSONSchemaProps{
-								"maxRetryCount": {
-									Type:    "integer",
-									Minimum: common.PtrFloat64(ExtendedUnlimitedValue),
-								},
+								"spec": buildFrameworkSpecValidation(),
 							},
 						},
-						"taskRoles": {
-							// TODO: names in array should not duplicate
-							Type: "array",
+					},
+				},
+			},
+		},
+	}
+}
+
+// The uniqueness of MemberNames and the acyclicity of the dependencies cannot be
+// expressed by the schema, so they are validated by the controller.
+func buildFrameworkGroupValidation() *apiExtensions.CustomResourceValidation {
+	return &apiExtensions.CustomResourceValidation{
+		OpenAPIV3Schema: &apiExtensions.JSONSchemaProps{
+			Type:     "object",
+			Required: []string{"spec"},
+			Properties: map[string]apiExtensions.JSONSchemaProps{
+				"metadata": {
+					Type: "object",
+					Properties: map[string]apiExtensions.JSONSchemaProps{
+						"name": {
+							Type:    "string",
+							Pattern: FrameworkGroupNamingConvention,
+						},
+					},
+				},
+				"spec": {
+					Type:     "object",
+					Required: []string{"members"},
+					Properties: map[string]apiExtensions.JSONSchemaProps{
+						"members": {
+							Type:     "array",
+							MinItems: common.PtrInt64(1),
 							Items: &apiExtensions.JSONSchemaPropsOrArray{
 								Schema: &apiExtensions.JSONSchemaProps{
+									Type:     "object",
+									Required: []string{"name", "frameworkTemplate"},
 									Properties: map[string]apiExtensions.JSONSchemaProps{
 										"name": {
 											Type:    "string",
-											Pattern: NamingConvention,
+											Pattern: FrameworkGroupMemberNamingConvention,
 										},
-										"taskNumber": {
-											Type:    "integer",
-											Minimum: common.PtrFloat64(0),
-											Maximum: common.PtrFloat64(10000),
+										"dependsOn": {
+											Type: "array",
+											Items: &apiExtensions.JSONSchemaPropsOrArray{
+												Schema: &apiExtensions.JSONSchemaProps{
+													Type:     "object",
+													Required: []string{"name"},
+													Properties: map[string]apiExtensions.JSONSchemaProps{
+														"name": {
+															Type:    "string",
+															Pattern: FrameworkGroupMemberNamingConvention,
+														},
+														"condition": {
+															Type: "string",
+															Enum: []apiExtensions.JSON{
+																{Raw: []byte(common.Quote(string(DependencySucceeded)))},
+																{Raw: []byte(common.Quote(string(DependencyFailed)))},
+																{Raw: []byte(common.Quote(string(DependencyCompleted)))},
+															},
+														},
+													},
+												},
+											},
 										},
-										"frameworkAttemptCompletionPolicy": {
+										"frameworkTemplate": {
+											Type:     "object",
+											Required: []string{"spec"},
 											Properties: map[string]apiExtensions.JSONSchemaProps{
-												"minFailedTaskCount": {
-													Type: "integer",
-													// TODO: should not allow 0
-													Minimum: common.PtrFloat64(UnlimitedValue),
+												"spec": buildFrameworkSpecValidation(),
+											},
+										},
+									},
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildQueueValidation() *apiExtensions.CustomResourceValidation {
+	return &apiExtensions.CustomResourceValidation{
+		OpenAPIV3Schema: &apiExtensions.JSONSchemaProps{
+			Type:     "object",
+			Required: []string{"spec"},
+			Properties: map[string]apiExtensions.JSONSchemaProps{
+				"spec": {
+					Type: "object",
+					Properties: map[string]apiExtensions.JSONSchemaProps{
+						"maxRunningFrameworks": {
+							Type:    "integer",
+							Minimum: common.PtrFloat64(0),
+						},
+						"capacity": {
+							Type: "object",
+						},
+						"orderPolicy": {
+							Type: "string",
+							Enum: []apiExtensions.JSON{
+								{Raw: []byte(common.Quote(string(QueueOrderFIFO)))},
+								{Raw: []byte(common.Quote(string(QueueOrderPriority)))},
+							},
+						},
+						"preemptionPolicy": {
+							Type: "string",
+							Enum: []apiExtensions.JSON{
+								{Raw: []byte(common.Quote(string(QueuePreemptNever)))},
+								{Raw: []byte(common.Quote(string(QueuePreemptLowerPriority)))},
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildFrameworkQuotaValidation() *apiExtensions.CustomResourceValidation {
+	return &apiExtensions.CustomResourceValidation{
+		OpenAPIV3Schema: &apiExtensions.JSONSchemaProps{
+			Type:     "object",
+			Required: []string{"spec"},
+			Properties: map[string]apiExtensions.JSONSchemaProps{
+				"spec": {
+					Type: "object",
+					Properties: map[string]apiExtensions.JSONSchemaProps{
+						"maxFrameworks": {
+							Type:    "integer",
+							Minimum: common.PtrFloat64(0),
+						},
+						"maxTasks": {
+							Type:    "integer",
+							Minimum: common.PtrFloat64(0),
+						},
+						"hard": {
+							Type: "object",
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildTaskRoleScaleValidation() *apiExtensions.CustomResourceValidation {
+	return &apiExtensions.CustomResourceValidation{
+		OpenAPIV3Schema: &apiExtensions.JSONSchemaProps{
+			Type:     "object",
+			Required: []string{"spec"},
+			Properties: map[string]apiExtensions.JSONSchemaProps{
+				"spec": {
+					Type:     "object",
+					Required: []string{"frameworkName", "taskRoleName", "replicas"},
+					Properties: map[string]apiExtensions.JSONSchemaProps{
+						"frameworkName": {
+							Type: "string",
+						},
+						"taskRoleName": {
+							Type: "string",
+						},
+						"replicas": {
+							Type:    "integer",
+							Minimum: common.PtrFloat64(0),
+						},
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildFrameworkSpecValidation() apiExtensions.JSONSchemaProps {
+	return apiExtensions.JSONSchemaProps{
+		Type:     "object",
+		Required: []string{"taskRoles"},
+		Properties: map[string]apiExtensions.JSONSchemaProps{
+			"description": {
+				Type: "string",
+			},
+			"executionType": {
+				Type: "string",
+				Enum: []apiExtensions.JSON{
+					{Raw: []byte(common.Quote(string(ExecutionStart)))},
+					{Raw: []byte(common.Quote(string(ExecutionStop)))},
+					{Raw: []byte(common.Quote(string(ExecutionSuspend)))},
+				},
+			},
+			"retryPolicy": buildRetryPolicyValidation(),
+			"queue": {
+				Type: "string",
+			},
+			"queuePriority": {
+				Type: "integer",
+			},
+			"attemptSchedulingTimeoutSec": {
+				Type:    "integer",
+				Minimum: common.PtrFloat64(1),
+			},
+			"restartAttemptID": {
+				Type:    "integer",
+				Minimum: common.PtrFloat64(0),
+			},
+			"succeededRetainSec": {
+				Type:    "integer",
+				Minimum: common.PtrFloat64(0),
+			},
+			"failedRetainSec": {
+				Type:    "integer",
+				Minimum: common.PtrFloat64(0),
+			},
+			"frameworkDeletionPropagationPolicy": buildDeletionPropagationValidation(),
+			"configMapDeletionPropagationPolicy": buildDeletionPropagationValidation(),
+			"debugRetainFailedPodSec": {
+				Type:    "integer",
+				Minimum: common.PtrFloat64(0),
+			},
+			"retryBudget": {
+				Type: "object",
+				Properties: map[string]apiExtensions.JSONSchemaProps{
+					"maxTaskRetryCount": {
+						Type:    "integer",
+						Minimum: common.PtrFloat64(0),
+					},
+					"maxRetriedTaskPercent": {
+						Type:    "integer",
+						Minimum: common.PtrFloat64(0),
+						Maximum: common.PtrFloat64(100),
+					},
+				},
+			},
+			"networkPolicy": {
+				Type: "object",
+				Properties: map[string]apiExtensions.JSONSchemaProps{
+					"extraPeers": {
+						Type: "array",
+						Items: &apiExtensions.JSONSchemaPropsOrArray{
+							Schema: &apiExtensions.JSONSchemaProps{
+								Type: "object",
+							},
+						},
+					},
+				},
+			},
+			"colocation": {
+				Type: "object",
+				Properties: map[string]apiExtensions.JSONSchemaProps{
+					"taskRoles": {
+						Type: "array",
+						Items: &apiExtensions.JSONSchemaPropsOrArray{
+							Schema: &apiExtensions.JSONSchemaProps{
+								Type: "string",
+							},
+						},
+					},
+					"topologyKey": {
+						Type: "string",
+					},
+					"required": {
+						Type: "boolean",
+					},
+				},
+			},
+			"hooks": {
+				Type: "object",
+				Properties: map[string]apiExtensions.JSONSchemaProps{
+					"preAttempt":  buildHookValidation(),
+					"postAttempt": buildHookValidation(),
+				},
+			},
+			"taskRoles": {
+				// TODO: names in array should not duplicate
+				Type: "array",
+				Items: &apiExtensions.JSONSchemaPropsOrArray{
+					Schema: &apiExtensions.JSONSchemaProps{
+						Type:     "object",
+						Required: []string{"name", "taskNumber", "task"},
+						Properties: map[string]apiExtensions.JSONSchemaProps{
+							"name": {
+								Type:    "string",
+								Pattern: NamingConvention,
+							},
+							"taskNumber": {
+								Type:    "integer",
+								Minimum: common.PtrFloat64(0),
+								Maximum: common.PtrFloat64(10000),
+							},
+							"frameworkAttemptCompletionPolicy": {
+								Type: "object",
+								Properties: map[string]apiExtensions.JSONSchemaProps{
+									"minFailedTaskCount":    buildMinTaskCountValidation(),
+									"minSucceededTaskCount": buildMinTaskCountValidation(),
+								},
+							},
+							"task": {
+								Type:     "object",
+								Required: []string{"pod"},
+								Properties: map[string]apiExtensions.JSONSchemaProps{
+									"retryPolicy": buildRetryPolicyValidation(),
+									"podGracefulDeletionTimeoutSec": {
+										Type:    "integer",
+										Minimum: common.PtrFloat64(0),
+									},
+									"attemptMaxRunDurationSec": {
+										Type:    "integer",
+										Minimum: common.PtrFloat64(1),
+									},
+									"heartbeatTimeoutSec": {
+										Type:    "integer",
+										Minimum: common.PtrFloat64(1),
+									},
+									"restartRequests": {
+										Type: "array",
+										Items: &apiExtensions.JSONSchemaPropsOrArray{
+											Schema: &apiExtensions.JSONSchemaProps{
+												Type:     "object",
+												Required: []string{"index", "attemptID"},
+												Properties: map[string]apiExtensions.JSONSchemaProps{
+													"index": {
+														Type:    "integer",
+														Minimum: common.PtrFloat64(0),
+													},
+													"attemptID": {
+														Type:    "integer",
+														Minimum: common.PtrFloat64(0),
+													},
 												},
-												"minSucceededTaskCount": {
-													Type: "integer",
-													// TODO: should not allow 0
-													Minimum: common.PtrFloat64(UnlimitedValue),
+											},
+										},
+									},
+									"stopIndexes": {
+										Type: "array",
+										Items: &apiExtensions.JSONSchemaPropsOrArray{
+											Schema: &apiExtensions.JSONSchemaProps{
+												Type:    "integer",
+												Minimum: common.PtrFloat64(0),
+											},
+										},
+									},
+									"pod": {
+										Type: "object",
+									},
+								},
+							},
+							"taskOverrides": {
+								Type: "array",
+								Items: &apiExtensions.JSONSchemaPropsOrArray{
+									Schema: &apiExtensions.JSONSchemaProps{
+										Type:     "object",
+										Required: []string{"minTaskIndex", "maxTaskIndex", "pod"},
+										Properties: map[string]apiExtensions.JSONSchemaProps{
+											"minTaskIndex": {
+												Type:    "integer",
+												Minimum: common.PtrFloat64(0),
+											},
+											"maxTaskIndex": {
+												Type:    "integer",
+												Minimum: common.PtrFloat64(0),
+											},
+											"pod": {
+												Type: "object",
+											},
+										},
+									},
+								},
+							},
+							"expose": {
+								Type: "object",
+								Properties: map[string]apiExtensions.JSONSchemaProps{
+									"type": {
+										Type: "string",
+										Enum: []apiExtensions.JSON{
+											{Raw: []byte(common.Quote(string(core.ServiceTypeClusterIP)))},
+											{Raw: []byte(common.Quote(string(core.ServiceTypeNodePort)))},
+										},
+									},
+									"ports": {
+										Type: "array",
+										Items: &apiExtensions.JSONSchemaPropsOrArray{
+											Schema: &apiExtensions.JSONSchemaProps{
+												Type: "object",
+											},
+										},
+									},
+									"ingress": {
+										Type: "object",
+									},
+								},
+							},
+							"disruptionBudget": {
+								Type: "object",
+								Properties: map[string]apiExtensions.JSONSchemaProps{
+									// IntOrString
+									"minAvailable": {},
+									// IntOrString
+									"maxUnavailable": {},
+								},
+							},
+							"updateStrategy": {
+								Type: "object",
+								Properties: map[string]apiExtensions.JSONSchemaProps{
+									"type": {
+										Type: "string",
+										Enum: []apiExtensions.JSON{
+											{Raw: []byte(common.Quote(""))},
+											{Raw: []byte(common.Quote(string(UpdateStrategyReject)))},
+											{Raw: []byte(common.Quote(string(UpdateStrategyOnTaskRetry)))},
+											{Raw: []byte(common.Quote(string(UpdateStrategyRolling)))},
+										},
+									},
+									// IntOrString
+									"maxUnavailable": {},
+								},
+							},
+							"schedulerName": {
+								Type: "string",
+							},
+							"topologySpreadConstraints": {
+								Type: "array",
+								Items: &apiExtensions.JSONSchemaPropsOrArray{
+									Schema: &apiExtensions.JSONSchemaProps{
+										Type:     "object",
+										Required: []string{"topologyKey"},
+										Properties: map[string]apiExtensions.JSONSchemaProps{
+											"maxSkew": {
+												Type:    "integer",
+												Minimum: common.PtrFloat64(0),
+											},
+											"topologyKey": {
+												Type: "string",
+											},
+											"whenUnsatisfiable": {
+												Type: "string",
+												Enum: []apiExtensions.JSON{
+													{Raw: []byte(common.Quote(""))},
+													{Raw: []byte(common.Quote(string(UnsatisfiableDoNotSchedule)))},
+													{Raw: []byte(common.Quote(string(UnsatisfiableScheduleAnyway)))},
 												},
 											},
 										},
 									},
 								},
 							},
+							"hived": {
+								Type:     "object",
+								Required: []string{"virtualCluster"},
+								Properties: map[string]apiExtensions.JSONSchemaProps{
+									"virtualCluster": {
+										Type: "string",
+									},
+									"priority": {
+										Type:    "integer",
+										Minimum: common.PtrFloat64(-1),
+									},
+									"pinnedCellId": {
+										Type: "string",
+									},
+									"leafCellType": {
+										Type: "string",
+									},
+									"leafCellNumber": {
+										Type:    "integer",
+										Minimum: common.PtrFloat64(0),
+									},
+								},
+							},
+							"antiAffinity": {
+								Type: "object",
+								Properties: map[string]apiExtensions.JSONSchemaProps{
+									"topologyKey": {
+										Type: "string",
+									},
+									"required": {
+										Type: "boolean",
+									},
+								},
+							},
+							"os": {
+								Type: "string",
+								Enum: []apiExtensions.JSON{
+									{Raw: []byte(common.Quote(""))},
+									{Raw: []byte(common.Quote(OSLinux))},
+									{Raw: []byte(common.Quote(OSWindows))},
+								},
+							},
+							"arch": {
+								Type: "string",
+							},
+							"minTaskNumber": {
+								Type:    "integer",
+								Minimum: common.PtrFloat64(0),
+							},
+							"dependsOn": {
+								Type: "array",
+								Items: &apiExtensions.JSONSchemaPropsOrArray{
+									Schema: &apiExtensions.JSONSchemaProps{
+										Type: "string",
+									},
+								},
+							},
+							"completionContainer": {
+								Type: "string",
+							},
+							"podFailurePolicy": {
+								Type: "object",
+								Properties: map[string]apiExtensions.JSONSchemaProps{
+									"rules": {
+										Type: "array",
+										Items: &apiExtensions.JSONSchemaPropsOrArray{
+											Schema: &apiExtensions.JSONSchemaProps{
+												Type:     "object",
+												Required: []string{"action"},
+												Properties: map[string]apiExtensions.JSONSchemaProps{
+													"action": {
+														Type: "string",
+														Enum: []apiExtensions.JSON{
+															{Raw: []byte(common.Quote(string(PodFailurePolicyActionIgnore)))},
+															{Raw: []byte(common.Quote(string(PodFailurePolicyActionRetry)))},
+															{Raw: []byte(common.Quote(string(PodFailurePolicyActionFailTask)))},
+															{Raw: []byte(common.Quote(string(PodFailurePolicyActionFailFramework)))},
+														},
+													},
+													"onExitCodes": {
+														Type:     "object",
+														Required: []string{"operator", "values"},
+														Properties: map[string]apiExtensions.JSONSchemaProps{
+															"containerName": {
+																Type: "string",
+															},
+															"operator": {
+																Type: "string",
+																Enum: []apiExtensions.JSON{
+																	{Raw: []byte(common.Quote(string(PodFailurePolicyOnExitCodesOpIn)))},
+																	{Raw: []byte(common.Quote(string(PodFailurePolicyOnExitCodesOpNotIn)))},
+																},
+															},
+															"values": {
+																Type: "array",
+																Items: &apiExtensions.JSONSchemaPropsOrArray{
+																	Schema: &apiExtensions.JSONSchemaProps{
+																		Type: "integer",
+																	},
+																},
+															},
+														},
+													},
+													"onPodConditions": {
+														Type: "array",
+														Items: &apiExtensions.JSONSchemaPropsOrArray{
+															Schema: &apiExtensions.JSONSchemaProps{
+																Type:     "object",
+																Required: []string{"type"},
+																Properties: map[string]apiExtensions.JSONSchemaProps{
+																	"type": {
+																		Type: "string",
+																	},
+																	"status": {
+																		Type: "string",
+																	},
+																},
+															},
+														},
+													},
+												},
+											},
+										},
+									},
+								},
+							},
+							"memoryEscalation": {
+								Type: "object",
+								Properties: map[string]apiExtensions.JSONSchemaProps{
+									"factorPercent": {
+										Type:    "integer",
+										Minimum: common.PtrFloat64(0),
+									},
+									// Quantity
+									"maxMemory": {},
+								},
+							},
+							"sidecarQuit": {
+								Type: "object",
+								Properties: map[string]apiExtensions.JSONSchemaProps{
+									"container": {
+										Type: "string",
+									},
+									"quitCommand": {
+										Type: "string",
+									},
+								},
+							},
+							"spotInterruption": {
+								Type: "object",
+								Properties: map[string]apiExtensions.JSONSchemaProps{
+									"drainOnNotice": {
+										Type: "boolean",
+									},
+								},
+							},
+							"portNumber": {
+								Type:    "integer",
+								Minimum: common.PtrFloat64(0),
+							},
+							"sshKey": {
+								Type: "object",
+								Properties: map[string]apiExtensions.JSONSchemaProps{
+									"mountPath": {
+										Type: "string",
+									},
+								},
+							},
+							"secretRefs": {
+								Type: "array",
+								Items: &apiExtensions.JSONSchemaPropsOrArray{
+									Schema: &apiExtensions.JSONSchemaProps{
+										Type:     "object",
+										Required: []string{"name", "key"},
+										Properties: map[string]apiExtensions.JSONSchemaProps{
+											"store": {
+												Type: "string",
+											},
+											"name": {
+												Type: "string",
+											},
+											"key": {
+												Type: "string",
+											},
+											"envName": {
+												Type: "string",
+											},
+											"mountPath": {
+												Type: "string",
+											},
+										},
+									},
+								},
+							},
+							"hostfile": {
+								Type: "object",
+								Properties: map[string]apiExtensions.JSONSchemaProps{
+									"slots": {
+										Type:    "integer",
+										Minimum: common.PtrFloat64(0),
+									},
+								},
+							},
 						},
 					},
 				},
@@ -125,3 +929,137 @@ func buildFrameworkValidation() *apiExtensions.CustomResourceValidation {
 		},
 	}
 }
+
+func buildRetryPolicyValidation() apiExtensions.JSONSchemaProps {
+	return apiExtensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiExtensions.JSONSchemaProps{
+			"fancyRetryPolicy": {
+				Type: "boolean",
+			},
+			"maxRetryCount": {
+				Type:    "integer",
+				Minimum: common.PtrFloat64(ExtendedUnlimitedValue),
+			},
+			"completionOverrides": {
+				Type: "array",
+				Items: &apiExtensions.JSONSchemaPropsOrArray{
+					Schema: &apiExtensions.JSONSchemaProps{
+						Type: "object",
+						Properties: map[string]apiExtensions.JSONSchemaProps{
+							"minCode": {
+								Type: "integer",
+							},
+							"maxCode": {
+								Type: "integer",
+							},
+							"typeName": {
+								Type: "string",
+								Enum: []apiExtensions.JSON{
+									{Raw: []byte(common.Quote(""))},
+									{Raw: []byte(common.Quote(string(CompletionTypeNameSucceeded)))},
+									{Raw: []byte(common.Quote(string(CompletionTypeNameFailed)))},
+								},
+							},
+							"typeAttributes": {
+								Type: "array",
+								Items: &apiExtensions.JSONSchemaPropsOrArray{
+									Schema: &apiExtensions.JSONSchemaProps{
+										Type: "string",
+									},
+								},
+							},
+							"maxRetryCount": {
+								Type:    "integer",
+								Minimum: common.PtrFloat64(UnlimitedValue),
+							},
+							"delaySec": {
+								Type:    "integer",
+								Minimum: common.PtrFloat64(0),
+							},
+						},
+					},
+				},
+			},
+			"backoffPolicy": {
+				Type:     "object",
+				Required: []string{"baseDelaySec"},
+				Properties: map[string]apiExtensions.JSONSchemaProps{
+					"type": {
+						Type: "string",
+						Enum: []apiExtensions.JSON{
+							{Raw: []byte(common.Quote(""))},
+							{Raw: []byte(common.Quote(string(BackoffFixed)))},
+							{Raw: []byte(common.Quote(string(BackoffExponential)))},
+						},
+					},
+					"baseDelaySec": {
+						Type:    "integer",
+						Minimum: common.PtrFloat64(0),
+					},
+					"maxDelaySec": {
+						Type:    "integer",
+						Minimum: common.PtrFloat64(0),
+					},
+					"jitterPercent": {
+						Type:    "integer",
+						Minimum: common.PtrFloat64(0),
+						Maximum: common.PtrFloat64(100),
+					},
+				},
+			},
+		},
+	}
+}
+
+func buildMinTaskCountValidation() apiExtensions.JSONSchemaProps {
+	return apiExtensions.JSONSchemaProps{
+		Type: "integer",
+		// TODO: should not allow 0, but it is the zero value serialized by
+		// existing clients, so it is still treated as UnlimitedValue.
+		Minimum: common.PtrFloat64(UnlimitedValue),
+	}
+}
+
+func buildHookValidation() apiExtensions.JSONSchemaProps {
+	return apiExtensions.JSONSchemaProps{
+		Type: "object",
+		Properties: map[string]apiExtensions.JSONSchemaProps{
+			"pod": {
+				Type: "object",
+			},
+			"webhook": {
+				Type:     "object",
+				Required: []string{"url"},
+				Properties: map[string]apiExtensions.JSONSchemaProps{
+					"url": {
+						Type: "string",
+					},
+				},
+			},
+			"timeoutSec": {
+				Type:    "integer",
+				Minimum: common.PtrFloat64(1),
+			},
+			"failurePolicy": {
+				Type: "string",
+				Enum: []apiExtensions.JSON{
+					{Raw: []byte(common.Quote(""))},
+					{Raw: []byte(common.Quote(string(HookFailurePolicyFail)))},
+					{Raw: []byte(common.Quote(string(HookFailurePolicyIgnore)))},
+				},
+			},
+		},
+	}
+}
+
+func buildDeletionPropagationValidation() apiExtensions.JSONSchemaProps {
+	enum := []apiExtensions.JSON{}
+	for _, policy := range ValidDeletionPropagations {
+		enum = append(enum, apiExtensions.JSON{Raw: []byte(common.Quote(string(policy)))})
+	}
+	return apiExtensions.JSONSchemaProps{
+		Type: "string",
+		Enum: enum,
+	}
+}
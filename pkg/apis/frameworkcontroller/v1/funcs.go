@@ -25,13 +25,22 @@ package v1
 import (
 	"fmt"
 	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"hash/fnv"
 	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/apimachinery/pkg/util/strategicpatch"
 	"k8s.io/klog"
+	"math"
+	"regexp"
 	"sort"
 	"strconv"
 	"strings"
+	"time"
 )
 
 ///////////////////////////////////////////////////////////////////////////////////////
@@ -57,6 +66,90 @@ func SplitConfigMapName(configMapName string) (frameworkName string) {
 	return parts[0]
 }
 
+func IsValidDeletionPropagation(policy meta.DeletionPropagation) bool {
+	for _, validPolicy := range ValidDeletionPropagations {
+		if policy == validPolicy {
+			return true
+		}
+	}
+	return false
+}
+
+func GetFrameworkAttemptHistoryName(frameworkName string, frameworkAttemptID int32) string {
+	return strings.Join([]string{frameworkName, "attempt", fmt.Sprint(frameworkAttemptID)}, "-")
+}
+
+// The scheduled time is truncated to minute, so different scheduled times of the
+// same ScheduledFramework always get different Framework names.
+func GetScheduledFrameworkInstanceName(
+	scheduledFrameworkName string, scheduledTime time.Time) string {
+	return fmt.Sprintf("%v%v", scheduledFrameworkName, scheduledTime.Unix()/60)
+}
+
+// The FrameworkGroupName and MemberName are limited, so the concatenation is
+// always a valid Framework name.
+func GetFrameworkGroupMemberFrameworkName(
+	frameworkGroupName string, memberName string) string {
+	return frameworkGroupName + memberName
+}
+
+// A PodGroup is created for each FrameworkAttempt, so that a new FrameworkAttempt
+// does not need to wait for the previous PodGroup to be garbage collected.
+func GetPodGroupName(frameworkName string, frameworkAttemptID int32) string {
+	return strings.Join([]string{frameworkName, "attempt", fmt.Sprint(frameworkAttemptID), "podgroup"}, "-")
+}
+
+// Same as the PodGroup, a Kueue Workload is created for each FrameworkAttempt.
+func GetKueueWorkloadName(frameworkName string, frameworkAttemptID int32) string {
+	return strings.Join([]string{frameworkName, "attempt", fmt.Sprint(frameworkAttemptID), "workload"}, "-")
+}
+
+func GetHeadlessServiceName(frameworkName string, taskRoleName string) string {
+	return strings.Join([]string{frameworkName, taskRoleName}, "-")
+}
+
+func GetExposedServiceName(frameworkName string, taskRoleName string) string {
+	return strings.Join([]string{frameworkName, taskRoleName, "exposed"}, "-")
+}
+
+func GetTaskHostname(taskRoleName string, taskIndex int32) string {
+	return strings.Join([]string{taskRoleName, fmt.Sprint(taskIndex)}, "-")
+}
+
+// Same as the PodGroup, a SSH keypair Secret is created for each FrameworkAttempt.
+func GetSSHSecretName(frameworkName string, frameworkAttemptID int32) string {
+	return strings.Join([]string{frameworkName, "attempt", fmt.Sprint(frameworkAttemptID), "ssh"}, "-")
+}
+
+// Same as the PodGroup, the hook Pods are created for each FrameworkAttempt.
+func GetHookPodName(frameworkName string, frameworkAttemptID int32, hookName string) string {
+	return strings.Join([]string{frameworkName, "attempt", fmt.Sprint(frameworkAttemptID), hookName}, "-")
+}
+
+// Same as the PodGroup, a NetworkPolicy is created for each FrameworkAttempt.
+func GetNetworkPolicyName(frameworkName string, frameworkAttemptID int32) string {
+	return strings.Join([]string{frameworkName, "attempt", fmt.Sprint(frameworkAttemptID)}, "-")
+}
+
+// See https://kubernetes.io/docs/concepts/services-networking/dns-pod-service/#pod-s-hostname-and-subdomain-fields
+func GetPodFQDN(pod *core.Pod, clusterDomain string) *string {
+	if pod.Spec.Hostname == "" || pod.Spec.Subdomain == "" {
+		return nil
+	}
+	return common.PtrString(strings.Join([]string{
+		pod.Spec.Hostname, pod.Spec.Subdomain, pod.Namespace, "svc", clusterDomain}, "."))
+}
+
+func GetTaskRoleEnvName(taskRoleName string, suffix string) string {
+	return strings.Join([]string{"FC", strings.ToUpper(taskRoleName), suffix}, "_")
+}
+
+// Same as the headless Service, a PodDisruptionBudget is created for each
+// TaskRole of each FrameworkAttempt.
+func GetPodDisruptionBudgetName(frameworkName string, taskRoleName string) string {
+	return strings.Join([]string{frameworkName, taskRoleName}, "-")
+}
+
 func GetPodName(frameworkName string, taskRoleName string, taskIndex int32) string {
 	return strings.Join([]string{frameworkName, taskRoleName, fmt.Sprint(taskIndex)}, "-")
 }
@@ -143,6 +236,156 @@ func GetAllContainerStatuses(pod *core.Pod) []core.ContainerStatus {
 		pod.Status.ContainerStatuses...)
 }
 
+// Get the Pod phase with respect to the completion of the completionContainer,
+// i.e. once the completionContainer is terminated and will not be restarted,
+// the Pod is treated as completed with its ExitCode, regardless of other
+// Containers.
+func GetPodCompletionPhase(pod *core.Pod, completionContainer string) core.PodPhase {
+	if completionContainer == "" {
+		return pod.Status.Phase
+	}
+
+	podCompleted := pod.Status.Phase == core.PodSucceeded ||
+		pod.Status.Phase == core.PodFailed
+	for _, container := range GetAllContainerStatuses(pod) {
+		if container.Name != completionContainer {
+			continue
+		}
+
+		term := container.State.Terminated
+		if term == nil {
+			break
+		}
+		if term.ExitCode == 0 {
+			if podCompleted || pod.Spec.RestartPolicy != core.RestartPolicyAlways {
+				return core.PodSucceeded
+			}
+		} else {
+			if podCompleted || pod.Spec.RestartPolicy == core.RestartPolicyNever {
+				return core.PodFailed
+			}
+		}
+		break
+	}
+
+	return pod.Status.Phase
+}
+
+var podNodeUnmatchedTotalRegex = regexp.MustCompile(
+	`^0/(\d+) nodes are available`)
+var podNodeUnmatchedSelectorRegex = regexp.MustCompile(
+	`(\d+) node\(s\) didn't match (?:Pod's )?node (?:affinity/)?selector`)
+
+// Get the latest heartbeat time reported by the Pod, or nil if it has not
+// reported any valid heartbeat.
+func GetPodHeartbeatTime(pod *core.Pod) *meta.Time {
+	value, ok := pod.Annotations[AnnotationKeyHeartbeatTime]
+	if !ok {
+		return nil
+	}
+	t, err := time.Parse(time.RFC3339, value)
+	if err != nil {
+		return nil
+	}
+	return &meta.Time{Time: t}
+}
+
+// Get the extended resources allocated to the Pod, and the device IDs exposed
+// in the Pod annotations with the deviceIDAnnotationKeys, or nil if the Pod
+// does not request any extended resource.
+func GetPodAllocatedDevices(
+	pod *core.Pod, deviceIDAnnotationKeys []string) *PodAllocatedDevices {
+	// Same as the effective request of the Pod, i.e. the max of the sum of all
+	// AppContainers and each InitContainer.
+	resources := map[core.ResourceName]int64{}
+	for _, container := range pod.Spec.Containers {
+		for name, quantity := range container.Resources.Limits {
+			if isExtendedResourceName(name) {
+				resources[name] += quantity.Value()
+			}
+		}
+	}
+	for _, container := range pod.Spec.InitContainers {
+		for name, quantity := range container.Resources.Limits {
+			if isExtendedResourceName(name) && quantity.Value() > resources[name] {
+				resources[name] = quantity.Value()
+			}
+		}
+	}
+	if len(resources) == 0 {
+		return nil
+	}
+
+	devices := &PodAllocatedDevices{Resources: resources}
+	for _, key := range deviceIDAnnotationKeys {
+		for _, id := range strings.Split(pod.Annotations[key], ",") {
+			if id = strings.TrimSpace(id); id != "" {
+				devices.DeviceIDs = append(devices.DeviceIDs, id)
+			}
+		}
+	}
+	return devices
+}
+
+// The extended resource name is fully qualified and outside the kubernetes.io
+// domain, such as nvidia.com/gpu.
+func isExtendedResourceName(name core.ResourceName) bool {
+	return strings.Contains(string(name), "/") &&
+		!strings.Contains(string(name), core.ResourceDefaultNamespacePrefix) &&
+		!strings.HasPrefix(string(name), core.DefaultResourceRequestsPrefix)
+}
+
+// Get the True DisruptionTarget condition if the Pod is disrupted, otherwise
+// nil.
+func GetPodDisruptionCondition(pod *core.Pod) *core.PodCondition {
+	for i := range pod.Status.Conditions {
+		cond := &pod.Status.Conditions[i]
+		if cond.Type == PodConditionTypeDisruptionTarget &&
+			cond.Status == core.ConditionTrue {
+			return cond
+		}
+	}
+	return nil
+}
+
+// Get the Pod unschedulable condition if the Pod is not yet scheduled to any
+// node, otherwise nil.
+func GetPodUnschedulableCondition(pod *core.Pod) *core.PodCondition {
+	if pod.Spec.NodeName != "" {
+		return nil
+	}
+	for i := range pod.Status.Conditions {
+		cond := &pod.Status.Conditions[i]
+		if cond.Type == core.PodScheduled &&
+			cond.Status == core.ConditionFalse &&
+			cond.Reason == core.PodReasonUnschedulable {
+			return cond
+		}
+	}
+	return nil
+}
+
+// Get the Pod unschedulable condition if all nodes do not match the Pod's
+// NodeSelector or NodeAffinity, otherwise nil.
+func GetPodNodeUnmatchedCondition(pod *core.Pod) *core.PodCondition {
+	for i := range pod.Status.Conditions {
+		cond := &pod.Status.Conditions[i]
+		if cond.Type != core.PodScheduled ||
+			cond.Status != core.ConditionFalse ||
+			cond.Reason != core.PodReasonUnschedulable {
+			continue
+		}
+
+		total := podNodeUnmatchedTotalRegex.FindStringSubmatch(cond.Message)
+		unmatched := podNodeUnmatchedSelectorRegex.FindStringSubmatch(cond.Message)
+		if total != nil && unmatched != nil && total[1] == unmatched[1] {
+			return cond
+		}
+		return nil
+	}
+	return nil
+}
+
 func BindIDP(
 	selectorIDP TaskStatusSelectorIDP,
 	ignoreDeletionPending bool) TaskStatusSelector {
@@ -207,6 +450,48 @@ func NewCompletedTaskTriggeredCompletionStatus(
 	}
 }
 
+func NewPodFailurePolicyTriggeredCompletionStatus(
+	triggerTaskStatus *TaskStatus,
+	triggerTaskRoleName string) *FrameworkAttemptCompletionStatus {
+	pfp := triggerTaskStatus.AttemptStatus.CompletionStatus.PodFailurePolicy
+	diag := fmt.Sprintf(
+		"TaskAttempt matched PodFailurePolicy Rules[%v] with Action %v",
+		pfp.RuleIndex, pfp.Action)
+	return CompletionCodePodFailurePolicyFailFramework.NewFrameworkAttemptCompletionStatus(
+		diag, &CompletionPolicyTriggerStatus{
+			Message:      diag,
+			TaskRoleName: triggerTaskRoleName,
+			TaskIndex:    triggerTaskStatus.Index,
+		},
+	)
+}
+
+func NewSchedulingTimeoutCompletionStatus(
+	triggerTaskStatus *TaskStatus,
+	triggerTaskRoleName string,
+	message string) *FrameworkAttemptCompletionStatus {
+	return CompletionCodeFrameworkSchedulingTimeout.NewFrameworkAttemptCompletionStatus(
+		message, &CompletionPolicyTriggerStatus{
+			Message:      message,
+			TaskRoleName: triggerTaskRoleName,
+			TaskIndex:    triggerTaskStatus.Index,
+		},
+	)
+}
+
+func NewRetryBudgetExceededCompletionStatus(
+	triggerTaskStatus *TaskStatus,
+	triggerTaskRoleName string,
+	message string) *FrameworkAttemptCompletionStatus {
+	return CompletionCodeFrameworkRetryBudgetExceeded.NewFrameworkAttemptCompletionStatus(
+		message, &CompletionPolicyTriggerStatus{
+			Message:      message,
+			TaskRoleName: triggerTaskRoleName,
+			TaskIndex:    triggerTaskStatus.Index,
+		},
+	)
+}
+
 ///////////////////////////////////////////////////////////////////////////////////////
 // Interfaces
 ///////////////////////////////////////////////////////////////////////////////////////
@@ -243,11 +528,24 @@ func (f *Framework) TaskRoleSpec(taskRoleName string) *TaskRoleSpec {
 func (f *Framework) GetTaskCountSpec() int32 {
 	taskCount := int32(0)
 	for _, taskRole := range f.Spec.TaskRoles {
-		taskCount += taskRole.TaskNumber
+		taskCount += f.GetTaskNumber(taskRole)
 	}
 	return taskCount
 }
 
+// GetTaskNumber returns the effective TaskNumber of the TaskRole in current
+// FrameworkAttempt, i.e. its AutoscaledTaskNumber if it is not nil, otherwise
+// its TaskRoleSpec TaskNumber.
+func (f *Framework) GetTaskNumber(taskRoleSpec *TaskRoleSpec) int32 {
+	if f.Status != nil {
+		taskRoleStatus := f.GetTaskRoleStatus(taskRoleSpec.Name)
+		if taskRoleStatus != nil && taskRoleStatus.AutoscaledTaskNumber != nil {
+			return *taskRoleStatus.AutoscaledTaskNumber
+		}
+	}
+	return taskRoleSpec.TaskNumber
+}
+
 func (f *Framework) GetTotalTaskCountSpec() int32 {
 	return f.GetTaskCountSpec()
 }
@@ -345,6 +643,69 @@ func (ts *TaskStatus) IsCompleted(ignoreDeletionPending bool) bool {
 	return ts.State == TaskCompleted
 }
 
+func (f *Framework) GetPhase() FrameworkPhase {
+	if f.IsSucceeded() {
+		return FrameworkPhaseSucceeded
+	} else if f.IsCompleted() {
+		return FrameworkPhaseFailed
+	} else if f.IsRunning() {
+		return FrameworkPhaseRunning
+	} else {
+		return FrameworkPhasePending
+	}
+}
+
+// Recompute the Status.Progress from current TaskRoleStatuses, which should be
+// decompressed.
+func (f *Framework) UpdateProgress() {
+	if f.Status == nil {
+		return
+	}
+
+	progress := &FrameworkProgress{TaskRoles: []*TaskRoleProgress{}}
+	var taskCount, completedTaskCount int32
+	for _, taskRoleStatus := range f.TaskRoleStatuses() {
+		trp := &TaskRoleProgress{Name: taskRoleStatus.Name}
+		failedTasks := []*TaskStatus{}
+		for _, taskStatus := range taskRoleStatus.TaskStatuses {
+			if taskStatus.DeletionPending {
+				continue
+			}
+			trp.TaskCount++
+			if taskStatus.IsSucceeded(true) {
+				trp.SucceededTaskCount++
+			} else if taskStatus.IsFailed(true) {
+				trp.FailedTaskCount++
+				failedTasks = append(failedTasks, taskStatus)
+			} else if taskStatus.IsRunning(true) {
+				trp.RunningTaskCount++
+			} else {
+				trp.PendingTaskCount++
+			}
+		}
+		sort.SliceStable(failedTasks, func(i, j int) bool {
+			return failedTasks[j].CompletionTime.Before(failedTasks[i].CompletionTime)
+		})
+		for i := 0; i < len(failedTasks) && i < ProgressFailedTaskSampleMaxCount; i++ {
+			trp.FailedTaskSamples = append(trp.FailedTaskSamples, &FailedTaskSample{
+				Index:            failedTasks[i].Index,
+				AttemptID:        failedTasks[i].TaskAttemptID(),
+				CompletionTime:   failedTasks[i].CompletionTime,
+				CompletionStatus: failedTasks[i].AttemptStatus.CompletionStatus.CompletionStatus,
+			})
+		}
+
+		taskCount += trp.TaskCount
+		completedTaskCount += trp.SucceededTaskCount + trp.FailedTaskCount
+		progress.TaskRoles = append(progress.TaskRoles, trp)
+	}
+	if taskCount > 0 {
+		progress.CompletionPercentage = int32(
+			int64(completedTaskCount) * 100 / int64(taskCount))
+	}
+	f.Status.Progress = progress
+}
+
 func (f *Framework) IsRunning() bool {
 	return f.Status.State == FrameworkAttemptRunning
 }
@@ -356,6 +717,127 @@ func (ts *TaskStatus) IsRunning(ignoreDeletionPending bool) bool {
 	return ts.State == TaskAttemptRunning
 }
 
+// Return the reason if retrying the Task would exceed the RetryBudget in current
+// FrameworkAttempt, otherwise empty.
+func (f *Framework) GetRetryBudgetExceededReason(taskStatus *TaskStatus) string {
+	budget := f.Spec.RetryBudget
+	if budget == nil {
+		return ""
+	}
+
+	totalRetriedCount := int32(0)
+	retriedTaskCount := int32(0)
+	totalTaskCount := int32(0)
+	for _, taskRoleStatus := range f.TaskRoleStatuses() {
+		for _, ts := range taskRoleStatus.TaskStatuses {
+			totalTaskCount++
+			totalRetriedCount += ts.RetryPolicyStatus.TotalRetriedCount
+			if ts.RetryPolicyStatus.TotalRetriedCount > 0 || ts == taskStatus {
+				retriedTaskCount++
+			}
+		}
+	}
+	totalRetriedCount++
+
+	if budget.MaxTaskRetryCount != nil && totalRetriedCount > *budget.MaxTaskRetryCount {
+		return fmt.Sprintf(
+			"TaskRetryCount %v would exceed RetryBudget MaxTaskRetryCount %v",
+			totalRetriedCount, *budget.MaxTaskRetryCount)
+	}
+	if budget.MaxRetriedTaskPercent != nil &&
+		retriedTaskCount*100 > *budget.MaxRetriedTaskPercent*totalTaskCount {
+		return fmt.Sprintf(
+			"RetriedTaskCount %v of TotalTaskCount %v would exceed "+
+				"RetryBudget MaxRetriedTaskPercent %v",
+			retriedTaskCount, totalTaskCount, *budget.MaxRetriedTaskPercent)
+	}
+	return ""
+}
+
+// The TaskRoleSpec may be nil, such as it is already deleted.
+func (trs *TaskRoleSpec) IsTaskStopRequested(taskIndex int32) bool {
+	if trs == nil {
+		return false
+	}
+	for _, index := range trs.Task.StopIndexes {
+		if index == taskIndex {
+			return true
+		}
+	}
+	return false
+}
+
+// The TaskRoleSpec may be nil, such as it is already deleted.
+func (trs *TaskRoleSpec) IsTaskRestartRequested(taskIndex int32, taskAttemptID int32) bool {
+	if trs == nil {
+		return false
+	}
+	for _, req := range trs.Task.RestartRequests {
+		if req.Index == taskIndex && req.AttemptID == taskAttemptID {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns nil if the hook is not specified.
+func (f *Framework) HookSpec(hookName string) *HookSpec {
+	if f.Spec.Hooks == nil {
+		return nil
+	}
+	switch hookName {
+	case HookNamePreAttempt:
+		return f.Spec.Hooks.PreAttempt
+	case HookNamePostAttempt:
+		return f.Spec.Hooks.PostAttempt
+	}
+	return nil
+}
+
+func (f *Framework) HookStatus(hookName string) *HookStatus {
+	switch hookName {
+	case HookNamePreAttempt:
+		return f.Status.AttemptStatus.PreAttemptHookStatus
+	case HookNamePostAttempt:
+		return f.Status.AttemptStatus.PostAttemptHookStatus
+	}
+	return nil
+}
+
+func (f *Framework) SetHookStatus(hookName string, hookStatus *HookStatus) {
+	switch hookName {
+	case HookNamePreAttempt:
+		f.Status.AttemptStatus.PreAttemptHookStatus = hookStatus
+	case HookNamePostAttempt:
+		f.Status.AttemptStatus.PostAttemptHookStatus = hookStatus
+	}
+}
+
+func (f *Framework) IsRestartAttemptRequested() bool {
+	return f.Spec.RestartAttemptID != nil &&
+		*f.Spec.RestartAttemptID == f.FrameworkAttemptID()
+}
+
+func (f *Framework) IsSuspended() bool {
+	return f.Status.State == FrameworkSuspended
+}
+
+func (f *Framework) IsStopOrSuspendRequested() bool {
+	return f.Spec.ExecutionType == ExecutionStop ||
+		f.Spec.ExecutionType == ExecutionSuspend
+}
+
+// The CompletionStatus to complete current FrameworkAttempt due to the
+// ExecutionType, so it is only valid if IsStopOrSuspendRequested.
+func (f *Framework) NewStopOrSuspendRequestedCompletionStatus() *FrameworkAttemptCompletionStatus {
+	if f.Spec.ExecutionType == ExecutionSuspend {
+		return CompletionCodeSuspendFrameworkRequested.NewFrameworkAttemptCompletionStatus(
+			"User has requested to suspend the Framework", nil)
+	}
+	return CompletionCodeStopFrameworkRequested.NewFrameworkAttemptCompletionStatus(
+		"User has requested to stop the Framework", nil)
+}
+
 func (f *Framework) IsCompleting() bool {
 	return f.Status.State == FrameworkAttemptDeletionPending ||
 		f.Status.State == FrameworkAttemptDeletionRequested ||
@@ -443,6 +925,72 @@ func (f *Framework) IsAnyTaskRunning(ignoreDeletionPending bool) bool {
 		(*TaskStatus).IsRunning, ignoreDeletionPending)) > 0
 }
 
+// GetMinTaskNumber returns the MinTaskNumber capped by the TaskNumber, and it
+// is the TaskNumber if the MinTaskNumber is not specified.
+func (trs *TaskRoleSpec) GetMinTaskNumber() int32 {
+	if trs.MinTaskNumber <= 0 || trs.MinTaskNumber > trs.TaskNumber {
+		return trs.TaskNumber
+	}
+	return trs.MinTaskNumber
+}
+
+// GetMinTaskNumberUnsatisfiedReason returns the non-empty reason if any TaskRole
+// has less available, i.e. Running or Succeeded, Tasks than its MinTaskNumber.
+func (f *Framework) GetMinTaskNumberUnsatisfiedReason() string {
+	availableTaskSelector := func(taskStatus *TaskStatus) bool {
+		return taskStatus.IsRunning(true) || taskStatus.IsSucceeded(true)
+	}
+
+	for _, taskRoleSpec := range f.Spec.TaskRoles {
+		if taskRoleSpec.MinTaskNumber <= 0 {
+			continue
+		}
+		minTaskNumber := taskRoleSpec.GetMinTaskNumber()
+
+		taskRoleStatus := f.GetTaskRoleStatus(taskRoleSpec.Name)
+		if taskRoleStatus == nil {
+			continue
+		}
+		availableTaskCount := taskRoleStatus.GetTaskCountStatus(availableTaskSelector)
+		if availableTaskCount < minTaskNumber {
+			return fmt.Sprintf(
+				"TaskRole %v has %v available Tasks, which is less than its "+
+					"MinTaskNumber %v", taskRoleSpec.Name, availableTaskCount, minTaskNumber)
+		}
+	}
+	return ""
+}
+
+// GetTaskRoleDependencyUnsatisfiedReason returns the non-empty reason if any
+// prerequisite TaskRole of the TaskRole has not all its Tasks Running or
+// Succeeded.
+func (f *Framework) GetTaskRoleDependencyUnsatisfiedReason(
+	taskRoleSpec *TaskRoleSpec) string {
+	availableTaskSelector := func(taskStatus *TaskStatus) bool {
+		return taskStatus.IsRunning(true) || taskStatus.IsSucceeded(true)
+	}
+
+	for _, depName := range taskRoleSpec.DependsOn {
+		depSpec := f.GetTaskRoleSpec(depName)
+		if depSpec == nil || depName == taskRoleSpec.Name {
+			continue
+		}
+		depTaskNumber := f.GetTaskNumber(depSpec)
+
+		availableTaskCount := int32(0)
+		depStatus := f.GetTaskRoleStatus(depName)
+		if depStatus != nil {
+			availableTaskCount = depStatus.GetTaskCountStatus(availableTaskSelector)
+		}
+		if availableTaskCount < depTaskNumber {
+			return fmt.Sprintf(
+				"Prerequisite TaskRole %v has %v available Tasks, which is less than "+
+					"its TaskNumber %v", depName, availableTaskCount, depTaskNumber)
+		}
+	}
+	return ""
+}
+
 func (f *Framework) NewConfigMap() *core.ConfigMap {
 	frameworkAttemptIDStr := fmt.Sprint(f.FrameworkAttemptID())
 
@@ -469,9 +1017,62 @@ func (f *Framework) NewConfigMap() *core.ConfigMap {
 	return cm
 }
 
-func (f *Framework) NewPod(cm *core.ConfigMap, taskRoleName string, taskIndex int32) *core.Pod {
+func (f *Framework) NewHeadlessService(
+	cm *core.ConfigMap, taskRoleName string) *core.Service {
+	svc := &core.Service{
+		ObjectMeta: meta.ObjectMeta{},
+	}
+
+	svc.Name = GetHeadlessServiceName(f.Name, taskRoleName)
+	svc.Namespace = f.Namespace
+	svc.OwnerReferences = []meta.OwnerReference{*meta.NewControllerRef(cm, ConfigMapGroupVersionKind)}
+
+	svc.Annotations = map[string]string{}
+	svc.Annotations[AnnotationKeyFrameworkNamespace] = f.Namespace
+	svc.Annotations[AnnotationKeyFrameworkName] = f.Name
+	svc.Annotations[AnnotationKeyTaskRoleName] = taskRoleName
+	svc.Annotations[AnnotationKeyConfigMapName] = cm.Name
+	svc.Annotations[AnnotationKeyFrameworkAttemptID] = fmt.Sprint(f.FrameworkAttemptID())
+
+	svc.Labels = map[string]string{}
+	svc.Labels[LabelKeyFrameworkName] = f.Name
+	svc.Labels[LabelKeyTaskRoleName] = taskRoleName
+
+	svc.Spec.ClusterIP = core.ClusterIPNone
+	svc.Spec.Selector = map[string]string{
+		LabelKeyFrameworkName: f.Name,
+		LabelKeyTaskRoleName:  taskRoleName,
+	}
+	// The peers should be resolvable before they are ready, such as during the
+	// FrameworkBarrier.
+	svc.Spec.PublishNotReadyAddresses = true
+
+	return svc
+}
+
+func (f *Framework) NewExposedService(
+	cm *core.ConfigMap, taskRoleName string) *core.Service {
+	expose := f.TaskRoleSpec(taskRoleName).Expose
+	svc := f.NewHeadlessService(cm, taskRoleName)
+
+	svc.Name = GetExposedServiceName(f.Name, taskRoleName)
+	svc.Spec.ClusterIP = ""
+	svc.Spec.PublishNotReadyAddresses = false
+	svc.Spec.Type = expose.Type
+	if svc.Spec.Type == "" {
+		svc.Spec.Type = core.ServiceTypeClusterIP
+	}
+	svc.Spec.Ports = append([]core.ServicePort{}, expose.Ports...)
+
+	return svc
+}
+
+func (f *Framework) NewPod(cm *core.ConfigMap, taskRoleName string, taskIndex int32) (*core.Pod, error) {
 	// Deep copy Task.Pod before modify it
-	taskPodJson := common.ToJson(f.TaskRoleSpec(taskRoleName).Task.Pod)
+	taskPodJson, err := f.GetTaskPodJson(taskRoleName, taskIndex)
+	if err != nil {
+		return nil, err
+	}
 	taskStatus := f.TaskStatus(taskRoleName, taskIndex)
 	taskIndexStr := fmt.Sprint(taskIndex)
 	frameworkAttemptIDStr := fmt.Sprint(f.FrameworkAttemptID())
@@ -491,7 +1092,11 @@ func (f *Framework) NewPod(cm *core.ConfigMap, taskRoleName string, taskIndex in
 		common.ReferPlaceholder(PlaceholderTaskRoleName), taskRoleName,
 		common.ReferPlaceholder(PlaceholderTaskIndex), taskIndexStr,
 		common.ReferPlaceholder(PlaceholderConfigMapName), f.ConfigMapName(),
-		common.ReferPlaceholder(PlaceholderPodName), taskStatus.PodName())
+		common.ReferPlaceholder(PlaceholderPodName), taskStatus.PodName(),
+		common.ReferPlaceholder(PlaceholderFrameworkAttemptID), frameworkAttemptIDStr,
+		common.ReferPlaceholder(PlaceholderFrameworkAttemptInstanceUID), frameworkAttemptInstanceUIDStr,
+		common.ReferPlaceholder(PlaceholderConfigMapUID), configMapUIDStr,
+		common.ReferPlaceholder(PlaceholderTaskAttemptID), taskAttemptIDStr)
 
 	// Using Json to avoid breaking one Placeholder to multiple lines
 	common.FromJson(placeholderReplacer.Replace(taskPodJson), &podTemplate)
@@ -528,6 +1133,8 @@ func (f *Framework) NewPod(cm *core.ConfigMap, taskRoleName string, taskIndex in
 	pod.Annotations[AnnotationKeyFrameworkAttemptInstanceUID] = frameworkAttemptInstanceUIDStr
 	pod.Annotations[AnnotationKeyConfigMapUID] = configMapUIDStr
 	pod.Annotations[AnnotationKeyTaskAttemptID] = taskAttemptIDStr
+	pod.Annotations[AnnotationKeyPodTemplateHash] = GetPodTemplateHash(taskPodJson)
+	pod.Annotations[AnnotationKeyJobCompletionIndex] = taskIndexStr
 
 	if pod.Labels == nil {
 		pod.Labels = map[string]string{}
@@ -549,6 +1156,7 @@ func (f *Framework) NewPod(cm *core.ConfigMap, taskRoleName string, taskIndex in
 		{Name: EnvNameTaskAttemptID, Value: taskAttemptIDStr},
 		{Name: EnvNamePodUID, ValueFrom: ObjectUIDEnvVarSource},
 		{Name: EnvNameTaskAttemptInstanceUID, Value: taskAttemptInstanceUIDReferStr},
+		{Name: EnvNameJobCompletionIndex, Value: taskIndexStr},
 	}
 
 	// Prepend predefinedEnvs so that they can be referred by the environment variable
@@ -574,83 +1182,1003 @@ func (f *Framework) NewPod(cm *core.ConfigMap, taskRoleName string, taskIndex in
 		}
 	}
 
-	return pod
-}
+	if sshKey := f.TaskRoleSpec(taskRoleName).SSHKey; sshKey != nil {
+		mountPath := sshKey.MountPath
+		if mountPath == "" {
+			mountPath = SSHDefaultMountPath
+		}
+		// The sshd requires the private key is not accessible by others.
+		pod.Spec.Volumes = append(pod.Spec.Volumes, core.Volume{
+			Name: SSHVolumeName,
+			VolumeSource: core.VolumeSource{
+				Secret: &core.SecretVolumeSource{
+					SecretName:  GetSSHSecretName(f.Name, f.FrameworkAttemptID()),
+					DefaultMode: common.PtrInt32(0600),
+				},
+			},
+		})
+		for i := range pod.Spec.Containers {
+			pod.Spec.Containers[i].VolumeMounts = append(
+				pod.Spec.Containers[i].VolumeMounts, core.VolumeMount{
+					Name:      SSHVolumeName,
+					MountPath: mountPath,
+					ReadOnly:  true,
+				})
+		}
+	}
 
-func (f *Framework) NewFrameworkStatus() *FrameworkStatus {
-	return &FrameworkStatus{
-		StartTime:      meta.Now(),
-		CompletionTime: nil,
-		State:          FrameworkAttemptCreationPending,
-		TransitionTime: meta.Now(),
-		RetryPolicyStatus: RetryPolicyStatus{
-			TotalRetriedCount:       0,
-			AccountableRetriedCount: 0,
-			RetryDelaySec:           nil,
-		},
-		AttemptStatus: f.NewFrameworkAttemptStatus(0),
+	if memoryEscalation := taskStatus.MemoryEscalation; memoryEscalation != nil {
+		escalateContainerMemory(pod,
+			f.TaskRoleSpec(taskRoleName).MemoryEscalation, memoryEscalation)
 	}
-}
 
-func (f *Framework) NewFrameworkAttemptStatus(
-	frameworkAttemptID int32) FrameworkAttemptStatus {
-	return FrameworkAttemptStatus{
-		ID:                         frameworkAttemptID,
-		StartTime:                  meta.Now(),
-		RunTime:                    nil,
-		CompletionTime:             nil,
-		InstanceUID:                nil,
-		ConfigMapName:              GetConfigMapName(f.Name),
-		ConfigMapUID:               nil,
-		CompletionStatus:           nil,
-		TaskRoleStatuses:           f.NewTaskRoleStatuses(),
-		TaskRoleStatusesCompressed: nil,
+	if err := setNodePlatformSelector(pod, f.TaskRoleSpec(taskRoleName)); err != nil {
+		return nil, err
 	}
-}
 
-func (f *Framework) NewTaskRoleStatuses() []*TaskRoleStatus {
-	trss := []*TaskRoleStatus{}
-	for _, taskRole := range f.Spec.TaskRoles {
-		trs := TaskRoleStatus{Name: taskRole.Name, TaskStatuses: []*TaskStatus{}}
-		for taskIndex := int32(0); taskIndex < taskRole.TaskNumber; taskIndex++ {
-			trs.TaskStatuses = append(trs.TaskStatuses, f.NewTaskStatus(taskRole.Name, taskIndex))
+	if hived := f.TaskRoleSpec(taskRoleName).HiveD; hived != nil {
+		if _, ok := pod.Annotations[AnnotationKeyHiveDPodSchedulingSpec]; !ok {
+			pod.Annotations[AnnotationKeyHiveDPodSchedulingSpec] =
+				f.NewHiveDPodSchedulingSpec(hived, frameworkAttemptInstanceUIDStr)
 		}
-		trss = append(trss, &trs)
 	}
-	return trss
-}
 
-func (f *Framework) NewTaskStatus(taskRoleName string, taskIndex int32) *TaskStatus {
-	return &TaskStatus{
-		Index:           taskIndex,
-		StartTime:       meta.Now(),
-		CompletionTime:  nil,
-		State:           TaskAttemptCreationPending,
-		TransitionTime:  meta.Now(),
-		DeletionPending: false,
-		RetryPolicyStatus: RetryPolicyStatus{
-			TotalRetriedCount:       0,
+	if colocation := f.Spec.Colocation; colocation != nil {
+		setPodColocation(pod, f.Name, taskRoleName, colocation)
+	}
+	if antiAffinity := f.TaskRoleSpec(taskRoleName).AntiAffinity; antiAffinity != nil {
+		setPodAntiAffinity(pod, f.Name, taskRoleName, antiAffinity)
+	}
+
+	if pod.Spec.SchedulerName == "" {
+		pod.Spec.SchedulerName = f.TaskRoleSpec(taskRoleName).SchedulerName
+	}
+
+	if sidecarQuit := f.TaskRoleSpec(taskRoleName).SidecarQuit; sidecarQuit != nil {
+		err := wrapSidecarQuitContainer(
+			pod, sidecarQuit, f.TaskRoleSpec(taskRoleName).CompletionContainer)
+		if err != nil {
+			return nil, err
+		}
+	}
+
+	// The hostfile is not yet written when the Pod is created, so the ConfigMap
+	// keys are optional and the containers should wait for them to appear.
+	if f.IsHostfileEnabled() {
+		pod.Spec.Volumes = append(pod.Spec.Volumes, core.Volume{
+			Name: HostfileVolumeName,
+			VolumeSource: core.VolumeSource{
+				ConfigMap: &core.ConfigMapVolumeSource{
+					LocalObjectReference: core.LocalObjectReference{Name: cm.Name},
+					Items: []core.KeyToPath{
+						{Key: ConfigMapKeyHostfile, Path: ConfigMapKeyHostfile},
+						{Key: ConfigMapKeyPeerList, Path: ConfigMapKeyPeerList},
+					},
+					Optional: common.PtrBool(true),
+				},
+			},
+		})
+		hostfileMount := core.VolumeMount{
+			Name:      HostfileVolumeName,
+			MountPath: HostfileMountPath,
+			ReadOnly:  true,
+		}
+		for i := range pod.Spec.Containers {
+			pod.Spec.Containers[i].VolumeMounts = append(
+				pod.Spec.Containers[i].VolumeMounts, hostfileMount)
+		}
+		for i := range pod.Spec.InitContainers {
+			pod.Spec.InitContainers[i].VolumeMounts = append(
+				pod.Spec.InitContainers[i].VolumeMounts, hostfileMount)
+		}
+	}
+
+	return pod, nil
+}
+
+func (f *Framework) NewHookPod(cm *core.ConfigMap, hookName string) (*core.Pod, error) {
+	hook := f.HookSpec(hookName)
+	podName := GetHookPodName(f.Name, f.FrameworkAttemptID(), hookName)
+	frameworkAttemptIDStr := fmt.Sprint(f.FrameworkAttemptID())
+	frameworkAttemptInstanceUIDStr := string(*f.FrameworkAttemptInstanceUID())
+	configMapUIDStr := string(*f.ConfigMapUID())
+
+	// Replace the Framework level Placeholders in Hook.Pod
+	podTemplate := core.PodTemplateSpec{}
+
+	placeholderReplacer := strings.NewReplacer(
+		common.ReferPlaceholder(PlaceholderFrameworkNamespace), f.Namespace,
+		common.ReferPlaceholder(PlaceholderFrameworkName), f.Name,
+		common.ReferPlaceholder(PlaceholderConfigMapName), f.ConfigMapName(),
+		common.ReferPlaceholder(PlaceholderPodName), podName,
+		common.ReferPlaceholder(PlaceholderFrameworkAttemptID), frameworkAttemptIDStr,
+		common.ReferPlaceholder(PlaceholderFrameworkAttemptInstanceUID), frameworkAttemptInstanceUIDStr,
+		common.ReferPlaceholder(PlaceholderConfigMapUID), configMapUIDStr)
+
+	// Using Json to avoid breaking one Placeholder to multiple lines
+	common.FromJson(placeholderReplacer.Replace(common.ToJson(hook.Pod)), &podTemplate)
+
+	// Override Hook.Pod
+	pod := &core.Pod{
+		ObjectMeta: podTemplate.ObjectMeta,
+		Spec:       podTemplate.Spec,
+	}
+
+	pod.Name = podName
+	pod.Namespace = f.Namespace
+	if pod.Spec.RestartPolicy == "" {
+		pod.Spec.RestartPolicy = core.RestartPolicyNever
+	}
+	if pod.Spec.RestartPolicy == core.RestartPolicyAlways {
+		return nil, fmt.Errorf(
+			"Hook %v Pod RestartPolicy should not be %v, since it must complete",
+			hookName, core.RestartPolicyAlways)
+	}
+
+	// Augment Hook.Pod
+	if pod.OwnerReferences == nil {
+		pod.OwnerReferences = []meta.OwnerReference{}
+	}
+	pod.OwnerReferences = append(pod.OwnerReferences, *meta.NewControllerRef(cm, ConfigMapGroupVersionKind))
+
+	if pod.Annotations == nil {
+		pod.Annotations = map[string]string{}
+	}
+	pod.Annotations[AnnotationKeyFrameworkNamespace] = f.Namespace
+	pod.Annotations[AnnotationKeyFrameworkName] = f.Name
+	pod.Annotations[AnnotationKeyConfigMapName] = f.ConfigMapName()
+	pod.Annotations[AnnotationKeyPodName] = pod.Name
+	pod.Annotations[AnnotationKeyFrameworkAttemptID] = frameworkAttemptIDStr
+	pod.Annotations[AnnotationKeyFrameworkAttemptInstanceUID] = frameworkAttemptInstanceUIDStr
+	pod.Annotations[AnnotationKeyConfigMapUID] = configMapUIDStr
+	pod.Annotations[AnnotationKeyHookName] = hookName
+
+	// The TaskRole label is not set, so that the hook Pod is not selected by
+	// the TaskRole Services.
+	if pod.Labels == nil {
+		pod.Labels = map[string]string{}
+	}
+	pod.Labels[LabelKeyFrameworkName] = f.Name
+
+	predefinedEnvs := []core.EnvVar{
+		{Name: EnvNameFrameworkNamespace, Value: f.Namespace},
+		{Name: EnvNameFrameworkName, Value: f.Name},
+		{Name: EnvNameConfigMapName, Value: f.ConfigMapName()},
+		{Name: EnvNamePodName, Value: pod.Name},
+		{Name: EnvNameFrameworkAttemptID, Value: frameworkAttemptIDStr},
+		{Name: EnvNameFrameworkAttemptInstanceUID, Value: frameworkAttemptInstanceUIDStr},
+		{Name: EnvNameConfigMapUID, Value: configMapUIDStr},
+		{Name: EnvNamePodUID, ValueFrom: ObjectUIDEnvVarSource},
+		{Name: EnvNameHookName, Value: hookName},
+	}
+
+	// Same as the Task Pod.
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Env = append(append([]core.EnvVar{},
+			predefinedEnvs...), pod.Spec.Containers[i].Env...)
+		if len(pod.Spec.Containers[i].TerminationMessagePolicy) == 0 {
+			pod.Spec.Containers[i].TerminationMessagePolicy = core.TerminationMessageFallbackToLogsOnError
+		}
+	}
+	for i := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[i].Env = append(append([]core.EnvVar{},
+			predefinedEnvs...), pod.Spec.InitContainers[i].Env...)
+		if len(pod.Spec.InitContainers[i].TerminationMessagePolicy) == 0 {
+			pod.Spec.InitContainers[i].TerminationMessagePolicy = core.TerminationMessageFallbackToLogsOnError
+		}
+	}
+
+	return pod, nil
+}
+
+// Returns the Task.Pod in Json with all its matched TaskOverrides applied.
+func (f *Framework) GetTaskPodJson(taskRoleName string, taskIndex int32) (string, error) {
+	taskRoleSpec := f.TaskRoleSpec(taskRoleName)
+	taskPodJson := []byte(common.ToJson(taskRoleSpec.Task.Pod))
+	for i, override := range taskRoleSpec.TaskOverrides {
+		if taskIndex < override.MinTaskIndex || taskIndex > override.MaxTaskIndex {
+			continue
+		}
+
+		var err error
+		taskPodJson, err = strategicpatch.StrategicMergePatch(
+			taskPodJson, override.Pod.Raw, core.PodTemplateSpec{})
+		if err != nil {
+			return "", fmt.Errorf(
+				"Failed to apply TaskOverrides[%v] to Task.Pod: %v", i, err)
+		}
+	}
+	return string(taskPodJson), nil
+}
+
+// GetTaskPodTemplateHash returns the hash of the Task's current Pod template,
+// including its TaskOverrides, which is compared with the Pod's
+// AnnotationKeyPodTemplateHash to detect the outdated TaskAttempt.
+func (f *Framework) GetTaskPodTemplateHash(taskRoleName string, taskIndex int32) (string, error) {
+	taskPodJson, err := f.GetTaskPodJson(taskRoleName, taskIndex)
+	if err != nil {
+		return "", err
+	}
+	return GetPodTemplateHash(taskPodJson), nil
+}
+
+func GetPodTemplateHash(taskPodJson string) string {
+	hash := fnv.New32a()
+	hash.Write([]byte(taskPodJson))
+	return fmt.Sprintf("%08x", hash.Sum32())
+}
+
+func GetPodTemplateHashAnnotation(pod *core.Pod) *string {
+	if podTemplateHash, ok := pod.Annotations[AnnotationKeyPodTemplateHash]; ok {
+		return &podTemplateHash
+	}
+	return nil
+}
+
+// GetPodTemplateUpdateRejectedReason returns the non-empty reason if the Task's
+// Pod template is changed since its current TaskAttempt, but the TaskRole
+// UpdateStrategy is Reject.
+func (f *Framework) GetPodTemplateUpdateRejectedReason(
+	taskRoleName string, taskIndex int32) string {
+	trs := f.GetTaskRoleSpec(taskRoleName)
+	if trs == nil || trs.UpdateStrategy == nil ||
+		trs.UpdateStrategy.Type != UpdateStrategyReject {
+		return ""
+	}
+
+	attemptPodTemplateHash := f.TaskStatus(taskRoleName, taskIndex).
+		AttemptStatus.PodTemplateHash
+	if attemptPodTemplateHash == nil {
+		return ""
+	}
+	podTemplateHash, err := f.GetTaskPodTemplateHash(taskRoleName, taskIndex)
+	if err != nil || podTemplateHash == *attemptPodTemplateHash {
+		return ""
+	}
+	return fmt.Sprintf(
+		"Pod template is changed from %v to %v, but UpdateStrategy is %v",
+		*attemptPodTemplateHash, podTemplateHash, UpdateStrategyReject)
+}
+
+func (trs *TaskRoleSpec) IsRollingUpdate() bool {
+	return trs != nil && trs.UpdateStrategy != nil &&
+		trs.UpdateStrategy.Type == UpdateStrategyRolling
+}
+
+func (trs *TaskRoleSpec) GetUpdateMaxUnavailable() int32 {
+	maxUnavailable := int32(1)
+	if trs.UpdateStrategy != nil && trs.UpdateStrategy.MaxUnavailable != nil {
+		value, err := intstr.GetValueFromIntOrPercent(
+			trs.UpdateStrategy.MaxUnavailable, int(trs.TaskNumber), false)
+		if err == nil && value > 1 {
+			maxUnavailable = int32(value)
+		}
+	}
+	return maxUnavailable
+}
+
+func (f *Framework) IsSSHKeyEnabled() bool {
+	for _, taskRole := range f.Spec.TaskRoles {
+		if taskRole.SSHKey != nil {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Framework) NewSSHSecret(
+	cm *core.ConfigMap, privateKey []byte, authorizedKey []byte) *core.Secret {
+	secret := &core.Secret{
+		ObjectMeta: meta.ObjectMeta{},
+	}
+
+	secret.Name = GetSSHSecretName(f.Name, f.FrameworkAttemptID())
+	secret.Namespace = f.Namespace
+	secret.OwnerReferences = []meta.OwnerReference{*meta.NewControllerRef(cm, ConfigMapGroupVersionKind)}
+
+	secret.Annotations = map[string]string{}
+	secret.Annotations[AnnotationKeyFrameworkNamespace] = f.Namespace
+	secret.Annotations[AnnotationKeyFrameworkName] = f.Name
+	secret.Annotations[AnnotationKeyConfigMapName] = cm.Name
+	secret.Annotations[AnnotationKeyFrameworkAttemptID] = fmt.Sprint(f.FrameworkAttemptID())
+
+	secret.Labels = map[string]string{}
+	secret.Labels[LabelKeyFrameworkName] = f.Name
+
+	secret.Data = map[string][]byte{
+		SecretKeySSHPrivateKey:     privateKey,
+		SecretKeySSHPublicKey:      authorizedKey,
+		SecretKeySSHAuthorizedKeys: authorizedKey,
+		SecretKeySSHConfig:         []byte(SSHConfig),
+	}
+
+	return secret
+}
+
+func escalateContainerMemory(
+	pod *core.Pod, spec *MemoryEscalationSpec, status *MemoryEscalationStatus) {
+	escalate := func(resources core.ResourceList) {
+		quantity, ok := resources[core.ResourceMemory]
+		if !ok {
+			return
+		}
+		escalated := resource.NewQuantity(
+			quantity.Value()/100*status.MemoryPercent, resource.BinarySI)
+		if spec != nil && spec.MaxMemory != nil && escalated.Cmp(*spec.MaxMemory) > 0 {
+			escalated = spec.MaxMemory
+		}
+		if escalated.Cmp(quantity) > 0 {
+			resources[core.ResourceMemory] = *escalated
+		}
+	}
+
+	for i := range pod.Spec.Containers {
+		escalate(pod.Spec.Containers[i].Resources.Requests)
+		escalate(pod.Spec.Containers[i].Resources.Limits)
+	}
+	for i := range pod.Spec.InitContainers {
+		escalate(pod.Spec.InitContainers[i].Resources.Requests)
+		escalate(pod.Spec.InitContainers[i].Resources.Limits)
+	}
+}
+
+// Escalate the memory of the Task's next TaskAttempt if its current TaskAttempt
+// is OOMKilled.
+func (ts *TaskStatus) EscalateMemory(spec *MemoryEscalationSpec) bool {
+	cs := ts.AttemptStatus.CompletionStatus
+	if spec == nil || cs == nil || cs.Pod == nil {
+		return false
+	}
+
+	oomKilled := false
+	for _, container := range cs.Pod.Containers {
+		if container.Reason == ContainerReasonOOMKilled {
+			oomKilled = true
+			break
+		}
+	}
+	if !oomKilled {
+		return false
+	}
+
+	factorPercent := int64(spec.FactorPercent)
+	if factorPercent == 0 {
+		factorPercent = MemoryEscalationDefaultFactorPercent
+	}
+	if ts.MemoryEscalation == nil {
+		ts.MemoryEscalation = &MemoryEscalationStatus{MemoryPercent: 100}
+	}
+	ts.MemoryEscalation.OOMKilledCount++
+	// Avoid overflow, and the MaxMemory should have been reached far before.
+	if ts.MemoryEscalation.MemoryPercent < math.MaxInt32 {
+		ts.MemoryEscalation.MemoryPercent =
+			ts.MemoryEscalation.MemoryPercent * factorPercent / 100
+	}
+	return true
+}
+
+func setNodePlatformSelector(pod *core.Pod, taskRoleSpec *TaskRoleSpec) error {
+	if taskRoleSpec.OS == OSWindows &&
+		taskRoleSpec.Arch != "" && taskRoleSpec.Arch != ArchAMD64 {
+		return fmt.Errorf(
+			"TaskRole %v OS %v does not support Arch %v",
+			taskRoleSpec.Name, taskRoleSpec.OS, taskRoleSpec.Arch)
+	}
+
+	for _, label := range []struct{ key, value string }{
+		{core.LabelOSStable, taskRoleSpec.OS},
+		{core.LabelArchStable, taskRoleSpec.Arch},
+	} {
+		if label.value == "" {
+			continue
+		}
+		if pod.Spec.NodeSelector == nil {
+			pod.Spec.NodeSelector = map[string]string{}
+		}
+		if existing, ok := pod.Spec.NodeSelector[label.key]; ok && existing != label.value {
+			return fmt.Errorf(
+				"TaskRole %v requires NodeSelector %v=%v, but the Pod template "+
+					"specifies %v=%v", taskRoleSpec.Name,
+				label.key, label.value, label.key, existing)
+		}
+		pod.Spec.NodeSelector[label.key] = label.value
+	}
+	return nil
+}
+
+// The HiveDScheduler pod-scheduling-spec, see
+// https://github.com/microsoft/hivedscheduler/blob/master/example/request/design/request.yaml
+type hivedPodSchedulingSpec struct {
+	VirtualCluster string                 `yaml:"virtualCluster"`
+	Priority       int32                  `yaml:"priority"`
+	PinnedCellID   string                 `yaml:"pinnedCellId,omitempty"`
+	LeafCellType   string                 `yaml:"leafCellType,omitempty"`
+	LeafCellNumber int32                  `yaml:"leafCellNumber"`
+	AffinityGroup  hivedAffinityGroupSpec `yaml:"affinityGroup"`
+}
+
+type hivedAffinityGroupSpec struct {
+	Name    string                         `yaml:"name"`
+	Members []hivedAffinityGroupMemberSpec `yaml:"members"`
+}
+
+type hivedAffinityGroupMemberSpec struct {
+	PodNumber      int32 `yaml:"podNumber"`
+	LeafCellNumber int32 `yaml:"leafCellNumber"`
+}
+
+// The affinity group covers all Tasks of the TaskRoles with the HiveD spec.
+func (f *Framework) NewHiveDPodSchedulingSpec(
+	hived *HiveDSpec, frameworkAttemptInstanceUID string) string {
+	members := []hivedAffinityGroupMemberSpec{}
+	for _, taskRole := range f.Spec.TaskRoles {
+		if taskRole.HiveD == nil || taskRole.TaskNumber == 0 {
+			continue
+		}
+		members = append(members, hivedAffinityGroupMemberSpec{
+			PodNumber:      taskRole.TaskNumber,
+			LeafCellNumber: taskRole.HiveD.LeafCellNumber,
+		})
+	}
+
+	return common.ToYaml(hivedPodSchedulingSpec{
+		VirtualCluster: hived.VirtualCluster,
+		Priority:       hived.Priority,
+		PinnedCellID:   hived.PinnedCellID,
+		LeafCellType:   hived.LeafCellType,
+		LeafCellNumber: hived.LeafCellNumber,
+		AffinityGroup: hivedAffinityGroupSpec{
+			Name: fmt.Sprintf("%v/%v/%v",
+				f.Namespace, f.Name, frameworkAttemptInstanceUID),
+			Members: members,
+		},
+	})
+}
+
+// Inject the PodAffinity to the other co-located Pods of the Framework.
+func setPodColocation(
+	pod *core.Pod, frameworkName string, taskRoleName string,
+	colocation *ColocationSpec) {
+	selector := &meta.LabelSelector{
+		MatchLabels: map[string]string{LabelKeyFrameworkName: frameworkName},
+	}
+	if len(colocation.TaskRoles) > 0 {
+		found := false
+		for _, name := range colocation.TaskRoles {
+			if name == taskRoleName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return
+		}
+		selector.MatchExpressions = []meta.LabelSelectorRequirement{{
+			Key:      LabelKeyTaskRoleName,
+			Operator: meta.LabelSelectorOpIn,
+			Values:   colocation.TaskRoles,
+		}}
+	}
+	topologyKey := colocation.TopologyKey
+	if topologyKey == "" {
+		topologyKey = core.LabelHostname
+	}
+	term := core.PodAffinityTerm{
+		LabelSelector: selector,
+		TopologyKey:   topologyKey,
+	}
+
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &core.Affinity{}
+	}
+	if pod.Spec.Affinity.PodAffinity == nil {
+		pod.Spec.Affinity.PodAffinity = &core.PodAffinity{}
+	}
+	podAffinity := pod.Spec.Affinity.PodAffinity
+	if colocation.Required {
+		podAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+			podAffinity.RequiredDuringSchedulingIgnoredDuringExecution, term)
+	} else {
+		podAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			podAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			core.WeightedPodAffinityTerm{
+				Weight:          ColocationPreferredWeight,
+				PodAffinityTerm: term,
+			})
+	}
+}
+
+// Inject the PodAntiAffinity to the other Pods of the TaskRole.
+func setPodAntiAffinity(
+	pod *core.Pod, frameworkName string, taskRoleName string,
+	antiAffinity *AntiAffinitySpec) {
+	topologyKey := antiAffinity.TopologyKey
+	if topologyKey == "" {
+		topologyKey = core.LabelHostname
+	}
+	term := core.PodAffinityTerm{
+		LabelSelector: &meta.LabelSelector{
+			MatchLabels: map[string]string{
+				LabelKeyFrameworkName: frameworkName,
+				LabelKeyTaskRoleName:  taskRoleName,
+			},
+		},
+		TopologyKey: topologyKey,
+	}
+
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &core.Affinity{}
+	}
+	if pod.Spec.Affinity.PodAntiAffinity == nil {
+		pod.Spec.Affinity.PodAntiAffinity = &core.PodAntiAffinity{}
+	}
+	podAntiAffinity := pod.Spec.Affinity.PodAntiAffinity
+	if antiAffinity.Required {
+		podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution = append(
+			podAntiAffinity.RequiredDuringSchedulingIgnoredDuringExecution, term)
+	} else {
+		podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution = append(
+			podAntiAffinity.PreferredDuringSchedulingIgnoredDuringExecution,
+			core.WeightedPodAffinityTerm{
+				Weight:          AntiAffinityPreferredWeight,
+				PodAffinityTerm: term,
+			})
+	}
+}
+
+func wrapSidecarQuitContainer(
+	pod *core.Pod, sidecarQuit *SidecarQuitSpec, completionContainer string) error {
+	containerName := sidecarQuit.Container
+	if containerName == "" {
+		containerName = completionContainer
+	}
+	quitCommand := sidecarQuit.QuitCommand
+	if quitCommand == "" {
+		quitCommand = SidecarQuitDefaultCommand
+	}
+
+	for i := range pod.Spec.Containers {
+		container := &pod.Spec.Containers[i]
+		if containerName != "" && container.Name != containerName {
+			continue
+		}
+		if len(container.Command) == 0 {
+			return fmt.Errorf(
+				"SidecarQuit Container %v does not specify its command", container.Name)
+		}
+
+		container.Command, container.Args = append(append([]string{
+			"/bin/sh", "-c", fmt.Sprintf(SidecarQuitWrapperScript, quitCommand), "--"},
+			container.Command...), container.Args...), nil
+		return nil
+	}
+
+	return fmt.Errorf("SidecarQuit Container %v does not exist", containerName)
+}
+
+func (f *Framework) NewNetworkPolicy(cm *core.ConfigMap) *networking.NetworkPolicy {
+	np := &networking.NetworkPolicy{
+		ObjectMeta: meta.ObjectMeta{},
+	}
+
+	np.Name = GetNetworkPolicyName(f.Name, f.FrameworkAttemptID())
+	np.Namespace = f.Namespace
+	np.OwnerReferences = []meta.OwnerReference{*meta.NewControllerRef(cm, ConfigMapGroupVersionKind)}
+
+	np.Annotations = map[string]string{}
+	np.Annotations[AnnotationKeyFrameworkNamespace] = f.Namespace
+	np.Annotations[AnnotationKeyFrameworkName] = f.Name
+	np.Annotations[AnnotationKeyConfigMapName] = cm.Name
+	np.Annotations[AnnotationKeyFrameworkAttemptID] = fmt.Sprint(f.FrameworkAttemptID())
+
+	np.Labels = map[string]string{}
+	np.Labels[LabelKeyFrameworkName] = f.Name
+
+	frameworkSelector := meta.LabelSelector{
+		MatchLabels: map[string]string{LabelKeyFrameworkName: f.Name},
+	}
+	peers := []networking.NetworkPolicyPeer{{PodSelector: &frameworkSelector}}
+	peers = append(peers, f.Spec.NetworkPolicy.ExtraPeers...)
+
+	np.Spec = networking.NetworkPolicySpec{
+		PodSelector: frameworkSelector,
+		PolicyTypes: []networking.PolicyType{networking.PolicyTypeIngress},
+		Ingress:     []networking.NetworkPolicyIngressRule{{From: peers}},
+	}
+
+	return np
+}
+
+func (f *Framework) IsHostfileEnabled() bool {
+	for _, taskRole := range f.Spec.TaskRoles {
+		if taskRole.Hostfile != nil {
+			return true
+		}
+	}
+	return false
+}
+
+// Returns the hostfile and the peer list of current FrameworkAttempt, or false
+// if any Task to be included has not yet been assigned a PodIP.
+func (f *Framework) NewHostfile() (hostfile string, peerList string, ready bool) {
+	var hostfileBuilder, peerListBuilder strings.Builder
+	for _, taskRole := range f.Spec.TaskRoles {
+		if taskRole.Hostfile == nil {
+			continue
+		}
+		taskNumber := f.GetTaskNumber(taskRole)
+		taskRoleStatus := f.GetTaskRoleStatus(taskRole.Name)
+		if taskRoleStatus == nil ||
+			int32(len(taskRoleStatus.TaskStatuses)) < taskNumber {
+			return "", "", false
+		}
+
+		slots := taskRole.Hostfile.Slots
+		if slots <= 0 {
+			slots = 1
+		}
+
+		taskIPs := []string{}
+		for taskIndex := int32(0); taskIndex < taskNumber; taskIndex++ {
+			taskIP := taskRoleStatus.TaskStatuses[taskIndex].AttemptStatus.PodIP
+			if taskIP == nil || *taskIP == "" {
+				return "", "", false
+			}
+			taskIPs = append(taskIPs, *taskIP)
+			hostfileBuilder.WriteString(fmt.Sprintf("%v slots=%v\n", *taskIP, slots))
+		}
+		peerListBuilder.WriteString(fmt.Sprintf("%v=%v\n",
+			GetTaskRoleEnvName(taskRole.Name, EnvNameSuffixIPs),
+			strings.Join(taskIPs, ",")))
+	}
+	return hostfileBuilder.String(), peerListBuilder.String(), true
+}
+
+func (f *Framework) NewFrameworkAttemptHistory() *FrameworkAttemptHistory {
+	frameworkAttemptIDStr := fmt.Sprint(f.FrameworkAttemptID())
+
+	h := &FrameworkAttemptHistory{
+		ObjectMeta: meta.ObjectMeta{},
+	}
+
+	// Init FrameworkAttemptHistory
+	h.Name = GetFrameworkAttemptHistoryName(f.Name, f.FrameworkAttemptID())
+	h.Namespace = f.Namespace
+	h.OwnerReferences = []meta.OwnerReference{*meta.NewControllerRef(f, FrameworkGroupVersionKind)}
+
+	h.Annotations = map[string]string{}
+	h.Annotations[AnnotationKeyFrameworkNamespace] = f.Namespace
+	h.Annotations[AnnotationKeyFrameworkName] = f.Name
+	h.Annotations[AnnotationKeyFrameworkAttemptID] = frameworkAttemptIDStr
+
+	h.Labels = map[string]string{}
+	h.Labels[LabelKeyFrameworkName] = f.Name
+
+	h.FrameworkName = f.Name
+	h.FrameworkUID = f.UID
+
+	// Deep copy the AttemptStatus, since f may be further modified.
+	as := f.Status.AttemptStatus.DeepCopy()
+	h.AttemptStatus = FrameworkAttemptHistoryStatus{
+		ID:               as.ID,
+		StartTime:        as.StartTime,
+		RunTime:          as.RunTime,
+		CompletionTime:   as.CompletionTime,
+		InstanceUID:      as.InstanceUID,
+		CompletionStatus: as.CompletionStatus,
+		TaskRoleStatuses: []*TaskRoleHistoryStatus{},
+	}
+	for _, trs := range f.TaskRoleStatuses() {
+		trhs := &TaskRoleHistoryStatus{Name: trs.Name, TaskStatuses: []*TaskHistoryStatus{}}
+		for _, ts := range trs.DeepCopy().TaskStatuses {
+			trhs.TaskStatuses = append(trhs.TaskStatuses, &TaskHistoryStatus{
+				Index:             ts.Index,
+				State:             ts.State,
+				RetryPolicyStatus: ts.RetryPolicyStatus,
+				AttemptStatus:     ts.AttemptStatus,
+			})
+		}
+		h.AttemptStatus.TaskRoleStatuses = append(h.AttemptStatus.TaskRoleStatuses, trhs)
+	}
+
+	return h
+}
+
+func (sf *ScheduledFramework) Key() string {
+	return sf.Namespace + "/" + sf.Name
+}
+
+func (sf *ScheduledFramework) NewFramework(scheduledTime time.Time) *Framework {
+	f := &Framework{
+		ObjectMeta: meta.ObjectMeta{},
+		Spec:       *sf.Spec.FrameworkTemplate.Spec.DeepCopy(),
+	}
+
+	// Init Framework
+	f.Name = GetScheduledFrameworkInstanceName(sf.Name, scheduledTime)
+	f.Namespace = sf.Namespace
+	f.OwnerReferences = []meta.OwnerReference{
+		*meta.NewControllerRef(sf, ScheduledFrameworkGroupVersionKind)}
+
+	f.Annotations = map[string]string{}
+	for k, v := range sf.Spec.FrameworkTemplate.Annotations {
+		f.Annotations[k] = v
+	}
+	f.Annotations[AnnotationKeyScheduledTime] = scheduledTime.UTC().Format(time.RFC3339)
+
+	f.Labels = map[string]string{}
+	for k, v := range sf.Spec.FrameworkTemplate.Labels {
+		f.Labels[k] = v
+	}
+	f.Labels[LabelKeyScheduledFrameworkName] = sf.Name
+
+	return f
+}
+
+// The total resource requests of all Tasks in the Framework.
+// For each Pod, the effective resource request is the larger one of the sum of
+// all app containers and any init container.
+func IsHiveDPreemptedPod(pod *core.Pod) bool {
+	if _, ok := pod.Annotations[AnnotationKeyHiveDPodSchedulingSpec]; !ok {
+		return false
+	}
+	cond := GetPodDisruptionCondition(pod)
+	return cond != nil && cond.Reason == PreemptionBySchedulerReason
+}
+
+func IsVolcanoEvictedPod(pod *core.Pod) bool {
+	for _, condition := range pod.Status.Conditions {
+		if condition.Type == core.PodReady &&
+			condition.Status == core.ConditionFalse &&
+			condition.Reason == VolcanoEvictReason {
+			return true
+		}
+	}
+	return false
+}
+
+func (f *Framework) GetResourceRequests() core.ResourceList {
+	requests := core.ResourceList{}
+	for _, taskRole := range f.Spec.TaskRoles {
+		podRequests := GetPodResourceRequests(&taskRole.Task.Pod.Spec)
+		for name, quantity := range podRequests {
+			total := requests[name]
+			total.Add(*resource.NewMilliQuantity(
+				quantity.MilliValue()*int64(taskRole.TaskNumber), quantity.Format))
+			requests[name] = total
+		}
+	}
+	return requests
+}
+
+// The effective resource requests of the Pod, i.e. the larger one of the total
+// requests of its Containers and the max requests of its InitContainers.
+func GetPodResourceRequests(podSpec *core.PodSpec) core.ResourceList {
+	requests := core.ResourceList{}
+	for _, container := range podSpec.Containers {
+		for name, quantity := range container.Resources.Requests {
+			total := requests[name]
+			total.Add(quantity)
+			requests[name] = total
+		}
+	}
+	for _, container := range podSpec.InitContainers {
+		for name, quantity := range container.Resources.Requests {
+			if total, ok := requests[name]; !ok || quantity.Cmp(total) > 0 {
+				requests[name] = quantity.DeepCopy()
+			}
+		}
+	}
+	return requests
+}
+
+func (g *FrameworkGroup) Key() string {
+	return g.Namespace + "/" + g.Name
+}
+
+func (g *FrameworkGroup) NewFramework(member *FrameworkGroupMemberSpec) *Framework {
+	f := &Framework{
+		ObjectMeta: meta.ObjectMeta{},
+		Spec:       *member.FrameworkTemplate.Spec.DeepCopy(),
+	}
+
+	// Init Framework
+	f.Name = GetFrameworkGroupMemberFrameworkName(g.Name, member.Name)
+	f.Namespace = g.Namespace
+	f.OwnerReferences = []meta.OwnerReference{
+		*meta.NewControllerRef(g, FrameworkGroupGroupVersionKind)}
+
+	f.Annotations = map[string]string{}
+	for k, v := range member.FrameworkTemplate.Annotations {
+		f.Annotations[k] = v
+	}
+	f.Annotations[AnnotationKeyFrameworkGroupMemberName] = member.Name
+
+	f.Labels = map[string]string{}
+	for k, v := range member.FrameworkTemplate.Labels {
+		f.Labels[k] = v
+	}
+	f.Labels[LabelKeyFrameworkGroupName] = g.Name
+
+	return f
+}
+
+func (g *FrameworkGroup) NewFrameworkGroupStatus() *FrameworkGroupStatus {
+	s := &FrameworkGroupStatus{
+		StartTime:      common.Now(),
+		State:          FrameworkGroupRunning,
+		MemberStatuses: []FrameworkGroupMemberStatus{},
+	}
+	for _, member := range g.Spec.Members {
+		s.MemberStatuses = append(s.MemberStatuses, FrameworkGroupMemberStatus{
+			Name:  member.Name,
+			State: MemberWaiting,
+		})
+	}
+	return s
+}
+
+func (s *FrameworkGroupStatus) IsCompleted() bool {
+	return s.State == FrameworkGroupSucceeded || s.State == FrameworkGroupFailed
+}
+
+func (s FrameworkGroupMemberState) IsCompleted() bool {
+	return s == MemberSucceeded || s == MemberFailed || s == MemberSkipped
+}
+
+func (c DependencyCondition) IsSatisfiedBy(s FrameworkGroupMemberState) bool {
+	switch c {
+	case DependencyFailed:
+		return s == MemberFailed
+	case DependencyCompleted:
+		return s == MemberSucceeded || s == MemberFailed
+	default:
+		return s == MemberSucceeded
+	}
+}
+
+func (f *Framework) NewFrameworkStatus() *FrameworkStatus {
+	state := FrameworkAttemptCreationPending
+	if f.Spec.Queue != "" {
+		state = FrameworkQueuing
+	}
+
+	return &FrameworkStatus{
+		StartTime:      common.Now(),
+		CompletionTime: nil,
+		State:          state,
+		TransitionTime: common.Now(),
+		Phase:          FrameworkPhasePending,
+		RetryPolicyStatus: RetryPolicyStatus{
+			TotalRetriedCount:       0,
 			AccountableRetriedCount: 0,
 			RetryDelaySec:           nil,
 		},
-		AttemptStatus: f.NewTaskAttemptStatus(taskRoleName, taskIndex, 0),
+		AttemptStatus:       f.NewFrameworkAttemptStatus(0),
+		ObservedSpecSummary: f.NewSpecSummary(),
+		SpecChangeHistory:   nil,
+	}
+}
+
+func (f *Framework) NewSpecSummary() *SpecSummary {
+	summary := &SpecSummary{
+		ExecutionType: f.Spec.ExecutionType,
+		TaskNumbers:   map[string]int32{},
+	}
+	for _, taskRole := range f.Spec.TaskRoles {
+		summary.TaskNumbers[taskRole.Name] = taskRole.TaskNumber
+	}
+	return summary
+}
+
+// Return the human readable summaries of the changes from s to newS, which are
+// sorted to be deterministic.
+func (s *SpecSummary) Diff(newS *SpecSummary) []string {
+	changes := []string{}
+	if s.ExecutionType != newS.ExecutionType {
+		changes = append(changes, fmt.Sprintf(
+			"ExecutionType: %v -> %v", s.ExecutionType, newS.ExecutionType))
+	}
+
+	taskRoleNames := []string{}
+	for taskRoleName := range s.TaskNumbers {
+		taskRoleNames = append(taskRoleNames, taskRoleName)
+	}
+	for taskRoleName := range newS.TaskNumbers {
+		if _, ok := s.TaskNumbers[taskRoleName]; !ok {
+			taskRoleNames = append(taskRoleNames, taskRoleName)
+		}
+	}
+	sort.Strings(taskRoleNames)
+
+	for _, taskRoleName := range taskRoleNames {
+		taskNumber, ok := s.TaskNumbers[taskRoleName]
+		newTaskNumber, newOK := newS.TaskNumbers[taskRoleName]
+		if !ok {
+			changes = append(changes, fmt.Sprintf(
+				"TaskRole[%v]: Added with TaskNumber %v", taskRoleName, newTaskNumber))
+		} else if !newOK {
+			changes = append(changes, fmt.Sprintf(
+				"TaskRole[%v]: Deleted with TaskNumber %v", taskRoleName, taskNumber))
+		} else if taskNumber != newTaskNumber {
+			changes = append(changes, fmt.Sprintf(
+				"TaskRole[%v].TaskNumber: %v -> %v", taskRoleName, taskNumber, newTaskNumber))
+		}
+	}
+
+	return changes
+}
+
+func (f *Framework) NewFrameworkAttemptStatus(
+	frameworkAttemptID int32) FrameworkAttemptStatus {
+	return FrameworkAttemptStatus{
+		ID:                         frameworkAttemptID,
+		StartTime:                  common.Now(),
+		RunTime:                    nil,
+		CompletionTime:             nil,
+		InstanceUID:                nil,
+		ConfigMapName:              GetConfigMapName(f.Name),
+		ConfigMapUID:               nil,
+		PodGroupUID:                nil,
+		KueueWorkloadUID:           nil,
+		SSHSecretUID:               nil,
+		NetworkPolicyUID:           nil,
+		LastAutoscaleTime:          nil,
+		HostfileGenerated:          false,
+		CompletionStatus:           nil,
+		TaskRoleStatuses:           f.NewTaskRoleStatuses(),
+		TaskRoleStatusesCompressed: nil,
+	}
+}
+
+func (f *Framework) NewTaskRoleStatuses() []*TaskRoleStatus {
+	trss := []*TaskRoleStatus{}
+	for _, taskRole := range f.Spec.TaskRoles {
+		trs := TaskRoleStatus{Name: taskRole.Name, TaskStatuses: []*TaskStatus{}}
+		for taskIndex := int32(0); taskIndex < taskRole.TaskNumber; taskIndex++ {
+			trs.TaskStatuses = append(trs.TaskStatuses, f.NewTaskStatus(taskRole.Name, taskIndex))
+		}
+		trss = append(trss, &trs)
+	}
+	return trss
+}
+
+func (f *Framework) NewTaskStatus(taskRoleName string, taskIndex int32) *TaskStatus {
+	return &TaskStatus{
+		Index:           taskIndex,
+		StartTime:       common.Now(),
+		CompletionTime:  nil,
+		State:           TaskAttemptCreationPending,
+		TransitionTime:  common.Now(),
+		DeletionPending: false,
+		RetryPolicyStatus: RetryPolicyStatus{
+			TotalRetriedCount:       0,
+			AccountableRetriedCount: 0,
+			RetryDelaySec:           nil,
+		},
+		AttemptStatus:    f.NewTaskAttemptStatus(taskRoleName, taskIndex, 0),
+		AllocatedPorts:   nil,
+		MemoryEscalation: nil,
 	}
 }
 
 func (f *Framework) NewTaskAttemptStatus(
 	taskRoleName string, taskIndex int32, taskAttemptID int32) TaskAttemptStatus {
 	return TaskAttemptStatus{
-		ID:               taskAttemptID,
-		StartTime:        meta.Now(),
-		RunTime:          nil,
-		CompletionTime:   nil,
-		InstanceUID:      nil,
-		PodName:          GetPodName(f.Name, taskRoleName, taskIndex),
-		PodUID:           nil,
-		PodNodeName:      nil,
-		PodIP:            nil,
-		PodHostIP:        nil,
-		CompletionStatus: nil,
+		ID:                  taskAttemptID,
+		StartTime:           common.Now(),
+		RunTime:             nil,
+		CompletionTime:      nil,
+		InstanceUID:         nil,
+		PodName:             GetPodName(f.Name, taskRoleName, taskIndex),
+		PodUID:              nil,
+		PodNodeName:         nil,
+		PodIP:               nil,
+		PodHostIP:           nil,
+		PodFQDN:             nil,
+		PodTemplateHash:     nil,
+		PodAllocatedDevices: nil,
+		CompletionStatus:    nil,
 	}
 }
 
@@ -670,6 +2198,166 @@ func (rd RetryDecision) String() string {
 }
 
 func (rp RetryPolicySpec) ShouldRetry(
+	rps RetryPolicyStatus,
+	cs *CompletionStatus,
+	minDelaySecForTransientConflictFailed int64,
+	maxDelaySecForTransientConflictFailed int64) RetryDecision {
+	rd := rp.shouldRetry(rps, cs,
+		minDelaySecForTransientConflictFailed,
+		maxDelaySecForTransientConflictFailed)
+
+	// The preempted or suspended Framework is requeued or resumed, and the
+	// restarted or updated Task or Framework is requested by the user, instead
+	// of retried, so they should not be delayed.
+	if rd.ShouldRetry && rp.BackoffPolicy != nil &&
+		cs.Code != CompletionCodeFrameworkPreempted &&
+		cs.Code != CompletionCodeSuspendFrameworkRequested &&
+		cs.Code != CompletionCodeRestartTaskRequested &&
+		cs.Code != CompletionCodeRestartFrameworkRequested &&
+		cs.Code != CompletionCodePodTemplateUpdated {
+		backoffDelaySec := rp.BackoffPolicy.DelaySec(rps.TotalRetriedCount)
+		if backoffDelaySec > rd.DelaySec {
+			rd.DelaySec = backoffDelaySec
+			rd.Reason += fmt.Sprintf(
+				", and delayed by %v BackoffPolicy", rp.BackoffPolicy.Type)
+		}
+	}
+	return rd
+}
+
+func (bp BackoffPolicySpec) DelaySec(totalRetriedCount int32) int64 {
+	delaySec := bp.BaseDelaySec
+	if bp.Type == BackoffExponential {
+		for i := int32(0); i < totalRetriedCount; i++ {
+			if bp.MaxDelaySec > 0 && delaySec >= bp.MaxDelaySec {
+				break
+			}
+			if delaySec > math.MaxInt64/2 {
+				break
+			}
+			delaySec *= 2
+		}
+		if bp.MaxDelaySec > 0 && delaySec > bp.MaxDelaySec {
+			delaySec = bp.MaxDelaySec
+		}
+	}
+
+	if bp.JitterPercent > 0 && delaySec > 0 {
+		delaySec += common.RandInt64(0,
+			int64(float64(delaySec)*float64(bp.JitterPercent)/100))
+	}
+	return delaySec
+}
+
+// Return the first matched Rule, or nil if none is matched.
+func (pfp *PodFailurePolicySpec) Match(pod *core.Pod) *PodFailurePolicyStatus {
+	if pfp == nil {
+		return nil
+	}
+
+	for i, rule := range pfp.Rules {
+		if rule.OnExitCodes == nil && len(rule.OnPodConditions) == 0 {
+			continue
+		}
+		if rule.OnExitCodes != nil && !rule.OnExitCodes.Matches(pod) {
+			continue
+		}
+		if len(rule.OnPodConditions) > 0 && !matchPodConditions(pod, rule.OnPodConditions) {
+			continue
+		}
+		return &PodFailurePolicyStatus{RuleIndex: int32(i), Action: rule.Action}
+	}
+	return nil
+}
+
+func (req *PodFailurePolicyOnExitCodesRequirement) Matches(pod *core.Pod) bool {
+	for _, container := range GetAllContainerStatuses(pod) {
+		if req.ContainerName != "" && container.Name != req.ContainerName {
+			continue
+		}
+		term := container.State.Terminated
+		if term == nil || term.ExitCode == 0 {
+			continue
+		}
+
+		in := false
+		for _, value := range req.Values {
+			if term.ExitCode == value {
+				in = true
+				break
+			}
+		}
+		if in == (req.Operator == PodFailurePolicyOnExitCodesOpIn) {
+			return true
+		}
+	}
+	return false
+}
+
+func matchPodConditions(
+	pod *core.Pod, patterns []PodFailurePolicyOnPodConditionsPattern) bool {
+	for _, pattern := range patterns {
+		status := pattern.Status
+		if status == "" {
+			status = core.ConditionTrue
+		}
+		for _, cond := range pod.Status.Conditions {
+			if cond.Type == pattern.Type && cond.Status == status {
+				return true
+			}
+		}
+	}
+	return false
+}
+
+// The Retry Action still counts into the AccountableRetriedCount, so it stops
+// retrying once the MaxRetryCount of the Task RetryPolicy is reached, the same
+// as the Count Action of the Kubernetes Job PodFailurePolicy respects the
+// backoffLimit.
+func (pfps *PodFailurePolicyStatus) RetryDecision(
+	rp RetryPolicySpec, rps RetryPolicyStatus) RetryDecision {
+	reason := fmt.Sprintf(
+		"PodFailurePolicy Rules[%v] matched with Action %v",
+		pfps.RuleIndex, pfps.Action)
+	switch pfps.Action {
+	case PodFailurePolicyActionIgnore:
+		return RetryDecision{true, false, 0, reason}
+	case PodFailurePolicyActionRetry:
+		if rp.MaxRetryCount == ExtendedUnlimitedValue ||
+			rp.MaxRetryCount == UnlimitedValue ||
+			rps.AccountableRetriedCount < rp.MaxRetryCount {
+			return RetryDecision{true, true, 0, fmt.Sprintf(
+				"%v and AccountableRetriedCount %v has not reached MaxRetryCount %v",
+				reason, rps.AccountableRetriedCount, rp.MaxRetryCount)}
+		} else {
+			return RetryDecision{false, true, 0, fmt.Sprintf(
+				"%v and AccountableRetriedCount %v has reached MaxRetryCount %v",
+				reason, rps.AccountableRetriedCount, rp.MaxRetryCount)}
+		}
+	default:
+		return RetryDecision{false, true, 0, reason}
+	}
+}
+
+func (ros RetryOverrideSpec) Matches(cs *CompletionStatus) bool {
+	if ros.MinCode != nil && cs.Code < *ros.MinCode {
+		return false
+	}
+	if ros.MaxCode != nil && cs.Code > *ros.MaxCode {
+		return false
+	}
+	if ros.TypeName != "" && cs.Type.Name != ros.TypeName {
+		return false
+	}
+	for _, attribute := range ros.TypeAttributes {
+		if !cs.Type.ContainsAttribute(attribute) {
+			return false
+		}
+	}
+	return true
+}
+
+func (rp RetryPolicySpec) shouldRetry(
 	rps RetryPolicyStatus,
 	cs *CompletionStatus,
 	minDelaySecForTransientConflictFailed int64,
@@ -679,12 +2367,49 @@ func (rp RetryPolicySpec) ShouldRetry(
 	// 0. Built-in Always-on RetryPolicy
 	if cs.Code == CompletionCodeStopFrameworkRequested ||
 		cs.Code == CompletionCodeFrameworkAttemptCompletion ||
-		cs.Code == CompletionCodeDeleteTaskRequested {
+		cs.Code == CompletionCodeDeleteTaskRequested ||
+		cs.Code == CompletionCodeStopTaskRequested {
 		return RetryDecision{false, true, 0, fmt.Sprintf(
 			"CompletionCode is %v, %v", cs.Code, cs.Phrase)}
 	}
+	if cs.Code == CompletionCodeSuspendFrameworkRequested ||
+		cs.Code == CompletionCodeRestartTaskRequested ||
+		cs.Code == CompletionCodeRestartFrameworkRequested ||
+		cs.Code == CompletionCodePodTemplateUpdated {
+		// The suspended Framework should always be retried after it is resumed,
+		// and the restarted or updated Task or Framework should always be retried
+		// immediately, and they should not consume the MaxRetryCount.
+		return RetryDecision{true, false, 0, fmt.Sprintf(
+			"CompletionCode is %v, %v", cs.Code, cs.Phrase)}
+	}
+	if cs.Code == CompletionCodeFrameworkPreempted {
+		// The preempted Framework should always be requeued, and the preemption
+		// should not consume its MaxRetryCount.
+		return RetryDecision{true, false, 0, fmt.Sprintf(
+			"CompletionCode is %v, %v", cs.Code, cs.Phrase)}
+	}
+
+	// 1. CompletionOverrides
+	for i, override := range rp.CompletionOverrides {
+		if !override.Matches(cs) {
+			continue
+		}
+
+		if override.MaxRetryCount == UnlimitedValue ||
+			rps.AccountableRetriedCount < override.MaxRetryCount {
+			return RetryDecision{true, true, override.DelaySec, fmt.Sprintf(
+				"CompletionOverrides[%v] matched and AccountableRetriedCount %v "+
+					"has not reached its MaxRetryCount %v",
+				i, rps.AccountableRetriedCount, override.MaxRetryCount)}
+		} else {
+			return RetryDecision{false, true, 0, fmt.Sprintf(
+				"CompletionOverrides[%v] matched and AccountableRetriedCount %v "+
+					"has reached its MaxRetryCount %v",
+				i, rps.AccountableRetriedCount, override.MaxRetryCount)}
+		}
+	}
 
-	// 1. FancyRetryPolicy
+	// 2. FancyRetryPolicy
 	if rp.FancyRetryPolicy {
 		reason := fmt.Sprintf(
 			"FancyRetryPolicy is %v and CompletionType is %v",
@@ -706,7 +2431,7 @@ func (rp RetryPolicySpec) ShouldRetry(
 		}
 	}
 
-	// 2. NormalRetryPolicy
+	// 3. NormalRetryPolicy
 	if (rp.MaxRetryCount == ExtendedUnlimitedValue) ||
 		(ct.IsFailed() && rp.MaxRetryCount == UnlimitedValue) ||
 		(ct.IsFailed() && rps.AccountableRetriedCount < rp.MaxRetryCount) {
@@ -725,6 +2450,16 @@ func (rp RetryPolicySpec) ShouldRetry(
 	}
 }
 
+func (f *Framework) GetFrameworkCondition(
+	conditionType FrameworkConditionType) *FrameworkCondition {
+	for i := range f.Status.Conditions {
+		if f.Status.Conditions[i].Type == conditionType {
+			return &f.Status.Conditions[i]
+		}
+	}
+	return nil
+}
+
 ///////////////////////////////////////////////////////////////////////////////////////
 // Status Write Methods
 ///////////////////////////////////////////////////////////////////////////////////////
@@ -748,12 +2483,37 @@ func (f *Framework) TransitionFrameworkState(dstState FrameworkState) {
 
 	f.Status.State = dstState
 	f.Status.TransitionTime = *now
+	f.Status.Phase = f.GetPhase()
 
 	klog.Infof(
 		"[%v]: Transitioned Framework from [%v] to [%v]",
 		f.Key(), srcState, dstState)
 }
 
+// This is the only interface to modify FrameworkCondition, and the
+// LastTransitionTime is only changed if the Status is changed.
+func (f *Framework) SetFrameworkCondition(
+	conditionType FrameworkConditionType, status core.ConditionStatus,
+	reason string, message string) {
+	condition := f.GetFrameworkCondition(conditionType)
+	if condition == nil {
+		f.Status.Conditions = append(f.Status.Conditions, FrameworkCondition{
+			Type: conditionType,
+		})
+		condition = &f.Status.Conditions[len(f.Status.Conditions)-1]
+	}
+
+	if condition.Status != status {
+		condition.LastTransitionTime = common.Now()
+		klog.Infof(
+			"[%v]: Transitioned FrameworkCondition %v from [%v] to [%v]: %v",
+			f.Key(), conditionType, condition.Status, status, reason)
+	}
+	condition.Status = status
+	condition.Reason = reason
+	condition.Message = message
+}
+
 // This is the only interface to modify TaskState
 func (f *Framework) TransitionTaskState(
 	taskRoleName string, taskIndex int32, dstState TaskState) {
@@ -848,3 +2608,16 @@ func (ts *TaskStatus) MarkAsDeletionPending() (isNewDeletionPendingTask bool) {
 	}
 	return true
 }
+
+func (ts *TaskRoleScale) Key() string {
+	return ts.Namespace + "/" + ts.Name
+}
+
+// GetTaskRolePodSelector returns the label selector of the TaskRole's Pods in
+// the serialized form.
+func GetTaskRolePodSelector(frameworkName string, taskRoleName string) string {
+	return labels.SelectorFromSet(labels.Set{
+		LabelKeyFrameworkName: frameworkName,
+		LabelKeyTaskRoleName:  taskRoleName,
+	}).String()
+}
@@ -0,0 +1,254 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package v1
+
+import (
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func TestBackoffPolicyDelaySec(t *testing.T) {
+	cases := []struct {
+		name              string
+		bp                BackoffPolicySpec
+		totalRetriedCount int32
+		want              int64
+	}{
+		{"Fixed",
+			BackoffPolicySpec{Type: BackoffFixed, BaseDelaySec: 10}, 5, 10},
+		{"DefaultIsFixed",
+			BackoffPolicySpec{BaseDelaySec: 10}, 5, 10},
+		{"ExponentialFirst",
+			BackoffPolicySpec{Type: BackoffExponential, BaseDelaySec: 10}, 0, 10},
+		{"Exponential",
+			BackoffPolicySpec{Type: BackoffExponential, BaseDelaySec: 10}, 3, 80},
+		{"ExponentialCapped",
+			BackoffPolicySpec{Type: BackoffExponential, BaseDelaySec: 10,
+				MaxDelaySec: 50}, 3, 50},
+		{"ExponentialUnlimitedNotOverflow",
+			BackoffPolicySpec{Type: BackoffExponential, BaseDelaySec: 1}, 1000,
+			1 << 62},
+		{"ZeroBase",
+			BackoffPolicySpec{Type: BackoffExponential, JitterPercent: 50}, 3, 0},
+	}
+
+	for _, c := range cases {
+		if got := c.bp.DelaySec(c.totalRetriedCount); got != c.want {
+			t.Errorf("%v: got %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestBackoffPolicyDelaySecJitter(t *testing.T) {
+	bp := BackoffPolicySpec{
+		Type: BackoffExponential, BaseDelaySec: 100, JitterPercent: 10}
+	for i := 0; i < 100; i++ {
+		if got := bp.DelaySec(1); got < 200 || got > 220 {
+			t.Fatalf("got %v, want within [200, 220]", got)
+		}
+	}
+}
+
+func newTerminatedPod(
+	conditions []core.PodCondition, exitCodes map[string]int32) *core.Pod {
+	pod := &core.Pod{Status: core.PodStatus{
+		Phase: core.PodFailed, Conditions: conditions}}
+	for name, exitCode := range exitCodes {
+		pod.Status.ContainerStatuses = append(pod.Status.ContainerStatuses,
+			core.ContainerStatus{
+				Name: name,
+				State: core.ContainerState{Terminated: &core.ContainerStateTerminated{
+					ExitCode: exitCode,
+				}},
+			})
+	}
+	return pod
+}
+
+func TestPodFailurePolicyMatch(t *testing.T) {
+	pfp := &PodFailurePolicySpec{Rules: []PodFailurePolicyRule{
+		// Never matches without any requirement.
+		{Action: PodFailurePolicyActionFailFramework},
+		{Action: PodFailurePolicyActionIgnore,
+			OnPodConditions: []PodFailurePolicyOnPodConditionsPattern{
+				{Type: PodConditionTypeDisruptionTarget}}},
+		{Action: PodFailurePolicyActionFailTask,
+			OnExitCodes: &PodFailurePolicyOnExitCodesRequirement{
+				ContainerName: "main",
+				Operator:      PodFailurePolicyOnExitCodesOpIn,
+				Values:        []int32{42}}},
+		{Action: PodFailurePolicyActionRetry,
+			OnExitCodes: &PodFailurePolicyOnExitCodesRequirement{
+				Operator: PodFailurePolicyOnExitCodesOpNotIn,
+				Values:   []int32{1}}},
+	}}
+	disrupted := []core.PodCondition{
+		{Type: PodConditionTypeDisruptionTarget, Status: core.ConditionTrue}}
+	notDisrupted := []core.PodCondition{
+		{Type: PodConditionTypeDisruptionTarget, Status: core.ConditionFalse}}
+
+	cases := []struct {
+		name string
+		pfp  *PodFailurePolicySpec
+		pod  *core.Pod
+		// -1 means no Rule is matched.
+		wantRuleIndex int32
+	}{
+		{"NilPolicy", nil,
+			newTerminatedPod(disrupted, nil), -1},
+		{"PodCondition", pfp,
+			newTerminatedPod(disrupted, map[string]int32{"main": 42}), 1},
+		{"PodConditionStatusMismatch", pfp,
+			newTerminatedPod(notDisrupted, map[string]int32{"main": 1}), -1},
+		{"ExitCodeInContainer", pfp,
+			newTerminatedPod(nil, map[string]int32{"main": 42}), 2},
+		{"ExitCodeInOtherContainer", pfp,
+			newTerminatedPod(nil, map[string]int32{"sidecar": 42}), 3},
+		{"ExitCodeNotIn", pfp,
+			newTerminatedPod(nil, map[string]int32{"main": 2}), 3},
+		{"ExitCodeNotInExcluded", pfp,
+			newTerminatedPod(nil, map[string]int32{"main": 1}), -1},
+		{"SucceededContainerIgnored", pfp,
+			newTerminatedPod(nil, map[string]int32{"main": 0, "sidecar": 1}), -1},
+	}
+
+	for _, c := range cases {
+		got := c.pfp.Match(c.pod)
+		gotRuleIndex := int32(-1)
+		if got != nil {
+			gotRuleIndex = got.RuleIndex
+			if got.Action != c.pfp.Rules[got.RuleIndex].Action {
+				t.Errorf("%v: got Action %v, want %v",
+					c.name, got.Action, c.pfp.Rules[got.RuleIndex].Action)
+			}
+		}
+		if gotRuleIndex != c.wantRuleIndex {
+			t.Errorf("%v: got RuleIndex %v, want %v",
+				c.name, gotRuleIndex, c.wantRuleIndex)
+		}
+	}
+}
+
+func TestPodFailurePolicyRetryDecision(t *testing.T) {
+	cases := []struct {
+		name              string
+		action            PodFailurePolicyAction
+		maxRetryCount     int32
+		accountableCount  int32
+		wantShouldRetry   bool
+		wantIsAccountable bool
+	}{
+		{"Ignore", PodFailurePolicyActionIgnore, 0, 0, true, false},
+		{"RetryNotReached", PodFailurePolicyActionRetry, 3, 2, true, true},
+		{"RetryReached", PodFailurePolicyActionRetry, 3, 3, false, true},
+		{"RetryUnlimited", PodFailurePolicyActionRetry, UnlimitedValue, 100, true, true},
+		{"RetryExtendedUnlimited", PodFailurePolicyActionRetry,
+			ExtendedUnlimitedValue, 100, true, true},
+		{"FailTask", PodFailurePolicyActionFailTask, UnlimitedValue, 0, false, true},
+		{"FailFramework", PodFailurePolicyActionFailFramework,
+			UnlimitedValue, 0, false, true},
+	}
+
+	for _, c := range cases {
+		pfps := &PodFailurePolicyStatus{Action: c.action}
+		rd := pfps.RetryDecision(
+			RetryPolicySpec{MaxRetryCount: c.maxRetryCount},
+			RetryPolicyStatus{AccountableRetriedCount: c.accountableCount})
+		if rd.ShouldRetry != c.wantShouldRetry ||
+			rd.IsAccountable != c.wantIsAccountable {
+			t.Errorf("%v: got %v, want ShouldRetry %v and IsAccountable %v",
+				c.name, rd, c.wantShouldRetry, c.wantIsAccountable)
+		}
+	}
+}
+
+func newCompletedTaskStatus(
+	index int32, typeName CompletionTypeName, completionTime time.Time) *TaskStatus {
+	mt := meta.NewTime(completionTime)
+	return &TaskStatus{
+		Index:          index,
+		State:          TaskCompleted,
+		CompletionTime: &mt,
+		AttemptStatus: TaskAttemptStatus{
+			CompletionStatus: &TaskAttemptCompletionStatus{
+				CompletionStatus: &CompletionStatus{
+					Type: CompletionType{Name: typeName},
+				},
+			},
+		},
+	}
+}
+
+func TestFrameworkUpdateProgress(t *testing.T) {
+	now := time.Now()
+	f := &Framework{Status: &FrameworkStatus{AttemptStatus: FrameworkAttemptStatus{
+		TaskRoleStatuses: []*TaskRoleStatus{
+			{Name: "a", TaskStatuses: []*TaskStatus{
+				{Index: 0, State: TaskAttemptCreationPending},
+				{Index: 1, State: TaskAttemptRunning},
+				newCompletedTaskStatus(2, CompletionTypeNameSucceeded, now),
+				newCompletedTaskStatus(3, CompletionTypeNameFailed, now.Add(-time.Minute)),
+				newCompletedTaskStatus(4, CompletionTypeNameFailed, now),
+				{Index: 5, State: TaskAttemptRunning, DeletionPending: true},
+			}},
+			{Name: "b", TaskStatuses: []*TaskStatus{}},
+		},
+	}}}
+
+	f.UpdateProgress()
+	p := f.Status.Progress
+	if p == nil {
+		t.Fatalf("Progress is nil")
+	}
+	// 3 of 5 not DeletionPending Tasks are completed.
+	if p.CompletionPercentage != 60 {
+		t.Errorf("got CompletionPercentage %v, want 60", p.CompletionPercentage)
+	}
+	if len(p.TaskRoles) != 2 {
+		t.Fatalf("got %v TaskRoles, want 2", len(p.TaskRoles))
+	}
+
+	a := p.TaskRoles[0]
+	if a.Name != "a" || a.TaskCount != 5 || a.PendingTaskCount != 1 ||
+		a.RunningTaskCount != 1 || a.SucceededTaskCount != 1 ||
+		a.FailedTaskCount != 2 {
+		t.Errorf("got TaskRole progress %+v", *a)
+	}
+	// The latest failed Task is sampled first.
+	if len(a.FailedTaskSamples) != 2 ||
+		a.FailedTaskSamples[0].Index != 4 || a.FailedTaskSamples[1].Index != 3 {
+		t.Errorf("got FailedTaskSamples %+v", a.FailedTaskSamples)
+	}
+	if b := p.TaskRoles[1]; b.Name != "b" || b.TaskCount != 0 {
+		t.Errorf("got TaskRole progress %+v", *b)
+	}
+
+	// Nothing to do without Status.
+	f = &Framework{}
+	f.UpdateProgress()
+	if f.Status != nil {
+		t.Errorf("got Status %+v, want nil", f.Status)
+	}
+}
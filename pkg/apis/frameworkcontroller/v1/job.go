@@ -0,0 +1,177 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package v1
+
+import (
+	"fmt"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	batch "k8s.io/api/batch/v1"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"math"
+)
+
+// Conversion between the single TaskRole Framework and the batch/v1 Job, to ease
+// the migration between them.
+//
+// The Framework TaskIndex semantics, i.e. each TaskIndex in [0, TaskNumber) is
+// completed once, is the same as the Indexed Job, and its TaskIndex is also
+// exposed as the Indexed Job completion index, i.e. the
+// AnnotationKeyJobCompletionIndex and EnvNameJobCompletionIndex.
+// However, the Job CompletionMode is not available in the supported k8s API
+// version, so the converted Job is always NonIndexed, and the caller needs to
+// set it by itself if it is expected.
+
+const (
+	// The TaskRoleName of the Framework converted from a Job.
+	JobTaskRoleName = "main"
+)
+
+// NewFrameworkFromJob converts the Job to a Framework with a single TaskRole
+// JobTaskRoleName:
+// 1. TaskNumber is the Job Completions, or the Job Parallelism for the work
+//    queue Job, i.e. the Job Completions is nil, and it succeeds once any Task
+//    succeeded.
+//    Note, all Tasks are run in parallel, even if the Job Parallelism is less
+//    than the Job Completions.
+// 2. The Job BackoffLimit is the RetryBudget MaxTaskRetryCount, as well as the
+//    Task RetryPolicy MaxRetryCount.
+// 3. The Job TTLSecondsAfterFinished is the SucceededRetainSec and
+//    FailedRetainSec.
+// The Job ActiveDeadlineSeconds and ManualSelector are not supported and ignored.
+func NewFrameworkFromJob(job *batch.Job) *Framework {
+	parallelism := int32(1)
+	if job.Spec.Parallelism != nil {
+		parallelism = *job.Spec.Parallelism
+	}
+	backoffLimit := int32(6)
+	if job.Spec.BackoffLimit != nil {
+		backoffLimit = *job.Spec.BackoffLimit
+	}
+
+	taskNumber := parallelism
+	minSucceededTaskCount := int32(1)
+	if job.Spec.Completions != nil {
+		taskNumber = *job.Spec.Completions
+		minSucceededTaskCount = UnlimitedValue
+	}
+
+	pod := *job.Spec.Template.DeepCopy()
+	// The Job Pod labels are also generated by the Job controller.
+	delete(pod.Labels, "controller-uid")
+	delete(pod.Labels, "job-name")
+
+	f := &Framework{
+		ObjectMeta: meta.ObjectMeta{
+			Name:        job.Name,
+			Namespace:   job.Namespace,
+			Labels:      job.Labels,
+			Annotations: job.Annotations,
+		},
+		Spec: FrameworkSpec{
+			ExecutionType: ExecutionStart,
+			RetryPolicy:   RetryPolicySpec{MaxRetryCount: 0},
+			RetryBudget: &RetryBudgetSpec{
+				MaxTaskRetryCount: common.PtrInt32(backoffLimit),
+			},
+			TaskRoles: []*TaskRoleSpec{{
+				Name:       JobTaskRoleName,
+				TaskNumber: taskNumber,
+				FrameworkAttemptCompletionPolicy: CompletionPolicySpec{
+					MinFailedTaskCount:    1,
+					MinSucceededTaskCount: minSucceededTaskCount,
+				},
+				Task: TaskSpec{
+					RetryPolicy: RetryPolicySpec{MaxRetryCount: backoffLimit},
+					Pod:         pod,
+				},
+			}},
+		},
+	}
+	if job.Spec.TTLSecondsAfterFinished != nil {
+		retainSec := int64(*job.Spec.TTLSecondsAfterFinished)
+		f.Spec.SucceededRetainSec = common.PtrInt64(retainSec)
+		f.Spec.FailedRetainSec = common.PtrInt64(retainSec)
+	}
+	return f
+}
+
+// ToJob converts the single TaskRole Framework to a Job:
+// 1. Parallelism is the TaskNumber, and Completions is the TaskRole
+//    MinSucceededTaskCount, or the TaskNumber if it is UnlimitedValue.
+// 2. BackoffLimit is the RetryBudget MaxTaskRetryCount, or the Task RetryPolicy
+//    MaxRetryCount, and the unlimited retry is converted to math.MaxInt32.
+// 3. TTLSecondsAfterFinished is the SucceededRetainSec if it is the same as the
+//    FailedRetainSec.
+// 4. The Pod RestartPolicy is converted to Never if it is Always, which is not
+//    allowed by the Job.
+// The Framework RetryPolicy and other Framework specific features are ignored.
+func (f *Framework) ToJob() (*batch.Job, error) {
+	if len(f.Spec.TaskRoles) != 1 {
+		return nil, fmt.Errorf(
+			"Failed to convert Framework %v to Job: Only the Framework with a "+
+				"single TaskRole can be converted, but it has %v TaskRoles",
+			f.Key(), len(f.Spec.TaskRoles))
+	}
+	taskRole := f.Spec.TaskRoles[0]
+
+	completions := taskRole.TaskNumber
+	minSucceeded := taskRole.FrameworkAttemptCompletionPolicy.MinSucceededTaskCount
+	if minSucceeded > 0 && minSucceeded < completions {
+		completions = minSucceeded
+	}
+
+	backoffLimit := taskRole.Task.RetryPolicy.MaxRetryCount
+	if f.Spec.RetryBudget != nil && f.Spec.RetryBudget.MaxTaskRetryCount != nil {
+		backoffLimit = *f.Spec.RetryBudget.MaxTaskRetryCount
+	}
+	if backoffLimit < 0 {
+		backoffLimit = math.MaxInt32
+	}
+
+	pod := *taskRole.Task.Pod.DeepCopy()
+	if pod.Spec.RestartPolicy == "" || pod.Spec.RestartPolicy == core.RestartPolicyAlways {
+		pod.Spec.RestartPolicy = core.RestartPolicyNever
+	}
+
+	job := &batch.Job{
+		ObjectMeta: meta.ObjectMeta{
+			Name:        f.Name,
+			Namespace:   f.Namespace,
+			Labels:      f.Labels,
+			Annotations: f.Annotations,
+		},
+		Spec: batch.JobSpec{
+			Parallelism:  common.PtrInt32(taskRole.TaskNumber),
+			Completions:  common.PtrInt32(completions),
+			BackoffLimit: common.PtrInt32(backoffLimit),
+			Template:     pod,
+		},
+	}
+	if f.Spec.SucceededRetainSec != nil && f.Spec.FailedRetainSec != nil &&
+		*f.Spec.SucceededRetainSec == *f.Spec.FailedRetainSec {
+		job.Spec.TTLSecondsAfterFinished = common.PtrInt32(
+			int32(*f.Spec.SucceededRetainSec))
+	}
+	return job, nil
+}
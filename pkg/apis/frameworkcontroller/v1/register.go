@@ -47,6 +47,18 @@ func addKnownTypes(scheme *runtime.Scheme) error {
 		SchemeGroupVersion,
 		&Framework{},
 		&FrameworkList{},
+		&FrameworkAttemptHistory{},
+		&FrameworkAttemptHistoryList{},
+		&ScheduledFramework{},
+		&ScheduledFrameworkList{},
+		&FrameworkGroup{},
+		&FrameworkGroupList{},
+		&Queue{},
+		&QueueList{},
+		&TaskRoleScale{},
+		&TaskRoleScaleList{},
+		&FrameworkQuota{},
+		&FrameworkQuotaList{},
 	)
 
 	// register the type in the scheme
@@ -24,8 +24,12 @@ package v1
 
 import (
 	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
 )
 
 // +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
@@ -71,12 +75,172 @@ type Framework struct {
 //////////////////////////////////////////////////////////////////////////////////////////////////
 type FrameworkSpec struct {
 	Description string `json:"description"`
-	// Only support to update from ExecutionStart to ExecutionStop
+	// Only support to update from ExecutionStart to ExecutionStop or
+	// ExecutionSuspend, and from ExecutionSuspend to ExecutionStart or
+	// ExecutionStop
 	ExecutionType ExecutionType   `json:"executionType"`
 	RetryPolicy   RetryPolicySpec `json:"retryPolicy"`
 	TaskRoles     []*TaskRoleSpec `json:"taskRoles"`
+
+	// The name of the Queue to admit the Framework before its first
+	// FrameworkAttempt is created.
+	// Default to empty, i.e. the Framework is admitted immediately.
+	// See Queue.
+	Queue string `json:"queue"`
+	// The higher QueuePriority Framework is admitted earlier if the Queue
+	// OrderPolicy is QueueOrderPriority.
+	// Default to 0.
+	QueuePriority int32 `json:"queuePriority"`
+
+	// If it is not nil, a NetworkPolicy is created for each FrameworkAttempt
+	// before its Pods are created, which only allows the ingress traffic to the
+	// Framework's Pods from the Framework's Pods and the ExtraPeers, so that the
+	// Frameworks in a multi-tenant cluster are isolated from each other.
+	// NetworkPolicyName = {FrameworkName}-attempt-{FrameworkAttemptID}
+	NetworkPolicy *NetworkPolicySpec `json:"networkPolicy"`
+
+	// If it is not nil, the specified hooks are run around each FrameworkAttempt,
+	// such as to stage the dataset before the Pods are created, and to publish
+	// the results after the FrameworkAttempt is completed, without wrapping
+	// every container image.
+	// See FrameworkHooksSpec.
+	Hooks *FrameworkHooksSpec `json:"hooks"`
+
+	// If it is not nil, the PodAffinity is injected into the Pods of the
+	// Framework, so that they are packed into the same topology domain, such as
+	// the same node or zone, to reduce the cross-domain network traffic of the
+	// allreduce-heavy training.
+	// See ColocationSpec.
+	Colocation *ColocationSpec `json:"colocation"`
+
+	// If it is not nil, the Task retries across all TaskRoles in each
+	// FrameworkAttempt are limited, and once a Task retry would exceed the
+	// budget, the FrameworkAttempt is completed with
+	// CompletionCodeFrameworkRetryBudgetExceeded instead, so that the
+	// crash-looping Tasks cannot consume the cluster resources indefinitely.
+	RetryBudget *RetryBudgetSpec `json:"retryBudget"`
+
+	// If any Pod of the FrameworkAttempt has been unschedulable longer than this
+	// timeout, such as the cluster does not have enough resource for it, the
+	// FrameworkAttempt will be completed with CompletionCodeFrameworkSchedulingTimeout,
+	// which is Transient Conflict Failed, so that it can be re-attempted later by
+	// the Framework RetryPolicy, instead of blocking forever.
+	// Default to nil, i.e. no timeout.
+	AttemptSchedulingTimeoutSec *int64 `json:"attemptSchedulingTimeoutSec"`
+
+	// Override the Config FrameworkSucceededRetainSec and FrameworkFailedRetainSec
+	// for this Framework respectively.
+	// Default to nil, i.e. not override.
+	SucceededRetainSec *int64 `json:"succeededRetainSec"`
+	FailedRetainSec    *int64 `json:"failedRetainSec"`
+
+	// Override the Config FrameworkDeletionPropagationPolicy and
+	// ConfigMapDeletionPropagationPolicy for this Framework respectively.
+	// Default to nil, i.e. not override.
+	FrameworkDeletionPropagationPolicy *meta.DeletionPropagation `json:"frameworkDeletionPropagationPolicy"`
+	ConfigMapDeletionPropagationPolicy *meta.DeletionPropagation `json:"configMapDeletionPropagationPolicy"`
+
+	// Request to restart the FrameworkAttempt with this FrameworkAttemptID, such
+	// as after the external dependencies are fixed, i.e. its ConfigMap will be
+	// gracefully deleted and completed with CompletionCodeRestartFrameworkRequested,
+	// and then immediately retried without consuming the Framework RetryPolicy.
+	// It is idempotent and will not impact the later FrameworkAttempts.
+	// Default to nil, i.e. no FrameworkAttempt is requested to restart.
+	RestartAttemptID *int32 `json:"restartAttemptID"`
+
+	// If it is not nil, the Pod of the failed TaskAttempt and the ConfigMap of the
+	// failed FrameworkAttempt are retained for this duration after the attempt is
+	// failed, instead of being deleted immediately, so that the failed Pod can
+	// still be inspected, such as by kubectl exec and describe.
+	// The corresponding retry is also delayed until they are deleted, and it is
+	// ignored once the Framework is stopped or being deleted.
+	// Default to nil, i.e. not retain.
+	DebugRetainFailedPodSec *int64 `json:"debugRetainFailedPodSec"`
+}
+
+type RetryBudgetSpec struct {
+	// The max total TotalRetriedCount of all Tasks.
+	// Default to nil, i.e. unlimited.
+	MaxTaskRetryCount *int32 `json:"maxTaskRetryCount"`
+	// The max percentage of the Tasks which have been retried at least once.
+	// Default to nil, i.e. unlimited.
+	MaxRetriedTaskPercent *int32 `json:"maxRetriedTaskPercent"`
+}
+
+type NetworkPolicySpec struct {
+	// The extra peers allowed to access the Framework's Pods, such as the
+	// monitoring or serving gateway Pods.
+	ExtraPeers []networking.NetworkPolicyPeer `json:"extraPeers"`
+}
+
+type ColocationSpec struct {
+	// The TaskRoles whose Pods are co-located with each other.
+	// Default to empty, i.e. all TaskRoles.
+	TaskRoles []string `json:"taskRoles"`
+	// The node label key of the topology domain to co-locate in, such as
+	// topology.kubernetes.io/zone.
+	// Default to kubernetes.io/hostname, i.e. co-locate on the same node.
+	TopologyKey string `json:"topologyKey"`
+	// If true, the co-location is required, i.e. a Pod can only be scheduled
+	// into the topology domain where other co-located Pods are running, unless
+	// there is no such Pod yet, so the Pods may be pending if the domain is full.
+	// Otherwise, it is only preferred with the ColocationPreferredWeight.
+	// Default to false.
+	Required bool `json:"required"`
+}
+
+type FrameworkHooksSpec struct {
+	// Run after the ConfigMap of the FrameworkAttempt is created, and before any
+	// Pod of the FrameworkAttempt is created, i.e. the Pods are only created
+	// after it is completed.
+	// If it failed with HookFailurePolicyFail, the FrameworkAttempt is completed
+	// with CompletionCodeFrameworkPreAttemptHookFailed.
+	PreAttempt *HookSpec `json:"preAttempt"`
+	// Run after the FrameworkAttempt is completed, and before its ConfigMap is
+	// deleted, so the Pods of the not yet completed Tasks may be still running.
+	// If it failed with HookFailurePolicyFail, the Succeeded FrameworkAttempt is
+	// overridden to be completed with CompletionCodeFrameworkPostAttemptHookFailed.
+	// It is skipped if the Framework is being deleted.
+	PostAttempt *HookSpec `json:"postAttempt"`
+}
+
+// Exactly one of the Pod and Webhook should be specified.
+type HookSpec struct {
+	// The hook Pod is named {FrameworkName}-attempt-{FrameworkAttemptID}-{HookName}
+	// and controlled by the ConfigMap of the FrameworkAttempt, and the hook is
+	// succeeded if and only if the Pod is Succeeded.
+	// Its RestartPolicy is default to Never, and the Framework level
+	// Placeholders and Predefined Environment Variables are also available in it.
+	Pod *core.PodTemplateSpec `json:"pod"`
+	// The hook is succeeded if and only if the Webhook responds 2XX for the
+	// POSTed HookRequest.
+	Webhook *HookWebhookSpec `json:"webhook"`
+
+	// If the hook Pod is still not completed after this timeout, it is failed
+	// and deleted.
+	// It is also the request timeout of the Webhook, capped to
+	// HookWebhookMaxTimeoutSec, since the Webhook is called synchronously on the
+	// sync worker.
+	// Default to nil, i.e. no timeout for the Pod and HookWebhookDefaultTimeoutSec
+	// for the Webhook.
+	TimeoutSec *int64 `json:"timeoutSec"`
+	// Default to HookFailurePolicyFail.
+	FailurePolicy HookFailurePolicy `json:"failurePolicy"`
 }
 
+type HookWebhookSpec struct {
+	URL string `json:"url"`
+}
+
+type HookFailurePolicy string
+
+const (
+	// The hook failure fails the FrameworkAttempt, see FrameworkHooksSpec.
+	HookFailurePolicyFail HookFailurePolicy = "Fail"
+	// The hook failure is only recorded in its HookStatus.
+	HookFailurePolicyIgnore HookFailurePolicy = "Ignore"
+)
+
 type TaskRoleSpec struct {
 	// TaskRoleName
 	Name string `json:"name"`
@@ -85,6 +249,423 @@ type TaskRoleSpec struct {
 	TaskNumber                       int32                `json:"taskNumber"`
 	FrameworkAttemptCompletionPolicy CompletionPolicySpec `json:"frameworkAttemptCompletionPolicy"`
 	Task                             TaskSpec             `json:"task"`
+
+	// The minimum number of the TaskRole's available Tasks, i.e. the Running or
+	// Succeeded Tasks, for the gang of the FrameworkAttempt:
+	// 1. The FrameworkAttempt is only transitioned to FrameworkAttemptRunning once
+	//    at least MinTaskNumber Tasks are available.
+	// 2. Once the FrameworkAttempt is FrameworkAttemptRunning, if the available
+	//    Tasks drop below MinTaskNumber, the FrameworkAttempt is completed with
+	//    CompletionCodeMinTaskNumberUnsatisfied, which is Transient
+	//    Failed.
+	// So the Task failures above it can be tolerated, if the
+	// FrameworkAttemptCompletionPolicy MinFailedTaskCount is also relaxed, such
+	// as to TaskNumber - MinTaskNumber + 1.
+	// It is capped by the TaskNumber.
+	// Default to 0, i.e. no requirement.
+	MinTaskNumber int32 `json:"minTaskNumber"`
+
+	// The names of the prerequisite TaskRoles, i.e. the TaskAttempts of the
+	// TaskRole are only created after all Tasks of the prerequisite TaskRoles
+	// are Running or Succeeded in current FrameworkAttempt, such as to start the
+	// workers after the parameter servers.
+	// The dependencies should not be cyclic, otherwise the Tasks will never be
+	// created, and the not existing TaskRoles are ignored.
+	// Default to empty, i.e. no dependency.
+	DependsOn []string `json:"dependsOn"`
+
+	// The node OS and architecture required by the TaskRole's Pods, such as
+	// linux/amd64 and windows/amd64, which are injected into the Pods as the
+	// kubernetes.io/os and kubernetes.io/arch NodeSelector.
+	// The Pod which cannot be scheduled since no node matches its NodeSelector is
+	// completed with CompletionCodePodNodeUnmatched after the Config
+	// PodNodeUnmatchedTimeoutSec.
+	// Default to empty, i.e. no requirement.
+	OS   string `json:"os"`
+	Arch string `json:"arch"`
+
+	// The schedulerName applied to the TaskRole's Pods whose Pod template does
+	// not specify one, such as volcano or hivedscheduler.
+	// Default to empty, i.e. the Config PodDefaults SchedulerName is applied.
+	SchedulerName string `json:"schedulerName"`
+
+	// If it is not nil, the HiveDScheduler pod-scheduling-spec annotation is
+	// generated for the TaskRole's Pods, unless the Pod template specifies it.
+	// All such TaskRoles of the Framework are gang scheduled together in the
+	// same HiveD affinity group, which is named by the FrameworkAttempt
+	// InstanceUID, so the retried FrameworkAttempt will not reuse the
+	// released group.
+	// The Pod preempted by HiveD is completed with
+	// CompletionCodePodHiveDPreempted.
+	// Note, the SchedulerName should also be set to the HiveDScheduler, unless it
+	// is called by the K8S default scheduler as an extender.
+	HiveD *HiveDSpec `json:"hived"`
+
+	// If it is not nil, the PodAntiAffinity is injected into the TaskRole's
+	// Pods, so that no two Pods of the TaskRole land in the same topology
+	// domain, such as to avoid the GPU contention between the Tasks which assume
+	// the exclusive node resources.
+	// See AntiAffinitySpec.
+	AntiAffinity *AntiAffinitySpec `json:"antiAffinity"`
+
+	// The TopologySpreadConstraints rendered into the TaskRole's Pods, whose
+	// LabelSelector is automatically filled to select the Pods of the TaskRole in
+	// the Framework, so that the users do not need to maintain the matching
+	// labels.
+	// Note, the field is not in the vendored Pod type, so such Pods are created
+	// as the unstructured objects, and it requires K8S 1.19 or later.
+	// Default to empty, i.e. no TopologySpreadConstraint.
+	TopologySpreadConstraints []TopologySpreadConstraintSpec `json:"topologySpreadConstraints"`
+
+	// The name of the Container whose termination decides the completion of the
+	// TaskAttempt, instead of the whole Pod phase.
+	// Once the Container is terminated and will not be restarted, the TaskAttempt
+	// is completed as succeeded if its ExitCode is 0, otherwise failed, so that
+	// the long-running sidecar Containers, such as logging agents and proxies,
+	// cannot keep the Pod running forever. The Pod is then deleted as usual,
+	// which also kills the sidecar Containers.
+	// Default to empty, i.e. the Pod phase decides the completion.
+	CompletionContainer string `json:"completionContainer"`
+
+	// If it is not nil, the failed or externally deleted TaskAttempt is matched
+	// against its Rules in order, and the Action of the first matched Rule
+	// overrides the Task RetryPolicy decision, similar to the batch/v1 Job
+	// PodFailurePolicy.
+	PodFailurePolicy *PodFailurePolicySpec `json:"podFailurePolicy"`
+
+	// If it is not nil, once a TaskAttempt is OOMKilled and the Task will be
+	// retried, the memory requests and limits of all Containers of the next
+	// TaskAttempt are multiplied by FactorPercent, and the escalation is
+	// recorded in the TaskStatus MemoryEscalation.
+	// Note, the OOMKilled failure is Permanent Failed by default, so the Task
+	// RetryPolicy CompletionOverrides may be needed to retry it.
+	MemoryEscalation *MemoryEscalationSpec `json:"memoryEscalation"`
+
+	// If it is not nil, the TaskRole tolerates the spot node interruptions, i.e.
+	// the TaskAttempt completed with CompletionCodePodSpotInterrupted is always
+	// retried without consuming its MaxRetryCount, regardless of its RetryPolicy
+	// and PodFailurePolicy.
+	// See Config SpotInterruption.
+	SpotInterruption *SpotInterruptionSpec `json:"spotInterruption"`
+
+	// If it is not nil, the main Container is wrapped to run the QuitCommand
+	// after it exits, so that the injected sidecar Containers, such as the Istio
+	// Envoy proxy, are stopped and the Pod can reach the Succeeded or Failed
+	// phase instead of being kept running.
+	SidecarQuit *SidecarQuitSpec `json:"sidecarQuit"`
+
+	// If it is not nil, the TaskRole's Tasks are included in the hostfile of
+	// each FrameworkAttempt.
+	// Once all Tasks of all such TaskRoles have been assigned PodIPs, the
+	// hostfile and the peer list are written into the FrameworkAttempt's
+	// ConfigMap, which is mounted by all Pods of the Framework at
+	// HostfileMountPath, so that mpirun-style launchers can be used without the
+	// FrameworkBarrier.
+	// Note, the hostfile is only written once for each FrameworkAttempt, so the
+	// retried Task's new PodIP is not reflected. For MPI, it is better to
+	// complete the FrameworkAttempt once any Task failed, see
+	// CompletionPolicySpec.
+	Hostfile *HostfileSpec `json:"hostfile"`
+
+	// If it is not nil, a SSH keypair is generated for each FrameworkAttempt
+	// and mounted into all Pods of the TaskRole, so that the Tasks in all such
+	// TaskRoles can SSH to each other, such as for multi-node MPI.
+	// The keypair is stored in a Secret which is controlled by the ConfigMap of
+	// the FrameworkAttempt, so it will be garbage collected together with the
+	// FrameworkAttempt.
+	// SecretName = {FrameworkName}-attempt-{FrameworkAttemptID}-ssh
+	SSHKey *SSHKeySpec `json:"sshKey"`
+
+	// The secrets to be injected into all Pods of the TaskRole, as environment
+	// variables or mounted files, by the built-in SecretRef PodDecorator at Pod
+	// creation, so that the credentials do not need to be copied into the Pod
+	// template verbatim.
+	// See Config PodDecorators.
+	SecretRefs []SecretRefSpec `json:"secretRefs"`
+
+	// Override the Task.Pod for the Tasks in specific TaskIndex ranges, such as
+	// the chief Task needs more resources or different environment variables
+	// than the other worker Tasks, without defining an artificial TaskRole.
+	// The matched overrides are applied in order, so the later one takes
+	// precedence.
+	TaskOverrides []TaskOverrideSpec `json:"taskOverrides"`
+
+	// The number of host ports to allocate for each Task, which is useful for
+	// the HostNetwork Pods whose static ports may collide with each other on the
+	// same node.
+	// The ports are allocated before the Task's first TaskAttempt is created and
+	// kept across its TaskAttempts, and they are exposed in the TaskStatus and
+	// the environment variables FC_TASK_PORT_MIN and FC_TASK_PORT_MAX.
+	// See Config PortAllocationRange.
+	// Default to 0, i.e. no port is allocated.
+	PortNumber int32 `json:"portNumber"`
+
+	// If it is not nil, a Service and an optional Ingress are created for the
+	// TaskRole of each FrameworkAttempt to expose its Pods, which is useful for
+	// the long-running serving TaskRoles, such as parameter servers and
+	// TensorBoard.
+	// They are controlled by the ConfigMap of the FrameworkAttempt, so they will
+	// be garbage collected together with the FrameworkAttempt.
+	// ServiceName = IngressName = {FrameworkName}-{TaskRoleName}-exposed
+	Expose *ExposeSpec `json:"expose"`
+
+	// If it is not nil, a PodDisruptionBudget covering the TaskRole's Pods is
+	// created for each FrameworkAttempt, so that the voluntary disruptions, such
+	// as node drains, cannot silently evict too many Pods of a gang-scheduled
+	// TaskRole.
+	// It is controlled by the ConfigMap of the FrameworkAttempt, so it will be
+	// garbage collected together with the FrameworkAttempt.
+	// PodDisruptionBudgetName = {FrameworkName}-{TaskRoleName}
+	DisruptionBudget *DisruptionBudgetSpec `json:"disruptionBudget"`
+
+	// The strategy to apply the updated Task.Pod or TaskOverrides to the TaskRole's
+	// existing TaskAttempts.
+	// The Task.Pod and TaskOverrides can be updated at any time, and the new
+	// TaskAttempts are always created with the latest ones, unless the update is
+	// rejected.
+	// Default to OnTaskRetry if it is nil.
+	UpdateStrategy *UpdateStrategySpec `json:"updateStrategy"`
+}
+
+type UpdateStrategyType string
+
+const (
+	// The Task whose Pod template is changed since its completed TaskAttempt will
+	// not be retried, i.e. the Pod template should not be changed during the
+	// whole lifetime of a Task.
+	UpdateStrategyReject UpdateStrategyType = "Reject"
+	// The existing TaskAttempts are kept, and the updated Pod template only takes
+	// effect once the Task is retried.
+	UpdateStrategyOnTaskRetry UpdateStrategyType = "OnTaskRetry"
+	// The outdated TaskAttempts are progressively completed with
+	// CompletionCodePodTemplateUpdated, and then immediately retried with the
+	// updated Pod template without consuming the Task RetryPolicy.
+	UpdateStrategyRolling UpdateStrategyType = "Rolling"
+)
+
+type UpdateStrategySpec struct {
+	// Default to OnTaskRetry if it is empty.
+	Type UpdateStrategyType `json:"type"`
+	// The maximum number of the TaskRole's Tasks that can be unavailable, i.e.
+	// not TaskAttemptRunning, when a Running TaskAttempt is to be completed for
+	// the Rolling update.
+	// The outdated TaskAttempts which are not yet Running are updated regardless
+	// of it.
+	// The percentage is calculated from the TaskNumber and rounded down, but at
+	// least 1.
+	// Default to 1 if it is nil.
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable"`
+}
+
+type PodFailurePolicySpec struct {
+	Rules []PodFailurePolicyRule `json:"rules"`
+}
+
+type PodFailurePolicyAction string
+
+const (
+	// Retry the Task without counting into its AccountableRetriedCount.
+	PodFailurePolicyActionIgnore PodFailurePolicyAction = "Ignore"
+	// Retry the Task and count into its AccountableRetriedCount, until the
+	// MaxRetryCount of the Task RetryPolicy is reached, regardless of the other
+	// fields of the Task RetryPolicy.
+	PodFailurePolicyActionRetry PodFailurePolicyAction = "Retry"
+	// Complete the Task without retry, regardless of the Task RetryPolicy.
+	PodFailurePolicyActionFailTask PodFailurePolicyAction = "FailTask"
+	// Complete the Task without retry, and complete the FrameworkAttempt with
+	// CompletionCodePodFailurePolicyFailFramework.
+	PodFailurePolicyActionFailFramework PodFailurePolicyAction = "FailFramework"
+)
+
+// The Rule matches if all of its specified requirements are matched, and the
+// Rule without any requirement never matches.
+type PodFailurePolicyRule struct {
+	Action          PodFailurePolicyAction                   `json:"action"`
+	OnExitCodes     *PodFailurePolicyOnExitCodesRequirement  `json:"onExitCodes"`
+	OnPodConditions []PodFailurePolicyOnPodConditionsPattern `json:"onPodConditions"`
+}
+
+type PodFailurePolicyOnExitCodesOperator string
+
+const (
+	PodFailurePolicyOnExitCodesOpIn    PodFailurePolicyOnExitCodesOperator = "In"
+	PodFailurePolicyOnExitCodesOpNotIn PodFailurePolicyOnExitCodesOperator = "NotIn"
+)
+
+// The requirement matches if any terminated Container with non-zero ExitCode
+// matches, and the succeeded Containers are ignored.
+type PodFailurePolicyOnExitCodesRequirement struct {
+	// Default to empty, i.e. match any Container.
+	ContainerName string                              `json:"containerName"`
+	Operator      PodFailurePolicyOnExitCodesOperator `json:"operator"`
+	Values        []int32                             `json:"values"`
+}
+
+// The pattern matches if the Pod has the condition with the Type and Status,
+// such as the DisruptionTarget condition.
+type PodFailurePolicyOnPodConditionsPattern struct {
+	Type core.PodConditionType `json:"type"`
+	// Default to True if it is empty.
+	Status core.ConditionStatus `json:"status"`
+}
+
+type SpotInterruptionSpec struct {
+	// If true, once the interruption notice is found on the node of a not
+	// completed TaskAttempt, the TaskAttempt is drained in advance, i.e.
+	// completed with CompletionCodePodSpotInterrupted, so that it can be retried
+	// on another node before the node is reclaimed.
+	DrainOnNotice bool `json:"drainOnNotice"`
+}
+
+type MemoryEscalationSpec struct {
+	// The percentage to multiply the memory for each OOMKilled TaskAttempt.
+	// Default to 200 if it is 0, i.e. double the memory.
+	FactorPercent int32 `json:"factorPercent"`
+	// The max memory request and limit of each Container after escalation.
+	// Default to nil, i.e. unlimited.
+	MaxMemory *resource.Quantity `json:"maxMemory"`
+}
+
+type HiveDSpec struct {
+	// The HiveD virtual cluster to schedule the Pods in.
+	VirtualCluster string `json:"virtualCluster"`
+	// The HiveD priority of the Pods, -1 means the opportunistic priority.
+	// Default to 0.
+	Priority int32 `json:"priority"`
+	// The HiveD pinned cell to schedule the Pods in.
+	// Default to empty, i.e. no pinned cell.
+	PinnedCellID string `json:"pinnedCellId"`
+	// The HiveD leaf cell type, such as the GPU model.
+	// Default to empty, i.e. any leaf cell type.
+	LeafCellType string `json:"leafCellType"`
+	// The HiveD leaf cell number, such as the GPU number, of each Pod.
+	LeafCellNumber int32 `json:"leafCellNumber"`
+}
+
+type AntiAffinitySpec struct {
+	// The node label key of the topology domain to spread over.
+	// Default to kubernetes.io/hostname, i.e. one Pod per node.
+	TopologyKey string `json:"topologyKey"`
+	// If true, the anti-affinity is required, so the Pods may be pending if
+	// there are not enough topology domains.
+	// Otherwise, it is only preferred with the AntiAffinityPreferredWeight.
+	// Default to false.
+	Required bool `json:"required"`
+}
+
+type TopologySpreadConstraintSpec struct {
+	// The maximum allowed difference of the Pod numbers between any two topology
+	// domains.
+	// Default to 1.
+	MaxSkew int32 `json:"maxSkew"`
+	// The node label key of the topology domain, such as
+	// topology.kubernetes.io/zone.
+	TopologyKey string `json:"topologyKey"`
+	// Default to UnsatisfiableDoNotSchedule.
+	WhenUnsatisfiable UnsatisfiableConstraintAction `json:"whenUnsatisfiable"`
+}
+
+type UnsatisfiableConstraintAction string
+
+const (
+	// The Pod is not scheduled if the constraint cannot be satisfied.
+	UnsatisfiableDoNotSchedule UnsatisfiableConstraintAction = "DoNotSchedule"
+	// The Pod is still scheduled, but prefers the domains reducing the skew.
+	UnsatisfiableScheduleAnyway UnsatisfiableConstraintAction = "ScheduleAnyway"
+)
+
+type SidecarQuitSpec struct {
+	// The name of the main Container to be wrapped, and the Container must
+	// specify its command explicitly and provide /bin/sh.
+	// Default to the CompletionContainer if it is not empty, otherwise the first
+	// Container.
+	Container string `json:"container"`
+	// The shell command to stop the sidecar Containers, which is run in the main
+	// Container after its original command exits, and its failure is ignored.
+	// The main Container exits with the original command's ExitCode.
+	// Default to SidecarQuitDefaultCommand if it is empty, i.e. quit the Istio
+	// Envoy proxy by its pilot-agent quitquitquit endpoint.
+	QuitCommand string `json:"quitCommand"`
+}
+
+type SecretRefSpec struct {
+	// The name of the SecretStore to resolve the secret, and other SecretStores,
+	// such as Vault, can be compiled in by controller.RegisterSecretStore.
+	// Default to SecretStoreKubernetes, i.e. the Kubernetes Secret in the
+	// Framework namespace.
+	Store string `json:"store"`
+	// The name of the secret in the Store.
+	Name string `json:"name"`
+	// The key of the secret value in the secret.
+	Key string `json:"key"`
+
+	// Exactly one of EnvName and MountPath should be specified.
+	// The environment variable to hold the secret value in all Containers.
+	EnvName string `json:"envName"`
+	// The file path to hold the secret value in all Containers.
+	MountPath string `json:"mountPath"`
+}
+
+type DisruptionBudgetSpec struct {
+	// At most one of MinAvailable and MaxUnavailable can be specified.
+	// Default to MinAvailable 100% if both are nil, i.e. no Pod of the TaskRole
+	// can be voluntarily evicted.
+	MinAvailable   *intstr.IntOrString `json:"minAvailable"`
+	MaxUnavailable *intstr.IntOrString `json:"maxUnavailable"`
+}
+
+type ExposeSpec struct {
+	// The Service type, only ClusterIP and NodePort are supported.
+	// Default to ClusterIP if it is empty.
+	Type core.ServiceType `json:"type"`
+	// The Service ports, and their targetPorts refer the Pod ports.
+	Ports []core.ServicePort `json:"ports"`
+	// If it is not nil, an Ingress is also created to route to the Service.
+	Ingress *ExposeIngressSpec `json:"ingress"`
+}
+
+type ExposeIngressSpec struct {
+	// The IngressClassName of the Ingress.
+	// Default to empty, i.e. the default IngressClass of the cluster is used.
+	ClassName string `json:"className"`
+	// The host of the Ingress rule.
+	// Default to empty, i.e. all hosts are matched.
+	Host string `json:"host"`
+	// The path prefix of the Ingress rule.
+	// Default to / if it is empty.
+	Path string `json:"path"`
+	// The Service port to route to.
+	// Default to the first port of the Service if it is 0.
+	ServicePort int32 `json:"servicePort"`
+}
+
+type TaskOverrideSpec struct {
+	// The Tasks with TaskIndex in range [MinTaskIndex, MaxTaskIndex] are
+	// overridden.
+	MinTaskIndex int32 `json:"minTaskIndex"`
+	MaxTaskIndex int32 `json:"maxTaskIndex"`
+
+	// The strategic merge patch to the Task.Pod, such as the containers and
+	// their env are merged by name.
+	// The Predefined Pod Template Placeholders can also be referred in it.
+	// If the patch cannot be applied, the TaskAttempt will be completed with
+	// CompletionCodePodSpecPermanentError.
+	Pod runtime.RawExtension `json:"pod"`
+}
+
+type SSHKeySpec struct {
+	// The directory to mount the Secret, which contains the files id_rsa,
+	// id_rsa.pub, authorized_keys and config.
+	// The config disables the host key checking, since the host keys of the
+	// Tasks are not known in advance.
+	// Default to /root/.ssh if it is empty.
+	MountPath string `json:"mountPath"`
+}
+
+type HostfileSpec struct {
+	// The number of process slots of each Task in the hostfile.
+	// Default to 1 if it is not positive.
+	Slots int32 `json:"slots"`
 }
 
 type TaskSpec struct {
@@ -111,8 +692,48 @@ type TaskSpec struct {
 	// one instance of a specific Task is running at any point in time.
 	// So, in this setting, the Task behaves like StatefulSet, and choose it if the Task
 	// favors consistency over availability, such as stateful Task.
-	PodGracefulDeletionTimeoutSec *int64               `json:"podGracefulDeletionTimeoutSec"`
-	Pod                           core.PodTemplateSpec `json:"pod"`
+	PodGracefulDeletionTimeoutSec *int64 `json:"podGracefulDeletionTimeoutSec"`
+
+	// If the TaskAttempt has been running longer than this timeout, such as the
+	// Task is hung, it will be completed with CompletionCodeTaskAttemptTimeExceeded,
+	// which is Transient Failed, so it can be retried by the Task RetryPolicy.
+	// Default to nil, i.e. no timeout.
+	AttemptMaxRunDurationSec *int64 `json:"attemptMaxRunDurationSec"`
+
+	// If the running TaskAttempt has not reported its heartbeat longer than this
+	// timeout, such as the Task is stuck, it will be completed with
+	// CompletionCodeTaskHeartbeatTimeout, which is Transient Failed, so it can be
+	// retried by the Task RetryPolicy.
+	// The heartbeat is reported by updating the Pod annotation
+	// AnnotationKeyHeartbeatTime, and before the first heartbeat, the timeout is
+	// counted from the TaskAttempt RunTime.
+	// Default to nil, i.e. no heartbeat is required.
+	HeartbeatTimeoutSec *int64 `json:"heartbeatTimeoutSec"`
+
+	// The indexes of the Tasks which are requested to stop, i.e. their current
+	// TaskAttempts will be gracefully deleted and completed with
+	// CompletionCodeStopTaskRequested without retry, but unlike the ScaleDown,
+	// the Tasks are still kept and occupy their indexes.
+	// The stopped Task is Permanent Failed, so it is still considered by the
+	// FrameworkAttemptCompletionPolicy.
+	// Default to empty, i.e. no Task is requested to stop.
+	StopIndexes []int32 `json:"stopIndexes"`
+
+	// Request to restart the specified TaskAttempts, such as a wedged Task, i.e.
+	// their Pods will be gracefully deleted and completed with
+	// CompletionCodeRestartTaskRequested, and then immediately retried without
+	// consuming the Task RetryPolicy.
+	// Default to empty, i.e. no TaskAttempt is requested to restart.
+	RestartRequests []TaskRestartRequest `json:"restartRequests"`
+
+	Pod core.PodTemplateSpec `json:"pod"`
+}
+
+type TaskRestartRequest struct {
+	Index int32 `json:"index"`
+	// The request only restarts the TaskAttempt with this TaskAttemptID, so it
+	// is idempotent and will not impact the later TaskAttempts.
+	AttemptID int32 `json:"attemptID"`
 }
 
 type ExecutionType string
@@ -120,6 +741,11 @@ type ExecutionType string
 const (
 	ExecutionStart ExecutionType = "Start"
 	ExecutionStop  ExecutionType = "Stop"
+	// Gracefully delete current FrameworkAttempt like ExecutionStop, but keep the
+	// Framework in FrameworkSuspended state, until the ExecutionType is updated
+	// back to ExecutionStart, then a new FrameworkAttempt will be created without
+	// consuming the Framework RetryPolicy.
+	ExecutionSuspend ExecutionType = "Suspend"
 )
 
 // RetryPolicySpec can be configured for the whole Framework and each TaskRole
@@ -136,11 +762,14 @@ const (
 //    complete a single Task in the TaskRole.
 //
 // Usage:
-// If the ExecutionType is ExecutionStop or
+// If the ExecutionType is ExecutionStop or ExecutionSuspend or
 // the Task's FrameworkAttempt is completing or
 // the Task is DeletionPending (ScaleDown),
 //   will not retry.
 //
+// If any CompletionOverrides matches the completion,
+//   will apply the NormalRetryPolicy with the matched MaxRetryCount instead.
+//
 // If the FancyRetryPolicy is enabled,
 //   will retry if the completion is due to Transient Failed CompletionType,
 //   will not retry if the completion is due to Permanent Failed CompletionType,
@@ -176,6 +805,61 @@ const (
 type RetryPolicySpec struct {
 	FancyRetryPolicy bool  `json:"fancyRetryPolicy"`
 	MaxRetryCount    int32 `json:"maxRetryCount"`
+
+	// If it is not nil, the retry is delayed by at least the BackoffPolicy
+	// delay, i.e. if the RetryPolicy decides to retry, its RetryDelaySec is the
+	// max of the original delay and the BackoffPolicy delay.
+	// Default to nil, i.e. only the transient conflict failure is delayed, see
+	// FancyRetryPolicy.
+	BackoffPolicy *BackoffPolicySpec `json:"backoffPolicy"`
+
+	// Override the FancyRetryPolicy and MaxRetryCount for the completed attempt
+	// whose CompletionStatus matches, such as the OOM failure which is Permanent
+	// Failed by default can still be retried twice.
+	// The first matched override is used, and if none is matched, the
+	// FancyRetryPolicy and MaxRetryCount are used as usual.
+	// Note, the built-in always-on RetryPolicy, such as for the
+	// CompletionCodeStopFrameworkRequested, cannot be overridden.
+	CompletionOverrides []RetryOverrideSpec `json:"completionOverrides"`
+}
+
+type RetryOverrideSpec struct {
+	// The CompletionStatus matches if its Code is within [MinCode, MaxCode],
+	// and its Type has the TypeName and all the TypeAttributes.
+	// Default to nil or empty, i.e. match any.
+	MinCode        *CompletionCode           `json:"minCode"`
+	MaxCode        *CompletionCode           `json:"maxCode"`
+	TypeName       CompletionTypeName        `json:"typeName"`
+	TypeAttributes []CompletionTypeAttribute `json:"typeAttributes"`
+
+	// For the matched CompletionStatus, retry if AccountableRetriedCount has not
+	// reached MaxRetryCount, same as the NormalRetryPolicy.
+	// And the UnlimitedValue means always retry.
+	MaxRetryCount int32 `json:"maxRetryCount"`
+	// The retry is delayed by DelaySec, and the BackoffPolicy is still applied.
+	// Default to 0, i.e. no delay.
+	DelaySec int64 `json:"delaySec"`
+}
+
+type BackoffPolicyType string
+
+const (
+	// Delay = BaseDelaySec
+	BackoffFixed BackoffPolicyType = "Fixed"
+	// Delay = min(BaseDelaySec * 2 ^ TotalRetriedCount, MaxDelaySec)
+	BackoffExponential BackoffPolicyType = "Exponential"
+)
+
+type BackoffPolicySpec struct {
+	// Default to Fixed if it is empty.
+	Type         BackoffPolicyType `json:"type"`
+	BaseDelaySec int64             `json:"baseDelaySec"`
+	// Default to 0, i.e. the exponential delay is unlimited.
+	MaxDelaySec int64 `json:"maxDelaySec"`
+	// The delay is randomly increased by up to JitterPercent of itself, so that
+	// the retries of many Frameworks or Tasks failed at the same time are spread.
+	// Default to 0, i.e. no jitter.
+	JitterPercent int32 `json:"jitterPercent"`
 }
 
 // CompletionPolicySpec can be configured for each TaskRole to control:
@@ -184,9 +868,9 @@ type RetryPolicySpec struct {
 //    2. The CompletionStatus of the completed FrameworkAttempt.
 //
 // Usage:
-// 1. If the ExecutionType is ExecutionStop, immediately complete the FrameworkAttempt,
-//    regardless of any uncompleted Task, and the CompletionStatus is failed which
-//    is not inherited from any Task.
+// 1. If the ExecutionType is ExecutionStop or ExecutionSuspend, immediately
+//    complete the FrameworkAttempt, regardless of any uncompleted Task, and the
+//    CompletionStatus is failed which is not inherited from any Task.
 // 2. If MinFailedTaskCount >= 1 and MinFailedTaskCount <= failed Task count of
 //    current TaskRole, immediately complete the FrameworkAttempt, regardless of
 //    any uncompleted Task, and the CompletionStatus is failed which is inherited
@@ -250,6 +934,119 @@ type FrameworkStatus struct {
 	TransitionTime    meta.Time              `json:"transitionTime"`
 	RetryPolicyStatus RetryPolicyStatus      `json:"retryPolicyStatus"`
 	AttemptStatus     FrameworkAttemptStatus `json:"attemptStatus"`
+
+	// The coarse-grained summary of the State, which only has a single terminal
+	// value for each completion outcome, so that it can be stably matched by
+	// the external workflow engines, such as the Argo resource template
+	// successCondition and failureCondition.
+	// See FrameworkPhase.
+	Phase FrameworkPhase `json:"phase"`
+
+	// The aggregate Task counts of current FrameworkAttempt, so that the
+	// dashboards can show the progress without downloading and walking the
+	// TaskRoleStatuses, which may be multi-megabytes or even compressed.
+	// It is refreshed after each sync, and it is nil until the first sync.
+	// See Framework.UpdateProgress.
+	Progress *FrameworkProgress `json:"progress,omitempty"`
+
+	// The summary of the Spec last observed by FrameworkController, which is
+	// used to detect the Spec changes.
+	ObservedSpecSummary *SpecSummary `json:"observedSpecSummary,omitempty"`
+	// The recent Spec changes, in ascending order of the observed time, and at
+	// most SpecChangeHistoryMaxLength changes are retained.
+	SpecChangeHistory []*SpecChangeRecord `json:"specChangeHistory,omitempty"`
+
+	// The nodes to be avoided by the Framework's later created Pods, in
+	// ascending order of the LastFailedTime.
+	// See Config NodeBlacklist.
+	BlacklistedNodes []*BlacklistedNode `json:"blacklistedNodes,omitempty"`
+
+	// The latest observations of the Framework, such as why it is waiting.
+	// See FrameworkConditionType.
+	Conditions []FrameworkCondition `json:"conditions,omitempty"`
+}
+
+type FrameworkCondition struct {
+	Type   FrameworkConditionType `json:"type"`
+	Status core.ConditionStatus   `json:"status"`
+	// The machine readable reason of the last transition.
+	Reason string `json:"reason"`
+	// The human readable message of the latest observation.
+	Message string `json:"message"`
+	// The last time the Status changed.
+	LastTransitionTime meta.Time `json:"lastTransitionTime"`
+}
+
+type FrameworkConditionType string
+
+const (
+	// Whether the Framework is admitted by all FrameworkQuotas in its namespace,
+	// i.e. it is only set if there is any FrameworkQuota.
+	// See FrameworkQuota.
+	FrameworkQuotaAdmitted FrameworkConditionType = "QuotaAdmitted"
+	// Whether the FrameworkAttempt may fit into the schedulable capacity of the
+	// cluster, i.e. it is only set if the capacity is ever insufficient.
+	// See Config CapacityCheck.
+	FrameworkCapacitySufficient FrameworkConditionType = "CapacitySufficient"
+)
+
+type FrameworkProgress struct {
+	// The percentage of the completed Tasks in all Tasks, rounded down.
+	CompletionPercentage int32               `json:"completionPercentage"`
+	TaskRoles            []*TaskRoleProgress `json:"taskRoles"`
+}
+
+// The DeletionPending Tasks are excluded from all counts, since they are
+// logically detached from the Framework.
+type TaskRoleProgress struct {
+	Name      string `json:"name"`
+	TaskCount int32  `json:"taskCount"`
+	// The Tasks which are neither running nor completed, such as the ones
+	// waiting to be scheduled or retried.
+	PendingTaskCount   int32 `json:"pendingTaskCount"`
+	RunningTaskCount   int32 `json:"runningTaskCount"`
+	SucceededTaskCount int32 `json:"succeededTaskCount"`
+	FailedTaskCount    int32 `json:"failedTaskCount"`
+
+	// The latest completed failed Tasks, in descending order of the
+	// CompletionTime, and at most ProgressFailedTaskSampleMaxCount Tasks are
+	// sampled, so that the failures can be diagnosed without walking the
+	// TaskRoleStatuses.
+	FailedTaskSamples []*FailedTaskSample `json:"failedTaskSamples,omitempty"`
+}
+
+type FailedTaskSample struct {
+	Index            int32             `json:"index"`
+	AttemptID        int32             `json:"attemptID"`
+	CompletionTime   *meta.Time        `json:"completionTime"`
+	CompletionStatus *CompletionStatus `json:"completionStatus"`
+}
+
+type BlacklistedNode struct {
+	NodeName       string         `json:"nodeName"`
+	LastFailedTime meta.Time      `json:"lastFailedTime"`
+	FailedCount    int32          `json:"failedCount"`
+	CompletionCode CompletionCode `json:"completionCode"`
+}
+
+type SpecSummary struct {
+	ExecutionType ExecutionType `json:"executionType"`
+	// TaskRoleName -> TaskNumber
+	TaskNumbers map[string]int32 `json:"taskNumbers"`
+}
+
+type SpecChangeRecord struct {
+	// The time when the change is observed by FrameworkController, which may be
+	// later than the time when the change is made.
+	ObservedTime meta.Time `json:"observedTime"`
+	// The Framework ResourceVersion which contains the change.
+	ResourceVersion string `json:"resourceVersion"`
+	// The kubernetes.io/change-cause annotation of the Framework, such as set by
+	// kubectl --record, so that it can tell who made the change.
+	ChangeCause string `json:"changeCause,omitempty"`
+	// Human readable summaries of the change, such as
+	// "TaskRole[worker].TaskNumber: 4 -> 2".
+	Changes []string `json:"changes"`
 }
 
 type FrameworkAttemptStatus struct {
@@ -273,12 +1070,54 @@ type FrameworkAttemptStatus struct {
 	// It will never be changed during the whole lifetime of a specific Framework.
 	ConfigMapName string `json:"configMapName"`
 	// ConfigMapUID can also universally locate the FrameworkAttemptInstance.
-	ConfigMapUID               *types.UID                        `json:"configMapUID"`
+	ConfigMapUID *types.UID `json:"configMapUID"`
+	// The PodGroup of the FrameworkAttemptInstance, which is controlled by its
+	// ConfigMap.
+	// It is nil if no PodGroup is needed according to the GangSchedulingConfig,
+	// or the PodGroup is not yet created.
+	PodGroupUID *types.UID `json:"podGroupUID"`
+	// The Kueue Workload of the FrameworkAttemptInstance, which is controlled by
+	// its ConfigMap.
+	// It is nil if the Framework is not managed by Kueue according to the
+	// KueueConfig, or the Workload is not yet created.
+	KueueWorkloadUID *types.UID `json:"kueueWorkloadUID"`
+	// The SSH keypair Secret of the FrameworkAttemptInstance, which is controlled
+	// by its ConfigMap.
+	// It is nil if no TaskRole specifies the SSHKey, or the Secret is not yet
+	// created.
+	SSHSecretUID *types.UID `json:"sshSecretUID"`
+	// The NetworkPolicy of the FrameworkAttemptInstance, which is controlled
+	// by its ConfigMap.
+	// It is nil if the Framework does not specify the NetworkPolicy, or the
+	// NetworkPolicy is not yet created.
+	NetworkPolicyUID *types.UID `json:"networkPolicyUID"`
+	// The last time the TaskRoleAutoscaler was consulted for the
+	// FrameworkAttempt.
+	// It is nil if the TaskRoleAutoscaler is disabled or not yet consulted.
+	LastAutoscaleTime *meta.Time `json:"lastAutoscaleTime"`
+	// Whether the hostfile has been written into the ConfigMap.
+	// It is always false if no TaskRole specifies the Hostfile.
+	HostfileGenerated bool `json:"hostfileGenerated"`
+	// The statuses of the FrameworkHooksSpec of the FrameworkAttempt.
+	// They are nil if the hook is not specified or not yet started.
+	PreAttemptHookStatus       *HookStatus                       `json:"preAttemptHookStatus"`
+	PostAttemptHookStatus      *HookStatus                       `json:"postAttemptHookStatus"`
 	CompletionStatus           *FrameworkAttemptCompletionStatus `json:"completionStatus"`
 	TaskRoleStatuses           []*TaskRoleStatus                 `json:"taskRoleStatuses"`
 	TaskRoleStatusesCompressed []byte                            `json:"taskRoleStatusesCompressed,omitempty"`
 }
 
+type HookStatus struct {
+	StartTime meta.Time `json:"startTime"`
+	// The hook Pod, which is nil for the Webhook.
+	PodName *string    `json:"podName"`
+	PodUID  *types.UID `json:"podUID"`
+	// It is nil if the hook is not yet completed.
+	CompletionTime *meta.Time `json:"completionTime"`
+	Succeeded      bool       `json:"succeeded"`
+	Diagnostics    string     `json:"diagnostics"`
+}
+
 type TaskRoleStatus struct {
 	// TaskRoleName
 	Name string `json:"name"`
@@ -289,6 +1128,31 @@ type TaskRoleStatus struct {
 	// TaskRoleStatus still exist due to graceful deletion.
 	PodGracefulDeletionTimeoutSec *int64 `json:"podGracefulDeletionTimeoutSec"`
 
+	// The headless Service of the TaskRole in current FrameworkAttemptInstance,
+	// which is controlled by its ConfigMap.
+	// It is nil if the TaskRoleHeadlessServiceEnabled is false, or the Service is
+	// not yet created.
+	HeadlessServiceUID *types.UID `json:"headlessServiceUID"`
+
+	// The exposed Service and Ingress of the TaskRole in current
+	// FrameworkAttemptInstance, which are controlled by its ConfigMap.
+	// They are nil if the TaskRole Expose is nil, or they are not yet created.
+	ExposedServiceUID *types.UID `json:"exposedServiceUID"`
+	IngressUID        *types.UID `json:"ingressUID"`
+
+	// The PodDisruptionBudget of the TaskRole in current FrameworkAttemptInstance,
+	// which is controlled by its ConfigMap.
+	// It is nil if the TaskRole DisruptionBudget is nil, or it is not yet created.
+	PodDisruptionBudgetUID *types.UID `json:"podDisruptionBudgetUID"`
+
+	// The TaskNumber adjusted by the TaskRoleAutoscaler, which overrides the
+	// TaskRoleSpec TaskNumber in current FrameworkAttempt, without changing the
+	// Framework Spec.
+	// It is nil if the TaskRoleAutoscaler has not adjusted the TaskRole, and it is
+	// reset once a new FrameworkAttempt is created.
+	// See Config TaskRoleAutoscaler.
+	AutoscaledTaskNumber *int32 `json:"autoscaledTaskNumber"`
+
 	// Tasks with TaskIndex in range [0, TaskNumber)
 	TaskStatuses []*TaskStatus `json:"taskStatuses"`
 }
@@ -309,6 +1173,31 @@ type TaskStatus struct {
 	DeletionPending   bool              `json:"deletionPending"`
 	RetryPolicyStatus RetryPolicyStatus `json:"retryPolicyStatus"`
 	AttemptStatus     TaskAttemptStatus `json:"attemptStatus"`
+
+	// The host ports allocated to the Task, which are released once the Task or
+	// its FrameworkAttempt is completed.
+	// It is nil if the TaskRole PortNumber is 0, or the ports are not yet
+	// allocated.
+	AllocatedPorts *PortRange `json:"allocatedPorts"`
+
+	// The memory escalation applied to the Task's later TaskAttempts.
+	// It is nil if the TaskRole MemoryEscalation is nil, or no TaskAttempt has
+	// been OOMKilled.
+	MemoryEscalation *MemoryEscalationStatus `json:"memoryEscalation"`
+}
+
+type MemoryEscalationStatus struct {
+	// The number of OOMKilled TaskAttempts.
+	OOMKilledCount int32 `json:"oomKilledCount"`
+	// The accumulated percentage to multiply the memory of all Containers
+	// specified in the Pod template, before capped by the MaxMemory.
+	MemoryPercent int64 `json:"memoryPercent"`
+}
+
+// Represent [Min, Max].
+type PortRange struct {
+	Min int32 `json:"min"`
+	Max int32 `json:"max"`
 }
 
 type TaskAttemptStatus struct {
@@ -332,11 +1221,36 @@ type TaskAttemptStatus struct {
 	// It will never be changed during the whole lifetime of a specific Task.
 	PodName string `json:"podName"`
 	// PodUID can also universally locate the TaskAttemptInstance.
-	PodUID           *types.UID                   `json:"podUID"`
-	PodNodeName      *string                      `json:"podNodeName"`
-	PodIP            *string                      `json:"podIP"`
-	PodHostIP        *string                      `json:"podHostIP"`
-	CompletionStatus *TaskAttemptCompletionStatus `json:"completionStatus"`
+	PodUID      *types.UID `json:"podUID"`
+	PodNodeName *string    `json:"podNodeName"`
+	PodIP       *string    `json:"podIP"`
+	PodHostIP   *string    `json:"podHostIP"`
+	// The stable DNS name of the Pod, which is available before the Pod is
+	// started, and can be used as the Task address instead of the PodIP.
+	// It is nil if the Pod Hostname or Subdomain is not set.
+	// See TaskRoleHeadlessServiceEnabled.
+	PodFQDN *string `json:"podFQDN"`
+	// The hash of the Task's Pod template which the Pod was created from, so
+	// that the TaskAttempts created from different Pod templates can be told
+	// apart.
+	// It is nil if the Pod is not yet created.
+	// See TaskRoleSpec.UpdateStrategy.
+	PodTemplateHash *string `json:"podTemplateHash"`
+	// The extended resources and devices allocated to the Pod, so that the
+	// accelerator usage can be attributed to the Framework.
+	// It is nil if the Pod is not yet running or does not request any extended
+	// resource.
+	PodAllocatedDevices *PodAllocatedDevices         `json:"podAllocatedDevices,omitempty"`
+	CompletionStatus    *TaskAttemptCompletionStatus `json:"completionStatus"`
+}
+
+type PodAllocatedDevices struct {
+	// The allocated count of each extended resource, such as nvidia.com/gpu.
+	Resources map[core.ResourceName]int64 `json:"resources"`
+	// The allocated device IDs, such as the GPU UUIDs or indexes, if they are
+	// exposed by the device plugin or scheduler in the Pod annotations.
+	// See Config.PodDeviceIDAnnotationKeys.
+	DeviceIDs []string `json:"deviceIDs,omitempty"`
 }
 
 type RetryPolicyStatus struct {
@@ -382,17 +1296,30 @@ type CompletionStatus struct {
 }
 
 type PodCompletionStatus struct {
-	Reason     string                       `json:"reason,omitempty"`
-	Message    string                       `json:"message,omitempty"`
-	Containers []*ContainerCompletionStatus `json:"containers,omitempty"`
+	Reason  string `json:"reason,omitempty"`
+	Message string `json:"message,omitempty"`
+	// The reason of the True DisruptionTarget Pod condition, such as
+	// PreemptionByScheduler, EvictionByEvictionAPI and DeletionByTaintManager.
+	DisruptionReason string                       `json:"disruptionReason,omitempty"`
+	Containers       []*ContainerCompletionStatus `json:"containers,omitempty"`
 }
 
 type ContainerCompletionStatus struct {
-	Name    string `json:"name"`
-	Reason  string `json:"reason,omitempty"`
-	Message string `json:"message,omitempty"`
-	Signal  int32  `json:"signal,omitempty"`
-	Code    int32  `json:"code"`
+	Name string `json:"name"`
+	// Whether it is an InitContainer.
+	Init bool `json:"init,omitempty"`
+	// If the container is not terminated, they are from its last termination if
+	// it has been restarted, otherwise from its waiting state, such as the
+	// ImagePullBackOff.
+	Reason       string `json:"reason,omitempty"`
+	Message      string `json:"message,omitempty"`
+	Signal       int32  `json:"signal,omitempty"`
+	Code         int32  `json:"code"`
+	RestartCount int32  `json:"restartCount,omitempty"`
+	// The truncated tail of the failed container's logs, captured when the
+	// TaskAttempt is completed.
+	// See Config.FailedContainerLogTailLines.
+	LogTail string `json:"logTail,omitempty"`
 }
 
 type TaskAttemptCompletionStatus struct {
@@ -401,6 +1328,13 @@ type TaskAttemptCompletionStatus struct {
 	*CompletionStatus `json:",inline"`
 	// Detail
 	Pod *PodCompletionStatus `json:"pod,omitempty"`
+	// The matched Rule of the TaskRole PodFailurePolicy.
+	PodFailurePolicy *PodFailurePolicyStatus `json:"podFailurePolicy,omitempty"`
+}
+
+type PodFailurePolicyStatus struct {
+	RuleIndex int32                  `json:"ruleIndex"`
+	Action    PodFailurePolicyAction `json:"action"`
 }
 
 type CompletionPolicyTriggerStatus struct {
@@ -457,6 +1391,16 @@ const (
 type FrameworkState string
 
 const (
+	// ConfigMap does not exist and
+	// is not expected to exist until the Framework is admitted by its Queue.
+	// It is only the StartState of the Framework with not empty Queue, or the
+	// AttemptStartState after the Framework is preempted.
+	// [StartState]
+	// [AttemptStartState]
+	// -> FrameworkAttemptCreationPending
+	// -> FrameworkAttemptCompleted
+	FrameworkQueuing FrameworkState = "Queuing"
+
 	// ConfigMap does not exist and
 	// may not have been creation requested successfully and is expected to exist.
 	// [StartState]
@@ -519,10 +1463,22 @@ const (
 	// is not expected to exist and will never exist and
 	// current attempt is not the last attempt or to be determined.
 	// [AttemptFinalState]
+	// -> FrameworkQueuing
 	// -> FrameworkAttemptCreationPending
+	// -> FrameworkSuspended
 	// -> FrameworkCompleted
 	FrameworkAttemptCompleted FrameworkState = "AttemptCompleted"
 
+	// ConfigMap does not exist and
+	// is not expected to exist until the ExecutionType is updated back to
+	// ExecutionStart and
+	// current attempt is to be retried after the Framework is resumed.
+	// [AttemptFinalState]
+	// -> FrameworkQueuing
+	// -> FrameworkAttemptCreationPending
+	// -> FrameworkCompleted
+	FrameworkSuspended FrameworkState = "Suspended"
+
 	// ConfigMap does not exist and
 	// is not expected to exist and will never exist and
 	// current attempt is the last attempt.
@@ -530,6 +1486,22 @@ const (
 	FrameworkCompleted FrameworkState = "Completed"
 )
 
+// FrameworkPhase is derived from the FrameworkState, and it will not be changed
+// once it is FrameworkPhaseSucceeded or FrameworkPhaseFailed.
+type FrameworkPhase string
+
+const (
+	// The Framework is not yet running, such as queuing, preparing, suspended or
+	// waiting to retry.
+	FrameworkPhasePending FrameworkPhase = "Pending"
+	// The FrameworkAttempt is running.
+	FrameworkPhaseRunning FrameworkPhase = "Running"
+	// The Framework is completed and succeeded.
+	FrameworkPhaseSucceeded FrameworkPhase = "Succeeded"
+	// The Framework is completed but not succeeded.
+	FrameworkPhaseFailed FrameworkPhase = "Failed"
+)
+
 // The ground truth of TaskState is the current associated TaskAttemptInstance
 // which is represented by the PodUID and the corresponding Pod object in the
 // local cache.
@@ -608,3 +1580,497 @@ const (
 	// [FinalState]
 	TaskCompleted TaskState = "Completed"
 )
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type FrameworkAttemptHistoryList struct {
+	meta.TypeMeta `json:",inline"`
+	meta.ListMeta `json:"metadata"`
+	Items         []FrameworkAttemptHistory `json:"items"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+//////////////////////////////////////////////////////////////////////////////////////////////////
+// A FrameworkAttemptHistory records a completed FrameworkAttempt of a Framework:
+// 1. Written by FrameworkController once the FrameworkAttempt is completed, so
+//    previous FrameworkAttempts can still be inspected after the Framework is
+//    retried with a new FrameworkAttempt.
+// 2. With consistent identity {FrameworkName}-attempt-{FrameworkAttemptID} as
+//    its name, in the same namespace as the Framework.
+// 3. Controlled by the Framework, so it will be garbage collected together with
+//    the Framework.
+//
+// Notes:
+// 1. It is immutable after created, and should not be modified by others.
+//////////////////////////////////////////////////////////////////////////////////////////////////
+type FrameworkAttemptHistory struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata"`
+	FrameworkName   string                        `json:"frameworkName"`
+	FrameworkUID    types.UID                     `json:"frameworkUID"`
+	AttemptStatus   FrameworkAttemptHistoryStatus `json:"attemptStatus"`
+}
+
+type FrameworkAttemptHistoryStatus struct {
+	// FrameworkAttemptID
+	ID int32 `json:"id"`
+
+	StartTime      meta.Time  `json:"startTime"`
+	RunTime        *meta.Time `json:"runTime"`
+	CompletionTime *meta.Time `json:"completionTime"`
+
+	// FrameworkAttemptInstanceUID
+	InstanceUID *types.UID `json:"instanceUID"`
+
+	CompletionStatus *FrameworkAttemptCompletionStatus `json:"completionStatus"`
+	TaskRoleStatuses []*TaskRoleHistoryStatus          `json:"taskRoleStatuses"`
+}
+
+type TaskRoleHistoryStatus struct {
+	// TaskRoleName
+	Name string `json:"name"`
+
+	TaskStatuses []*TaskHistoryStatus `json:"taskStatuses"`
+}
+
+// The summary of the last TaskAttempt of the Task within the FrameworkAttempt.
+type TaskHistoryStatus struct {
+	// TaskIndex
+	Index int32 `json:"index"`
+
+	State             TaskState         `json:"state"`
+	RetryPolicyStatus RetryPolicyStatus `json:"retryPolicyStatus"`
+	AttemptStatus     TaskAttemptStatus `json:"attemptStatus"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type ScheduledFrameworkList struct {
+	meta.TypeMeta `json:",inline"`
+	meta.ListMeta `json:"metadata"`
+	Items         []ScheduledFramework `json:"items"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+//////////////////////////////////////////////////////////////////////////////////////////////////
+// A ScheduledFramework creates Frameworks from its FrameworkTemplate on a cron
+// Schedule, similar to CronJob but for Frameworks:
+// 1. Each scheduled Framework is named {ScheduledFrameworkName}{ScheduledTimeInUnixMinutes},
+//    so the ScheduledFrameworkName should be up to 52 lower case alphanumeric
+//    characters.
+// 2. Each scheduled Framework is controlled by the ScheduledFramework, so it
+//    will be garbage collected together with the ScheduledFramework.
+// 3. The completed scheduled Frameworks are retained according to the history
+//    limits, even if they have not reached the FrameworkCompletedRetainSec.
+//
+// Notes:
+// 1. Status field should only be modified by FrameworkController, and
+//    other fields should not be modified by FrameworkController.
+//////////////////////////////////////////////////////////////////////////////////////////////////
+type ScheduledFramework struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata"`
+	Spec            ScheduledFrameworkSpec    `json:"spec"`
+	Status          *ScheduledFrameworkStatus `json:"status"`
+}
+
+type ScheduledFrameworkSpec struct {
+	// The standard 5 fields cron schedule, such as "0 * * * *" or "@hourly".
+	// See common.CronSchedule.
+	Schedule string `json:"schedule"`
+	// The IANA time zone name to interpret the Schedule, such as "Asia/Shanghai".
+	// Default to UTC.
+	TimeZone *string `json:"timeZone"`
+
+	// If the Framework cannot be created within this deadline after its
+	// scheduled time, such as FrameworkController downtime, it will be skipped.
+	// Default to nil, i.e. no deadline, and only the most recent missed
+	// scheduled time will be created.
+	StartingDeadlineSec *int64 `json:"startingDeadlineSec"`
+
+	ConcurrencyPolicy ConcurrencyPolicy `json:"concurrencyPolicy"`
+
+	// Suspend subsequent schedules, and it does not impact already created
+	// Frameworks.
+	Suspend bool `json:"suspend"`
+
+	// The number of the completed succeeded and failed Frameworks to retain.
+	// Default to 3 and 1 respectively.
+	SucceededFrameworksHistoryLimit *int32 `json:"succeededFrameworksHistoryLimit"`
+	FailedFrameworksHistoryLimit    *int32 `json:"failedFrameworksHistoryLimit"`
+
+	FrameworkTemplate FrameworkTemplateSpec `json:"frameworkTemplate"`
+}
+
+type FrameworkTemplateSpec struct {
+	// Only the Labels and Annotations are used.
+	meta.ObjectMeta `json:"metadata"`
+	Spec            FrameworkSpec `json:"spec"`
+}
+
+type ConcurrencyPolicy string
+
+const (
+	// Allow the scheduled Frameworks to run concurrently.
+	// It is the default ConcurrencyPolicy.
+	AllowConcurrent ConcurrencyPolicy = "Allow"
+	// Skip the new scheduled Framework if the previous one has not completed.
+	ForbidConcurrent ConcurrencyPolicy = "Forbid"
+	// Delete the previous not completed Frameworks before creating the new one.
+	ReplaceConcurrent ConcurrencyPolicy = "Replace"
+)
+
+type ScheduledFrameworkStatus struct {
+	// The scheduled time of the last created Framework.
+	LastScheduleTime *meta.Time `json:"lastScheduleTime"`
+	// The names of the not completed Frameworks.
+	ActiveFrameworks []string `json:"activeFrameworks"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type FrameworkGroupList struct {
+	meta.TypeMeta `json:",inline"`
+	meta.ListMeta `json:"metadata"`
+	Items         []FrameworkGroup `json:"items"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+//////////////////////////////////////////////////////////////////////////////////////////////////
+// A FrameworkGroup runs multiple Frameworks as a DAG, i.e. a downstream
+// Framework is created only after all its upstream Frameworks are completed
+// and satisfy the DependencyConditions:
+// 1. Each member Framework is named {FrameworkGroupName}{MemberName}, so the
+//    FrameworkGroupName should be up to 31 and the MemberName should be up to 32
+//    lower case alphanumeric characters.
+// 2. Each member Framework is controlled by the FrameworkGroup, so it will be
+//    garbage collected together with the FrameworkGroup.
+// 3. A member whose dependencies can never be satisfied is Skipped.
+// 4. The FrameworkGroup is Succeeded if all members are completed and each
+//    Failed member is depended on by another member with DependencyFailed or
+//    DependencyCompleted condition, i.e. the failure is handled, otherwise it
+//    is Failed once all members are completed.
+// 5. An invalid Spec, such as a cyclic dependency, makes the FrameworkGroup
+//    Failed immediately without creating any Framework.
+//
+// Notes:
+// 1. Status field should only be modified by FrameworkController, and
+//    other fields should not be modified by FrameworkController.
+// 2. The Spec should not be modified after creation, otherwise the behavior is
+//    undefined.
+//////////////////////////////////////////////////////////////////////////////////////////////////
+type FrameworkGroup struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata"`
+	Spec            FrameworkGroupSpec    `json:"spec"`
+	Status          *FrameworkGroupStatus `json:"status"`
+}
+
+type FrameworkGroupSpec struct {
+	Members []FrameworkGroupMemberSpec `json:"members"`
+}
+
+type FrameworkGroupMemberSpec struct {
+	// MemberName, unique within the FrameworkGroup.
+	Name      string                `json:"name"`
+	DependsOn []FrameworkDependency `json:"dependsOn"`
+
+	FrameworkTemplate FrameworkTemplateSpec `json:"frameworkTemplate"`
+}
+
+type FrameworkDependency struct {
+	// The upstream MemberName.
+	Name      string              `json:"name"`
+	Condition DependencyCondition `json:"condition"`
+}
+
+type DependencyCondition string
+
+const (
+	// The upstream Framework should be completed and succeeded.
+	// It is the default DependencyCondition.
+	DependencySucceeded DependencyCondition = "Succeeded"
+	// The upstream Framework should be completed and failed.
+	DependencyFailed DependencyCondition = "Failed"
+	// The upstream Framework should be completed, no matter succeeded or failed.
+	DependencyCompleted DependencyCondition = "Completed"
+)
+
+type FrameworkGroupStatus struct {
+	StartTime meta.Time `json:"startTime"`
+	// Must be not nil for Succeeded and Failed FrameworkGroup.
+	CompletionTime *meta.Time          `json:"completionTime"`
+	State          FrameworkGroupState `json:"state"`
+	// The reason of the Failed FrameworkGroup, such as the invalid Spec.
+	Diagnostics string `json:"diagnostics"`
+
+	// The same order as FrameworkGroupSpec.Members.
+	MemberStatuses []FrameworkGroupMemberStatus `json:"memberStatuses"`
+}
+
+type FrameworkGroupState string
+
+const (
+	FrameworkGroupRunning   FrameworkGroupState = "Running"
+	FrameworkGroupSucceeded FrameworkGroupState = "Succeeded"
+	FrameworkGroupFailed    FrameworkGroupState = "Failed"
+)
+
+type FrameworkGroupMemberStatus struct {
+	// MemberName
+	Name  string                    `json:"name"`
+	State FrameworkGroupMemberState `json:"state"`
+	// Must be not empty for Created, Succeeded and Failed member.
+	FrameworkName string    `json:"frameworkName"`
+	FrameworkUID  types.UID `json:"frameworkUID"`
+	// The reason of the Skipped or Failed member.
+	Diagnostics string `json:"diagnostics"`
+}
+
+// Succeeded, Failed and Skipped are the final states, i.e. the member will
+// never be re-evaluated, even if its Framework is deleted later, such as by
+// FrameworkCompletedRetainSec.
+type FrameworkGroupMemberState string
+
+const (
+	// Waiting for its dependencies to be satisfied.
+	MemberWaiting FrameworkGroupMemberState = "Waiting"
+	// Its Framework is created and not yet completed.
+	MemberCreated   FrameworkGroupMemberState = "Created"
+	MemberSucceeded FrameworkGroupMemberState = "Succeeded"
+	MemberFailed    FrameworkGroupMemberState = "Failed"
+	// Its dependencies can never be satisfied, so its Framework is never created.
+	MemberSkipped FrameworkGroupMemberState = "Skipped"
+)
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type QueueList struct {
+	meta.TypeMeta `json:",inline"`
+	meta.ListMeta `json:"metadata"`
+	Items         []Queue `json:"items"`
+}
+
+// +genclient
+// +genclient:nonNamespaced
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+//////////////////////////////////////////////////////////////////////////////////////////////////
+// A Queue limits the Frameworks which reference it by Framework.Spec.Queue:
+// 1. A Framework is held in FrameworkQueuing state until it is admitted by the
+//    Queue, and only then its first FrameworkAttempt is created.
+// 2. An admitted Framework occupies the Queue capacity until it is
+//    FrameworkCompleted, including its retries.
+// 3. The Frameworks are admitted in the OrderPolicy, and a Framework which
+//    cannot fit into the remaining capacity blocks all the Frameworks after it,
+//    to avoid starvation. However, a Framework which can never fit into the
+//    whole capacity does not block others and is kept queuing.
+// 4. If the first blocked Framework cannot fit even after the pending
+//    preemptions, the Queue may preempt admitted Frameworks according to the
+//    PreemptionPolicy, i.e. complete their current FrameworkAttempts with
+//    CompletionCodeFrameworkPreempted and requeue them in FrameworkQueuing
+//    state. The preemption does not consume their RetryPolicy MaxRetryCount.
+// 5. If the Queue does not exist, the Frameworks which reference it are kept
+//    queuing.
+//
+// Notes:
+// 1. Status field should only be modified by FrameworkController, and
+//    other fields should not be modified by FrameworkController.
+// 2. The resource requests of a Framework is the sum of the resource requests
+//    of all its Tasks, and the resource requests of a Task is the same as the
+//    effective resource requests of its Pod, without overhead.
+//////////////////////////////////////////////////////////////////////////////////////////////////
+type Queue struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata"`
+	Spec            QueueSpec    `json:"spec"`
+	Status          *QueueStatus `json:"status"`
+}
+
+type QueueSpec struct {
+	// The max number of admitted and not completed Frameworks.
+	// Default to nil, i.e. unlimited.
+	MaxRunningFrameworks *int32 `json:"maxRunningFrameworks"`
+
+	// The max total resource requests of admitted and not completed Frameworks,
+	// such as cpu, memory and nvidia.com/gpu.
+	// The resources which are not specified are unlimited.
+	Capacity core.ResourceList `json:"capacity"`
+
+	OrderPolicy      QueueOrderPolicy      `json:"orderPolicy"`
+	PreemptionPolicy QueuePreemptionPolicy `json:"preemptionPolicy"`
+}
+
+type QueueOrderPolicy string
+
+const (
+	// Admit the earlier created Framework first.
+	// It is the default QueueOrderPolicy.
+	QueueOrderFIFO QueueOrderPolicy = "FIFO"
+	// Admit the higher QueuePriority Framework first, and then the earlier
+	// created Framework first.
+	QueueOrderPriority QueueOrderPolicy = "Priority"
+)
+
+type QueuePreemptionPolicy string
+
+const (
+	// Never preempt admitted Frameworks.
+	// It is the default QueuePreemptionPolicy.
+	QueuePreemptNever QueuePreemptionPolicy = "Never"
+	// Preempt the admitted Frameworks with lower QueuePriority than the first
+	// blocked Framework, the lower QueuePriority and then the later created ones
+	// first, and only if the first blocked Framework can fit after the preemption.
+	QueuePreemptLowerPriority QueuePreemptionPolicy = "LowerPriority"
+)
+
+type QueueStatus struct {
+	// The number and total resource requests of admitted and not completed
+	// Frameworks.
+	RunningFrameworks int32             `json:"runningFrameworks"`
+	Allocated         core.ResourceList `json:"allocated"`
+
+	// The number of not yet admitted Frameworks.
+	QueuingFrameworks int32 `json:"queuingFrameworks"`
+
+	// The UIDs of the admitted Frameworks which are still in FrameworkQueuing
+	// state, i.e. waiting to create their first FrameworkAttempts.
+	AdmittedFrameworkUIDs []types.UID `json:"admittedFrameworkUIDs"`
+
+	// The UIDs of the admitted Frameworks which are being preempted, i.e.
+	// waiting to complete their current FrameworkAttempts and to be requeued.
+	PreemptingFrameworkUIDs []types.UID `json:"preemptingFrameworkUIDs"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type FrameworkQuotaList struct {
+	meta.TypeMeta `json:",inline"`
+	meta.ListMeta `json:"metadata"`
+	Items         []FrameworkQuota `json:"items"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+//////////////////////////////////////////////////////////////////////////////////////////////////
+// A FrameworkQuota limits the aggregate usage of all Frameworks in its
+// namespace:
+// 1. A Framework is held in FrameworkAttemptCreationPending state, with the
+//    FrameworkQuotaAdmitted Condition False, until it is admitted by all the
+//    FrameworkQuotas in its namespace, and only then its FrameworkAttempt is
+//    created.
+// 2. An admitted Framework occupies the quota until it is FrameworkCompleted
+//    or suspended, including its retries.
+// 3. The Frameworks are admitted in the creation time order, and a Framework
+//    which cannot fit into the remaining quota blocks all the Frameworks after
+//    it, to avoid starvation. However, a Framework which can never fit into the
+//    whole quota does not block others and is kept waiting.
+// 4. The Frameworks which have already created their FrameworkAttempts before
+//    the FrameworkQuota is created are also counted as admitted, even if they
+//    exceed the quota.
+// 5. The Frameworks which are still waiting for their Queues are not counted,
+//    see Queue.
+//
+// Notes:
+// 1. Status field should only be modified by FrameworkController, and
+//    other fields should not be modified by FrameworkController.
+// 2. All the FrameworkQuotas in the same namespace are evaluated together, so
+//    they share the same Status, except for the Spec dependent fields.
+// 3. The resource requests of a Framework is the same as the one of the Queue.
+//////////////////////////////////////////////////////////////////////////////////////////////////
+type FrameworkQuota struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata"`
+	Spec            FrameworkQuotaSpec    `json:"spec"`
+	Status          *FrameworkQuotaStatus `json:"status"`
+}
+
+type FrameworkQuotaSpec struct {
+	// The max number of admitted and not completed Frameworks.
+	// Default to nil, i.e. unlimited.
+	MaxFrameworks *int32 `json:"maxFrameworks"`
+
+	// The max total TaskNumber of admitted and not completed Frameworks.
+	// Default to nil, i.e. unlimited.
+	MaxTasks *int32 `json:"maxTasks"`
+
+	// The max total resource requests of admitted and not completed Frameworks,
+	// such as nvidia.com/gpu.
+	// The resources which are not specified are unlimited.
+	Hard core.ResourceList `json:"hard"`
+}
+
+type FrameworkQuotaStatus struct {
+	// The number, total TaskNumber and total resource requests of admitted and
+	// not completed Frameworks in the namespace.
+	Frameworks int32             `json:"frameworks"`
+	Tasks      int32             `json:"tasks"`
+	Used       core.ResourceList `json:"used"`
+
+	// The number of not yet admitted Frameworks in the namespace.
+	WaitingFrameworks int32 `json:"waitingFrameworks"`
+
+	// The UIDs of the admitted and not completed Frameworks in the namespace.
+	AdmittedFrameworkUIDs []types.UID `json:"admittedFrameworkUIDs"`
+}
+
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+type TaskRoleScaleList struct {
+	meta.TypeMeta `json:",inline"`
+	meta.ListMeta `json:"metadata"`
+	Items         []TaskRoleScale `json:"items"`
+}
+
+// +genclient
+// +genclient:noStatus
+// +k8s:deepcopy-gen:interfaces=k8s.io/apimachinery/pkg/runtime.Object
+
+//////////////////////////////////////////////////////////////////////////////////////////////////
+// A TaskRoleScale exposes the TaskNumber of a TaskRole of a Framework in the
+// same namespace through the scale subresource, so that the TaskRole can be
+// scaled by the HorizontalPodAutoscaler and kubectl scale:
+// 1. Its Spec.Replicas is synced to the TaskRole TaskNumber, so the Framework
+//    is rescaled by the existing ScaleUp/ScaleDown path.
+// 2. Its Status.Replicas is the number of the not DeletionPending Tasks of the
+//    TaskRole in current FrameworkAttempt, and its Status.Selector selects the
+//    Pods of the TaskRole, so that the HorizontalPodAutoscaler can aggregate
+//    the Pod metrics.
+// 3. It is not synced if the Framework is already FrameworkCompleted.
+//
+// Notes:
+// 1. Status field should only be modified by FrameworkController, and
+//    other fields should not be modified by FrameworkController.
+// 2. The TaskRole TaskNumber should not be modified by others once it is
+//    referenced by a TaskRoleScale, otherwise it will be overridden.
+//////////////////////////////////////////////////////////////////////////////////////////////////
+type TaskRoleScale struct {
+	meta.TypeMeta   `json:",inline"`
+	meta.ObjectMeta `json:"metadata"`
+	Spec            TaskRoleScaleSpec    `json:"spec"`
+	Status          *TaskRoleScaleStatus `json:"status"`
+}
+
+type TaskRoleScaleSpec struct {
+	FrameworkName string `json:"frameworkName"`
+	TaskRoleName  string `json:"taskRoleName"`
+	// The desired TaskNumber of the TaskRole.
+	Replicas int32 `json:"replicas"`
+}
+
+type TaskRoleScaleStatus struct {
+	// The number of the not DeletionPending Tasks of the TaskRole in current
+	// FrameworkAttempt.
+	Replicas int32 `json:"replicas"`
+	// The label selector of the TaskRole's Pods in the serialized form.
+	Selector string `json:"selector"`
+	// The reason why the Spec.Replicas cannot be synced, such as the Framework or
+	// the TaskRole does not exist.
+	Diagnostics string `json:"diagnostics"`
+}
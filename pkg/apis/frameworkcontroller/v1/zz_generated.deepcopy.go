@@ -21,10 +21,110 @@ limitations under the License.
 package v1
 
 import (
+	corev1 "k8s.io/api/core/v1"
+	networkingv1 "k8s.io/api/networking/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
 	runtime "k8s.io/apimachinery/pkg/runtime"
 	types "k8s.io/apimachinery/pkg/types"
+	intstr "k8s.io/apimachinery/pkg/util/intstr"
 )
 
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *AntiAffinitySpec) DeepCopyInto(out *AntiAffinitySpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new AntiAffinitySpec.
+func (in *AntiAffinitySpec) DeepCopy() *AntiAffinitySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(AntiAffinitySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BackoffPolicySpec) DeepCopyInto(out *BackoffPolicySpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BackoffPolicySpec.
+func (in *BackoffPolicySpec) DeepCopy() *BackoffPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(BackoffPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *BlacklistedNode) DeepCopyInto(out *BlacklistedNode) {
+	*out = *in
+	in.LastFailedTime.DeepCopyInto(&out.LastFailedTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new BlacklistedNode.
+func (in *BlacklistedNode) DeepCopy() *BlacklistedNode {
+	if in == nil {
+		return nil
+	}
+	out := new(BlacklistedNode)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *CapacityCheckConfig) DeepCopyInto(out *CapacityCheckConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RecheckIntervalSec != nil {
+		in, out := &in.RecheckIntervalSec, &out.RecheckIntervalSec
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new CapacityCheckConfig.
+func (in *CapacityCheckConfig) DeepCopy() *CapacityCheckConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(CapacityCheckConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ColocationSpec) DeepCopyInto(out *ColocationSpec) {
+	*out = *in
+	if in.TaskRoles != nil {
+		in, out := &in.TaskRoles, &out.TaskRoles
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ColocationSpec.
+func (in *ColocationSpec) DeepCopy() *ColocationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ColocationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *CompletionCodeInfo) DeepCopyInto(out *CompletionCodeInfo) {
 	*out = *in
@@ -162,11 +262,118 @@ func (in *Config) DeepCopyInto(out *Config) {
 		*out = new(int32)
 		**out = **in
 	}
+	if in.ConfigReloadIntervalSec != nil {
+		in, out := &in.ConfigReloadIntervalSec, &out.ConfigReloadIntervalSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ShutdownDrainTimeoutSec != nil {
+		in, out := &in.ShutdownDrainTimeoutSec, &out.ShutdownDrainTimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	in.Sharding.DeepCopyInto(&out.Sharding)
 	if in.LargeFrameworkCompression != nil {
 		in, out := &in.LargeFrameworkCompression, &out.LargeFrameworkCompression
 		*out = new(bool)
 		**out = **in
 	}
+	if in.LocalCacheObjectTransform != nil {
+		in, out := &in.LocalCacheObjectTransform, &out.LocalCacheObjectTransform
+		*out = new(bool)
+		**out = **in
+	}
+	if in.DryRunEnabled != nil {
+		in, out := &in.DryRunEnabled, &out.DryRunEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FrameworkAttemptHistoryEnabled != nil {
+		in, out := &in.FrameworkAttemptHistoryEnabled, &out.FrameworkAttemptHistoryEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ScheduledFrameworkEnabled != nil {
+		in, out := &in.ScheduledFrameworkEnabled, &out.ScheduledFrameworkEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ScheduledFrameworkWorkerNumber != nil {
+		in, out := &in.ScheduledFrameworkWorkerNumber, &out.ScheduledFrameworkWorkerNumber
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FrameworkGroupEnabled != nil {
+		in, out := &in.FrameworkGroupEnabled, &out.FrameworkGroupEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FrameworkGroupWorkerNumber != nil {
+		in, out := &in.FrameworkGroupWorkerNumber, &out.FrameworkGroupWorkerNumber
+		*out = new(int32)
+		**out = **in
+	}
+	if in.QueueEnabled != nil {
+		in, out := &in.QueueEnabled, &out.QueueEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.QueueWorkerNumber != nil {
+		in, out := &in.QueueWorkerNumber, &out.QueueWorkerNumber
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FrameworkQuotaEnabled != nil {
+		in, out := &in.FrameworkQuotaEnabled, &out.FrameworkQuotaEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.FrameworkQuotaWorkerNumber != nil {
+		in, out := &in.FrameworkQuotaWorkerNumber, &out.FrameworkQuotaWorkerNumber
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TaskRoleScaleEnabled != nil {
+		in, out := &in.TaskRoleScaleEnabled, &out.TaskRoleScaleEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TaskRoleScaleWorkerNumber != nil {
+		in, out := &in.TaskRoleScaleWorkerNumber, &out.TaskRoleScaleWorkerNumber
+		*out = new(int32)
+		**out = **in
+	}
+	if in.KubeflowJobEnabled != nil {
+		in, out := &in.KubeflowJobEnabled, &out.KubeflowJobEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.KubeflowJobWorkerNumber != nil {
+		in, out := &in.KubeflowJobWorkerNumber, &out.KubeflowJobWorkerNumber
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TaskRoleHeadlessServiceEnabled != nil {
+		in, out := &in.TaskRoleHeadlessServiceEnabled, &out.TaskRoleHeadlessServiceEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.TaskHostnameEnabled != nil {
+		in, out := &in.TaskHostnameEnabled, &out.TaskHostnameEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PodReadinessGateEnabled != nil {
+		in, out := &in.PodReadinessGateEnabled, &out.PodReadinessGateEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.ClusterDomain != nil {
+		in, out := &in.ClusterDomain, &out.ClusterDomain
+		*out = new(string)
+		**out = **in
+	}
+	in.PortAllocationRange.DeepCopyInto(&out.PortAllocationRange)
 	if in.CRDEstablishedCheckIntervalSec != nil {
 		in, out := &in.CRDEstablishedCheckIntervalSec, &out.CRDEstablishedCheckIntervalSec
 		*out = new(int64)
@@ -182,11 +389,62 @@ func (in *Config) DeepCopyInto(out *Config) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.PodNodeUnmatchedTimeoutSec != nil {
+		in, out := &in.PodNodeUnmatchedTimeoutSec, &out.PodNodeUnmatchedTimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.PodEventDiagnosticsMaxCount != nil {
+		in, out := &in.PodEventDiagnosticsMaxCount, &out.PodEventDiagnosticsMaxCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.PodEventDiagnosticsMaxBytes != nil {
+		in, out := &in.PodEventDiagnosticsMaxBytes, &out.PodEventDiagnosticsMaxBytes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedContainerLogTailLines != nil {
+		in, out := &in.FailedContainerLogTailLines, &out.FailedContainerLogTailLines
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FailedContainerLogTailMaxBytes != nil {
+		in, out := &in.FailedContainerLogTailMaxBytes, &out.FailedContainerLogTailMaxBytes
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedContainerLogTailTimeoutSec != nil {
+		in, out := &in.FailedContainerLogTailTimeoutSec, &out.FailedContainerLogTailTimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
 	if in.FrameworkCompletedRetainSec != nil {
 		in, out := &in.FrameworkCompletedRetainSec, &out.FrameworkCompletedRetainSec
 		*out = new(int64)
 		**out = **in
 	}
+	if in.FrameworkSucceededRetainSec != nil {
+		in, out := &in.FrameworkSucceededRetainSec, &out.FrameworkSucceededRetainSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FrameworkFailedRetainSec != nil {
+		in, out := &in.FrameworkFailedRetainSec, &out.FrameworkFailedRetainSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FrameworkDeletionPropagationPolicy != nil {
+		in, out := &in.FrameworkDeletionPropagationPolicy, &out.FrameworkDeletionPropagationPolicy
+		*out = new(metav1.DeletionPropagation)
+		**out = **in
+	}
+	if in.ConfigMapDeletionPropagationPolicy != nil {
+		in, out := &in.ConfigMapDeletionPropagationPolicy, &out.ConfigMapDeletionPropagationPolicy
+		*out = new(metav1.DeletionPropagation)
+		**out = **in
+	}
+	in.FrameworkArchive.DeepCopyInto(&out.FrameworkArchive)
 	if in.FrameworkMinRetryDelaySecForTransientConflictFailed != nil {
 		in, out := &in.FrameworkMinRetryDelaySecForTransientConflictFailed, &out.FrameworkMinRetryDelaySecForTransientConflictFailed
 		*out = new(int64)
@@ -197,6 +455,39 @@ func (in *Config) DeepCopyInto(out *Config) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.RetryDecider != nil {
+		in, out := &in.RetryDecider, &out.RetryDecider
+		*out = new(string)
+		**out = **in
+	}
+	in.TaskRoleAutoscaler.DeepCopyInto(&out.TaskRoleAutoscaler)
+	in.GangScheduling.DeepCopyInto(&out.GangScheduling)
+	in.Kueue.DeepCopyInto(&out.Kueue)
+	in.NodeLost.DeepCopyInto(&out.NodeLost)
+	in.SpotInterruption.DeepCopyInto(&out.SpotInterruption)
+	in.CapacityCheck.DeepCopyInto(&out.CapacityCheck)
+	in.FairShare.DeepCopyInto(&out.FairShare)
+	in.EventSink.DeepCopyInto(&out.EventSink)
+	in.Tracing.DeepCopyInto(&out.Tracing)
+	in.PodDefaults.DeepCopyInto(&out.PodDefaults)
+	if in.PodDecorators != nil {
+		in, out := &in.PodDecorators, &out.PodDecorators
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.NodeBlacklist.DeepCopyInto(&out.NodeBlacklist)
+	in.FailureClassifier.DeepCopyInto(&out.FailureClassifier)
+	if in.TerminationMessageCompletionEnabled != nil {
+		in, out := &in.TerminationMessageCompletionEnabled, &out.TerminationMessageCompletionEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.PodDeviceIDAnnotationKeys != nil {
+		in, out := &in.PodDeviceIDAnnotationKeys, &out.PodDeviceIDAnnotationKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	in.FaultInjection.DeepCopyInto(&out.FaultInjection)
 	in.LogObjectSnapshot.DeepCopyInto(&out.LogObjectSnapshot)
 	if in.PodFailureSpec != nil {
 		in, out := &in.PodFailureSpec, &out.PodFailureSpec
@@ -260,82 +551,427 @@ func (in *ContainerPattern) DeepCopy() *ContainerPattern {
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Framework) DeepCopyInto(out *Framework) {
+func (in *DisruptionBudgetSpec) DeepCopyInto(out *DisruptionBudgetSpec) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
-	in.Spec.DeepCopyInto(&out.Spec)
-	if in.Status != nil {
-		in, out := &in.Status, &out.Status
-		*out = new(FrameworkStatus)
-		(*in).DeepCopyInto(*out)
+	if in.MinAvailable != nil {
+		in, out := &in.MinAvailable, &out.MinAvailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Framework.
-func (in *Framework) DeepCopy() *Framework {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new DisruptionBudgetSpec.
+func (in *DisruptionBudgetSpec) DeepCopy() *DisruptionBudgetSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(Framework)
+	out := new(DisruptionBudgetSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *Framework) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *EventSinkConfig) DeepCopyInto(out *EventSinkConfig) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(EventSinkType)
+		**out = **in
 	}
-	return nil
+	if in.NATSAddress != nil {
+		in, out := &in.NATSAddress, &out.NATSAddress
+		*out = new(string)
+		**out = **in
+	}
+	if in.NATSSubject != nil {
+		in, out := &in.NATSSubject, &out.NATSSubject
+		*out = new(string)
+		**out = **in
+	}
+	if in.KafkaRESTProxyURL != nil {
+		in, out := &in.KafkaRESTProxyURL, &out.KafkaRESTProxyURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.KafkaTopic != nil {
+		in, out := &in.KafkaTopic, &out.KafkaTopic
+		*out = new(string)
+		**out = **in
+	}
+	if in.BufferSize != nil {
+		in, out := &in.BufferSize, &out.BufferSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TimeoutSec != nil {
+		in, out := &in.TimeoutSec, &out.TimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new EventSinkConfig.
+func (in *EventSinkConfig) DeepCopy() *EventSinkConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(EventSinkConfig)
+	in.DeepCopyInto(out)
+	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FrameworkAttemptCompletionStatus) DeepCopyInto(out *FrameworkAttemptCompletionStatus) {
+func (in *ExposeIngressSpec) DeepCopyInto(out *ExposeIngressSpec) {
 	*out = *in
-	if in.CompletionStatus != nil {
-		in, out := &in.CompletionStatus, &out.CompletionStatus
-		*out = new(CompletionStatus)
-		(*in).DeepCopyInto(*out)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposeIngressSpec.
+func (in *ExposeIngressSpec) DeepCopy() *ExposeIngressSpec {
+	if in == nil {
+		return nil
 	}
-	if in.Trigger != nil {
-		in, out := &in.Trigger, &out.Trigger
-		*out = new(CompletionPolicyTriggerStatus)
+	out := new(ExposeIngressSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ExposeSpec) DeepCopyInto(out *ExposeSpec) {
+	*out = *in
+	if in.Ports != nil {
+		in, out := &in.Ports, &out.Ports
+		*out = make([]corev1.ServicePort, len(*in))
+		copy(*out, *in)
+	}
+	if in.Ingress != nil {
+		in, out := &in.Ingress, &out.Ingress
+		*out = new(ExposeIngressSpec)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkAttemptCompletionStatus.
-func (in *FrameworkAttemptCompletionStatus) DeepCopy() *FrameworkAttemptCompletionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ExposeSpec.
+func (in *ExposeSpec) DeepCopy() *ExposeSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FrameworkAttemptCompletionStatus)
+	out := new(ExposeSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FrameworkAttemptStatus) DeepCopyInto(out *FrameworkAttemptStatus) {
+func (in *FailedTaskSample) DeepCopyInto(out *FailedTaskSample) {
 	*out = *in
-	in.StartTime.DeepCopyInto(&out.StartTime)
-	if in.RunTime != nil {
-		in, out := &in.RunTime, &out.RunTime
-		*out = (*in).DeepCopy()
-	}
 	if in.CompletionTime != nil {
 		in, out := &in.CompletionTime, &out.CompletionTime
 		*out = (*in).DeepCopy()
 	}
-	if in.InstanceUID != nil {
-		in, out := &in.InstanceUID, &out.InstanceUID
-		*out = new(types.UID)
-		**out = **in
-	}
-	if in.ConfigMapUID != nil {
-		in, out := &in.ConfigMapUID, &out.ConfigMapUID
+	if in.CompletionStatus != nil {
+		in, out := &in.CompletionStatus, &out.CompletionStatus
+		*out = new(CompletionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailedTaskSample.
+func (in *FailedTaskSample) DeepCopy() *FailedTaskSample {
+	if in == nil {
+		return nil
+	}
+	out := new(FailedTaskSample)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FailureClassifierConfig) DeepCopyInto(out *FailureClassifierConfig) {
+	*out = *in
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+	if in.FailurePolicy != nil {
+		in, out := &in.FailurePolicy, &out.FailurePolicy
+		*out = new(FailureClassifierFailurePolicy)
+		**out = **in
+	}
+	if in.TimeoutSec != nil {
+		in, out := &in.TimeoutSec, &out.TimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FailureClassifierConfig.
+func (in *FailureClassifierConfig) DeepCopy() *FailureClassifierConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FailureClassifierConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FairShareConfig) DeepCopyInto(out *FairShareConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.GroupBy != nil {
+		in, out := &in.GroupBy, &out.GroupBy
+		*out = new(FairShareGroupBy)
+		**out = **in
+	}
+	if in.Weights != nil {
+		in, out := &in.Weights, &out.Weights
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FairShareConfig.
+func (in *FairShareConfig) DeepCopy() *FairShareConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FairShareConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FaultInjectionConfig) DeepCopyInto(out *FaultInjectionConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.WriteFailurePercent != nil {
+		in, out := &in.WriteFailurePercent, &out.WriteFailurePercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.WatchEventMaxDelayMs != nil {
+		in, out := &in.WatchEventMaxDelayMs, &out.WatchEventMaxDelayMs
+		*out = new(int64)
+		**out = **in
+	}
+	if in.CacheDropPercent != nil {
+		in, out := &in.CacheDropPercent, &out.CacheDropPercent
+		*out = new(int32)
+		**out = **in
+	}
+	if in.CacheDropIntervalSec != nil {
+		in, out := &in.CacheDropIntervalSec, &out.CacheDropIntervalSec
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FaultInjectionConfig.
+func (in *FaultInjectionConfig) DeepCopy() *FaultInjectionConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FaultInjectionConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Framework) DeepCopyInto(out *Framework) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(FrameworkStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Framework.
+func (in *Framework) DeepCopy() *Framework {
+	if in == nil {
+		return nil
+	}
+	out := new(Framework)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Framework) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkArchiveConfig) DeepCopyInto(out *FrameworkArchiveConfig) {
+	*out = *in
+	if in.Type != nil {
+		in, out := &in.Type, &out.Type
+		*out = new(FrameworkArchiveType)
+		**out = **in
+	}
+	if in.FileDir != nil {
+		in, out := &in.FileDir, &out.FileDir
+		*out = new(string)
+		**out = **in
+	}
+	if in.HTTPURL != nil {
+		in, out := &in.HTTPURL, &out.HTTPURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.AzureBlobContainerSASURL != nil {
+		in, out := &in.AzureBlobContainerSASURL, &out.AzureBlobContainerSASURL
+		*out = new(string)
+		**out = **in
+	}
+	if in.TimeoutSec != nil {
+		in, out := &in.TimeoutSec, &out.TimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkArchiveConfig.
+func (in *FrameworkArchiveConfig) DeepCopy() *FrameworkArchiveConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkArchiveConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkAttemptCompletionStatus) DeepCopyInto(out *FrameworkAttemptCompletionStatus) {
+	*out = *in
+	if in.CompletionStatus != nil {
+		in, out := &in.CompletionStatus, &out.CompletionStatus
+		*out = new(CompletionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Trigger != nil {
+		in, out := &in.Trigger, &out.Trigger
+		*out = new(CompletionPolicyTriggerStatus)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkAttemptCompletionStatus.
+func (in *FrameworkAttemptCompletionStatus) DeepCopy() *FrameworkAttemptCompletionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkAttemptCompletionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkAttemptHistory) DeepCopyInto(out *FrameworkAttemptHistory) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.AttemptStatus.DeepCopyInto(&out.AttemptStatus)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkAttemptHistory.
+func (in *FrameworkAttemptHistory) DeepCopy() *FrameworkAttemptHistory {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkAttemptHistory)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FrameworkAttemptHistory) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkAttemptHistoryList) DeepCopyInto(out *FrameworkAttemptHistoryList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FrameworkAttemptHistory, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkAttemptHistoryList.
+func (in *FrameworkAttemptHistoryList) DeepCopy() *FrameworkAttemptHistoryList {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkAttemptHistoryList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FrameworkAttemptHistoryList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkAttemptHistoryStatus) DeepCopyInto(out *FrameworkAttemptHistoryStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.RunTime != nil {
+		in, out := &in.RunTime, &out.RunTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.InstanceUID != nil {
+		in, out := &in.InstanceUID, &out.InstanceUID
 		*out = new(types.UID)
 		**out = **in
 	}
@@ -346,338 +982,2235 @@ func (in *FrameworkAttemptStatus) DeepCopyInto(out *FrameworkAttemptStatus) {
 	}
 	if in.TaskRoleStatuses != nil {
 		in, out := &in.TaskRoleStatuses, &out.TaskRoleStatuses
-		*out = make([]*TaskRoleStatus, len(*in))
+		*out = make([]*TaskRoleHistoryStatus, len(*in))
 		for i := range *in {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
-				*out = new(TaskRoleStatus)
+				*out = new(TaskRoleHistoryStatus)
 				(*in).DeepCopyInto(*out)
 			}
 		}
 	}
-	if in.TaskRoleStatusesCompressed != nil {
-		in, out := &in.TaskRoleStatusesCompressed, &out.TaskRoleStatusesCompressed
-		*out = make([]byte, len(*in))
-		copy(*out, *in)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkAttemptHistoryStatus.
+func (in *FrameworkAttemptHistoryStatus) DeepCopy() *FrameworkAttemptHistoryStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkAttemptHistoryStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkAttemptStatus) DeepCopyInto(out *FrameworkAttemptStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.RunTime != nil {
+		in, out := &in.RunTime, &out.RunTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
 	}
+	if in.InstanceUID != nil {
+		in, out := &in.InstanceUID, &out.InstanceUID
+		*out = new(types.UID)
+		**out = **in
+	}
+	if in.ConfigMapUID != nil {
+		in, out := &in.ConfigMapUID, &out.ConfigMapUID
+		*out = new(types.UID)
+		**out = **in
+	}
+	if in.PodGroupUID != nil {
+		in, out := &in.PodGroupUID, &out.PodGroupUID
+		*out = new(types.UID)
+		**out = **in
+	}
+	if in.KueueWorkloadUID != nil {
+		in, out := &in.KueueWorkloadUID, &out.KueueWorkloadUID
+		*out = new(types.UID)
+		**out = **in
+	}
+	if in.SSHSecretUID != nil {
+		in, out := &in.SSHSecretUID, &out.SSHSecretUID
+		*out = new(types.UID)
+		**out = **in
+	}
+	if in.NetworkPolicyUID != nil {
+		in, out := &in.NetworkPolicyUID, &out.NetworkPolicyUID
+		*out = new(types.UID)
+		**out = **in
+	}
+	if in.LastAutoscaleTime != nil {
+		in, out := &in.LastAutoscaleTime, &out.LastAutoscaleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.PreAttemptHookStatus != nil {
+		in, out := &in.PreAttemptHookStatus, &out.PreAttemptHookStatus
+		*out = new(HookStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostAttemptHookStatus != nil {
+		in, out := &in.PostAttemptHookStatus, &out.PostAttemptHookStatus
+		*out = new(HookStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CompletionStatus != nil {
+		in, out := &in.CompletionStatus, &out.CompletionStatus
+		*out = new(FrameworkAttemptCompletionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.TaskRoleStatuses != nil {
+		in, out := &in.TaskRoleStatuses, &out.TaskRoleStatuses
+		*out = make([]*TaskRoleStatus, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(TaskRoleStatus)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.TaskRoleStatusesCompressed != nil {
+		in, out := &in.TaskRoleStatusesCompressed, &out.TaskRoleStatusesCompressed
+		*out = make([]byte, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkAttemptStatus.
+func (in *FrameworkAttemptStatus) DeepCopy() *FrameworkAttemptStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkAttemptStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkCondition) DeepCopyInto(out *FrameworkCondition) {
+	*out = *in
+	in.LastTransitionTime.DeepCopyInto(&out.LastTransitionTime)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkCondition.
+func (in *FrameworkCondition) DeepCopy() *FrameworkCondition {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkCondition)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkDependency) DeepCopyInto(out *FrameworkDependency) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkDependency.
+func (in *FrameworkDependency) DeepCopy() *FrameworkDependency {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkDependency)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkGroup) DeepCopyInto(out *FrameworkGroup) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(FrameworkGroupStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkGroup.
+func (in *FrameworkGroup) DeepCopy() *FrameworkGroup {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkGroup)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FrameworkGroup) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkGroupList) DeepCopyInto(out *FrameworkGroupList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FrameworkGroup, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkGroupList.
+func (in *FrameworkGroupList) DeepCopy() *FrameworkGroupList {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkGroupList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FrameworkGroupList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkGroupMemberSpec) DeepCopyInto(out *FrameworkGroupMemberSpec) {
+	*out = *in
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]FrameworkDependency, len(*in))
+		copy(*out, *in)
+	}
+	in.FrameworkTemplate.DeepCopyInto(&out.FrameworkTemplate)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkGroupMemberSpec.
+func (in *FrameworkGroupMemberSpec) DeepCopy() *FrameworkGroupMemberSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkGroupMemberSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkGroupMemberStatus) DeepCopyInto(out *FrameworkGroupMemberStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkGroupMemberStatus.
+func (in *FrameworkGroupMemberStatus) DeepCopy() *FrameworkGroupMemberStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkGroupMemberStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkGroupSpec) DeepCopyInto(out *FrameworkGroupSpec) {
+	*out = *in
+	if in.Members != nil {
+		in, out := &in.Members, &out.Members
+		*out = make([]FrameworkGroupMemberSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkGroupSpec.
+func (in *FrameworkGroupSpec) DeepCopy() *FrameworkGroupSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkGroupSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkGroupStatus) DeepCopyInto(out *FrameworkGroupStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.MemberStatuses != nil {
+		in, out := &in.MemberStatuses, &out.MemberStatuses
+		*out = make([]FrameworkGroupMemberStatus, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkGroupStatus.
+func (in *FrameworkGroupStatus) DeepCopy() *FrameworkGroupStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkGroupStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkHooksSpec) DeepCopyInto(out *FrameworkHooksSpec) {
+	*out = *in
+	if in.PreAttempt != nil {
+		in, out := &in.PreAttempt, &out.PreAttempt
+		*out = new(HookSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PostAttempt != nil {
+		in, out := &in.PostAttempt, &out.PostAttempt
+		*out = new(HookSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkHooksSpec.
+func (in *FrameworkHooksSpec) DeepCopy() *FrameworkHooksSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkHooksSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkList) DeepCopyInto(out *FrameworkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Framework, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkList.
+func (in *FrameworkList) DeepCopy() *FrameworkList {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FrameworkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkProgress) DeepCopyInto(out *FrameworkProgress) {
+	*out = *in
+	if in.TaskRoles != nil {
+		in, out := &in.TaskRoles, &out.TaskRoles
+		*out = make([]*TaskRoleProgress, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(TaskRoleProgress)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkProgress.
+func (in *FrameworkProgress) DeepCopy() *FrameworkProgress {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkProgress)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkQuota) DeepCopyInto(out *FrameworkQuota) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(FrameworkQuotaStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkQuota.
+func (in *FrameworkQuota) DeepCopy() *FrameworkQuota {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkQuota)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FrameworkQuota) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkQuotaList) DeepCopyInto(out *FrameworkQuotaList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]FrameworkQuota, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkQuotaList.
+func (in *FrameworkQuotaList) DeepCopy() *FrameworkQuotaList {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkQuotaList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *FrameworkQuotaList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkQuotaSpec) DeepCopyInto(out *FrameworkQuotaSpec) {
+	*out = *in
+	if in.MaxFrameworks != nil {
+		in, out := &in.MaxFrameworks, &out.MaxFrameworks
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxTasks != nil {
+		in, out := &in.MaxTasks, &out.MaxTasks
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Hard != nil {
+		in, out := &in.Hard, &out.Hard
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkQuotaSpec.
+func (in *FrameworkQuotaSpec) DeepCopy() *FrameworkQuotaSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkQuotaSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkQuotaStatus) DeepCopyInto(out *FrameworkQuotaStatus) {
+	*out = *in
+	if in.Used != nil {
+		in, out := &in.Used, &out.Used
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.AdmittedFrameworkUIDs != nil {
+		in, out := &in.AdmittedFrameworkUIDs, &out.AdmittedFrameworkUIDs
+		*out = make([]types.UID, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkQuotaStatus.
+func (in *FrameworkQuotaStatus) DeepCopy() *FrameworkQuotaStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkQuotaStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkSpec) DeepCopyInto(out *FrameworkSpec) {
+	*out = *in
+	in.RetryPolicy.DeepCopyInto(&out.RetryPolicy)
+	if in.TaskRoles != nil {
+		in, out := &in.TaskRoles, &out.TaskRoles
+		*out = make([]*TaskRoleSpec, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(TaskRoleSpec)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.NetworkPolicy != nil {
+		in, out := &in.NetworkPolicy, &out.NetworkPolicy
+		*out = new(NetworkPolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Hooks != nil {
+		in, out := &in.Hooks, &out.Hooks
+		*out = new(FrameworkHooksSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Colocation != nil {
+		in, out := &in.Colocation, &out.Colocation
+		*out = new(ColocationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.RetryBudget != nil {
+		in, out := &in.RetryBudget, &out.RetryBudget
+		*out = new(RetryBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.AttemptSchedulingTimeoutSec != nil {
+		in, out := &in.AttemptSchedulingTimeoutSec, &out.AttemptSchedulingTimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SucceededRetainSec != nil {
+		in, out := &in.SucceededRetainSec, &out.SucceededRetainSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FailedRetainSec != nil {
+		in, out := &in.FailedRetainSec, &out.FailedRetainSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FrameworkDeletionPropagationPolicy != nil {
+		in, out := &in.FrameworkDeletionPropagationPolicy, &out.FrameworkDeletionPropagationPolicy
+		*out = new(metav1.DeletionPropagation)
+		**out = **in
+	}
+	if in.ConfigMapDeletionPropagationPolicy != nil {
+		in, out := &in.ConfigMapDeletionPropagationPolicy, &out.ConfigMapDeletionPropagationPolicy
+		*out = new(metav1.DeletionPropagation)
+		**out = **in
+	}
+	if in.RestartAttemptID != nil {
+		in, out := &in.RestartAttemptID, &out.RestartAttemptID
+		*out = new(int32)
+		**out = **in
+	}
+	if in.DebugRetainFailedPodSec != nil {
+		in, out := &in.DebugRetainFailedPodSec, &out.DebugRetainFailedPodSec
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkSpec.
+func (in *FrameworkSpec) DeepCopy() *FrameworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkStatus) DeepCopyInto(out *FrameworkStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	in.TransitionTime.DeepCopyInto(&out.TransitionTime)
+	in.RetryPolicyStatus.DeepCopyInto(&out.RetryPolicyStatus)
+	in.AttemptStatus.DeepCopyInto(&out.AttemptStatus)
+	if in.Progress != nil {
+		in, out := &in.Progress, &out.Progress
+		*out = new(FrameworkProgress)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.ObservedSpecSummary != nil {
+		in, out := &in.ObservedSpecSummary, &out.ObservedSpecSummary
+		*out = new(SpecSummary)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SpecChangeHistory != nil {
+		in, out := &in.SpecChangeHistory, &out.SpecChangeHistory
+		*out = make([]*SpecChangeRecord, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(SpecChangeRecord)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.BlacklistedNodes != nil {
+		in, out := &in.BlacklistedNodes, &out.BlacklistedNodes
+		*out = make([]*BlacklistedNode, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(BlacklistedNode)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	if in.Conditions != nil {
+		in, out := &in.Conditions, &out.Conditions
+		*out = make([]FrameworkCondition, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkStatus.
+func (in *FrameworkStatus) DeepCopy() *FrameworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *FrameworkTemplateSpec) DeepCopyInto(out *FrameworkTemplateSpec) {
+	*out = *in
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkTemplateSpec.
+func (in *FrameworkTemplateSpec) DeepCopy() *FrameworkTemplateSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(FrameworkTemplateSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *GangSchedulingConfig) DeepCopyInto(out *GangSchedulingConfig) {
+	*out = *in
+	if in.PodGroupEnabled != nil {
+		in, out := &in.PodGroupEnabled, &out.PodGroupEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.SchedulerName != nil {
+		in, out := &in.SchedulerName, &out.SchedulerName
+		*out = new(string)
+		**out = **in
+	}
+	if in.ScheduleTimeoutSec != nil {
+		in, out := &in.ScheduleTimeoutSec, &out.ScheduleTimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.VolcanoEnabled != nil {
+		in, out := &in.VolcanoEnabled, &out.VolcanoEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.VolcanoSchedulerName != nil {
+		in, out := &in.VolcanoSchedulerName, &out.VolcanoSchedulerName
+		*out = new(string)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new GangSchedulingConfig.
+func (in *GangSchedulingConfig) DeepCopy() *GangSchedulingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(GangSchedulingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HiveDSpec) DeepCopyInto(out *HiveDSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HiveDSpec.
+func (in *HiveDSpec) DeepCopy() *HiveDSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HiveDSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookSpec) DeepCopyInto(out *HookSpec) {
+	*out = *in
+	if in.Pod != nil {
+		in, out := &in.Pod, &out.Pod
+		*out = new(corev1.PodTemplateSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Webhook != nil {
+		in, out := &in.Webhook, &out.Webhook
+		*out = new(HookWebhookSpec)
+		**out = **in
+	}
+	if in.TimeoutSec != nil {
+		in, out := &in.TimeoutSec, &out.TimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookSpec.
+func (in *HookSpec) DeepCopy() *HookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookStatus) DeepCopyInto(out *HookStatus) {
+	*out = *in
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.PodName != nil {
+		in, out := &in.PodName, &out.PodName
+		*out = new(string)
+		**out = **in
+	}
+	if in.PodUID != nil {
+		in, out := &in.PodUID, &out.PodUID
+		*out = new(types.UID)
+		**out = **in
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookStatus.
+func (in *HookStatus) DeepCopy() *HookStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(HookStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HookWebhookSpec) DeepCopyInto(out *HookWebhookSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HookWebhookSpec.
+func (in *HookWebhookSpec) DeepCopy() *HookWebhookSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HookWebhookSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *HostfileSpec) DeepCopyInto(out *HostfileSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new HostfileSpec.
+func (in *HostfileSpec) DeepCopy() *HostfileSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(HostfileSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ImageRegistryRewriteConfig) DeepCopyInto(out *ImageRegistryRewriteConfig) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ImageRegistryRewriteConfig.
+func (in *ImageRegistryRewriteConfig) DeepCopy() *ImageRegistryRewriteConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(ImageRegistryRewriteConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Int32Range) DeepCopyInto(out *Int32Range) {
+	*out = *in
+	if in.Min != nil {
+		in, out := &in.Min, &out.Min
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Max != nil {
+		in, out := &in.Max, &out.Max
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Int32Range.
+func (in *Int32Range) DeepCopy() *Int32Range {
+	if in == nil {
+		return nil
+	}
+	out := new(Int32Range)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *KueueConfig) DeepCopyInto(out *KueueConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new KueueConfig.
+func (in *KueueConfig) DeepCopy() *KueueConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(KueueConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogFrameworkSnapshot) DeepCopyInto(out *LogFrameworkSnapshot) {
+	*out = *in
+	if in.OnTaskRetry != nil {
+		in, out := &in.OnTaskRetry, &out.OnTaskRetry
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OnFrameworkRetry != nil {
+		in, out := &in.OnFrameworkRetry, &out.OnFrameworkRetry
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OnFrameworkRescale != nil {
+		in, out := &in.OnFrameworkRescale, &out.OnFrameworkRescale
+		*out = new(bool)
+		**out = **in
+	}
+	if in.OnFrameworkDeletion != nil {
+		in, out := &in.OnFrameworkDeletion, &out.OnFrameworkDeletion
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogFrameworkSnapshot.
+func (in *LogFrameworkSnapshot) DeepCopy() *LogFrameworkSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(LogFrameworkSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogObjectSnapshot) DeepCopyInto(out *LogObjectSnapshot) {
+	*out = *in
+	in.Framework.DeepCopyInto(&out.Framework)
+	in.Pod.DeepCopyInto(&out.Pod)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogObjectSnapshot.
+func (in *LogObjectSnapshot) DeepCopy() *LogObjectSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(LogObjectSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *LogPodSnapshot) DeepCopyInto(out *LogPodSnapshot) {
+	*out = *in
+	if in.OnPodDeletion != nil {
+		in, out := &in.OnPodDeletion, &out.OnPodDeletion
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogPodSnapshot.
+func (in *LogPodSnapshot) DeepCopy() *LogPodSnapshot {
+	if in == nil {
+		return nil
+	}
+	out := new(LogPodSnapshot)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchedContainer) DeepCopyInto(out *MatchedContainer) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Code != nil {
+		in, out := &in.Code, &out.Code
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchedContainer.
+func (in *MatchedContainer) DeepCopy() *MatchedContainer {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchedContainer)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MatchedPod) DeepCopyInto(out *MatchedPod) {
+	*out = *in
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]*MatchedContainer, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(MatchedContainer)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchedPod.
+func (in *MatchedPod) DeepCopy() *MatchedPod {
+	if in == nil {
+		return nil
+	}
+	out := new(MatchedPod)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryEscalationSpec) DeepCopyInto(out *MemoryEscalationSpec) {
+	*out = *in
+	if in.MaxMemory != nil {
+		in, out := &in.MaxMemory, &out.MaxMemory
+		x := (*in).DeepCopy()
+		*out = &x
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryEscalationSpec.
+func (in *MemoryEscalationSpec) DeepCopy() *MemoryEscalationSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryEscalationSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *MemoryEscalationStatus) DeepCopyInto(out *MemoryEscalationStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MemoryEscalationStatus.
+func (in *MemoryEscalationStatus) DeepCopy() *MemoryEscalationStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(MemoryEscalationStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NetworkPolicySpec) DeepCopyInto(out *NetworkPolicySpec) {
+	*out = *in
+	if in.ExtraPeers != nil {
+		in, out := &in.ExtraPeers, &out.ExtraPeers
+		*out = make([]networkingv1.NetworkPolicyPeer, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NetworkPolicySpec.
+func (in *NetworkPolicySpec) DeepCopy() *NetworkPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(NetworkPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeBlacklistConfig) DeepCopyInto(out *NodeBlacklistConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MaxNodeCount != nil {
+		in, out := &in.MaxNodeCount, &out.MaxNodeCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.TTLSec != nil {
+		in, out := &in.TTLSec, &out.TTLSec
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeBlacklistConfig.
+func (in *NodeBlacklistConfig) DeepCopy() *NodeBlacklistConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeBlacklistConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *NodeLostConfig) DeepCopyInto(out *NodeLostConfig) {
+	*out = *in
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.NotReadyGraceSec != nil {
+		in, out := &in.NotReadyGraceSec, &out.NotReadyGraceSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ForceDeletePod != nil {
+		in, out := &in.ForceDeletePod, &out.ForceDeletePod
+		*out = new(bool)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new NodeLostConfig.
+func (in *NodeLostConfig) DeepCopy() *NodeLostConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(NodeLostConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodAllocatedDevices) DeepCopyInto(out *PodAllocatedDevices) {
+	*out = *in
+	if in.Resources != nil {
+		in, out := &in.Resources, &out.Resources
+		*out = make(map[corev1.ResourceName]int64, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
+	}
+	if in.DeviceIDs != nil {
+		in, out := &in.DeviceIDs, &out.DeviceIDs
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodAllocatedDevices.
+func (in *PodAllocatedDevices) DeepCopy() *PodAllocatedDevices {
+	if in == nil {
+		return nil
+	}
+	out := new(PodAllocatedDevices)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodCompletionStatus) DeepCopyInto(out *PodCompletionStatus) {
+	*out = *in
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]*ContainerCompletionStatus, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ContainerCompletionStatus)
+				**out = **in
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCompletionStatus.
+func (in *PodCompletionStatus) DeepCopy() *PodCompletionStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodCompletionStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodDefaultsConfig) DeepCopyInto(out *PodDefaultsConfig) {
+	*out = *in
+	if in.SchedulerName != nil {
+		in, out := &in.SchedulerName, &out.SchedulerName
+		*out = new(string)
+		**out = **in
+	}
+	if in.RuntimeClassName != nil {
+		in, out := &in.RuntimeClassName, &out.RuntimeClassName
+		*out = new(string)
+		**out = **in
+	}
+	if in.SeccompProfile != nil {
+		in, out := &in.SeccompProfile, &out.SeccompProfile
+		*out = new(string)
+		**out = **in
+	}
+	in.SecurityContext.DeepCopyInto(&out.SecurityContext)
+	if in.ImagePullSecrets != nil {
+		in, out := &in.ImagePullSecrets, &out.ImagePullSecrets
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	if in.ImageRegistryRewrites != nil {
+		in, out := &in.ImageRegistryRewrites, &out.ImageRegistryRewrites
+		*out = make([]ImageRegistryRewriteConfig, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodDefaultsConfig.
+func (in *PodDefaultsConfig) DeepCopy() *PodDefaultsConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodDefaultsConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodFailurePolicyOnExitCodesRequirement) DeepCopyInto(out *PodFailurePolicyOnExitCodesRequirement) {
+	*out = *in
+	if in.Values != nil {
+		in, out := &in.Values, &out.Values
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodFailurePolicyOnExitCodesRequirement.
+func (in *PodFailurePolicyOnExitCodesRequirement) DeepCopy() *PodFailurePolicyOnExitCodesRequirement {
+	if in == nil {
+		return nil
+	}
+	out := new(PodFailurePolicyOnExitCodesRequirement)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodFailurePolicyOnPodConditionsPattern) DeepCopyInto(out *PodFailurePolicyOnPodConditionsPattern) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodFailurePolicyOnPodConditionsPattern.
+func (in *PodFailurePolicyOnPodConditionsPattern) DeepCopy() *PodFailurePolicyOnPodConditionsPattern {
+	if in == nil {
+		return nil
+	}
+	out := new(PodFailurePolicyOnPodConditionsPattern)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodFailurePolicyRule) DeepCopyInto(out *PodFailurePolicyRule) {
+	*out = *in
+	if in.OnExitCodes != nil {
+		in, out := &in.OnExitCodes, &out.OnExitCodes
+		*out = new(PodFailurePolicyOnExitCodesRequirement)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.OnPodConditions != nil {
+		in, out := &in.OnPodConditions, &out.OnPodConditions
+		*out = make([]PodFailurePolicyOnPodConditionsPattern, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodFailurePolicyRule.
+func (in *PodFailurePolicyRule) DeepCopy() *PodFailurePolicyRule {
+	if in == nil {
+		return nil
+	}
+	out := new(PodFailurePolicyRule)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodFailurePolicySpec) DeepCopyInto(out *PodFailurePolicySpec) {
+	*out = *in
+	if in.Rules != nil {
+		in, out := &in.Rules, &out.Rules
+		*out = make([]PodFailurePolicyRule, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodFailurePolicySpec.
+func (in *PodFailurePolicySpec) DeepCopy() *PodFailurePolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(PodFailurePolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodFailurePolicyStatus) DeepCopyInto(out *PodFailurePolicyStatus) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodFailurePolicyStatus.
+func (in *PodFailurePolicyStatus) DeepCopy() *PodFailurePolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(PodFailurePolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodMatchResult) DeepCopyInto(out *PodMatchResult) {
+	*out = *in
+	if in.CodeInfo != nil {
+		in, out := &in.CodeInfo, &out.CodeInfo
+		*out = new(CompletionCodeInfo)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMatchResult.
+func (in *PodMatchResult) DeepCopy() *PodMatchResult {
+	if in == nil {
+		return nil
+	}
+	out := new(PodMatchResult)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodPattern) DeepCopyInto(out *PodPattern) {
+	*out = *in
+	in.NameRegex.DeepCopyInto(&out.NameRegex)
+	in.ReasonRegex.DeepCopyInto(&out.ReasonRegex)
+	in.MessageRegex.DeepCopyInto(&out.MessageRegex)
+	in.DisruptionReasonRegex.DeepCopyInto(&out.DisruptionReasonRegex)
+	if in.Containers != nil {
+		in, out := &in.Containers, &out.Containers
+		*out = make([]*ContainerPattern, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(ContainerPattern)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodPattern.
+func (in *PodPattern) DeepCopy() *PodPattern {
+	if in == nil {
+		return nil
+	}
+	out := new(PodPattern)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PodSecurityContextConfig) DeepCopyInto(out *PodSecurityContextConfig) {
+	*out = *in
+	if in.RunAsNonRoot != nil {
+		in, out := &in.RunAsNonRoot, &out.RunAsNonRoot
+		*out = new(bool)
+		**out = **in
+	}
+	if in.RunAsUser != nil {
+		in, out := &in.RunAsUser, &out.RunAsUser
+		*out = new(int64)
+		**out = **in
+	}
+	if in.RunAsGroup != nil {
+		in, out := &in.RunAsGroup, &out.RunAsGroup
+		*out = new(int64)
+		**out = **in
+	}
+	if in.FSGroup != nil {
+		in, out := &in.FSGroup, &out.FSGroup
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodSecurityContextConfig.
+func (in *PodSecurityContextConfig) DeepCopy() *PodSecurityContextConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(PodSecurityContextConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *PortRange) DeepCopyInto(out *PortRange) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PortRange.
+func (in *PortRange) DeepCopy() *PortRange {
+	if in == nil {
+		return nil
+	}
+	out := new(PortRange)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *Queue) DeepCopyInto(out *Queue) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(QueueStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Queue.
+func (in *Queue) DeepCopy() *Queue {
+	if in == nil {
+		return nil
+	}
+	out := new(Queue)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *Queue) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueueList) DeepCopyInto(out *QueueList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]Queue, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueueList.
+func (in *QueueList) DeepCopy() *QueueList {
+	if in == nil {
+		return nil
+	}
+	out := new(QueueList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *QueueList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueueSpec) DeepCopyInto(out *QueueSpec) {
+	*out = *in
+	if in.MaxRunningFrameworks != nil {
+		in, out := &in.MaxRunningFrameworks, &out.MaxRunningFrameworks
+		*out = new(int32)
+		**out = **in
+	}
+	if in.Capacity != nil {
+		in, out := &in.Capacity, &out.Capacity
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueueSpec.
+func (in *QueueSpec) DeepCopy() *QueueSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(QueueSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *QueueStatus) DeepCopyInto(out *QueueStatus) {
+	*out = *in
+	if in.Allocated != nil {
+		in, out := &in.Allocated, &out.Allocated
+		*out = make(corev1.ResourceList, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val.DeepCopy()
+		}
+	}
+	if in.AdmittedFrameworkUIDs != nil {
+		in, out := &in.AdmittedFrameworkUIDs, &out.AdmittedFrameworkUIDs
+		*out = make([]types.UID, len(*in))
+		copy(*out, *in)
+	}
+	if in.PreemptingFrameworkUIDs != nil {
+		in, out := &in.PreemptingFrameworkUIDs, &out.PreemptingFrameworkUIDs
+		*out = make([]types.UID, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new QueueStatus.
+func (in *QueueStatus) DeepCopy() *QueueStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(QueueStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Regex.
+func (in *Regex) DeepCopy() *Regex {
+	if in == nil {
+		return nil
+	}
+	out := new(Regex)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryBudgetSpec) DeepCopyInto(out *RetryBudgetSpec) {
+	*out = *in
+	if in.MaxTaskRetryCount != nil {
+		in, out := &in.MaxTaskRetryCount, &out.MaxTaskRetryCount
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MaxRetriedTaskPercent != nil {
+		in, out := &in.MaxRetriedTaskPercent, &out.MaxRetriedTaskPercent
+		*out = new(int32)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryBudgetSpec.
+func (in *RetryBudgetSpec) DeepCopy() *RetryBudgetSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryBudgetSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryDecision) DeepCopyInto(out *RetryDecision) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryDecision.
+func (in *RetryDecision) DeepCopy() *RetryDecision {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryDecision)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryOverrideSpec) DeepCopyInto(out *RetryOverrideSpec) {
+	*out = *in
+	if in.MinCode != nil {
+		in, out := &in.MinCode, &out.MinCode
+		*out = new(CompletionCode)
+		**out = **in
+	}
+	if in.MaxCode != nil {
+		in, out := &in.MaxCode, &out.MaxCode
+		*out = new(CompletionCode)
+		**out = **in
+	}
+	if in.TypeAttributes != nil {
+		in, out := &in.TypeAttributes, &out.TypeAttributes
+		*out = make([]CompletionTypeAttribute, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryOverrideSpec.
+func (in *RetryOverrideSpec) DeepCopy() *RetryOverrideSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryOverrideSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicySpec) DeepCopyInto(out *RetryPolicySpec) {
+	*out = *in
+	if in.BackoffPolicy != nil {
+		in, out := &in.BackoffPolicy, &out.BackoffPolicy
+		*out = new(BackoffPolicySpec)
+		**out = **in
+	}
+	if in.CompletionOverrides != nil {
+		in, out := &in.CompletionOverrides, &out.CompletionOverrides
+		*out = make([]RetryOverrideSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicySpec.
+func (in *RetryPolicySpec) DeepCopy() *RetryPolicySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *RetryPolicyStatus) DeepCopyInto(out *RetryPolicyStatus) {
+	*out = *in
+	if in.RetryDelaySec != nil {
+		in, out := &in.RetryDelaySec, &out.RetryDelaySec
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicyStatus.
+func (in *RetryPolicyStatus) DeepCopy() *RetryPolicyStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(RetryPolicyStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SSHKeySpec) DeepCopyInto(out *SSHKeySpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SSHKeySpec.
+func (in *SSHKeySpec) DeepCopy() *SSHKeySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(SSHKeySpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledFramework) DeepCopyInto(out *ScheduledFramework) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	in.Spec.DeepCopyInto(&out.Spec)
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(ScheduledFrameworkStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledFramework.
+func (in *ScheduledFramework) DeepCopy() *ScheduledFramework {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledFramework)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScheduledFramework) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledFrameworkList) DeepCopyInto(out *ScheduledFrameworkList) {
+	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]ScheduledFramework, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledFrameworkList.
+func (in *ScheduledFrameworkList) DeepCopy() *ScheduledFrameworkList {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledFrameworkList)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *ScheduledFrameworkList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledFrameworkSpec) DeepCopyInto(out *ScheduledFrameworkSpec) {
+	*out = *in
+	if in.TimeZone != nil {
+		in, out := &in.TimeZone, &out.TimeZone
+		*out = new(string)
+		**out = **in
+	}
+	if in.StartingDeadlineSec != nil {
+		in, out := &in.StartingDeadlineSec, &out.StartingDeadlineSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.SucceededFrameworksHistoryLimit != nil {
+		in, out := &in.SucceededFrameworksHistoryLimit, &out.SucceededFrameworksHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	if in.FailedFrameworksHistoryLimit != nil {
+		in, out := &in.FailedFrameworksHistoryLimit, &out.FailedFrameworksHistoryLimit
+		*out = new(int32)
+		**out = **in
+	}
+	in.FrameworkTemplate.DeepCopyInto(&out.FrameworkTemplate)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledFrameworkSpec.
+func (in *ScheduledFrameworkSpec) DeepCopy() *ScheduledFrameworkSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledFrameworkSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *ScheduledFrameworkStatus) DeepCopyInto(out *ScheduledFrameworkStatus) {
+	*out = *in
+	if in.LastScheduleTime != nil {
+		in, out := &in.LastScheduleTime, &out.LastScheduleTime
+		*out = (*in).DeepCopy()
+	}
+	if in.ActiveFrameworks != nil {
+		in, out := &in.ActiveFrameworks, &out.ActiveFrameworks
+		*out = make([]string, len(*in))
+		copy(*out, *in)
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ScheduledFrameworkStatus.
+func (in *ScheduledFrameworkStatus) DeepCopy() *ScheduledFrameworkStatus {
+	if in == nil {
+		return nil
+	}
+	out := new(ScheduledFrameworkStatus)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *SecretRefSpec) DeepCopyInto(out *SecretRefSpec) {
+	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkAttemptStatus.
-func (in *FrameworkAttemptStatus) DeepCopy() *FrameworkAttemptStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SecretRefSpec.
+func (in *SecretRefSpec) DeepCopy() *SecretRefSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FrameworkAttemptStatus)
+	out := new(SecretRefSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FrameworkList) DeepCopyInto(out *FrameworkList) {
+func (in *ShardingConfig) DeepCopyInto(out *ShardingConfig) {
 	*out = *in
-	out.TypeMeta = in.TypeMeta
-	out.ListMeta = in.ListMeta
-	if in.Items != nil {
-		in, out := &in.Items, &out.Items
-		*out = make([]Framework, len(*in))
-		for i := range *in {
-			(*in)[i].DeepCopyInto(&(*out)[i])
-		}
+	if in.ShardNumber != nil {
+		in, out := &in.ShardNumber, &out.ShardNumber
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ShardIndex != nil {
+		in, out := &in.ShardIndex, &out.ShardIndex
+		*out = new(int32)
+		**out = **in
+	}
+	if in.MembershipEnabled != nil {
+		in, out := &in.MembershipEnabled, &out.MembershipEnabled
+		*out = new(bool)
+		**out = **in
+	}
+	if in.MembershipNamespace != nil {
+		in, out := &in.MembershipNamespace, &out.MembershipNamespace
+		*out = new(string)
+		**out = **in
+	}
+	if in.MemberID != nil {
+		in, out := &in.MemberID, &out.MemberID
+		*out = new(string)
+		**out = **in
+	}
+	if in.MemberLeaseDurationSec != nil {
+		in, out := &in.MemberLeaseDurationSec, &out.MemberLeaseDurationSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.MemberRenewIntervalSec != nil {
+		in, out := &in.MemberRenewIntervalSec, &out.MemberRenewIntervalSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ShardLabelKey != nil {
+		in, out := &in.ShardLabelKey, &out.ShardLabelKey
+		*out = new(string)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkList.
-func (in *FrameworkList) DeepCopy() *FrameworkList {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new ShardingConfig.
+func (in *ShardingConfig) DeepCopy() *ShardingConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(FrameworkList)
+	out := new(ShardingConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
-func (in *FrameworkList) DeepCopyObject() runtime.Object {
-	if c := in.DeepCopy(); c != nil {
-		return c
-	}
-	return nil
-}
-
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FrameworkSpec) DeepCopyInto(out *FrameworkSpec) {
+func (in *SidecarQuitSpec) DeepCopyInto(out *SidecarQuitSpec) {
 	*out = *in
-	out.RetryPolicy = in.RetryPolicy
-	if in.TaskRoles != nil {
-		in, out := &in.TaskRoles, &out.TaskRoles
-		*out = make([]*TaskRoleSpec, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(TaskRoleSpec)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkSpec.
-func (in *FrameworkSpec) DeepCopy() *FrameworkSpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SidecarQuitSpec.
+func (in *SidecarQuitSpec) DeepCopy() *SidecarQuitSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(FrameworkSpec)
+	out := new(SidecarQuitSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *FrameworkStatus) DeepCopyInto(out *FrameworkStatus) {
+func (in *SpecChangeRecord) DeepCopyInto(out *SpecChangeRecord) {
 	*out = *in
-	in.StartTime.DeepCopyInto(&out.StartTime)
-	if in.CompletionTime != nil {
-		in, out := &in.CompletionTime, &out.CompletionTime
-		*out = (*in).DeepCopy()
+	in.ObservedTime.DeepCopyInto(&out.ObservedTime)
+	if in.Changes != nil {
+		in, out := &in.Changes, &out.Changes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	in.TransitionTime.DeepCopyInto(&out.TransitionTime)
-	in.RetryPolicyStatus.DeepCopyInto(&out.RetryPolicyStatus)
-	in.AttemptStatus.DeepCopyInto(&out.AttemptStatus)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new FrameworkStatus.
-func (in *FrameworkStatus) DeepCopy() *FrameworkStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpecChangeRecord.
+func (in *SpecChangeRecord) DeepCopy() *SpecChangeRecord {
 	if in == nil {
 		return nil
 	}
-	out := new(FrameworkStatus)
+	out := new(SpecChangeRecord)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *Int32Range) DeepCopyInto(out *Int32Range) {
+func (in *SpecSummary) DeepCopyInto(out *SpecSummary) {
 	*out = *in
-	if in.Min != nil {
-		in, out := &in.Min, &out.Min
-		*out = new(int32)
-		**out = **in
-	}
-	if in.Max != nil {
-		in, out := &in.Max, &out.Max
-		*out = new(int32)
-		**out = **in
+	if in.TaskNumbers != nil {
+		in, out := &in.TaskNumbers, &out.TaskNumbers
+		*out = make(map[string]int32, len(*in))
+		for key, val := range *in {
+			(*out)[key] = val
+		}
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Int32Range.
-func (in *Int32Range) DeepCopy() *Int32Range {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpecSummary.
+func (in *SpecSummary) DeepCopy() *SpecSummary {
 	if in == nil {
 		return nil
 	}
-	out := new(Int32Range)
+	out := new(SpecSummary)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogFrameworkSnapshot) DeepCopyInto(out *LogFrameworkSnapshot) {
+func (in *SpotInterruptionConfig) DeepCopyInto(out *SpotInterruptionConfig) {
 	*out = *in
-	if in.OnTaskRetry != nil {
-		in, out := &in.OnTaskRetry, &out.OnTaskRetry
+	if in.Enabled != nil {
+		in, out := &in.Enabled, &out.Enabled
 		*out = new(bool)
 		**out = **in
 	}
-	if in.OnFrameworkRetry != nil {
-		in, out := &in.OnFrameworkRetry, &out.OnFrameworkRetry
-		*out = new(bool)
-		**out = **in
+	if in.NodeTaintKeys != nil {
+		in, out := &in.NodeTaintKeys, &out.NodeTaintKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.OnFrameworkRescale != nil {
-		in, out := &in.OnFrameworkRescale, &out.OnFrameworkRescale
-		*out = new(bool)
-		**out = **in
+	if in.NodeConditionTypes != nil {
+		in, out := &in.NodeConditionTypes, &out.NodeConditionTypes
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.OnFrameworkDeletion != nil {
-		in, out := &in.OnFrameworkDeletion, &out.OnFrameworkDeletion
-		*out = new(bool)
-		**out = **in
+	if in.NodeAnnotationKeys != nil {
+		in, out := &in.NodeAnnotationKeys, &out.NodeAnnotationKeys
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogFrameworkSnapshot.
-func (in *LogFrameworkSnapshot) DeepCopy() *LogFrameworkSnapshot {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpotInterruptionConfig.
+func (in *SpotInterruptionConfig) DeepCopy() *SpotInterruptionConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(LogFrameworkSnapshot)
+	out := new(SpotInterruptionConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogObjectSnapshot) DeepCopyInto(out *LogObjectSnapshot) {
+func (in *SpotInterruptionSpec) DeepCopyInto(out *SpotInterruptionSpec) {
 	*out = *in
-	in.Framework.DeepCopyInto(&out.Framework)
-	in.Pod.DeepCopyInto(&out.Pod)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogObjectSnapshot.
-func (in *LogObjectSnapshot) DeepCopy() *LogObjectSnapshot {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new SpotInterruptionSpec.
+func (in *SpotInterruptionSpec) DeepCopy() *SpotInterruptionSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(LogObjectSnapshot)
+	out := new(SpotInterruptionSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *LogPodSnapshot) DeepCopyInto(out *LogPodSnapshot) {
+func (in *TaskAttemptCompletionStatus) DeepCopyInto(out *TaskAttemptCompletionStatus) {
 	*out = *in
-	if in.OnPodDeletion != nil {
-		in, out := &in.OnPodDeletion, &out.OnPodDeletion
-		*out = new(bool)
+	if in.CompletionStatus != nil {
+		in, out := &in.CompletionStatus, &out.CompletionStatus
+		*out = new(CompletionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.Pod != nil {
+		in, out := &in.Pod, &out.Pod
+		*out = new(PodCompletionStatus)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.PodFailurePolicy != nil {
+		in, out := &in.PodFailurePolicy, &out.PodFailurePolicy
+		*out = new(PodFailurePolicyStatus)
 		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new LogPodSnapshot.
-func (in *LogPodSnapshot) DeepCopy() *LogPodSnapshot {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskAttemptCompletionStatus.
+func (in *TaskAttemptCompletionStatus) DeepCopy() *TaskAttemptCompletionStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(LogPodSnapshot)
+	out := new(TaskAttemptCompletionStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MatchedContainer) DeepCopyInto(out *MatchedContainer) {
+func (in *TaskAttemptStatus) DeepCopyInto(out *TaskAttemptStatus) {
 	*out = *in
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
+	in.StartTime.DeepCopyInto(&out.StartTime)
+	if in.RunTime != nil {
+		in, out := &in.RunTime, &out.RunTime
+		*out = (*in).DeepCopy()
+	}
+	if in.CompletionTime != nil {
+		in, out := &in.CompletionTime, &out.CompletionTime
+		*out = (*in).DeepCopy()
+	}
+	if in.InstanceUID != nil {
+		in, out := &in.InstanceUID, &out.InstanceUID
+		*out = new(types.UID)
+		**out = **in
+	}
+	if in.PodUID != nil {
+		in, out := &in.PodUID, &out.PodUID
+		*out = new(types.UID)
+		**out = **in
+	}
+	if in.PodNodeName != nil {
+		in, out := &in.PodNodeName, &out.PodNodeName
 		*out = new(string)
 		**out = **in
 	}
-	if in.Code != nil {
-		in, out := &in.Code, &out.Code
-		*out = new(int32)
+	if in.PodIP != nil {
+		in, out := &in.PodIP, &out.PodIP
+		*out = new(string)
+		**out = **in
+	}
+	if in.PodHostIP != nil {
+		in, out := &in.PodHostIP, &out.PodHostIP
+		*out = new(string)
+		**out = **in
+	}
+	if in.PodFQDN != nil {
+		in, out := &in.PodFQDN, &out.PodFQDN
+		*out = new(string)
+		**out = **in
+	}
+	if in.PodTemplateHash != nil {
+		in, out := &in.PodTemplateHash, &out.PodTemplateHash
+		*out = new(string)
 		**out = **in
 	}
+	if in.PodAllocatedDevices != nil {
+		in, out := &in.PodAllocatedDevices, &out.PodAllocatedDevices
+		*out = new(PodAllocatedDevices)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.CompletionStatus != nil {
+		in, out := &in.CompletionStatus, &out.CompletionStatus
+		*out = new(TaskAttemptCompletionStatus)
+		(*in).DeepCopyInto(*out)
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchedContainer.
-func (in *MatchedContainer) DeepCopy() *MatchedContainer {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskAttemptStatus.
+func (in *TaskAttemptStatus) DeepCopy() *TaskAttemptStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MatchedContainer)
+	out := new(TaskAttemptStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *MatchedPod) DeepCopyInto(out *MatchedPod) {
+func (in *TaskHistoryStatus) DeepCopyInto(out *TaskHistoryStatus) {
 	*out = *in
-	if in.Name != nil {
-		in, out := &in.Name, &out.Name
-		*out = new(string)
-		**out = **in
-	}
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]*MatchedContainer, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(MatchedContainer)
-				(*in).DeepCopyInto(*out)
-			}
-		}
-	}
+	in.RetryPolicyStatus.DeepCopyInto(&out.RetryPolicyStatus)
+	in.AttemptStatus.DeepCopyInto(&out.AttemptStatus)
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new MatchedPod.
-func (in *MatchedPod) DeepCopy() *MatchedPod {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskHistoryStatus.
+func (in *TaskHistoryStatus) DeepCopy() *TaskHistoryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(MatchedPod)
+	out := new(TaskHistoryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodCompletionStatus) DeepCopyInto(out *PodCompletionStatus) {
+func (in *TaskOverrideSpec) DeepCopyInto(out *TaskOverrideSpec) {
 	*out = *in
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]*ContainerCompletionStatus, len(*in))
-		for i := range *in {
-			if (*in)[i] != nil {
-				in, out := &(*in)[i], &(*out)[i]
-				*out = new(ContainerCompletionStatus)
-				**out = **in
-			}
-		}
+	in.Pod.DeepCopyInto(&out.Pod)
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskOverrideSpec.
+func (in *TaskOverrideSpec) DeepCopy() *TaskOverrideSpec {
+	if in == nil {
+		return nil
 	}
+	out := new(TaskOverrideSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRestartRequest) DeepCopyInto(out *TaskRestartRequest) {
+	*out = *in
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodCompletionStatus.
-func (in *PodCompletionStatus) DeepCopy() *PodCompletionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRestartRequest.
+func (in *TaskRestartRequest) DeepCopy() *TaskRestartRequest {
 	if in == nil {
 		return nil
 	}
-	out := new(PodCompletionStatus)
+	out := new(TaskRestartRequest)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodMatchResult) DeepCopyInto(out *PodMatchResult) {
+func (in *TaskRoleAutoscalerConfig) DeepCopyInto(out *TaskRoleAutoscalerConfig) {
 	*out = *in
-	if in.CodeInfo != nil {
-		in, out := &in.CodeInfo, &out.CodeInfo
-		*out = new(CompletionCodeInfo)
-		(*in).DeepCopyInto(*out)
+	if in.Name != nil {
+		in, out := &in.Name, &out.Name
+		*out = new(string)
+		**out = **in
+	}
+	if in.IntervalSec != nil {
+		in, out := &in.IntervalSec, &out.IntervalSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.URL != nil {
+		in, out := &in.URL, &out.URL
+		*out = new(string)
+		**out = **in
+	}
+	if in.TimeoutSec != nil {
+		in, out := &in.TimeoutSec, &out.TimeoutSec
+		*out = new(int64)
+		**out = **in
 	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodMatchResult.
-func (in *PodMatchResult) DeepCopy() *PodMatchResult {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRoleAutoscalerConfig.
+func (in *TaskRoleAutoscalerConfig) DeepCopy() *TaskRoleAutoscalerConfig {
 	if in == nil {
 		return nil
 	}
-	out := new(PodMatchResult)
+	out := new(TaskRoleAutoscalerConfig)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *PodPattern) DeepCopyInto(out *PodPattern) {
+func (in *TaskRoleHistoryStatus) DeepCopyInto(out *TaskRoleHistoryStatus) {
 	*out = *in
-	in.NameRegex.DeepCopyInto(&out.NameRegex)
-	in.ReasonRegex.DeepCopyInto(&out.ReasonRegex)
-	in.MessageRegex.DeepCopyInto(&out.MessageRegex)
-	if in.Containers != nil {
-		in, out := &in.Containers, &out.Containers
-		*out = make([]*ContainerPattern, len(*in))
+	if in.TaskStatuses != nil {
+		in, out := &in.TaskStatuses, &out.TaskStatuses
+		*out = make([]*TaskHistoryStatus, len(*in))
 		for i := range *in {
 			if (*in)[i] != nil {
 				in, out := &(*in)[i], &(*out)[i]
-				*out = new(ContainerPattern)
+				*out = new(TaskHistoryStatus)
 				(*in).DeepCopyInto(*out)
 			}
 		}
@@ -685,165 +3218,222 @@ func (in *PodPattern) DeepCopyInto(out *PodPattern) {
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new PodPattern.
-func (in *PodPattern) DeepCopy() *PodPattern {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRoleHistoryStatus.
+func (in *TaskRoleHistoryStatus) DeepCopy() *TaskRoleHistoryStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(PodPattern)
+	out := new(TaskRoleHistoryStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new Regex.
-func (in *Regex) DeepCopy() *Regex {
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TaskRoleProgress) DeepCopyInto(out *TaskRoleProgress) {
+	*out = *in
+	if in.FailedTaskSamples != nil {
+		in, out := &in.FailedTaskSamples, &out.FailedTaskSamples
+		*out = make([]*FailedTaskSample, len(*in))
+		for i := range *in {
+			if (*in)[i] != nil {
+				in, out := &(*in)[i], &(*out)[i]
+				*out = new(FailedTaskSample)
+				(*in).DeepCopyInto(*out)
+			}
+		}
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRoleProgress.
+func (in *TaskRoleProgress) DeepCopy() *TaskRoleProgress {
 	if in == nil {
 		return nil
 	}
-	out := new(Regex)
+	out := new(TaskRoleProgress)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RetryDecision) DeepCopyInto(out *RetryDecision) {
+func (in *TaskRoleScale) DeepCopyInto(out *TaskRoleScale) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	in.ObjectMeta.DeepCopyInto(&out.ObjectMeta)
+	out.Spec = in.Spec
+	if in.Status != nil {
+		in, out := &in.Status, &out.Status
+		*out = new(TaskRoleScaleStatus)
+		**out = **in
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryDecision.
-func (in *RetryDecision) DeepCopy() *RetryDecision {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRoleScale.
+func (in *TaskRoleScale) DeepCopy() *TaskRoleScale {
 	if in == nil {
 		return nil
 	}
-	out := new(RetryDecision)
+	out := new(TaskRoleScale)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TaskRoleScale) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RetryPolicySpec) DeepCopyInto(out *RetryPolicySpec) {
+func (in *TaskRoleScaleList) DeepCopyInto(out *TaskRoleScaleList) {
 	*out = *in
+	out.TypeMeta = in.TypeMeta
+	out.ListMeta = in.ListMeta
+	if in.Items != nil {
+		in, out := &in.Items, &out.Items
+		*out = make([]TaskRoleScale, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicySpec.
-func (in *RetryPolicySpec) DeepCopy() *RetryPolicySpec {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRoleScaleList.
+func (in *TaskRoleScaleList) DeepCopy() *TaskRoleScaleList {
 	if in == nil {
 		return nil
 	}
-	out := new(RetryPolicySpec)
+	out := new(TaskRoleScaleList)
 	in.DeepCopyInto(out)
 	return out
 }
 
+// DeepCopyObject is an autogenerated deepcopy function, copying the receiver, creating a new runtime.Object.
+func (in *TaskRoleScaleList) DeepCopyObject() runtime.Object {
+	if c := in.DeepCopy(); c != nil {
+		return c
+	}
+	return nil
+}
+
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *RetryPolicyStatus) DeepCopyInto(out *RetryPolicyStatus) {
+func (in *TaskRoleScaleSpec) DeepCopyInto(out *TaskRoleScaleSpec) {
 	*out = *in
-	if in.RetryDelaySec != nil {
-		in, out := &in.RetryDelaySec, &out.RetryDelaySec
-		*out = new(int64)
-		**out = **in
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new RetryPolicyStatus.
-func (in *RetryPolicyStatus) DeepCopy() *RetryPolicyStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRoleScaleSpec.
+func (in *TaskRoleScaleSpec) DeepCopy() *TaskRoleScaleSpec {
 	if in == nil {
 		return nil
 	}
-	out := new(RetryPolicyStatus)
+	out := new(TaskRoleScaleSpec)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TaskAttemptCompletionStatus) DeepCopyInto(out *TaskAttemptCompletionStatus) {
+func (in *TaskRoleScaleStatus) DeepCopyInto(out *TaskRoleScaleStatus) {
 	*out = *in
-	if in.CompletionStatus != nil {
-		in, out := &in.CompletionStatus, &out.CompletionStatus
-		*out = new(CompletionStatus)
-		(*in).DeepCopyInto(*out)
-	}
-	if in.Pod != nil {
-		in, out := &in.Pod, &out.Pod
-		*out = new(PodCompletionStatus)
-		(*in).DeepCopyInto(*out)
-	}
 	return
 }
 
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskAttemptCompletionStatus.
-func (in *TaskAttemptCompletionStatus) DeepCopy() *TaskAttemptCompletionStatus {
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskRoleScaleStatus.
+func (in *TaskRoleScaleStatus) DeepCopy() *TaskRoleScaleStatus {
 	if in == nil {
 		return nil
 	}
-	out := new(TaskAttemptCompletionStatus)
+	out := new(TaskRoleScaleStatus)
 	in.DeepCopyInto(out)
 	return out
 }
 
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TaskAttemptStatus) DeepCopyInto(out *TaskAttemptStatus) {
+func (in *TaskRoleSpec) DeepCopyInto(out *TaskRoleSpec) {
 	*out = *in
-	in.StartTime.DeepCopyInto(&out.StartTime)
-	if in.RunTime != nil {
-		in, out := &in.RunTime, &out.RunTime
-		*out = (*in).DeepCopy()
+	out.FrameworkAttemptCompletionPolicy = in.FrameworkAttemptCompletionPolicy
+	in.Task.DeepCopyInto(&out.Task)
+	if in.DependsOn != nil {
+		in, out := &in.DependsOn, &out.DependsOn
+		*out = make([]string, len(*in))
+		copy(*out, *in)
 	}
-	if in.CompletionTime != nil {
-		in, out := &in.CompletionTime, &out.CompletionTime
-		*out = (*in).DeepCopy()
+	if in.HiveD != nil {
+		in, out := &in.HiveD, &out.HiveD
+		*out = new(HiveDSpec)
+		**out = **in
 	}
-	if in.InstanceUID != nil {
-		in, out := &in.InstanceUID, &out.InstanceUID
-		*out = new(types.UID)
+	if in.AntiAffinity != nil {
+		in, out := &in.AntiAffinity, &out.AntiAffinity
+		*out = new(AntiAffinitySpec)
 		**out = **in
 	}
-	if in.PodUID != nil {
-		in, out := &in.PodUID, &out.PodUID
-		*out = new(types.UID)
+	if in.TopologySpreadConstraints != nil {
+		in, out := &in.TopologySpreadConstraints, &out.TopologySpreadConstraints
+		*out = make([]TopologySpreadConstraintSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.PodFailurePolicy != nil {
+		in, out := &in.PodFailurePolicy, &out.PodFailurePolicy
+		*out = new(PodFailurePolicySpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.MemoryEscalation != nil {
+		in, out := &in.MemoryEscalation, &out.MemoryEscalation
+		*out = new(MemoryEscalationSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.SpotInterruption != nil {
+		in, out := &in.SpotInterruption, &out.SpotInterruption
+		*out = new(SpotInterruptionSpec)
 		**out = **in
 	}
-	if in.PodNodeName != nil {
-		in, out := &in.PodNodeName, &out.PodNodeName
-		*out = new(string)
+	if in.SidecarQuit != nil {
+		in, out := &in.SidecarQuit, &out.SidecarQuit
+		*out = new(SidecarQuitSpec)
 		**out = **in
 	}
-	if in.PodIP != nil {
-		in, out := &in.PodIP, &out.PodIP
-		*out = new(string)
+	if in.Hostfile != nil {
+		in, out := &in.Hostfile, &out.Hostfile
+		*out = new(HostfileSpec)
 		**out = **in
 	}
-	if in.PodHostIP != nil {
-		in, out := &in.PodHostIP, &out.PodHostIP
-		*out = new(string)
+	if in.SSHKey != nil {
+		in, out := &in.SSHKey, &out.SSHKey
+		*out = new(SSHKeySpec)
 		**out = **in
 	}
-	if in.CompletionStatus != nil {
-		in, out := &in.CompletionStatus, &out.CompletionStatus
-		*out = new(TaskAttemptCompletionStatus)
+	if in.SecretRefs != nil {
+		in, out := &in.SecretRefs, &out.SecretRefs
+		*out = make([]SecretRefSpec, len(*in))
+		copy(*out, *in)
+	}
+	if in.TaskOverrides != nil {
+		in, out := &in.TaskOverrides, &out.TaskOverrides
+		*out = make([]TaskOverrideSpec, len(*in))
+		for i := range *in {
+			(*in)[i].DeepCopyInto(&(*out)[i])
+		}
+	}
+	if in.Expose != nil {
+		in, out := &in.Expose, &out.Expose
+		*out = new(ExposeSpec)
 		(*in).DeepCopyInto(*out)
 	}
-	return
-}
-
-// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TaskAttemptStatus.
-func (in *TaskAttemptStatus) DeepCopy() *TaskAttemptStatus {
-	if in == nil {
-		return nil
+	if in.DisruptionBudget != nil {
+		in, out := &in.DisruptionBudget, &out.DisruptionBudget
+		*out = new(DisruptionBudgetSpec)
+		(*in).DeepCopyInto(*out)
+	}
+	if in.UpdateStrategy != nil {
+		in, out := &in.UpdateStrategy, &out.UpdateStrategy
+		*out = new(UpdateStrategySpec)
+		(*in).DeepCopyInto(*out)
 	}
-	out := new(TaskAttemptStatus)
-	in.DeepCopyInto(out)
-	return out
-}
-
-// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
-func (in *TaskRoleSpec) DeepCopyInto(out *TaskRoleSpec) {
-	*out = *in
-	out.FrameworkAttemptCompletionPolicy = in.FrameworkAttemptCompletionPolicy
-	in.Task.DeepCopyInto(&out.Task)
 	return
 }
 
@@ -865,6 +3455,31 @@ func (in *TaskRoleStatus) DeepCopyInto(out *TaskRoleStatus) {
 		*out = new(int64)
 		**out = **in
 	}
+	if in.HeadlessServiceUID != nil {
+		in, out := &in.HeadlessServiceUID, &out.HeadlessServiceUID
+		*out = new(types.UID)
+		**out = **in
+	}
+	if in.ExposedServiceUID != nil {
+		in, out := &in.ExposedServiceUID, &out.ExposedServiceUID
+		*out = new(types.UID)
+		**out = **in
+	}
+	if in.IngressUID != nil {
+		in, out := &in.IngressUID, &out.IngressUID
+		*out = new(types.UID)
+		**out = **in
+	}
+	if in.PodDisruptionBudgetUID != nil {
+		in, out := &in.PodDisruptionBudgetUID, &out.PodDisruptionBudgetUID
+		*out = new(types.UID)
+		**out = **in
+	}
+	if in.AutoscaledTaskNumber != nil {
+		in, out := &in.AutoscaledTaskNumber, &out.AutoscaledTaskNumber
+		*out = new(int32)
+		**out = **in
+	}
 	if in.TaskStatuses != nil {
 		in, out := &in.TaskStatuses, &out.TaskStatuses
 		*out = make([]*TaskStatus, len(*in))
@@ -892,12 +3507,32 @@ func (in *TaskRoleStatus) DeepCopy() *TaskRoleStatus {
 // DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
 func (in *TaskSpec) DeepCopyInto(out *TaskSpec) {
 	*out = *in
-	out.RetryPolicy = in.RetryPolicy
+	in.RetryPolicy.DeepCopyInto(&out.RetryPolicy)
 	if in.PodGracefulDeletionTimeoutSec != nil {
 		in, out := &in.PodGracefulDeletionTimeoutSec, &out.PodGracefulDeletionTimeoutSec
 		*out = new(int64)
 		**out = **in
 	}
+	if in.AttemptMaxRunDurationSec != nil {
+		in, out := &in.AttemptMaxRunDurationSec, &out.AttemptMaxRunDurationSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.HeartbeatTimeoutSec != nil {
+		in, out := &in.HeartbeatTimeoutSec, &out.HeartbeatTimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.StopIndexes != nil {
+		in, out := &in.StopIndexes, &out.StopIndexes
+		*out = make([]int32, len(*in))
+		copy(*out, *in)
+	}
+	if in.RestartRequests != nil {
+		in, out := &in.RestartRequests, &out.RestartRequests
+		*out = make([]TaskRestartRequest, len(*in))
+		copy(*out, *in)
+	}
 	in.Pod.DeepCopyInto(&out.Pod)
 	return
 }
@@ -923,6 +3558,16 @@ func (in *TaskStatus) DeepCopyInto(out *TaskStatus) {
 	in.TransitionTime.DeepCopyInto(&out.TransitionTime)
 	in.RetryPolicyStatus.DeepCopyInto(&out.RetryPolicyStatus)
 	in.AttemptStatus.DeepCopyInto(&out.AttemptStatus)
+	if in.AllocatedPorts != nil {
+		in, out := &in.AllocatedPorts, &out.AllocatedPorts
+		*out = new(PortRange)
+		**out = **in
+	}
+	if in.MemoryEscalation != nil {
+		in, out := &in.MemoryEscalation, &out.MemoryEscalation
+		*out = new(MemoryEscalationStatus)
+		**out = **in
+	}
 	return
 }
 
@@ -935,3 +3580,102 @@ func (in *TaskStatus) DeepCopy() *TaskStatus {
 	in.DeepCopyInto(out)
 	return out
 }
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TerminationMessageCompletion) DeepCopyInto(out *TerminationMessageCompletion) {
+	*out = *in
+	if in.Code != nil {
+		in, out := &in.Code, &out.Code
+		*out = new(CompletionCode)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TerminationMessageCompletion.
+func (in *TerminationMessageCompletion) DeepCopy() *TerminationMessageCompletion {
+	if in == nil {
+		return nil
+	}
+	out := new(TerminationMessageCompletion)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TopologySpreadConstraintSpec) DeepCopyInto(out *TopologySpreadConstraintSpec) {
+	*out = *in
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TopologySpreadConstraintSpec.
+func (in *TopologySpreadConstraintSpec) DeepCopy() *TopologySpreadConstraintSpec {
+	if in == nil {
+		return nil
+	}
+	out := new(TopologySpreadConstraintSpec)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *TracingConfig) DeepCopyInto(out *TracingConfig) {
+	*out = *in
+	if in.OTLPEndpoint != nil {
+		in, out := &in.OTLPEndpoint, &out.OTLPEndpoint
+		*out = new(string)
+		**out = **in
+	}
+	if in.ServiceName != nil {
+		in, out := &in.ServiceName, &out.ServiceName
+		*out = new(string)
+		**out = **in
+	}
+	if in.BufferSize != nil {
+		in, out := &in.BufferSize, &out.BufferSize
+		*out = new(int32)
+		**out = **in
+	}
+	if in.ExportIntervalSec != nil {
+		in, out := &in.ExportIntervalSec, &out.ExportIntervalSec
+		*out = new(int64)
+		**out = **in
+	}
+	if in.ExportTimeoutSec != nil {
+		in, out := &in.ExportTimeoutSec, &out.ExportTimeoutSec
+		*out = new(int64)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new TracingConfig.
+func (in *TracingConfig) DeepCopy() *TracingConfig {
+	if in == nil {
+		return nil
+	}
+	out := new(TracingConfig)
+	in.DeepCopyInto(out)
+	return out
+}
+
+// DeepCopyInto is an autogenerated deepcopy function, copying the receiver, writing into out. in must be non-nil.
+func (in *UpdateStrategySpec) DeepCopyInto(out *UpdateStrategySpec) {
+	*out = *in
+	if in.MaxUnavailable != nil {
+		in, out := &in.MaxUnavailable, &out.MaxUnavailable
+		*out = new(intstr.IntOrString)
+		**out = **in
+	}
+	return
+}
+
+// DeepCopy is an autogenerated deepcopy function, copying the receiver, creating a new UpdateStrategySpec.
+func (in *UpdateStrategySpec) DeepCopy() *UpdateStrategySpec {
+	if in == nil {
+		return nil
+	}
+	out := new(UpdateStrategySpec)
+	in.DeepCopyInto(out)
+	return out
+}
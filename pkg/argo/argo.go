@@ -0,0 +1,179 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package argo
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	kubeRuntime "k8s.io/apimachinery/pkg/runtime"
+	"regexp"
+	"sigs.k8s.io/yaml"
+	"sort"
+	"strings"
+)
+
+// The helpers to run the Framework as an Argo Workflow step by the Argo
+// resource template, which creates the Framework and waits until its
+// Status.Phase matches the SuccessCondition or FailureCondition:
+//   f := ... // the Framework whose string fields may refer argo.Parameter
+//   t, err := argo.NewTemplate("train", f, &argo.TemplateOptions{
+//     TaskNumberParameters: map[string]string{"worker": "workers"}})
+//   ... append t to the Workflow Spec.Templates ...
+
+const (
+	// The Argo resource template conditions, which are label selectors on the
+	// Framework fields.
+	// See FrameworkPhase.
+	SuccessCondition = "status.phase == " + string(ci.FrameworkPhaseSucceeded)
+	FailureCondition = "status.phase == " + string(ci.FrameworkPhaseFailed)
+
+	ResourceActionCreate = "create"
+)
+
+var parameterRegex = regexp.MustCompile(`{{inputs\.parameters\.([-\w]+)}}`)
+
+// Parameter returns the reference to the Argo input parameter, which can be
+// used in any string field of the Framework, such as the Name and the
+// container Args, and it is substituted by Argo before the Framework is
+// created.
+func Parameter(name string) string {
+	return "{{inputs.parameters." + name + "}}"
+}
+
+// The subset of the Argo Workflow Template for the resource template.
+type Template struct {
+	Name     string            `json:"name"`
+	Inputs   *Inputs           `json:"inputs,omitempty"`
+	Resource *ResourceTemplate `json:"resource"`
+}
+
+type Inputs struct {
+	Parameters []*InputParameter `json:"parameters"`
+}
+
+type InputParameter struct {
+	Name string `json:"name"`
+}
+
+type ResourceTemplate struct {
+	Action            string `json:"action"`
+	SetOwnerReference bool   `json:"setOwnerReference"`
+	SuccessCondition  string `json:"successCondition"`
+	FailureCondition  string `json:"failureCondition"`
+	Manifest          string `json:"manifest"`
+}
+
+type TemplateOptions struct {
+	// TaskRoleName -> Argo input parameter name, to refer the parameter in the
+	// TaskRole TaskNumber, which is not a string field.
+	TaskNumberParameters map[string]string
+	// Whether the Framework is deleted together with the Argo Workflow.
+	// Default to false.
+	SetOwnerReference bool
+}
+
+// NewTemplate returns the Argo resource template to create the Framework and
+// wait for its completion. All the Argo input parameters referred in the
+// Framework are declared as the template inputs.
+func NewTemplate(
+	name string, f *ci.Framework, opts *TemplateOptions) (*Template, error) {
+	if opts == nil {
+		opts = &TemplateOptions{}
+	}
+	manifest, err := NewManifest(f, opts.TaskNumberParameters)
+	if err != nil {
+		return nil, err
+	}
+
+	t := &Template{
+		Name: name,
+		Resource: &ResourceTemplate{
+			Action:            ResourceActionCreate,
+			SetOwnerReference: opts.SetOwnerReference,
+			SuccessCondition:  SuccessCondition,
+			FailureCondition:  FailureCondition,
+			Manifest:          manifest,
+		},
+	}
+	if parameterNames := GetParameterNames(manifest); len(parameterNames) > 0 {
+		t.Inputs = &Inputs{}
+		for _, parameterName := range parameterNames {
+			t.Inputs.Parameters = append(t.Inputs.Parameters,
+				&InputParameter{Name: parameterName})
+		}
+	}
+	return t, nil
+}
+
+// NewManifest returns the Framework YAML manifest without its Status, and the
+// TaskNumber of the TaskRoles in taskNumberParameters refers the
+// corresponding Argo input parameter.
+func NewManifest(
+	f *ci.Framework, taskNumberParameters map[string]string) (string, error) {
+	errPfx := fmt.Sprintf("Failed to generate manifest for Framework %v: ", f.Name)
+	obj, err := kubeRuntime.DefaultUnstructuredConverter.ToUnstructured(f)
+	if err != nil {
+		return "", fmt.Errorf(errPfx+"%v", err)
+	}
+	delete(obj, "status")
+	obj["apiVersion"] = ci.SchemeGroupVersion.String()
+	obj["kind"] = ci.FrameworkKind
+
+	taskRoles, _ := obj["spec"].(map[string]interface{})["taskRoles"].([]interface{})
+	for _, taskRole := range taskRoles {
+		taskRoleMap := taskRole.(map[string]interface{})
+		parameterName, ok := taskNumberParameters[taskRoleMap["name"].(string)]
+		if ok {
+			taskRoleMap["taskNumber"] = Parameter(parameterName)
+		}
+	}
+
+	manifestBytes, err := yaml.Marshal(obj)
+	if err != nil {
+		return "", fmt.Errorf(errPfx+"%v", err)
+	}
+
+	// The number parameter should not be quoted, so that it is still a number
+	// after it is substituted.
+	manifest := string(manifestBytes)
+	for _, parameterName := range taskNumberParameters {
+		manifest = strings.Replace(manifest,
+			"'"+Parameter(parameterName)+"'", Parameter(parameterName), -1)
+	}
+	return manifest, nil
+}
+
+// GetParameterNames returns the sorted distinct Argo input parameter names
+// referred in the manifest.
+func GetParameterNames(manifest string) []string {
+	nameSet := map[string]bool{}
+	for _, match := range parameterRegex.FindAllStringSubmatch(manifest, -1) {
+		nameSet[match[1]] = true
+	}
+	names := []string{}
+	for name := range nameSet {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
@@ -23,6 +23,7 @@
 package barrier
 
 import (
+	"context"
 	"fmt"
 	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
 	frameworkClient "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned"
@@ -30,7 +31,6 @@ import (
 	"github.com/microsoft/frameworkcontroller/pkg/internal"
 	"io/ioutil"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
-	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/util/wait"
 	kubeClient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
@@ -58,8 +58,8 @@ import (
 //    the same Framework without the need for k8s DNS.
 //
 // Usage:
-// It waits until all Tasks in the specified Framework object are ready with not
-// nil PodIP and then dumps the Framework object to local file: ./framework.json,
+// It waits until all Tasks in the specified Framework object are ready and then
+// dumps the Framework object to local file: ./framework.json,
 // besides it also generates the injector script to local file: ./injector.sh
 // which provides a default way to inject some Framework information into caller
 // process.
@@ -77,6 +77,22 @@ import (
 //
 // Caller can also write its own injector script to inject other Framework
 // information from the ./framework.json.
+//
+// Besides the ./injector.sh, it can also output the ready Tasks in the formats
+// specified by ${BARRIER_OUTPUT_FORMATS}, i.e. the comma separated injector
+// (default), json (./peers.json), dotenv (./peers.env) and hosts (./hosts),
+// so that the arbitrary entrypoints can consume them without custom parsing.
+//
+// By default, it waits for all TaskRoles and a Task is ready once its PodIP is
+// assigned. To avoid blocking on the optional TaskRoles in a heterogeneous
+// Framework, the caller can specify:
+//   ${BARRIER_TASK_ROLES}: The comma separated TaskRoleNames to wait for, and
+//     only they are injected by the ./injector.sh.
+//   ${BARRIER_READINESS}: The readiness of a Task, i.e. PodIPAssigned,
+//     PodRunning or ContainersReady.
+//   Note, the caller's own Pod can only be PodIPAssigned when the barrier is run
+//   as its initContainer, so the other readiness can only be used to wait for
+//   the other TaskRoles.
 type FrameworkBarrier struct {
 	kConfig *rest.Config
 	bConfig *Config
@@ -92,9 +108,30 @@ const (
 	ComponentName           = "frameworkbarrier"
 	FrameworkObjectFilePath = "./framework.json"
 	InjectorFilePath        = "./injector.sh"
+	PeersJsonFilePath       = "./peers.json"
+	PeersDotenvFilePath     = "./peers.env"
+	PeersHostsFilePath      = "./hosts"
 
 	EnvNameBarrierCheckIntervalSec = "BARRIER_CHECK_INTERVAL_SEC"
 	EnvNameBarrierCheckTimeoutSec  = "BARRIER_CHECK_TIMEOUT_SEC"
+	EnvNameBarrierTaskRoles        = "BARRIER_TASK_ROLES"
+	EnvNameBarrierReadiness        = "BARRIER_READINESS"
+	EnvNameBarrierOutputFormats    = "BARRIER_OUTPUT_FORMATS"
+)
+
+type OutputFormat string
+
+const (
+	// ./injector.sh
+	OutputFormatInjector OutputFormat = "injector"
+	// ./peers.json: The JSON array of the ready TaskInfos.
+	OutputFormatJson OutputFormat = "json"
+	// ./peers.env: One line FB_{UpperCase({TaskRoleName})}_IPS={Task[0].PodIP},...
+	// for each TaskRole, without the export and quotes.
+	OutputFormatDotenv OutputFormat = "dotenv"
+	// ./hosts: One line {PodIP} {TaskRoleName}-{TaskIndex} for each Task, which
+	// can be appended to the /etc/hosts.
+	OutputFormatHosts OutputFormat = "hosts"
 )
 
 ///////////////////////////////////////////////////////////////////////////////////////
@@ -113,6 +150,15 @@ type Config struct {
 	// barrier, i.e. are ready with not nil PodIP.
 	BarrierCheckIntervalSec int64 `yaml:"barrierCheckIntervalSec"`
 	BarrierCheckTimeoutSec  int64 `yaml:"barrierCheckTimeoutSec"`
+
+	// The TaskRoles for which the barrier waits.
+	// Empty means all TaskRoles in the Framework.
+	TaskRoleNames []string `yaml:"taskRoleNames"`
+	// The condition for a Task to reach the barrier.
+	Readiness Readiness `yaml:"readiness"`
+	// The formats of the local files to output the ready Tasks, besides the
+	// ./framework.json.
+	OutputFormats []OutputFormat `yaml:"outputFormats"`
 }
 
 func newConfig() *Config {
@@ -156,6 +202,28 @@ func newConfig() *Config {
 		c.BarrierCheckTimeoutSec = i
 	}
 
+	for _, taskRoleName := range strings.Split(os.Getenv(EnvNameBarrierTaskRoles), ",") {
+		taskRoleName = strings.TrimSpace(taskRoleName)
+		if taskRoleName != "" {
+			c.TaskRoleNames = append(c.TaskRoleNames, taskRoleName)
+		}
+	}
+
+	c.Readiness = Readiness(os.Getenv(EnvNameBarrierReadiness))
+	if c.Readiness == "" {
+		c.Readiness = ReadinessPodIPAssigned
+	}
+
+	for _, format := range strings.Split(os.Getenv(EnvNameBarrierOutputFormats), ",") {
+		format = strings.TrimSpace(format)
+		if format != "" {
+			c.OutputFormats = append(c.OutputFormats, OutputFormat(format))
+		}
+	}
+	if len(c.OutputFormats) == 0 {
+		c.OutputFormats = []OutputFormat{OutputFormatInjector}
+	}
+
 	// Validation
 	errPrefix := "Validation Failed: "
 	if c.FrameworkName == "" {
@@ -176,6 +244,27 @@ func newConfig() *Config {
 			EnvNameBarrierCheckTimeoutSec, c.BarrierCheckTimeoutSec)
 		exit(ci.CompletionCodeContainerPermanentFailed)
 	}
+	if c.Readiness != ReadinessPodIPAssigned &&
+		c.Readiness != ReadinessPodRunning &&
+		c.Readiness != ReadinessContainersReady {
+		klog.Errorf(errPrefix+
+			"${%v} %v should be %v, %v or %v",
+			EnvNameBarrierReadiness, c.Readiness, ReadinessPodIPAssigned,
+			ReadinessPodRunning, ReadinessContainersReady)
+		exit(ci.CompletionCodeContainerPermanentFailed)
+	}
+	for _, format := range c.OutputFormats {
+		if format != OutputFormatInjector &&
+			format != OutputFormatJson &&
+			format != OutputFormatDotenv &&
+			format != OutputFormatHosts {
+			klog.Errorf(errPrefix+
+				"${%v} %v should be %v, %v, %v or %v",
+				EnvNameBarrierOutputFormats, format, OutputFormatInjector,
+				OutputFormatJson, OutputFormatDotenv, OutputFormatHosts)
+			exit(ci.CompletionCodeContainerPermanentFailed)
+		}
+	}
 
 	return &c
 }
@@ -223,59 +312,35 @@ func NewFrameworkBarrier() *FrameworkBarrier {
 	}
 }
 
+func (b *FrameworkBarrier) waitOptions() *WaitOptions {
+	return &WaitOptions{
+		TaskRoleNames: b.bConfig.TaskRoleNames,
+		Readiness:     b.bConfig.Readiness,
+		CheckInterval: common.SecToDuration(&b.bConfig.BarrierCheckIntervalSec),
+		CheckTimeout:  common.SecToDuration(&b.bConfig.BarrierCheckTimeoutSec),
+	}
+}
+
 func (b *FrameworkBarrier) Run() {
 	klog.Infof("Running %v", ComponentName)
 
-	var f *ci.Framework
-	var err error
-	var isPassed bool
-	var isPermanentErr bool
-	wait.PollImmediate(
-		common.SecToDuration(&b.bConfig.BarrierCheckIntervalSec),
-		common.SecToDuration(&b.bConfig.BarrierCheckTimeoutSec),
-		func() (bool, error) {
-			f, err = b.fClient.FrameworkcontrollerV1().
-				Frameworks(b.bConfig.FrameworkNamespace).
-				Get(b.bConfig.FrameworkName, meta.GetOptions{})
-
-			if err == nil {
-				err = f.Decompress()
-				if err == nil {
-					isPassed = isBarrierPassed(f)
-					return isPassed, nil
-				} else {
-					klog.Warningf("Failed to decompress Framework object: %v", err)
-					// Unknown Error: Poll Until Timeout
-					isPermanentErr = false
-					return false, nil
-				}
-			} else {
-				klog.Warningf("Failed to get Framework object from ApiServer: %v", err)
-				if apiErrors.IsNotFound(err) {
-					// Permanent Error: Early Stop
-					isPermanentErr = true
-					return false, err
-				} else {
-					// Unknown Error: Poll Until Timeout
-					isPermanentErr = false
-					return false, nil
-				}
-			}
-		})
+	result, err := WaitFrameworkReady(
+		context.Background(), b.kClient, b.fClient,
+		b.bConfig.FrameworkNamespace+"/"+b.bConfig.FrameworkName, b.waitOptions())
 
-	if isPassed {
-		klog.Infof("BarrierSucceeded: " +
-			"All Tasks are ready with not nil PodIP.")
-		dumpFramework(f)
-		generateInjector(f)
+	if err == nil {
+		klog.Infof("BarrierSucceeded: "+
+			"All Tasks are ready by %v.", b.bConfig.Readiness)
+		dumpFramework(result.Framework)
+		b.generateOutputs(result)
 		exit(ci.CompletionCodeSucceeded)
 	} else {
-		if err == nil {
-			klog.Errorf("BarrierTransientConflictFailed: " +
-				"Timeout to wait all Tasks are ready with not nil PodIP.")
+		if err == wait.ErrWaitTimeout {
+			klog.Errorf("BarrierTransientConflictFailed: "+
+				"Timeout to wait all Tasks are ready by %v.", b.bConfig.Readiness)
 			exit(ci.CompletionCodeContainerTransientConflictFailed)
 		} else {
-			if isPermanentErr {
+			if apiErrors.IsNotFound(err) {
 				klog.Errorf("BarrierPermanentFailed: %v", err)
 				exit(ci.CompletionCodeContainerPermanentFailed)
 			} else {
@@ -287,57 +352,6 @@ func (b *FrameworkBarrier) Run() {
 	}
 }
 
-func isBarrierPassed(f *ci.Framework) bool {
-	// Fully counting Tasks in f.Status against f.Spec, as FrameworkController may
-	// have not persist DeletionPending (ScaleDown) Tasks according to current
-	// f.Spec.
-	totalTaskCount := int32(0)
-	readyTaskCount := int32(0)
-	for _, taskRoleSpec := range f.Spec.TaskRoles {
-		taskRoleName := taskRoleSpec.Name
-		taskCountSpec := taskRoleSpec.TaskNumber
-		totalTaskCount += taskCountSpec
-
-		if f.Status == nil {
-			continue
-		}
-
-		taskRoleStatus := f.GetTaskRoleStatus(taskRoleName)
-		if taskRoleStatus == nil {
-			continue
-		}
-
-		taskCountStatus := int32(len(taskRoleStatus.TaskStatuses))
-		taskCountStatusAndSpec := common.MinInt32(taskCountStatus, taskCountSpec)
-		for taskIndex := int32(0); taskIndex < taskCountStatusAndSpec; taskIndex++ {
-			taskStatus := taskRoleStatus.TaskStatuses[taskIndex]
-			if isTaskReady(taskStatus, true) {
-				readyTaskCount++
-			}
-		}
-	}
-
-	// Wait until readyTaskCount is consistent with totalTaskCount.
-	if readyTaskCount >= totalTaskCount {
-		klog.Infof("BarrierPassed: "+
-			"%v/%v Tasks are ready with not nil PodIP.",
-			readyTaskCount, totalTaskCount)
-		return true
-	} else {
-		klog.Warningf("BarrierNotPassed: "+
-			"%v/%v Tasks are ready with not nil PodIP.",
-			readyTaskCount, totalTaskCount)
-		return false
-	}
-}
-
-func isTaskReady(ts *ci.TaskStatus, ignoreDeletionPending bool) bool {
-	if ts.IsDeletionPendingIgnored(ignoreDeletionPending) {
-		return false
-	}
-	return ts.AttemptStatus.PodIP != nil && *ts.AttemptStatus.PodIP != ""
-}
-
 func dumpFramework(f *ci.Framework) {
 	err := ioutil.WriteFile(FrameworkObjectFilePath, []byte(common.ToJson(f)), 0644)
 	if err != nil {
@@ -352,13 +366,68 @@ func dumpFramework(f *ci.Framework) {
 		FrameworkObjectFilePath)
 }
 
+func (b *FrameworkBarrier) generateOutputs(result *FrameworkReadyResult) {
+	for _, format := range b.bConfig.OutputFormats {
+		switch format {
+		case OutputFormatInjector:
+			b.generateInjector(result.Framework)
+		case OutputFormatJson:
+			writeOutputFile(PeersJsonFilePath, common.ToJson(result.Tasks))
+		case OutputFormatDotenv:
+			generateDotenv(result)
+		case OutputFormatHosts:
+			generateHosts(result)
+		}
+	}
+}
+
+func writeOutputFile(filePath string, content string) {
+	err := ioutil.WriteFile(filePath, []byte(content), 0644)
+	if err != nil {
+		klog.Errorf(
+			"Failed to generate the output to local file: %v, %v", filePath, err)
+		exit(ci.CompletionCode(1))
+	}
+
+	klog.Infof("Succeeded to generate the output to local file: %v", filePath)
+}
+
+// FB_{UpperCase({TaskRoleName})}_IPS={Task[0].PodIP},...
+func generateDotenv(result *FrameworkReadyResult) {
+	var dotenv strings.Builder
+	for _, taskRoleSpec := range result.Framework.Spec.TaskRoles {
+		tasks := result.GetTaskRoleTasks(taskRoleSpec.Name)
+		if len(tasks) == 0 {
+			continue
+		}
+
+		ips := []string{}
+		for _, task := range tasks {
+			ips = append(ips, task.PodIP)
+		}
+		dotenv.WriteString(getTaskRoleEnvName(taskRoleSpec.Name, "IPS") + "=" +
+			strings.Join(ips, ",") + "\n")
+	}
+	writeOutputFile(PeersDotenvFilePath, dotenv.String())
+}
+
+// {PodIP} {TaskRoleName}-{TaskIndex}
+func generateHosts(result *FrameworkReadyResult) {
+	var hosts strings.Builder
+	for _, task := range result.Tasks {
+		hosts.WriteString(task.PodIP + " " +
+			ci.GetTaskHostname(task.TaskRoleName, task.TaskIndex) + "\n")
+	}
+	writeOutputFile(PeersHostsFilePath, hosts.String())
+}
+
 func getTaskRoleEnvName(taskRoleName string, suffix string) string {
 	return strings.Join([]string{"FB", strings.ToUpper(taskRoleName), suffix}, "_")
 }
 
-// All Tasks in f.Spec must be also included in f.Status as Ready, so inject from
-// f.Status is enough.
-func generateInjector(f *ci.Framework) {
+// All Tasks of the selected TaskRoles in f.Spec must be also included in f.Status
+// as Ready, so inject from f.Status is enough.
+func (b *FrameworkBarrier) generateInjector(f *ci.Framework) {
 	var injector strings.Builder
 	injector.WriteString("#!/bin/bash")
 	injector.WriteString("\n")
@@ -378,14 +447,14 @@ func generateInjector(f *ci.Framework) {
 			taskCountStatus := int32(len(taskRoleStatus.TaskStatuses))
 
 			taskRoleSpec := f.GetTaskRoleSpec(taskRoleName)
-			if taskRoleSpec == nil {
+			if taskRoleSpec == nil || !b.waitOptions().isTaskRoleSelected(taskRoleName) {
 				continue
 			}
 
 			ipsEnvName := getTaskRoleEnvName(taskRoleName, "IPS")
 			injector.WriteString("export " + ipsEnvName + "=")
 
-			taskCountSpec := taskRoleSpec.TaskNumber
+			taskCountSpec := f.GetTaskNumber(taskRoleSpec)
 			taskCountStatusAndSpec := common.MinInt32(taskCountStatus, taskCountSpec)
 			for taskIndex := int32(0); taskIndex < taskCountStatusAndSpec; taskIndex++ {
 				taskStatus := taskRoleStatus.TaskStatuses[taskIndex]
@@ -410,7 +479,7 @@ func generateInjector(f *ci.Framework) {
 			taskCountStatus := int32(len(taskRoleStatus.TaskStatuses))
 
 			taskRoleSpec := f.GetTaskRoleSpec(taskRoleName)
-			if taskRoleSpec == nil {
+			if taskRoleSpec == nil || !b.waitOptions().isTaskRoleSelected(taskRoleName) {
 				continue
 			}
 
@@ -418,7 +487,7 @@ func generateInjector(f *ci.Framework) {
 			portEnvName := getTaskRoleEnvName(taskRoleName, "PORT")
 			injector.WriteString("export " + addrsEnvName + "=")
 
-			taskCountSpec := taskRoleSpec.TaskNumber
+			taskCountSpec := f.GetTaskNumber(taskRoleSpec)
 			taskCountStatusAndSpec := common.MinInt32(taskCountStatus, taskCountSpec)
 			for taskIndex := int32(0); taskIndex < taskCountStatusAndSpec; taskIndex++ {
 				taskStatus := taskRoleStatus.TaskStatuses[taskIndex]
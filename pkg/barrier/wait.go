@@ -0,0 +1,308 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package barrier
+
+import (
+	"context"
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	frameworkClient "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	core "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeClient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+	"time"
+)
+
+// The library form of the FrameworkBarrier, so that the Go-based launchers can
+// embed the barrier behavior without the FrameworkBarrier initContainer:
+//   result, err := barrier.WaitFrameworkReady(ctx, kClient, fClient,
+//     "default/tf-ps", &barrier.WaitOptions{TaskRoleNames: []string{"ps"}})
+//   for _, task := range result.Tasks {
+//     ... task.PodIP ...
+//   }
+
+type Readiness string
+
+const (
+	ReadinessPodIPAssigned   Readiness = "PodIPAssigned"
+	ReadinessPodRunning      Readiness = "PodRunning"
+	ReadinessContainersReady Readiness = "ContainersReady"
+)
+
+type WaitOptions struct {
+	// The TaskRoles to wait for.
+	// Empty means all TaskRoles in the Framework.
+	TaskRoleNames []string
+	// The condition for a Task to be ready.
+	// Default to ReadinessPodIPAssigned.
+	Readiness Readiness
+	// Check interval and timeout to expect all Tasks of the TaskRoles are ready.
+	// The timeout is also bounded by the ctx.
+	// Default to 10s and 10min.
+	CheckInterval time.Duration
+	CheckTimeout  time.Duration
+}
+
+// TaskInfo is the ready Task in the FrameworkReadyResult.
+type TaskInfo struct {
+	TaskRoleName string    `json:"taskRoleName"`
+	TaskIndex    int32     `json:"taskIndex"`
+	PodName      string    `json:"podName"`
+	PodUID       types.UID `json:"podUID"`
+	PodIP        string    `json:"podIP"`
+	// Empty if it is not available.
+	PodHostIP string `json:"podHostIP"`
+	PodFQDN   string `json:"podFQDN"`
+}
+
+type FrameworkReadyResult struct {
+	// The decompressed Framework object which passed the barrier.
+	Framework *ci.Framework
+	// The ready Tasks of the TaskRoles to wait for, ordered by the TaskRoles in
+	// the Framework Spec and then the TaskIndex.
+	Tasks []*TaskInfo
+}
+
+// GetTaskRoleTasks returns the ready Tasks of the TaskRole ordered by the
+// TaskIndex.
+func (r *FrameworkReadyResult) GetTaskRoleTasks(taskRoleName string) []*TaskInfo {
+	tasks := []*TaskInfo{}
+	for _, task := range r.Tasks {
+		if task.TaskRoleName == taskRoleName {
+			tasks = append(tasks, task)
+		}
+	}
+	return tasks
+}
+
+func (o *WaitOptions) isTaskRoleSelected(taskRoleName string) bool {
+	if len(o.TaskRoleNames) == 0 {
+		return true
+	}
+	for _, selectedName := range o.TaskRoleNames {
+		if selectedName == taskRoleName {
+			return true
+		}
+	}
+	return false
+}
+
+// WaitFrameworkReady waits until all Tasks of the TaskRoles to wait for in the
+// Framework of the key, i.e. {FrameworkNamespace}/{FrameworkName}, are ready.
+// It returns:
+// 1. The apiErrors.IsNotFound error immediately, if the Framework does not exist.
+// 2. The wait.ErrWaitTimeout error, if the Tasks are not ready before timeout.
+// 3. The last error, if the Framework cannot be got or decompressed before
+//    timeout.
+// The kClient is only required by ReadinessContainersReady.
+func WaitFrameworkReady(
+	ctx context.Context,
+	kClient kubeClient.Interface, fClient frameworkClient.Interface,
+	key string, opts *WaitOptions) (*FrameworkReadyResult, error) {
+	namespace, name, err := cache.SplitMetaNamespaceKey(key)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to split Framework key %v: %v", key, err)
+	}
+
+	o := *opts
+	if o.Readiness == "" {
+		o.Readiness = ReadinessPodIPAssigned
+	}
+	if o.Readiness != ReadinessPodIPAssigned &&
+		o.Readiness != ReadinessPodRunning &&
+		o.Readiness != ReadinessContainersReady {
+		return nil, fmt.Errorf("Readiness %v is not supported", o.Readiness)
+	}
+	if o.Readiness == ReadinessContainersReady && kClient == nil {
+		return nil, fmt.Errorf(
+			"Readiness %v requires the kClient", ReadinessContainersReady)
+	}
+	if o.CheckInterval <= 0 {
+		o.CheckInterval = 10 * time.Second
+	}
+	if o.CheckTimeout <= 0 {
+		o.CheckTimeout = 10 * time.Minute
+	}
+
+	ctx, cancel := context.WithTimeout(ctx, o.CheckTimeout)
+	defer cancel()
+
+	var result *FrameworkReadyResult
+	var lastErr error
+	pollErr := wait.PollImmediateUntil(o.CheckInterval,
+		func() (bool, error) {
+			f, err := fClient.FrameworkcontrollerV1().
+				Frameworks(namespace).Get(name, meta.GetOptions{})
+			if err != nil {
+				klog.Warningf("Failed to get Framework object from ApiServer: %v", err)
+				if apiErrors.IsNotFound(err) {
+					// Permanent Error: Early Stop
+					return false, err
+				}
+				// Unknown Error: Poll Until Timeout
+				lastErr = err
+				return false, nil
+			}
+
+			err = f.Decompress()
+			if err != nil {
+				klog.Warningf("Failed to decompress Framework object: %v", err)
+				// Unknown Error: Poll Until Timeout
+				lastErr = err
+				return false, nil
+			}
+
+			lastErr = nil
+			result = getFrameworkReadyResult(kClient, f, &o)
+			return result != nil, nil
+		}, ctx.Done())
+
+	if pollErr == wait.ErrWaitTimeout && lastErr != nil {
+		// May also timeout, but still treat as Unknown Error
+		return nil, lastErr
+	}
+	if pollErr != nil {
+		return nil, pollErr
+	}
+	return result, nil
+}
+
+// Return nil if the barrier is not passed.
+func getFrameworkReadyResult(
+	kClient kubeClient.Interface, f *ci.Framework,
+	o *WaitOptions) *FrameworkReadyResult {
+	// Fully counting Tasks in f.Status against f.Spec, as FrameworkController may
+	// have not persist DeletionPending (ScaleDown) Tasks according to current
+	// f.Spec.
+	totalTaskCount := int32(0)
+	readyTasks := []*TaskInfo{}
+	for _, taskRoleSpec := range f.Spec.TaskRoles {
+		taskRoleName := taskRoleSpec.Name
+		if !o.isTaskRoleSelected(taskRoleName) {
+			continue
+		}
+		taskCountSpec := f.GetTaskNumber(taskRoleSpec)
+		totalTaskCount += taskCountSpec
+
+		if f.Status == nil {
+			continue
+		}
+
+		taskRoleStatus := f.GetTaskRoleStatus(taskRoleName)
+		if taskRoleStatus == nil {
+			continue
+		}
+
+		taskCountStatus := int32(len(taskRoleStatus.TaskStatuses))
+		taskCountStatusAndSpec := common.MinInt32(taskCountStatus, taskCountSpec)
+		for taskIndex := int32(0); taskIndex < taskCountStatusAndSpec; taskIndex++ {
+			taskStatus := taskRoleStatus.TaskStatuses[taskIndex]
+			if isTaskReady(kClient, f, taskStatus, o.Readiness, true) {
+				readyTasks = append(readyTasks, newTaskInfo(taskRoleName, taskStatus))
+			}
+		}
+	}
+
+	// Wait until readyTaskCount is consistent with totalTaskCount.
+	readyTaskCount := int32(len(readyTasks))
+	if readyTaskCount >= totalTaskCount {
+		klog.Infof("BarrierPassed: "+
+			"%v/%v Tasks are ready by %v.",
+			readyTaskCount, totalTaskCount, o.Readiness)
+		return &FrameworkReadyResult{Framework: f, Tasks: readyTasks}
+	} else {
+		klog.Warningf("BarrierNotPassed: "+
+			"%v/%v Tasks are ready by %v.",
+			readyTaskCount, totalTaskCount, o.Readiness)
+		return nil
+	}
+}
+
+func newTaskInfo(taskRoleName string, ts *ci.TaskStatus) *TaskInfo {
+	task := &TaskInfo{
+		TaskRoleName: taskRoleName,
+		TaskIndex:    ts.Index,
+		PodName:      ts.PodName(),
+		PodIP:        *ts.AttemptStatus.PodIP,
+	}
+	if ts.PodUID() != nil {
+		task.PodUID = *ts.PodUID()
+	}
+	if ts.AttemptStatus.PodHostIP != nil {
+		task.PodHostIP = *ts.AttemptStatus.PodHostIP
+	}
+	if ts.AttemptStatus.PodFQDN != nil {
+		task.PodFQDN = *ts.AttemptStatus.PodFQDN
+	}
+	return task
+}
+
+func isTaskReady(
+	kClient kubeClient.Interface, f *ci.Framework, ts *ci.TaskStatus,
+	readiness Readiness, ignoreDeletionPending bool) bool {
+	if ts.IsDeletionPendingIgnored(ignoreDeletionPending) {
+		return false
+	}
+	// The PodIP is always required to be injected.
+	if ts.AttemptStatus.PodIP == nil || *ts.AttemptStatus.PodIP == "" {
+		return false
+	}
+
+	switch readiness {
+	case ReadinessPodRunning:
+		return ts.State == ci.TaskAttemptRunning
+	case ReadinessContainersReady:
+		return isPodContainersReady(kClient, f, ts)
+	default:
+		return true
+	}
+}
+
+// The ContainersReady is not tracked in the TaskStatus, so get it from the Pod.
+func isPodContainersReady(
+	kClient kubeClient.Interface, f *ci.Framework, ts *ci.TaskStatus) bool {
+	if ts.PodName() == "" {
+		return false
+	}
+
+	pod, err := kClient.CoreV1().Pods(f.Namespace).Get(ts.PodName(), meta.GetOptions{})
+	if err != nil {
+		klog.Warningf("Failed to get Pod %v from ApiServer: %v", ts.PodName(), err)
+		return false
+	}
+	if ts.PodUID() == nil || pod.UID != *ts.PodUID() {
+		return false
+	}
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == core.ContainersReady {
+			return cond.Status == core.ConditionTrue
+		}
+	}
+	return false
+}
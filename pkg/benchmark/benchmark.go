@@ -0,0 +1,585 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package benchmark
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/builder"
+	frameworkClient "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"github.com/microsoft/frameworkcontroller/pkg/internal"
+	"github.com/microsoft/frameworkcontroller/pkg/test"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog"
+	"math/rand"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+)
+
+// FrameworkController Extension: FrameworkBenchmark
+//
+// Best Practice:
+// It is usually run before each release to measure the performance regressions
+// of FrameworkController, by comparing the Result with the previous releases
+// under the same Config.
+//
+// Usage:
+// It creates ${BENCH_FRAMEWORK_NUMBER} synthetic Frameworks, each with
+// ${BENCH_TASK_NUMBER} Tasks, waits until all of them are completed, and then
+// prints the Result in YAML to stdout.
+// ${BENCH_TASK_FAILURE_PERCENT} of the Tasks fail in their first TaskAttempts
+// with exit code 1 and then succeed in their retried TaskAttempts.
+//
+// ${BENCH_MODE} can be:
+//   Fake:
+//     Run an in-process FrameworkController against the fake clientsets, and
+//     simulate the kubelet to run and complete the Pods, so only the controller
+//     sync cost is measured, and the SyncLatency is recorded.
+//   Cluster:
+//     Create the Frameworks in the cluster specified by the same kube config as
+//     FrameworkController, such as a kind cluster, in which FrameworkController
+//     should be already running. Each Task runs ${BENCH_IMAGE} for
+//     ${BENCH_TASK_DURATION_SEC} seconds, and the Framework status updates are
+//     recorded instead of the syncs.
+//
+// The caller can also specify:
+//   ${BENCH_NAMESPACE}: The namespace of the Frameworks.
+//   ${BENCH_TIMEOUT_SEC}: The benchmark fails if not all Frameworks are
+//     completed within it.
+type FrameworkBenchmark struct {
+	bConfig *Config
+	runID   string
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// Constants
+///////////////////////////////////////////////////////////////////////////////////////
+const (
+	ComponentName = "fcbench"
+
+	// The label of all Frameworks created by the same benchmark run.
+	LabelKeyRunID = "fcbench-run-id"
+
+	// Space separated TaskIndices whose first TaskAttempts should fail.
+	EnvNameFailedTaskIndices = "BENCH_FAILED_TASK_INDICES"
+
+	EnvNameMode               = "BENCH_MODE"
+	EnvNameNamespace          = "BENCH_NAMESPACE"
+	EnvNameFrameworkNumber    = "BENCH_FRAMEWORK_NUMBER"
+	EnvNameTaskNumber         = "BENCH_TASK_NUMBER"
+	EnvNameTaskFailurePercent = "BENCH_TASK_FAILURE_PERCENT"
+	EnvNameTaskDurationSec    = "BENCH_TASK_DURATION_SEC"
+	EnvNameImage              = "BENCH_IMAGE"
+	EnvNameTimeoutSec         = "BENCH_TIMEOUT_SEC"
+)
+
+type Mode string
+
+const (
+	ModeFake    Mode = "Fake"
+	ModeCluster Mode = "Cluster"
+)
+
+///////////////////////////////////////////////////////////////////////////////////////
+// Config
+///////////////////////////////////////////////////////////////////////////////////////
+type Config struct {
+	// See the same fields in pkg/apis/frameworkcontroller/v1/config.go
+	KubeApiServerAddress string `yaml:"kubeApiServerAddress"`
+	KubeConfigFilePath   string `yaml:"kubeConfigFilePath"`
+
+	Mode               Mode   `yaml:"mode"`
+	Namespace          string `yaml:"namespace"`
+	FrameworkNumber    int32  `yaml:"frameworkNumber"`
+	TaskNumber         int32  `yaml:"taskNumber"`
+	TaskFailurePercent int32  `yaml:"taskFailurePercent"`
+	TaskDurationSec    int64  `yaml:"taskDurationSec"`
+	Image              string `yaml:"image"`
+	TimeoutSec         int64  `yaml:"timeoutSec"`
+}
+
+func newConfig() *Config {
+	c := Config{}
+
+	// Setting and Defaulting
+	c.KubeApiServerAddress = ci.EnvValueKubeApiServerAddress
+	c.KubeConfigFilePath = ci.EnvValueKubeConfigFilePath
+	if c.KubeConfigFilePath == "" {
+		if _, err := os.Stat(ci.DefaultKubeConfigFilePath); err == nil {
+			c.KubeConfigFilePath = ci.DefaultKubeConfigFilePath
+		}
+	}
+
+	c.Mode = Mode(getEnvString(EnvNameMode, string(ModeFake)))
+	c.Namespace = getEnvString(EnvNameNamespace, meta.NamespaceDefault)
+	c.FrameworkNumber = int32(getEnvInt(EnvNameFrameworkNumber, 100))
+	c.TaskNumber = int32(getEnvInt(EnvNameTaskNumber, 10))
+	c.TaskFailurePercent = int32(getEnvInt(EnvNameTaskFailurePercent, 0))
+	c.TaskDurationSec = getEnvInt(EnvNameTaskDurationSec, 1)
+	c.Image = getEnvString(EnvNameImage, "busybox")
+	c.TimeoutSec = getEnvInt(EnvNameTimeoutSec, 600)
+
+	// Validation
+	errPrefix := "Config Validation Failed: "
+	if c.Mode != ModeFake && c.Mode != ModeCluster {
+		panic(fmt.Errorf(errPrefix+
+			"%v %v is not supported", EnvNameMode, c.Mode))
+	}
+	if c.FrameworkNumber < 1 {
+		panic(fmt.Errorf(errPrefix+
+			"%v %v should not be less than 1", EnvNameFrameworkNumber, c.FrameworkNumber))
+	}
+	if c.TaskNumber < 1 {
+		panic(fmt.Errorf(errPrefix+
+			"%v %v should not be less than 1", EnvNameTaskNumber, c.TaskNumber))
+	}
+	if c.TaskFailurePercent < 0 || c.TaskFailurePercent > 100 {
+		panic(fmt.Errorf(errPrefix+
+			"%v %v should be within [0, 100]", EnvNameTaskFailurePercent, c.TaskFailurePercent))
+	}
+	if c.TaskDurationSec < 0 {
+		panic(fmt.Errorf(errPrefix+
+			"%v %v should not be less than 0", EnvNameTaskDurationSec, c.TaskDurationSec))
+	}
+	if c.TimeoutSec < 1 {
+		panic(fmt.Errorf(errPrefix+
+			"%v %v should not be less than 1", EnvNameTimeoutSec, c.TimeoutSec))
+	}
+
+	return &c
+}
+
+func getEnvString(name string, defaultValue string) string {
+	if value := os.Getenv(name); value != "" {
+		return value
+	}
+	return defaultValue
+}
+
+func getEnvInt(name string, defaultValue int64) int64 {
+	value := os.Getenv(name)
+	if value == "" {
+		return defaultValue
+	}
+	i, err := strconv.ParseInt(value, 10, 64)
+	if err != nil {
+		panic(fmt.Errorf("Failed to parse %v %v as integer: %v", name, value, err))
+	}
+	return i
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// Result
+///////////////////////////////////////////////////////////////////////////////////////
+type Result struct {
+	Config *Config `yaml:"config"`
+	RunID  string  `yaml:"runID"`
+
+	DurationSec               float64 `yaml:"durationSec"`
+	SucceededFrameworkNumber  int32   `yaml:"succeededFrameworkNumber"`
+	FailedFrameworkNumber     int32   `yaml:"failedFrameworkNumber"`
+	CompletedFrameworksPerSec float64 `yaml:"completedFrameworksPerSec"`
+
+	// From the Framework is created to it is observed as Completed.
+	FrameworkLatency *LatencyStats `yaml:"frameworkLatency"`
+
+	// Only for ModeFake.
+	SyncNumber     int64         `yaml:"syncNumber,omitempty"`
+	SyncsPerSec    float64       `yaml:"syncsPerSec,omitempty"`
+	SyncLatency    *LatencyStats `yaml:"syncLatency,omitempty"`
+	SyncErrorCount int64         `yaml:"syncErrorCount,omitempty"`
+
+	// Only for ModeCluster.
+	StatusUpdateNumber  int64   `yaml:"statusUpdateNumber,omitempty"`
+	StatusUpdatesPerSec float64 `yaml:"statusUpdatesPerSec,omitempty"`
+}
+
+type LatencyStats struct {
+	P50Ms float64 `yaml:"p50Ms"`
+	P90Ms float64 `yaml:"p90Ms"`
+	P99Ms float64 `yaml:"p99Ms"`
+	MaxMs float64 `yaml:"maxMs"`
+}
+
+func newLatencyStats(durations []time.Duration) *LatencyStats {
+	if len(durations) == 0 {
+		return &LatencyStats{}
+	}
+
+	sort.Slice(durations, func(i, j int) bool { return durations[i] < durations[j] })
+	percentile := func(p float64) float64 {
+		i := int(p * float64(len(durations)-1))
+		return float64(durations[i]) / float64(time.Millisecond)
+	}
+	return &LatencyStats{
+		P50Ms: percentile(0.50),
+		P90Ms: percentile(0.90),
+		P99Ms: percentile(0.99),
+		MaxMs: percentile(1),
+	}
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// Methods
+///////////////////////////////////////////////////////////////////////////////////////
+func NewFrameworkBenchmark() *FrameworkBenchmark {
+	klog.Infof("Initializing %v", ComponentName)
+
+	bConfig := newConfig()
+	klog.Infof("With Config: \n%v", common.ToYaml(bConfig))
+
+	return &FrameworkBenchmark{
+		bConfig: bConfig,
+		runID:   fmt.Sprintf("%08x", rand.Uint32()),
+	}
+}
+
+func (b *FrameworkBenchmark) Run() {
+	klog.Infof("Running %v %v in %v mode", ComponentName, b.runID, b.bConfig.Mode)
+
+	var result *Result
+	var err error
+	if b.bConfig.Mode == ModeFake {
+		result, err = b.runFake()
+	} else {
+		result, err = b.runCluster()
+	}
+	if err != nil {
+		panic(fmt.Errorf("Failed to run %v %v: %v", ComponentName, b.runID, err))
+	}
+
+	klog.Infof("Completed %v %v", ComponentName, b.runID)
+	fmt.Print(common.ToYaml(result))
+}
+
+// The TaskIndices whose first TaskAttempts should fail, which are evenly spread
+// across all Tasks of all Frameworks.
+func (b *FrameworkBenchmark) getFailedTaskIndices(frameworkIndex int32) []int32 {
+	indices := []int32{}
+	for taskIndex := int32(0); taskIndex < b.bConfig.TaskNumber; taskIndex++ {
+		globalIndex := int64(frameworkIndex)*int64(b.bConfig.TaskNumber) + int64(taskIndex)
+		if globalIndex*int64(b.bConfig.TaskFailurePercent)/100 !=
+			(globalIndex+1)*int64(b.bConfig.TaskFailurePercent)/100 {
+			indices = append(indices, taskIndex)
+		}
+	}
+	return indices
+}
+
+func (b *FrameworkBenchmark) newFramework(frameworkIndex int32) (*ci.Framework, error) {
+	failedTaskIndices := []string{}
+	for _, taskIndex := range b.getFailedTaskIndices(frameworkIndex) {
+		failedTaskIndices = append(failedTaskIndices, strconv.Itoa(int(taskIndex)))
+	}
+
+	// Each Task fails in its first TaskAttempt if its TaskIndex is in the
+	// failedTaskIndices, otherwise it succeeds after TaskDurationSec.
+	script := fmt.Sprintf(
+		`case " $%v " in *" $%v "*) [ "$%v" = 0 ] && exit 1;; esac; sleep %v`,
+		EnvNameFailedTaskIndices, ci.EnvNameTaskIndex, ci.EnvNameTaskAttemptID,
+		b.bConfig.TaskDurationSec)
+
+	// The runID has fixed length, so the name is unique even without separator,
+	// which is not allowed by the NamingConvention.
+	return builder.NewFramework(b.bConfig.Namespace,
+		fmt.Sprintf("%v%v%v", ComponentName, b.runID, frameworkIndex)).
+		Labels(map[string]string{LabelKeyRunID: b.runID}).
+		Role("worker", b.bConfig.TaskNumber).
+		RetryPolicy(false, 1).
+		Container(core.Container{
+			Name:    "worker",
+			Image:   b.bConfig.Image,
+			Command: []string{"sh", "-c", script},
+			Env: []core.EnvVar{{
+				Name:  EnvNameFailedTaskIndices,
+				Value: strings.Join(failedTaskIndices, " "),
+			}},
+		}).
+		Build()
+}
+
+// The result of a completed Framework, and ok is false if it is not completed.
+func getCompletedResult(f *ci.Framework) (succeeded bool, ok bool) {
+	if f.Status == nil || f.Status.State != ci.FrameworkCompleted {
+		return false, false
+	}
+	return f.GetPhase() == ci.FrameworkPhaseSucceeded, true
+}
+
+func (r *Result) recordCompleted(
+	f *ci.Framework, latency time.Duration, latencies *[]time.Duration) {
+	succeeded, _ := getCompletedResult(f)
+	if succeeded {
+		r.SucceededFrameworkNumber++
+	} else {
+		r.FailedFrameworkNumber++
+		klog.Warningf("[%v]: Framework is completed but not succeeded: %v",
+			f.Key(), f.Status.AttemptStatus.CompletionStatus)
+	}
+	*latencies = append(*latencies, latency)
+}
+
+func (r *Result) complete(duration time.Duration, latencies []time.Duration) {
+	r.DurationSec = duration.Seconds()
+	r.CompletedFrameworksPerSec =
+		float64(r.SucceededFrameworkNumber+r.FailedFrameworkNumber) / duration.Seconds()
+	r.FrameworkLatency = newLatencyStats(latencies)
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// Fake Mode
+///////////////////////////////////////////////////////////////////////////////////////
+func (b *FrameworkBenchmark) runFake() (*Result, error) {
+	h := test.NewHarness(nil)
+	defer h.Stop()
+
+	result := &Result{Config: b.bConfig, RunID: b.runID}
+	createTimes := map[string]time.Time{}
+	pendingKeys := []string{}
+	for i := int32(0); i < b.bConfig.FrameworkNumber; i++ {
+		f, err := b.newFramework(i)
+		if err != nil {
+			return nil, err
+		}
+		createTimes[f.Key()] = time.Now()
+		if _, err := h.CreateFramework(f); err != nil {
+			return nil, err
+		}
+		pendingKeys = append(pendingKeys, f.Key())
+	}
+
+	startTime := time.Now()
+	timeout := common.SecToDuration(&b.bConfig.TimeoutSec)
+	syncLatencies := []time.Duration{}
+	frameworkLatencies := []time.Duration{}
+	for len(pendingKeys) > 0 {
+		if time.Since(startTime) > timeout {
+			return nil, fmt.Errorf(
+				"%v Frameworks are not completed within %v",
+				len(pendingKeys), timeout)
+		}
+
+		// Each sync only touches the objects of its own Framework, so only wait for
+		// the local cache once per round.
+		if err := h.WaitForCacheSync(); err != nil {
+			return nil, err
+		}
+		var syncDuration time.Duration
+		for _, key := range pendingKeys {
+			syncStartTime := time.Now()
+			err := h.Controller.SyncFramework(key)
+			syncLatency := time.Since(syncStartTime)
+			syncDuration += syncLatency
+			syncLatencies = append(syncLatencies, syncLatency)
+			if err != nil {
+				result.SyncErrorCount++
+				klog.Warningf("[%v]: Failed to sync Framework: %v", key, err)
+			}
+		}
+		result.SyncNumber += int64(len(pendingKeys))
+
+		if err := h.WaitForCacheSync(); err != nil {
+			return nil, err
+		}
+		if err := b.simulateKubelet(h); err != nil {
+			return nil, err
+		}
+
+		remainingKeys := []string{}
+		for _, key := range pendingKeys {
+			namespace, name := ci.SplitFrameworkKey(key)
+			f, err := h.GetFramework(namespace, name)
+			if err != nil {
+				return nil, err
+			}
+			if _, ok := getCompletedResult(f); ok {
+				result.recordCompleted(
+					f, time.Since(createTimes[key]), &frameworkLatencies)
+			} else {
+				remainingKeys = append(remainingKeys, key)
+			}
+		}
+		pendingKeys = remainingKeys
+
+		// Pass the possible retry delay, which is not measured.
+		h.Step(time.Second)
+	}
+
+	result.complete(time.Since(startTime), frameworkLatencies)
+	var totalSyncDuration time.Duration
+	for _, syncLatency := range syncLatencies {
+		totalSyncDuration += syncLatency
+	}
+	result.SyncsPerSec = float64(result.SyncNumber) / totalSyncDuration.Seconds()
+	result.SyncLatency = newLatencyStats(syncLatencies)
+	return result, nil
+}
+
+// Run the Pending Pods and complete the Running Pods, i.e. each Pod completes
+// in the second round after it is created.
+func (b *FrameworkBenchmark) simulateKubelet(h *test.Harness) error {
+	pods, err := h.ListPods(b.bConfig.Namespace)
+	if err != nil {
+		return err
+	}
+
+	for _, pod := range pods {
+		if pod.DeletionTimestamp != nil {
+			continue
+		}
+
+		switch pod.Status.Phase {
+		case core.PodPending:
+			if _, err := h.RunPod(pod.Namespace, pod.Name); err != nil {
+				return err
+			}
+		case core.PodRunning:
+			exitCode := int32(0)
+			if pod.Annotations[ci.AnnotationKeyTaskAttemptID] == "0" &&
+				isFailedTaskIndex(&pod) {
+				exitCode = 1
+			}
+			if _, err := h.CompletePod(pod.Namespace, pod.Name, exitCode); err != nil {
+				return err
+			}
+		}
+	}
+	return nil
+}
+
+func isFailedTaskIndex(pod *core.Pod) bool {
+	taskIndex := pod.Annotations[ci.AnnotationKeyTaskIndex]
+	for _, container := range pod.Spec.Containers {
+		for _, env := range container.Env {
+			if env.Name == EnvNameFailedTaskIndices {
+				for _, failedTaskIndex := range strings.Fields(env.Value) {
+					if failedTaskIndex == taskIndex {
+						return true
+					}
+				}
+			}
+		}
+	}
+	return false
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// Cluster Mode
+///////////////////////////////////////////////////////////////////////////////////////
+func (b *FrameworkBenchmark) runCluster() (*Result, error) {
+	kConfig, err := clientcmd.BuildConfigFromFlags(
+		b.bConfig.KubeApiServerAddress, b.bConfig.KubeConfigFilePath)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to build KubeConfig: %v", err)
+	}
+	_, fClient := internal.CreateClients(kConfig)
+	defer b.cleanupCluster(fClient)
+
+	fInterface := fClient.FrameworkcontrollerV1().Frameworks(b.bConfig.Namespace)
+	listOptions := meta.ListOptions{LabelSelector: LabelKeyRunID + "=" + b.runID}
+
+	// Watch before creating, so that no status update is missed.
+	w, err := fInterface.Watch(listOptions)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to watch Frameworks: %v", err)
+	}
+	defer func() { w.Stop() }()
+
+	result := &Result{Config: b.bConfig, RunID: b.runID}
+	createTimes := map[string]time.Time{}
+	for i := int32(0); i < b.bConfig.FrameworkNumber; i++ {
+		f, err := b.newFramework(i)
+		if err != nil {
+			return nil, err
+		}
+		createTimes[f.Key()] = time.Now()
+		if _, err := fInterface.Create(f); err != nil {
+			return nil, fmt.Errorf("[%v]: Failed to create Framework: %v", f.Key(), err)
+		}
+	}
+
+	startTime := time.Now()
+	timeoutCh := time.After(common.SecToDuration(&b.bConfig.TimeoutSec))
+	frameworkLatencies := []time.Duration{}
+	for len(frameworkLatencies) < len(createTimes) {
+		select {
+		case <-timeoutCh:
+			return nil, fmt.Errorf(
+				"%v Frameworks are not completed within %vs",
+				len(createTimes)-len(frameworkLatencies), b.bConfig.TimeoutSec)
+		case event, ok := <-w.ResultChan():
+			if !ok {
+				// The watch may be closed by the ApiServer, so rewatch from the latest
+				// list, and the completed Frameworks in it are recorded below.
+				w, err = fInterface.Watch(listOptions)
+				if err != nil {
+					return nil, fmt.Errorf("Failed to rewatch Frameworks: %v", err)
+				}
+				continue
+			}
+			if event.Type != watch.Modified {
+				continue
+			}
+			f, ok := event.Object.(*ci.Framework)
+			if !ok {
+				continue
+			}
+			result.StatusUpdateNumber++
+
+			createTime, pending := createTimes[f.Key()]
+			if !pending {
+				continue
+			}
+			if err := f.Decompress(); err != nil {
+				return nil, err
+			}
+			if _, ok := getCompletedResult(f); ok {
+				result.recordCompleted(f, time.Since(createTime), &frameworkLatencies)
+				delete(createTimes, f.Key())
+			}
+		}
+	}
+
+	result.complete(time.Since(startTime), frameworkLatencies)
+	result.StatusUpdatesPerSec = float64(result.StatusUpdateNumber) / result.DurationSec
+	return result, nil
+}
+
+func (b *FrameworkBenchmark) cleanupCluster(fClient frameworkClient.Interface) {
+	klog.Infof("Deleting all Frameworks of %v %v", ComponentName, b.runID)
+	err := fClient.FrameworkcontrollerV1().Frameworks(b.bConfig.Namespace).DeleteCollection(
+		&meta.DeleteOptions{PropagationPolicy: common.PtrDeletionPropagation(
+			meta.DeletePropagationForeground)},
+		meta.ListOptions{LabelSelector: LabelKeyRunID + "=" + b.runID})
+	if err != nil {
+		klog.Warningf("Failed to delete Frameworks of %v %v: %v",
+			ComponentName, b.runID, err)
+	}
+}
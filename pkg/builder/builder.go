@@ -0,0 +1,244 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package builder
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/validation"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/errors"
+)
+
+// The fluent API to construct the Framework in Go, which applies the same
+// defaults as the Framework CRD and validates the Framework before it is
+// submitted:
+//   f, err := builder.NewFramework("default", "mnist").
+//     RetryPolicy(true, 2).
+//     Role("ps", 2).PodTemplate(psPod).
+//     Role("worker", 8).PodTemplate(workerPod).
+//     RetryPolicy(false, 0).CompletionPolicy(1, 8).
+//     Build()
+//
+// The methods after the Role apply to that TaskRole until the next Role, such
+// as the RetryPolicy of the RoleBuilder is the Task RetryPolicy, and the other
+// methods of the FrameworkBuilder can still be called.
+// Once any method fails, the following methods are ignored and the Build
+// returns the first error.
+
+type FrameworkBuilder struct {
+	f   *ci.Framework
+	err error
+}
+
+type RoleBuilder struct {
+	*FrameworkBuilder
+	taskRole *ci.TaskRoleSpec
+}
+
+// NewFramework starts to build the Framework with the ExecutionStart and no
+// Framework retry.
+func NewFramework(namespace string, name string) *FrameworkBuilder {
+	return &FrameworkBuilder{
+		f: &ci.Framework{
+			TypeMeta: meta.TypeMeta{
+				APIVersion: ci.SchemeGroupVersion.String(),
+				Kind:       ci.FrameworkKind,
+			},
+			ObjectMeta: meta.ObjectMeta{
+				Namespace: namespace,
+				Name:      name,
+			},
+			Spec: ci.FrameworkSpec{
+				ExecutionType: ci.ExecutionStart,
+				RetryPolicy:   ci.RetryPolicySpec{FancyRetryPolicy: false, MaxRetryCount: 0},
+			},
+		},
+	}
+}
+
+func (b *FrameworkBuilder) Labels(labels map[string]string) *FrameworkBuilder {
+	if b.err == nil {
+		b.f.Labels = labels
+	}
+	return b
+}
+
+func (b *FrameworkBuilder) Annotations(annotations map[string]string) *FrameworkBuilder {
+	if b.err == nil {
+		b.f.Annotations = annotations
+	}
+	return b
+}
+
+func (b *FrameworkBuilder) Description(description string) *FrameworkBuilder {
+	if b.err == nil {
+		b.f.Spec.Description = description
+	}
+	return b
+}
+
+func (b *FrameworkBuilder) ExecutionType(executionType ci.ExecutionType) *FrameworkBuilder {
+	if b.err == nil {
+		b.f.Spec.ExecutionType = executionType
+	}
+	return b
+}
+
+func (b *FrameworkBuilder) Queue(queue string, priority int32) *FrameworkBuilder {
+	if b.err == nil {
+		b.f.Spec.Queue = queue
+		b.f.Spec.QueuePriority = priority
+	}
+	return b
+}
+
+// RetryPolicy sets the Framework RetryPolicy.
+func (b *FrameworkBuilder) RetryPolicy(
+	fancyRetryPolicy bool, maxRetryCount int32) *FrameworkBuilder {
+	if b.err == nil {
+		b.f.Spec.RetryPolicy.FancyRetryPolicy = fancyRetryPolicy
+		b.f.Spec.RetryPolicy.MaxRetryCount = maxRetryCount
+	}
+	return b
+}
+
+func (b *FrameworkBuilder) RetryBudget(maxTaskRetryCount int32) *FrameworkBuilder {
+	if b.err == nil {
+		b.f.Spec.RetryBudget = &ci.RetryBudgetSpec{MaxTaskRetryCount: &maxTaskRetryCount}
+	}
+	return b
+}
+
+// Role appends a TaskRole with the given TaskNumber, no Task retry, and the
+// FrameworkAttemptCompletionPolicy which only completes the FrameworkAttempt
+// once all Tasks are completed.
+func (b *FrameworkBuilder) Role(name string, taskNumber int32) *RoleBuilder {
+	taskRole := &ci.TaskRoleSpec{
+		Name:       name,
+		TaskNumber: taskNumber,
+		FrameworkAttemptCompletionPolicy: ci.CompletionPolicySpec{
+			MinFailedTaskCount:    ci.UnlimitedValue,
+			MinSucceededTaskCount: ci.UnlimitedValue,
+		},
+		Task: ci.TaskSpec{
+			RetryPolicy: ci.RetryPolicySpec{FancyRetryPolicy: false, MaxRetryCount: 0},
+		},
+	}
+	if b.err == nil {
+		if b.f.GetTaskRoleSpec(name) != nil {
+			b.err = fmt.Errorf("TaskRole %v is duplicated", name)
+		} else {
+			b.f.Spec.TaskRoles = append(b.f.Spec.TaskRoles, taskRole)
+		}
+	}
+	return &RoleBuilder{FrameworkBuilder: b, taskRole: taskRole}
+}
+
+// PodTemplate sets the Pod template of the TaskRole, and its RestartPolicy is
+// defaulted to Never, since the Task is retried by a new Pod.
+func (b *RoleBuilder) PodTemplate(pod core.PodTemplateSpec) *RoleBuilder {
+	if b.err == nil {
+		if pod.Spec.RestartPolicy == "" {
+			pod.Spec.RestartPolicy = core.RestartPolicyNever
+		}
+		b.taskRole.Task.Pod = pod
+	}
+	return b
+}
+
+// Container appends a container to the Pod template of the TaskRole.
+func (b *RoleBuilder) Container(container core.Container) *RoleBuilder {
+	if b.err == nil {
+		if b.taskRole.Task.Pod.Spec.RestartPolicy == "" {
+			b.taskRole.Task.Pod.Spec.RestartPolicy = core.RestartPolicyNever
+		}
+		b.taskRole.Task.Pod.Spec.Containers = append(
+			b.taskRole.Task.Pod.Spec.Containers, container)
+	}
+	return b
+}
+
+// RetryPolicy sets the Task RetryPolicy of the TaskRole.
+func (b *RoleBuilder) RetryPolicy(
+	fancyRetryPolicy bool, maxRetryCount int32) *RoleBuilder {
+	if b.err == nil {
+		b.taskRole.Task.RetryPolicy.FancyRetryPolicy = fancyRetryPolicy
+		b.taskRole.Task.RetryPolicy.MaxRetryCount = maxRetryCount
+	}
+	return b
+}
+
+// CompletionPolicy sets the FrameworkAttemptCompletionPolicy of the TaskRole.
+func (b *RoleBuilder) CompletionPolicy(
+	minFailedTaskCount int32, minSucceededTaskCount int32) *RoleBuilder {
+	if b.err == nil {
+		b.taskRole.FrameworkAttemptCompletionPolicy.MinFailedTaskCount = minFailedTaskCount
+		b.taskRole.FrameworkAttemptCompletionPolicy.MinSucceededTaskCount = minSucceededTaskCount
+	}
+	return b
+}
+
+func (b *RoleBuilder) MinTaskNumber(minTaskNumber int32) *RoleBuilder {
+	if b.err == nil {
+		b.taskRole.MinTaskNumber = minTaskNumber
+	}
+	return b
+}
+
+func (b *RoleBuilder) DependsOn(taskRoleNames ...string) *RoleBuilder {
+	if b.err == nil {
+		b.taskRole.DependsOn = append(b.taskRole.DependsOn, taskRoleNames...)
+	}
+	return b
+}
+
+func (b *RoleBuilder) PodGracefulDeletionTimeoutSec(timeoutSec int64) *RoleBuilder {
+	if b.err == nil {
+		b.taskRole.Task.PodGracefulDeletionTimeoutSec = &timeoutSec
+	}
+	return b
+}
+
+// Build validates and returns the Framework, see validation.Validate.
+func (b *FrameworkBuilder) Build() (*ci.Framework, error) {
+	if b.err != nil {
+		return nil, fmt.Errorf(
+			"Failed to build Framework %v: %v", b.f.Name, b.err)
+	}
+	if err := Validate(b.f); err != nil {
+		return nil, err
+	}
+	return b.f.DeepCopy(), nil
+}
+
+// Validate returns the aggregated errors of validation.Validate.
+func Validate(f *ci.Framework) error {
+	errs := validation.Validate(f)
+	if len(errs) == 0 {
+		return nil
+	}
+	return fmt.Errorf("Framework %v is invalid: %v",
+		f.Name, errors.NewAggregate(errs))
+}
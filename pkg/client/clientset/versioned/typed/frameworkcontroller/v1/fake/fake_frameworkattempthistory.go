@@ -0,0 +1,128 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	frameworkcontrollerv1 "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeFrameworkAttemptHistories implements FrameworkAttemptHistoryInterface
+type FakeFrameworkAttemptHistories struct {
+	Fake *FakeFrameworkcontrollerV1
+	ns   string
+}
+
+var frameworkattempthistoriesResource = schema.GroupVersionResource{Group: "frameworkcontroller.microsoft.com", Version: "v1", Resource: "frameworkattempthistories"}
+
+var frameworkattempthistoriesKind = schema.GroupVersionKind{Group: "frameworkcontroller.microsoft.com", Version: "v1", Kind: "FrameworkAttemptHistory"}
+
+// Get takes name of the frameworkAttemptHistory, and returns the corresponding frameworkAttemptHistory object, and an error if there is any.
+func (c *FakeFrameworkAttemptHistories) Get(name string, options v1.GetOptions) (result *frameworkcontrollerv1.FrameworkAttemptHistory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(frameworkattempthistoriesResource, c.ns, name), &frameworkcontrollerv1.FrameworkAttemptHistory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.FrameworkAttemptHistory), err
+}
+
+// List takes label and field selectors, and returns the list of FrameworkAttemptHistories that match those selectors.
+func (c *FakeFrameworkAttemptHistories) List(opts v1.ListOptions) (result *frameworkcontrollerv1.FrameworkAttemptHistoryList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(frameworkattempthistoriesResource, frameworkattempthistoriesKind, c.ns, opts), &frameworkcontrollerv1.FrameworkAttemptHistoryList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &frameworkcontrollerv1.FrameworkAttemptHistoryList{ListMeta: obj.(*frameworkcontrollerv1.FrameworkAttemptHistoryList).ListMeta}
+	for _, item := range obj.(*frameworkcontrollerv1.FrameworkAttemptHistoryList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested frameworkAttemptHistories.
+func (c *FakeFrameworkAttemptHistories) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(frameworkattempthistoriesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a frameworkAttemptHistory and creates it.  Returns the server's representation of the frameworkAttemptHistory, and an error, if there is any.
+func (c *FakeFrameworkAttemptHistories) Create(frameworkAttemptHistory *frameworkcontrollerv1.FrameworkAttemptHistory) (result *frameworkcontrollerv1.FrameworkAttemptHistory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(frameworkattempthistoriesResource, c.ns, frameworkAttemptHistory), &frameworkcontrollerv1.FrameworkAttemptHistory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.FrameworkAttemptHistory), err
+}
+
+// Update takes the representation of a frameworkAttemptHistory and updates it. Returns the server's representation of the frameworkAttemptHistory, and an error, if there is any.
+func (c *FakeFrameworkAttemptHistories) Update(frameworkAttemptHistory *frameworkcontrollerv1.FrameworkAttemptHistory) (result *frameworkcontrollerv1.FrameworkAttemptHistory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(frameworkattempthistoriesResource, c.ns, frameworkAttemptHistory), &frameworkcontrollerv1.FrameworkAttemptHistory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.FrameworkAttemptHistory), err
+}
+
+// Delete takes name of the frameworkAttemptHistory and deletes it. Returns an error if one occurs.
+func (c *FakeFrameworkAttemptHistories) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(frameworkattempthistoriesResource, c.ns, name), &frameworkcontrollerv1.FrameworkAttemptHistory{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeFrameworkAttemptHistories) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(frameworkattempthistoriesResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &frameworkcontrollerv1.FrameworkAttemptHistoryList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched frameworkAttemptHistory.
+func (c *FakeFrameworkAttemptHistories) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *frameworkcontrollerv1.FrameworkAttemptHistory, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(frameworkattempthistoriesResource, c.ns, name, pt, data, subresources...), &frameworkcontrollerv1.FrameworkAttemptHistory{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.FrameworkAttemptHistory), err
+}
@@ -32,6 +32,30 @@ func (c *FakeFrameworkcontrollerV1) Frameworks(namespace string) v1.FrameworkInt
 	return &FakeFrameworks{c, namespace}
 }
 
+func (c *FakeFrameworkcontrollerV1) FrameworkAttemptHistories(namespace string) v1.FrameworkAttemptHistoryInterface {
+	return &FakeFrameworkAttemptHistories{c, namespace}
+}
+
+func (c *FakeFrameworkcontrollerV1) FrameworkGroups(namespace string) v1.FrameworkGroupInterface {
+	return &FakeFrameworkGroups{c, namespace}
+}
+
+func (c *FakeFrameworkcontrollerV1) FrameworkQuotas(namespace string) v1.FrameworkQuotaInterface {
+	return &FakeFrameworkQuotas{c, namespace}
+}
+
+func (c *FakeFrameworkcontrollerV1) Queues() v1.QueueInterface {
+	return &FakeQueues{c}
+}
+
+func (c *FakeFrameworkcontrollerV1) ScheduledFrameworks(namespace string) v1.ScheduledFrameworkInterface {
+	return &FakeScheduledFrameworks{c, namespace}
+}
+
+func (c *FakeFrameworkcontrollerV1) TaskRoleScales(namespace string) v1.TaskRoleScaleInterface {
+	return &FakeTaskRoleScales{c, namespace}
+}
+
 // RESTClient returns a RESTClient that is used to communicate
 // with API server by this client implementation.
 func (c *FakeFrameworkcontrollerV1) RESTClient() rest.Interface {
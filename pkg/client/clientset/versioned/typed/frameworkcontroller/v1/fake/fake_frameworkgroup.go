@@ -0,0 +1,128 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	frameworkcontrollerv1 "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeFrameworkGroups implements FrameworkGroupInterface
+type FakeFrameworkGroups struct {
+	Fake *FakeFrameworkcontrollerV1
+	ns   string
+}
+
+var frameworkgroupsResource = schema.GroupVersionResource{Group: "frameworkcontroller.microsoft.com", Version: "v1", Resource: "frameworkgroups"}
+
+var frameworkgroupsKind = schema.GroupVersionKind{Group: "frameworkcontroller.microsoft.com", Version: "v1", Kind: "FrameworkGroup"}
+
+// Get takes name of the frameworkGroup, and returns the corresponding frameworkGroup object, and an error if there is any.
+func (c *FakeFrameworkGroups) Get(name string, options v1.GetOptions) (result *frameworkcontrollerv1.FrameworkGroup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(frameworkgroupsResource, c.ns, name), &frameworkcontrollerv1.FrameworkGroup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.FrameworkGroup), err
+}
+
+// List takes label and field selectors, and returns the list of FrameworkGroups that match those selectors.
+func (c *FakeFrameworkGroups) List(opts v1.ListOptions) (result *frameworkcontrollerv1.FrameworkGroupList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(frameworkgroupsResource, frameworkgroupsKind, c.ns, opts), &frameworkcontrollerv1.FrameworkGroupList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &frameworkcontrollerv1.FrameworkGroupList{ListMeta: obj.(*frameworkcontrollerv1.FrameworkGroupList).ListMeta}
+	for _, item := range obj.(*frameworkcontrollerv1.FrameworkGroupList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested frameworkGroups.
+func (c *FakeFrameworkGroups) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(frameworkgroupsResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a frameworkGroup and creates it.  Returns the server's representation of the frameworkGroup, and an error, if there is any.
+func (c *FakeFrameworkGroups) Create(frameworkGroup *frameworkcontrollerv1.FrameworkGroup) (result *frameworkcontrollerv1.FrameworkGroup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(frameworkgroupsResource, c.ns, frameworkGroup), &frameworkcontrollerv1.FrameworkGroup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.FrameworkGroup), err
+}
+
+// Update takes the representation of a frameworkGroup and updates it. Returns the server's representation of the frameworkGroup, and an error, if there is any.
+func (c *FakeFrameworkGroups) Update(frameworkGroup *frameworkcontrollerv1.FrameworkGroup) (result *frameworkcontrollerv1.FrameworkGroup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(frameworkgroupsResource, c.ns, frameworkGroup), &frameworkcontrollerv1.FrameworkGroup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.FrameworkGroup), err
+}
+
+// Delete takes name of the frameworkGroup and deletes it. Returns an error if one occurs.
+func (c *FakeFrameworkGroups) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(frameworkgroupsResource, c.ns, name), &frameworkcontrollerv1.FrameworkGroup{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeFrameworkGroups) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(frameworkgroupsResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &frameworkcontrollerv1.FrameworkGroupList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched frameworkGroup.
+func (c *FakeFrameworkGroups) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *frameworkcontrollerv1.FrameworkGroup, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(frameworkgroupsResource, c.ns, name, pt, data, subresources...), &frameworkcontrollerv1.FrameworkGroup{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.FrameworkGroup), err
+}
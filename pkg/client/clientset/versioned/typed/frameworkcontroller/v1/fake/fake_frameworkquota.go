@@ -0,0 +1,128 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	frameworkcontrollerv1 "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeFrameworkQuotas implements FrameworkQuotaInterface
+type FakeFrameworkQuotas struct {
+	Fake *FakeFrameworkcontrollerV1
+	ns   string
+}
+
+var frameworkquotasResource = schema.GroupVersionResource{Group: "frameworkcontroller.microsoft.com", Version: "v1", Resource: "frameworkquotas"}
+
+var frameworkquotasKind = schema.GroupVersionKind{Group: "frameworkcontroller.microsoft.com", Version: "v1", Kind: "FrameworkQuota"}
+
+// Get takes name of the frameworkQuota, and returns the corresponding frameworkQuota object, and an error if there is any.
+func (c *FakeFrameworkQuotas) Get(name string, options v1.GetOptions) (result *frameworkcontrollerv1.FrameworkQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(frameworkquotasResource, c.ns, name), &frameworkcontrollerv1.FrameworkQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.FrameworkQuota), err
+}
+
+// List takes label and field selectors, and returns the list of FrameworkQuotas that match those selectors.
+func (c *FakeFrameworkQuotas) List(opts v1.ListOptions) (result *frameworkcontrollerv1.FrameworkQuotaList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(frameworkquotasResource, frameworkquotasKind, c.ns, opts), &frameworkcontrollerv1.FrameworkQuotaList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &frameworkcontrollerv1.FrameworkQuotaList{ListMeta: obj.(*frameworkcontrollerv1.FrameworkQuotaList).ListMeta}
+	for _, item := range obj.(*frameworkcontrollerv1.FrameworkQuotaList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested frameworkQuotas.
+func (c *FakeFrameworkQuotas) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(frameworkquotasResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a frameworkQuota and creates it.  Returns the server's representation of the frameworkQuota, and an error, if there is any.
+func (c *FakeFrameworkQuotas) Create(frameworkQuota *frameworkcontrollerv1.FrameworkQuota) (result *frameworkcontrollerv1.FrameworkQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(frameworkquotasResource, c.ns, frameworkQuota), &frameworkcontrollerv1.FrameworkQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.FrameworkQuota), err
+}
+
+// Update takes the representation of a frameworkQuota and updates it. Returns the server's representation of the frameworkQuota, and an error, if there is any.
+func (c *FakeFrameworkQuotas) Update(frameworkQuota *frameworkcontrollerv1.FrameworkQuota) (result *frameworkcontrollerv1.FrameworkQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(frameworkquotasResource, c.ns, frameworkQuota), &frameworkcontrollerv1.FrameworkQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.FrameworkQuota), err
+}
+
+// Delete takes name of the frameworkQuota and deletes it. Returns an error if one occurs.
+func (c *FakeFrameworkQuotas) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(frameworkquotasResource, c.ns, name), &frameworkcontrollerv1.FrameworkQuota{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeFrameworkQuotas) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(frameworkquotasResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &frameworkcontrollerv1.FrameworkQuotaList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched frameworkQuota.
+func (c *FakeFrameworkQuotas) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *frameworkcontrollerv1.FrameworkQuota, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(frameworkquotasResource, c.ns, name, pt, data, subresources...), &frameworkcontrollerv1.FrameworkQuota{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.FrameworkQuota), err
+}
@@ -0,0 +1,128 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	frameworkcontrollerv1 "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeScheduledFrameworks implements ScheduledFrameworkInterface
+type FakeScheduledFrameworks struct {
+	Fake *FakeFrameworkcontrollerV1
+	ns   string
+}
+
+var scheduledframeworksResource = schema.GroupVersionResource{Group: "frameworkcontroller.microsoft.com", Version: "v1", Resource: "scheduledframeworks"}
+
+var scheduledframeworksKind = schema.GroupVersionKind{Group: "frameworkcontroller.microsoft.com", Version: "v1", Kind: "ScheduledFramework"}
+
+// Get takes name of the scheduledFramework, and returns the corresponding scheduledFramework object, and an error if there is any.
+func (c *FakeScheduledFrameworks) Get(name string, options v1.GetOptions) (result *frameworkcontrollerv1.ScheduledFramework, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(scheduledframeworksResource, c.ns, name), &frameworkcontrollerv1.ScheduledFramework{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.ScheduledFramework), err
+}
+
+// List takes label and field selectors, and returns the list of ScheduledFrameworks that match those selectors.
+func (c *FakeScheduledFrameworks) List(opts v1.ListOptions) (result *frameworkcontrollerv1.ScheduledFrameworkList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(scheduledframeworksResource, scheduledframeworksKind, c.ns, opts), &frameworkcontrollerv1.ScheduledFrameworkList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &frameworkcontrollerv1.ScheduledFrameworkList{ListMeta: obj.(*frameworkcontrollerv1.ScheduledFrameworkList).ListMeta}
+	for _, item := range obj.(*frameworkcontrollerv1.ScheduledFrameworkList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested scheduledFrameworks.
+func (c *FakeScheduledFrameworks) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(scheduledframeworksResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a scheduledFramework and creates it.  Returns the server's representation of the scheduledFramework, and an error, if there is any.
+func (c *FakeScheduledFrameworks) Create(scheduledFramework *frameworkcontrollerv1.ScheduledFramework) (result *frameworkcontrollerv1.ScheduledFramework, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(scheduledframeworksResource, c.ns, scheduledFramework), &frameworkcontrollerv1.ScheduledFramework{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.ScheduledFramework), err
+}
+
+// Update takes the representation of a scheduledFramework and updates it. Returns the server's representation of the scheduledFramework, and an error, if there is any.
+func (c *FakeScheduledFrameworks) Update(scheduledFramework *frameworkcontrollerv1.ScheduledFramework) (result *frameworkcontrollerv1.ScheduledFramework, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(scheduledframeworksResource, c.ns, scheduledFramework), &frameworkcontrollerv1.ScheduledFramework{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.ScheduledFramework), err
+}
+
+// Delete takes name of the scheduledFramework and deletes it. Returns an error if one occurs.
+func (c *FakeScheduledFrameworks) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(scheduledframeworksResource, c.ns, name), &frameworkcontrollerv1.ScheduledFramework{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeScheduledFrameworks) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(scheduledframeworksResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &frameworkcontrollerv1.ScheduledFrameworkList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched scheduledFramework.
+func (c *FakeScheduledFrameworks) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *frameworkcontrollerv1.ScheduledFramework, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(scheduledframeworksResource, c.ns, name, pt, data, subresources...), &frameworkcontrollerv1.ScheduledFramework{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.ScheduledFramework), err
+}
@@ -0,0 +1,128 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package fake
+
+import (
+	frameworkcontrollerv1 "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	v1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	labels "k8s.io/apimachinery/pkg/labels"
+	schema "k8s.io/apimachinery/pkg/runtime/schema"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	testing "k8s.io/client-go/testing"
+)
+
+// FakeTaskRoleScales implements TaskRoleScaleInterface
+type FakeTaskRoleScales struct {
+	Fake *FakeFrameworkcontrollerV1
+	ns   string
+}
+
+var taskrolescalesResource = schema.GroupVersionResource{Group: "frameworkcontroller.microsoft.com", Version: "v1", Resource: "taskrolescales"}
+
+var taskrolescalesKind = schema.GroupVersionKind{Group: "frameworkcontroller.microsoft.com", Version: "v1", Kind: "TaskRoleScale"}
+
+// Get takes name of the taskRoleScale, and returns the corresponding taskRoleScale object, and an error if there is any.
+func (c *FakeTaskRoleScales) Get(name string, options v1.GetOptions) (result *frameworkcontrollerv1.TaskRoleScale, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewGetAction(taskrolescalesResource, c.ns, name), &frameworkcontrollerv1.TaskRoleScale{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.TaskRoleScale), err
+}
+
+// List takes label and field selectors, and returns the list of TaskRoleScales that match those selectors.
+func (c *FakeTaskRoleScales) List(opts v1.ListOptions) (result *frameworkcontrollerv1.TaskRoleScaleList, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewListAction(taskrolescalesResource, taskrolescalesKind, c.ns, opts), &frameworkcontrollerv1.TaskRoleScaleList{})
+
+	if obj == nil {
+		return nil, err
+	}
+
+	label, _, _ := testing.ExtractFromListOptions(opts)
+	if label == nil {
+		label = labels.Everything()
+	}
+	list := &frameworkcontrollerv1.TaskRoleScaleList{ListMeta: obj.(*frameworkcontrollerv1.TaskRoleScaleList).ListMeta}
+	for _, item := range obj.(*frameworkcontrollerv1.TaskRoleScaleList).Items {
+		if label.Matches(labels.Set(item.Labels)) {
+			list.Items = append(list.Items, item)
+		}
+	}
+	return list, err
+}
+
+// Watch returns a watch.Interface that watches the requested taskRoleScales.
+func (c *FakeTaskRoleScales) Watch(opts v1.ListOptions) (watch.Interface, error) {
+	return c.Fake.
+		InvokesWatch(testing.NewWatchAction(taskrolescalesResource, c.ns, opts))
+
+}
+
+// Create takes the representation of a taskRoleScale and creates it.  Returns the server's representation of the taskRoleScale, and an error, if there is any.
+func (c *FakeTaskRoleScales) Create(taskRoleScale *frameworkcontrollerv1.TaskRoleScale) (result *frameworkcontrollerv1.TaskRoleScale, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewCreateAction(taskrolescalesResource, c.ns, taskRoleScale), &frameworkcontrollerv1.TaskRoleScale{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.TaskRoleScale), err
+}
+
+// Update takes the representation of a taskRoleScale and updates it. Returns the server's representation of the taskRoleScale, and an error, if there is any.
+func (c *FakeTaskRoleScales) Update(taskRoleScale *frameworkcontrollerv1.TaskRoleScale) (result *frameworkcontrollerv1.TaskRoleScale, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewUpdateAction(taskrolescalesResource, c.ns, taskRoleScale), &frameworkcontrollerv1.TaskRoleScale{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.TaskRoleScale), err
+}
+
+// Delete takes name of the taskRoleScale and deletes it. Returns an error if one occurs.
+func (c *FakeTaskRoleScales) Delete(name string, options *v1.DeleteOptions) error {
+	_, err := c.Fake.
+		Invokes(testing.NewDeleteAction(taskrolescalesResource, c.ns, name), &frameworkcontrollerv1.TaskRoleScale{})
+
+	return err
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *FakeTaskRoleScales) DeleteCollection(options *v1.DeleteOptions, listOptions v1.ListOptions) error {
+	action := testing.NewDeleteCollectionAction(taskrolescalesResource, c.ns, listOptions)
+
+	_, err := c.Fake.Invokes(action, &frameworkcontrollerv1.TaskRoleScaleList{})
+	return err
+}
+
+// Patch applies the patch and returns the patched taskRoleScale.
+func (c *FakeTaskRoleScales) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *frameworkcontrollerv1.TaskRoleScale, err error) {
+	obj, err := c.Fake.
+		Invokes(testing.NewPatchSubresourceAction(taskrolescalesResource, c.ns, name, pt, data, subresources...), &frameworkcontrollerv1.TaskRoleScale{})
+
+	if obj == nil {
+		return nil, err
+	}
+	return obj.(*frameworkcontrollerv1.TaskRoleScale), err
+}
@@ -0,0 +1,174 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"time"
+
+	v1 "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	scheme "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// FrameworkAttemptHistoriesGetter has a method to return a FrameworkAttemptHistoryInterface.
+// A group's client should implement this interface.
+type FrameworkAttemptHistoriesGetter interface {
+	FrameworkAttemptHistories(namespace string) FrameworkAttemptHistoryInterface
+}
+
+// FrameworkAttemptHistoryInterface has methods to work with FrameworkAttemptHistory resources.
+type FrameworkAttemptHistoryInterface interface {
+	Create(*v1.FrameworkAttemptHistory) (*v1.FrameworkAttemptHistory, error)
+	Update(*v1.FrameworkAttemptHistory) (*v1.FrameworkAttemptHistory, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+	DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error
+	Get(name string, options metav1.GetOptions) (*v1.FrameworkAttemptHistory, error)
+	List(opts metav1.ListOptions) (*v1.FrameworkAttemptHistoryList, error)
+	Watch(opts metav1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.FrameworkAttemptHistory, err error)
+	FrameworkAttemptHistoryExpansion
+}
+
+// frameworkAttemptHistories implements FrameworkAttemptHistoryInterface
+type frameworkAttemptHistories struct {
+	client rest.Interface
+	ns     string
+}
+
+// newFrameworkAttemptHistories returns a FrameworkAttemptHistories
+func newFrameworkAttemptHistories(c *FrameworkcontrollerV1Client, namespace string) *frameworkAttemptHistories {
+	return &frameworkAttemptHistories{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the frameworkAttemptHistory, and returns the corresponding frameworkAttemptHistory object, and an error if there is any.
+func (c *frameworkAttemptHistories) Get(name string, options metav1.GetOptions) (result *v1.FrameworkAttemptHistory, err error) {
+	result = &v1.FrameworkAttemptHistory{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("frameworkattempthistories").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of FrameworkAttemptHistories that match those selectors.
+func (c *frameworkAttemptHistories) List(opts metav1.ListOptions) (result *v1.FrameworkAttemptHistoryList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.FrameworkAttemptHistoryList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("frameworkattempthistories").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested frameworkAttemptHistories.
+func (c *frameworkAttemptHistories) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("frameworkattempthistories").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a frameworkAttemptHistory and creates it.  Returns the server's representation of the frameworkAttemptHistory, and an error, if there is any.
+func (c *frameworkAttemptHistories) Create(frameworkAttemptHistory *v1.FrameworkAttemptHistory) (result *v1.FrameworkAttemptHistory, err error) {
+	result = &v1.FrameworkAttemptHistory{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("frameworkattempthistories").
+		Body(frameworkAttemptHistory).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a frameworkAttemptHistory and updates it. Returns the server's representation of the frameworkAttemptHistory, and an error, if there is any.
+func (c *frameworkAttemptHistories) Update(frameworkAttemptHistory *v1.FrameworkAttemptHistory) (result *v1.FrameworkAttemptHistory, err error) {
+	result = &v1.FrameworkAttemptHistory{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("frameworkattempthistories").
+		Name(frameworkAttemptHistory.Name).
+		Body(frameworkAttemptHistory).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the frameworkAttemptHistory and deletes it. Returns an error if one occurs.
+func (c *frameworkAttemptHistories) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("frameworkattempthistories").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *frameworkAttemptHistories) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("frameworkattempthistories").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched frameworkAttemptHistory.
+func (c *frameworkAttemptHistories) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.FrameworkAttemptHistory, err error) {
+	result = &v1.FrameworkAttemptHistory{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("frameworkattempthistories").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
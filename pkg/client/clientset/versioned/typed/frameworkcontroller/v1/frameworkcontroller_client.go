@@ -28,6 +28,12 @@ import (
 type FrameworkcontrollerV1Interface interface {
 	RESTClient() rest.Interface
 	FrameworksGetter
+	FrameworkAttemptHistoriesGetter
+	FrameworkGroupsGetter
+	FrameworkQuotasGetter
+	QueuesGetter
+	ScheduledFrameworksGetter
+	TaskRoleScalesGetter
 }
 
 // FrameworkcontrollerV1Client is used to interact with features provided by the frameworkcontroller.microsoft.com group.
@@ -39,6 +45,30 @@ func (c *FrameworkcontrollerV1Client) Frameworks(namespace string) FrameworkInte
 	return newFrameworks(c, namespace)
 }
 
+func (c *FrameworkcontrollerV1Client) FrameworkAttemptHistories(namespace string) FrameworkAttemptHistoryInterface {
+	return newFrameworkAttemptHistories(c, namespace)
+}
+
+func (c *FrameworkcontrollerV1Client) FrameworkGroups(namespace string) FrameworkGroupInterface {
+	return newFrameworkGroups(c, namespace)
+}
+
+func (c *FrameworkcontrollerV1Client) FrameworkQuotas(namespace string) FrameworkQuotaInterface {
+	return newFrameworkQuotas(c, namespace)
+}
+
+func (c *FrameworkcontrollerV1Client) Queues() QueueInterface {
+	return newQueues(c)
+}
+
+func (c *FrameworkcontrollerV1Client) ScheduledFrameworks(namespace string) ScheduledFrameworkInterface {
+	return newScheduledFrameworks(c, namespace)
+}
+
+func (c *FrameworkcontrollerV1Client) TaskRoleScales(namespace string) TaskRoleScaleInterface {
+	return newTaskRoleScales(c, namespace)
+}
+
 // NewForConfig creates a new FrameworkcontrollerV1Client for the given config.
 func NewForConfig(c *rest.Config) (*FrameworkcontrollerV1Client, error) {
 	config := *c
@@ -0,0 +1,174 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"time"
+
+	v1 "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	scheme "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// FrameworkGroupsGetter has a method to return a FrameworkGroupInterface.
+// A group's client should implement this interface.
+type FrameworkGroupsGetter interface {
+	FrameworkGroups(namespace string) FrameworkGroupInterface
+}
+
+// FrameworkGroupInterface has methods to work with FrameworkGroup resources.
+type FrameworkGroupInterface interface {
+	Create(*v1.FrameworkGroup) (*v1.FrameworkGroup, error)
+	Update(*v1.FrameworkGroup) (*v1.FrameworkGroup, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+	DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error
+	Get(name string, options metav1.GetOptions) (*v1.FrameworkGroup, error)
+	List(opts metav1.ListOptions) (*v1.FrameworkGroupList, error)
+	Watch(opts metav1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.FrameworkGroup, err error)
+	FrameworkGroupExpansion
+}
+
+// frameworkGroups implements FrameworkGroupInterface
+type frameworkGroups struct {
+	client rest.Interface
+	ns     string
+}
+
+// newFrameworkGroups returns a FrameworkGroups
+func newFrameworkGroups(c *FrameworkcontrollerV1Client, namespace string) *frameworkGroups {
+	return &frameworkGroups{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the frameworkGroup, and returns the corresponding frameworkGroup object, and an error if there is any.
+func (c *frameworkGroups) Get(name string, options metav1.GetOptions) (result *v1.FrameworkGroup, err error) {
+	result = &v1.FrameworkGroup{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("frameworkgroups").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of FrameworkGroups that match those selectors.
+func (c *frameworkGroups) List(opts metav1.ListOptions) (result *v1.FrameworkGroupList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.FrameworkGroupList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("frameworkgroups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested frameworkGroups.
+func (c *frameworkGroups) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("frameworkgroups").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a frameworkGroup and creates it.  Returns the server's representation of the frameworkGroup, and an error, if there is any.
+func (c *frameworkGroups) Create(frameworkGroup *v1.FrameworkGroup) (result *v1.FrameworkGroup, err error) {
+	result = &v1.FrameworkGroup{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("frameworkgroups").
+		Body(frameworkGroup).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a frameworkGroup and updates it. Returns the server's representation of the frameworkGroup, and an error, if there is any.
+func (c *frameworkGroups) Update(frameworkGroup *v1.FrameworkGroup) (result *v1.FrameworkGroup, err error) {
+	result = &v1.FrameworkGroup{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("frameworkgroups").
+		Name(frameworkGroup.Name).
+		Body(frameworkGroup).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the frameworkGroup and deletes it. Returns an error if one occurs.
+func (c *frameworkGroups) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("frameworkgroups").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *frameworkGroups) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("frameworkgroups").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched frameworkGroup.
+func (c *frameworkGroups) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.FrameworkGroup, err error) {
+	result = &v1.FrameworkGroup{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("frameworkgroups").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -0,0 +1,174 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"time"
+
+	v1 "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	scheme "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// FrameworkQuotasGetter has a method to return a FrameworkQuotaInterface.
+// A group's client should implement this interface.
+type FrameworkQuotasGetter interface {
+	FrameworkQuotas(namespace string) FrameworkQuotaInterface
+}
+
+// FrameworkQuotaInterface has methods to work with FrameworkQuota resources.
+type FrameworkQuotaInterface interface {
+	Create(*v1.FrameworkQuota) (*v1.FrameworkQuota, error)
+	Update(*v1.FrameworkQuota) (*v1.FrameworkQuota, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+	DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error
+	Get(name string, options metav1.GetOptions) (*v1.FrameworkQuota, error)
+	List(opts metav1.ListOptions) (*v1.FrameworkQuotaList, error)
+	Watch(opts metav1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.FrameworkQuota, err error)
+	FrameworkQuotaExpansion
+}
+
+// frameworkQuotas implements FrameworkQuotaInterface
+type frameworkQuotas struct {
+	client rest.Interface
+	ns     string
+}
+
+// newFrameworkQuotas returns a FrameworkQuotas
+func newFrameworkQuotas(c *FrameworkcontrollerV1Client, namespace string) *frameworkQuotas {
+	return &frameworkQuotas{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the frameworkQuota, and returns the corresponding frameworkQuota object, and an error if there is any.
+func (c *frameworkQuotas) Get(name string, options metav1.GetOptions) (result *v1.FrameworkQuota, err error) {
+	result = &v1.FrameworkQuota{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("frameworkquotas").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of FrameworkQuotas that match those selectors.
+func (c *frameworkQuotas) List(opts metav1.ListOptions) (result *v1.FrameworkQuotaList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.FrameworkQuotaList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("frameworkquotas").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested frameworkQuotas.
+func (c *frameworkQuotas) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("frameworkquotas").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a frameworkQuota and creates it.  Returns the server's representation of the frameworkQuota, and an error, if there is any.
+func (c *frameworkQuotas) Create(frameworkQuota *v1.FrameworkQuota) (result *v1.FrameworkQuota, err error) {
+	result = &v1.FrameworkQuota{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("frameworkquotas").
+		Body(frameworkQuota).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a frameworkQuota and updates it. Returns the server's representation of the frameworkQuota, and an error, if there is any.
+func (c *frameworkQuotas) Update(frameworkQuota *v1.FrameworkQuota) (result *v1.FrameworkQuota, err error) {
+	result = &v1.FrameworkQuota{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("frameworkquotas").
+		Name(frameworkQuota.Name).
+		Body(frameworkQuota).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the frameworkQuota and deletes it. Returns an error if one occurs.
+func (c *frameworkQuotas) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("frameworkquotas").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *frameworkQuotas) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("frameworkquotas").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched frameworkQuota.
+func (c *frameworkQuotas) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.FrameworkQuota, err error) {
+	result = &v1.FrameworkQuota{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("frameworkquotas").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -19,3 +19,15 @@ limitations under the License.
 package v1
 
 type FrameworkExpansion interface{}
+
+type FrameworkAttemptHistoryExpansion interface{}
+
+type FrameworkGroupExpansion interface{}
+
+type FrameworkQuotaExpansion interface{}
+
+type QueueExpansion interface{}
+
+type ScheduledFrameworkExpansion interface{}
+
+type TaskRoleScaleExpansion interface{}
@@ -0,0 +1,174 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"time"
+
+	v1 "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	scheme "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// ScheduledFrameworksGetter has a method to return a ScheduledFrameworkInterface.
+// A group's client should implement this interface.
+type ScheduledFrameworksGetter interface {
+	ScheduledFrameworks(namespace string) ScheduledFrameworkInterface
+}
+
+// ScheduledFrameworkInterface has methods to work with ScheduledFramework resources.
+type ScheduledFrameworkInterface interface {
+	Create(*v1.ScheduledFramework) (*v1.ScheduledFramework, error)
+	Update(*v1.ScheduledFramework) (*v1.ScheduledFramework, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+	DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error
+	Get(name string, options metav1.GetOptions) (*v1.ScheduledFramework, error)
+	List(opts metav1.ListOptions) (*v1.ScheduledFrameworkList, error)
+	Watch(opts metav1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.ScheduledFramework, err error)
+	ScheduledFrameworkExpansion
+}
+
+// scheduledFrameworks implements ScheduledFrameworkInterface
+type scheduledFrameworks struct {
+	client rest.Interface
+	ns     string
+}
+
+// newScheduledFrameworks returns a ScheduledFrameworks
+func newScheduledFrameworks(c *FrameworkcontrollerV1Client, namespace string) *scheduledFrameworks {
+	return &scheduledFrameworks{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the scheduledFramework, and returns the corresponding scheduledFramework object, and an error if there is any.
+func (c *scheduledFrameworks) Get(name string, options metav1.GetOptions) (result *v1.ScheduledFramework, err error) {
+	result = &v1.ScheduledFramework{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("scheduledframeworks").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of ScheduledFrameworks that match those selectors.
+func (c *scheduledFrameworks) List(opts metav1.ListOptions) (result *v1.ScheduledFrameworkList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.ScheduledFrameworkList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("scheduledframeworks").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested scheduledFrameworks.
+func (c *scheduledFrameworks) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("scheduledframeworks").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a scheduledFramework and creates it.  Returns the server's representation of the scheduledFramework, and an error, if there is any.
+func (c *scheduledFrameworks) Create(scheduledFramework *v1.ScheduledFramework) (result *v1.ScheduledFramework, err error) {
+	result = &v1.ScheduledFramework{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("scheduledframeworks").
+		Body(scheduledFramework).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a scheduledFramework and updates it. Returns the server's representation of the scheduledFramework, and an error, if there is any.
+func (c *scheduledFrameworks) Update(scheduledFramework *v1.ScheduledFramework) (result *v1.ScheduledFramework, err error) {
+	result = &v1.ScheduledFramework{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("scheduledframeworks").
+		Name(scheduledFramework.Name).
+		Body(scheduledFramework).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the scheduledFramework and deletes it. Returns an error if one occurs.
+func (c *scheduledFrameworks) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("scheduledframeworks").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *scheduledFrameworks) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("scheduledframeworks").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched scheduledFramework.
+func (c *scheduledFrameworks) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.ScheduledFramework, err error) {
+	result = &v1.ScheduledFramework{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("scheduledframeworks").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -0,0 +1,174 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by client-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	"time"
+
+	v1 "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	scheme "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned/scheme"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	types "k8s.io/apimachinery/pkg/types"
+	watch "k8s.io/apimachinery/pkg/watch"
+	rest "k8s.io/client-go/rest"
+)
+
+// TaskRoleScalesGetter has a method to return a TaskRoleScaleInterface.
+// A group's client should implement this interface.
+type TaskRoleScalesGetter interface {
+	TaskRoleScales(namespace string) TaskRoleScaleInterface
+}
+
+// TaskRoleScaleInterface has methods to work with TaskRoleScale resources.
+type TaskRoleScaleInterface interface {
+	Create(*v1.TaskRoleScale) (*v1.TaskRoleScale, error)
+	Update(*v1.TaskRoleScale) (*v1.TaskRoleScale, error)
+	Delete(name string, options *metav1.DeleteOptions) error
+	DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error
+	Get(name string, options metav1.GetOptions) (*v1.TaskRoleScale, error)
+	List(opts metav1.ListOptions) (*v1.TaskRoleScaleList, error)
+	Watch(opts metav1.ListOptions) (watch.Interface, error)
+	Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.TaskRoleScale, err error)
+	TaskRoleScaleExpansion
+}
+
+// taskRoleScales implements TaskRoleScaleInterface
+type taskRoleScales struct {
+	client rest.Interface
+	ns     string
+}
+
+// newTaskRoleScales returns a TaskRoleScales
+func newTaskRoleScales(c *FrameworkcontrollerV1Client, namespace string) *taskRoleScales {
+	return &taskRoleScales{
+		client: c.RESTClient(),
+		ns:     namespace,
+	}
+}
+
+// Get takes name of the taskRoleScale, and returns the corresponding taskRoleScale object, and an error if there is any.
+func (c *taskRoleScales) Get(name string, options metav1.GetOptions) (result *v1.TaskRoleScale, err error) {
+	result = &v1.TaskRoleScale{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("taskrolescales").
+		Name(name).
+		VersionedParams(&options, scheme.ParameterCodec).
+		Do().
+		Into(result)
+	return
+}
+
+// List takes label and field selectors, and returns the list of TaskRoleScales that match those selectors.
+func (c *taskRoleScales) List(opts metav1.ListOptions) (result *v1.TaskRoleScaleList, err error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	result = &v1.TaskRoleScaleList{}
+	err = c.client.Get().
+		Namespace(c.ns).
+		Resource("taskrolescales").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Do().
+		Into(result)
+	return
+}
+
+// Watch returns a watch.Interface that watches the requested taskRoleScales.
+func (c *taskRoleScales) Watch(opts metav1.ListOptions) (watch.Interface, error) {
+	var timeout time.Duration
+	if opts.TimeoutSeconds != nil {
+		timeout = time.Duration(*opts.TimeoutSeconds) * time.Second
+	}
+	opts.Watch = true
+	return c.client.Get().
+		Namespace(c.ns).
+		Resource("taskrolescales").
+		VersionedParams(&opts, scheme.ParameterCodec).
+		Timeout(timeout).
+		Watch()
+}
+
+// Create takes the representation of a taskRoleScale and creates it.  Returns the server's representation of the taskRoleScale, and an error, if there is any.
+func (c *taskRoleScales) Create(taskRoleScale *v1.TaskRoleScale) (result *v1.TaskRoleScale, err error) {
+	result = &v1.TaskRoleScale{}
+	err = c.client.Post().
+		Namespace(c.ns).
+		Resource("taskrolescales").
+		Body(taskRoleScale).
+		Do().
+		Into(result)
+	return
+}
+
+// Update takes the representation of a taskRoleScale and updates it. Returns the server's representation of the taskRoleScale, and an error, if there is any.
+func (c *taskRoleScales) Update(taskRoleScale *v1.TaskRoleScale) (result *v1.TaskRoleScale, err error) {
+	result = &v1.TaskRoleScale{}
+	err = c.client.Put().
+		Namespace(c.ns).
+		Resource("taskrolescales").
+		Name(taskRoleScale.Name).
+		Body(taskRoleScale).
+		Do().
+		Into(result)
+	return
+}
+
+// Delete takes name of the taskRoleScale and deletes it. Returns an error if one occurs.
+func (c *taskRoleScales) Delete(name string, options *metav1.DeleteOptions) error {
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("taskrolescales").
+		Name(name).
+		Body(options).
+		Do().
+		Error()
+}
+
+// DeleteCollection deletes a collection of objects.
+func (c *taskRoleScales) DeleteCollection(options *metav1.DeleteOptions, listOptions metav1.ListOptions) error {
+	var timeout time.Duration
+	if listOptions.TimeoutSeconds != nil {
+		timeout = time.Duration(*listOptions.TimeoutSeconds) * time.Second
+	}
+	return c.client.Delete().
+		Namespace(c.ns).
+		Resource("taskrolescales").
+		VersionedParams(&listOptions, scheme.ParameterCodec).
+		Timeout(timeout).
+		Body(options).
+		Do().
+		Error()
+}
+
+// Patch applies the patch and returns the patched taskRoleScale.
+func (c *taskRoleScales) Patch(name string, pt types.PatchType, data []byte, subresources ...string) (result *v1.TaskRoleScale, err error) {
+	result = &v1.TaskRoleScale{}
+	err = c.client.Patch(pt).
+		Namespace(c.ns).
+		Resource("taskrolescales").
+		SubResource(subresources...).
+		Name(name).
+		Body(data).
+		Do().
+		Into(result)
+	return
+}
@@ -0,0 +1,89 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by informer-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	time "time"
+
+	frameworkcontrollerv1 "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	versioned "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned"
+	internalinterfaces "github.com/microsoft/frameworkcontroller/pkg/client/informers/externalversions/internalinterfaces"
+	v1 "github.com/microsoft/frameworkcontroller/pkg/client/listers/frameworkcontroller/v1"
+	metav1 "k8s.io/apimachinery/pkg/apis/meta/v1"
+	runtime "k8s.io/apimachinery/pkg/runtime"
+	watch "k8s.io/apimachinery/pkg/watch"
+	cache "k8s.io/client-go/tools/cache"
+)
+
+// FrameworkAttemptHistoryInformer provides access to a shared informer and lister for
+// FrameworkAttemptHistories.
+type FrameworkAttemptHistoryInformer interface {
+	Informer() cache.SharedIndexInformer
+	Lister() v1.FrameworkAttemptHistoryLister
+}
+
+type frameworkAttemptHistoryInformer struct {
+	factory          internalinterfaces.SharedInformerFactory
+	tweakListOptions internalinterfaces.TweakListOptionsFunc
+	namespace        string
+}
+
+// NewFrameworkAttemptHistoryInformer constructs a new informer for FrameworkAttemptHistory type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFrameworkAttemptHistoryInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers) cache.SharedIndexInformer {
+	return NewFilteredFrameworkAttemptHistoryInformer(client, namespace, resyncPeriod, indexers, nil)
+}
+
+// NewFilteredFrameworkAttemptHistoryInformer constructs a new informer for FrameworkAttemptHistory type.
+// Always prefer using an informer factory to get a shared informer instead of getting an independent
+// one. This reduces memory footprint and number of connections to the server.
+func NewFilteredFrameworkAttemptHistoryInformer(client versioned.Interface, namespace string, resyncPeriod time.Duration, indexers cache.Indexers, tweakListOptions internalinterfaces.TweakListOptionsFunc) cache.SharedIndexInformer {
+	return cache.NewSharedIndexInformer(
+		&cache.ListWatch{
+			ListFunc: func(options metav1.ListOptions) (runtime.Object, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FrameworkcontrollerV1().FrameworkAttemptHistories(namespace).List(options)
+			},
+			WatchFunc: func(options metav1.ListOptions) (watch.Interface, error) {
+				if tweakListOptions != nil {
+					tweakListOptions(&options)
+				}
+				return client.FrameworkcontrollerV1().FrameworkAttemptHistories(namespace).Watch(options)
+			},
+		},
+		&frameworkcontrollerv1.FrameworkAttemptHistory{},
+		resyncPeriod,
+		indexers,
+	)
+}
+
+func (f *frameworkAttemptHistoryInformer) defaultInformer(client versioned.Interface, resyncPeriod time.Duration) cache.SharedIndexInformer {
+	return NewFilteredFrameworkAttemptHistoryInformer(client, f.namespace, resyncPeriod, cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}, f.tweakListOptions)
+}
+
+func (f *frameworkAttemptHistoryInformer) Informer() cache.SharedIndexInformer {
+	return f.factory.InformerFor(&frameworkcontrollerv1.FrameworkAttemptHistory{}, f.defaultInformer)
+}
+
+func (f *frameworkAttemptHistoryInformer) Lister() v1.FrameworkAttemptHistoryLister {
+	return v1.NewFrameworkAttemptHistoryLister(f.Informer().GetIndexer())
+}
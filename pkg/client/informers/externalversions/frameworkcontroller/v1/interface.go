@@ -26,6 +26,18 @@ import (
 type Interface interface {
 	// Frameworks returns a FrameworkInformer.
 	Frameworks() FrameworkInformer
+	// FrameworkAttemptHistories returns a FrameworkAttemptHistoryInformer.
+	FrameworkAttemptHistories() FrameworkAttemptHistoryInformer
+	// FrameworkGroups returns a FrameworkGroupInformer.
+	FrameworkGroups() FrameworkGroupInformer
+	// FrameworkQuotas returns a FrameworkQuotaInformer.
+	FrameworkQuotas() FrameworkQuotaInformer
+	// Queues returns a QueueInformer.
+	Queues() QueueInformer
+	// ScheduledFrameworks returns a ScheduledFrameworkInformer.
+	ScheduledFrameworks() ScheduledFrameworkInformer
+	// TaskRoleScales returns a TaskRoleScaleInformer.
+	TaskRoleScales() TaskRoleScaleInformer
 }
 
 type version struct {
@@ -43,3 +55,33 @@ func New(f internalinterfaces.SharedInformerFactory, namespace string, tweakList
 func (v *version) Frameworks() FrameworkInformer {
 	return &frameworkInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
 }
+
+// FrameworkAttemptHistories returns a FrameworkAttemptHistoryInformer.
+func (v *version) FrameworkAttemptHistories() FrameworkAttemptHistoryInformer {
+	return &frameworkAttemptHistoryInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// FrameworkGroups returns a FrameworkGroupInformer.
+func (v *version) FrameworkGroups() FrameworkGroupInformer {
+	return &frameworkGroupInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// FrameworkQuotas returns a FrameworkQuotaInformer.
+func (v *version) FrameworkQuotas() FrameworkQuotaInformer {
+	return &frameworkQuotaInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// Queues returns a QueueInformer.
+func (v *version) Queues() QueueInformer {
+	return &queueInformer{factory: v.factory, tweakListOptions: v.tweakListOptions}
+}
+
+// ScheduledFrameworks returns a ScheduledFrameworkInformer.
+func (v *version) ScheduledFrameworks() ScheduledFrameworkInformer {
+	return &scheduledFrameworkInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
+
+// TaskRoleScales returns a TaskRoleScaleInformer.
+func (v *version) TaskRoleScales() TaskRoleScaleInformer {
+	return &taskRoleScaleInformer{factory: v.factory, namespace: v.namespace, tweakListOptions: v.tweakListOptions}
+}
@@ -55,6 +55,18 @@ func (f *sharedInformerFactory) ForResource(resource schema.GroupVersionResource
 	// Group=frameworkcontroller.microsoft.com, Version=v1
 	case v1.SchemeGroupVersion.WithResource("frameworks"):
 		return &genericInformer{resource: resource.GroupResource(), informer: f.Frameworkcontroller().V1().Frameworks().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("frameworkattempthistories"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Frameworkcontroller().V1().FrameworkAttemptHistories().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("frameworkgroups"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Frameworkcontroller().V1().FrameworkGroups().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("frameworkquotas"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Frameworkcontroller().V1().FrameworkQuotas().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("queues"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Frameworkcontroller().V1().Queues().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("scheduledframeworks"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Frameworkcontroller().V1().ScheduledFrameworks().Informer()}, nil
+	case v1.SchemeGroupVersion.WithResource("taskrolescales"):
+		return &genericInformer{resource: resource.GroupResource(), informer: f.Frameworkcontroller().V1().TaskRoleScales().Informer()}, nil
 
 	}
 
@@ -25,3 +25,47 @@ type FrameworkListerExpansion interface{}
 // FrameworkNamespaceListerExpansion allows custom methods to be added to
 // FrameworkNamespaceLister.
 type FrameworkNamespaceListerExpansion interface{}
+
+// FrameworkAttemptHistoryListerExpansion allows custom methods to be added to
+// FrameworkAttemptHistoryLister.
+type FrameworkAttemptHistoryListerExpansion interface{}
+
+// FrameworkAttemptHistoryNamespaceListerExpansion allows custom methods to be added to
+// FrameworkAttemptHistoryNamespaceLister.
+type FrameworkAttemptHistoryNamespaceListerExpansion interface{}
+
+// FrameworkGroupListerExpansion allows custom methods to be added to
+// FrameworkGroupLister.
+type FrameworkGroupListerExpansion interface{}
+
+// FrameworkGroupNamespaceListerExpansion allows custom methods to be added to
+// FrameworkGroupNamespaceLister.
+type FrameworkGroupNamespaceListerExpansion interface{}
+
+// FrameworkQuotaListerExpansion allows custom methods to be added to
+// FrameworkQuotaLister.
+type FrameworkQuotaListerExpansion interface{}
+
+// FrameworkQuotaNamespaceListerExpansion allows custom methods to be added to
+// FrameworkQuotaNamespaceLister.
+type FrameworkQuotaNamespaceListerExpansion interface{}
+
+// QueueListerExpansion allows custom methods to be added to
+// QueueLister.
+type QueueListerExpansion interface{}
+
+// ScheduledFrameworkListerExpansion allows custom methods to be added to
+// ScheduledFrameworkLister.
+type ScheduledFrameworkListerExpansion interface{}
+
+// ScheduledFrameworkNamespaceListerExpansion allows custom methods to be added to
+// ScheduledFrameworkNamespaceLister.
+type ScheduledFrameworkNamespaceListerExpansion interface{}
+
+// TaskRoleScaleListerExpansion allows custom methods to be added to
+// TaskRoleScaleLister.
+type TaskRoleScaleListerExpansion interface{}
+
+// TaskRoleScaleNamespaceListerExpansion allows custom methods to be added to
+// TaskRoleScaleNamespaceLister.
+type TaskRoleScaleNamespaceListerExpansion interface{}
@@ -0,0 +1,94 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// FrameworkAttemptHistoryLister helps list FrameworkAttemptHistories.
+type FrameworkAttemptHistoryLister interface {
+	// List lists all FrameworkAttemptHistories in the indexer.
+	List(selector labels.Selector) (ret []*v1.FrameworkAttemptHistory, err error)
+	// FrameworkAttemptHistories returns an object that can list and get FrameworkAttemptHistories.
+	FrameworkAttemptHistories(namespace string) FrameworkAttemptHistoryNamespaceLister
+	FrameworkAttemptHistoryListerExpansion
+}
+
+// frameworkAttemptHistoryLister implements the FrameworkAttemptHistoryLister interface.
+type frameworkAttemptHistoryLister struct {
+	indexer cache.Indexer
+}
+
+// NewFrameworkAttemptHistoryLister returns a new FrameworkAttemptHistoryLister.
+func NewFrameworkAttemptHistoryLister(indexer cache.Indexer) FrameworkAttemptHistoryLister {
+	return &frameworkAttemptHistoryLister{indexer: indexer}
+}
+
+// List lists all FrameworkAttemptHistories in the indexer.
+func (s *frameworkAttemptHistoryLister) List(selector labels.Selector) (ret []*v1.FrameworkAttemptHistory, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.FrameworkAttemptHistory))
+	})
+	return ret, err
+}
+
+// FrameworkAttemptHistories returns an object that can list and get FrameworkAttemptHistories.
+func (s *frameworkAttemptHistoryLister) FrameworkAttemptHistories(namespace string) FrameworkAttemptHistoryNamespaceLister {
+	return frameworkAttemptHistoryNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// FrameworkAttemptHistoryNamespaceLister helps list and get FrameworkAttemptHistories.
+type FrameworkAttemptHistoryNamespaceLister interface {
+	// List lists all FrameworkAttemptHistories in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1.FrameworkAttemptHistory, err error)
+	// Get retrieves the FrameworkAttemptHistory from the indexer for a given namespace and name.
+	Get(name string) (*v1.FrameworkAttemptHistory, error)
+	FrameworkAttemptHistoryNamespaceListerExpansion
+}
+
+// frameworkAttemptHistoryNamespaceLister implements the FrameworkAttemptHistoryNamespaceLister
+// interface.
+type frameworkAttemptHistoryNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all FrameworkAttemptHistories in the indexer for a given namespace.
+func (s frameworkAttemptHistoryNamespaceLister) List(selector labels.Selector) (ret []*v1.FrameworkAttemptHistory, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.FrameworkAttemptHistory))
+	})
+	return ret, err
+}
+
+// Get retrieves the FrameworkAttemptHistory from the indexer for a given namespace and name.
+func (s frameworkAttemptHistoryNamespaceLister) Get(name string) (*v1.FrameworkAttemptHistory, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("frameworkattempthistory"), name)
+	}
+	return obj.(*v1.FrameworkAttemptHistory), nil
+}
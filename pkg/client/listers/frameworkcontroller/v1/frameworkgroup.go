@@ -0,0 +1,94 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// FrameworkGroupLister helps list FrameworkGroups.
+type FrameworkGroupLister interface {
+	// List lists all FrameworkGroups in the indexer.
+	List(selector labels.Selector) (ret []*v1.FrameworkGroup, err error)
+	// FrameworkGroups returns an object that can list and get FrameworkGroups.
+	FrameworkGroups(namespace string) FrameworkGroupNamespaceLister
+	FrameworkGroupListerExpansion
+}
+
+// frameworkGroupLister implements the FrameworkGroupLister interface.
+type frameworkGroupLister struct {
+	indexer cache.Indexer
+}
+
+// NewFrameworkGroupLister returns a new FrameworkGroupLister.
+func NewFrameworkGroupLister(indexer cache.Indexer) FrameworkGroupLister {
+	return &frameworkGroupLister{indexer: indexer}
+}
+
+// List lists all FrameworkGroups in the indexer.
+func (s *frameworkGroupLister) List(selector labels.Selector) (ret []*v1.FrameworkGroup, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.FrameworkGroup))
+	})
+	return ret, err
+}
+
+// FrameworkGroups returns an object that can list and get FrameworkGroups.
+func (s *frameworkGroupLister) FrameworkGroups(namespace string) FrameworkGroupNamespaceLister {
+	return frameworkGroupNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// FrameworkGroupNamespaceLister helps list and get FrameworkGroups.
+type FrameworkGroupNamespaceLister interface {
+	// List lists all FrameworkGroups in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1.FrameworkGroup, err error)
+	// Get retrieves the FrameworkGroup from the indexer for a given namespace and name.
+	Get(name string) (*v1.FrameworkGroup, error)
+	FrameworkGroupNamespaceListerExpansion
+}
+
+// frameworkGroupNamespaceLister implements the FrameworkGroupNamespaceLister
+// interface.
+type frameworkGroupNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all FrameworkGroups in the indexer for a given namespace.
+func (s frameworkGroupNamespaceLister) List(selector labels.Selector) (ret []*v1.FrameworkGroup, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.FrameworkGroup))
+	})
+	return ret, err
+}
+
+// Get retrieves the FrameworkGroup from the indexer for a given namespace and name.
+func (s frameworkGroupNamespaceLister) Get(name string) (*v1.FrameworkGroup, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("frameworkgroup"), name)
+	}
+	return obj.(*v1.FrameworkGroup), nil
+}
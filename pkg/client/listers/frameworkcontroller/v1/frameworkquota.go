@@ -0,0 +1,94 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// FrameworkQuotaLister helps list FrameworkQuotas.
+type FrameworkQuotaLister interface {
+	// List lists all FrameworkQuotas in the indexer.
+	List(selector labels.Selector) (ret []*v1.FrameworkQuota, err error)
+	// FrameworkQuotas returns an object that can list and get FrameworkQuotas.
+	FrameworkQuotas(namespace string) FrameworkQuotaNamespaceLister
+	FrameworkQuotaListerExpansion
+}
+
+// frameworkQuotaLister implements the FrameworkQuotaLister interface.
+type frameworkQuotaLister struct {
+	indexer cache.Indexer
+}
+
+// NewFrameworkQuotaLister returns a new FrameworkQuotaLister.
+func NewFrameworkQuotaLister(indexer cache.Indexer) FrameworkQuotaLister {
+	return &frameworkQuotaLister{indexer: indexer}
+}
+
+// List lists all FrameworkQuotas in the indexer.
+func (s *frameworkQuotaLister) List(selector labels.Selector) (ret []*v1.FrameworkQuota, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.FrameworkQuota))
+	})
+	return ret, err
+}
+
+// FrameworkQuotas returns an object that can list and get FrameworkQuotas.
+func (s *frameworkQuotaLister) FrameworkQuotas(namespace string) FrameworkQuotaNamespaceLister {
+	return frameworkQuotaNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// FrameworkQuotaNamespaceLister helps list and get FrameworkQuotas.
+type FrameworkQuotaNamespaceLister interface {
+	// List lists all FrameworkQuotas in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1.FrameworkQuota, err error)
+	// Get retrieves the FrameworkQuota from the indexer for a given namespace and name.
+	Get(name string) (*v1.FrameworkQuota, error)
+	FrameworkQuotaNamespaceListerExpansion
+}
+
+// frameworkQuotaNamespaceLister implements the FrameworkQuotaNamespaceLister
+// interface.
+type frameworkQuotaNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all FrameworkQuotas in the indexer for a given namespace.
+func (s frameworkQuotaNamespaceLister) List(selector labels.Selector) (ret []*v1.FrameworkQuota, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.FrameworkQuota))
+	})
+	return ret, err
+}
+
+// Get retrieves the FrameworkQuota from the indexer for a given namespace and name.
+func (s frameworkQuotaNamespaceLister) Get(name string) (*v1.FrameworkQuota, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("frameworkquota"), name)
+	}
+	return obj.(*v1.FrameworkQuota), nil
+}
@@ -0,0 +1,94 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// ScheduledFrameworkLister helps list ScheduledFrameworks.
+type ScheduledFrameworkLister interface {
+	// List lists all ScheduledFrameworks in the indexer.
+	List(selector labels.Selector) (ret []*v1.ScheduledFramework, err error)
+	// ScheduledFrameworks returns an object that can list and get ScheduledFrameworks.
+	ScheduledFrameworks(namespace string) ScheduledFrameworkNamespaceLister
+	ScheduledFrameworkListerExpansion
+}
+
+// scheduledFrameworkLister implements the ScheduledFrameworkLister interface.
+type scheduledFrameworkLister struct {
+	indexer cache.Indexer
+}
+
+// NewScheduledFrameworkLister returns a new ScheduledFrameworkLister.
+func NewScheduledFrameworkLister(indexer cache.Indexer) ScheduledFrameworkLister {
+	return &scheduledFrameworkLister{indexer: indexer}
+}
+
+// List lists all ScheduledFrameworks in the indexer.
+func (s *scheduledFrameworkLister) List(selector labels.Selector) (ret []*v1.ScheduledFramework, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ScheduledFramework))
+	})
+	return ret, err
+}
+
+// ScheduledFrameworks returns an object that can list and get ScheduledFrameworks.
+func (s *scheduledFrameworkLister) ScheduledFrameworks(namespace string) ScheduledFrameworkNamespaceLister {
+	return scheduledFrameworkNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// ScheduledFrameworkNamespaceLister helps list and get ScheduledFrameworks.
+type ScheduledFrameworkNamespaceLister interface {
+	// List lists all ScheduledFrameworks in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1.ScheduledFramework, err error)
+	// Get retrieves the ScheduledFramework from the indexer for a given namespace and name.
+	Get(name string) (*v1.ScheduledFramework, error)
+	ScheduledFrameworkNamespaceListerExpansion
+}
+
+// scheduledFrameworkNamespaceLister implements the ScheduledFrameworkNamespaceLister
+// interface.
+type scheduledFrameworkNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all ScheduledFrameworks in the indexer for a given namespace.
+func (s scheduledFrameworkNamespaceLister) List(selector labels.Selector) (ret []*v1.ScheduledFramework, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.ScheduledFramework))
+	})
+	return ret, err
+}
+
+// Get retrieves the ScheduledFramework from the indexer for a given namespace and name.
+func (s scheduledFrameworkNamespaceLister) Get(name string) (*v1.ScheduledFramework, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("scheduledframework"), name)
+	}
+	return obj.(*v1.ScheduledFramework), nil
+}
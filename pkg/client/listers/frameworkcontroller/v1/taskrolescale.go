@@ -0,0 +1,94 @@
+/*
+Copyright The Kubernetes Authors.
+
+Licensed under the Apache License, Version 2.0 (the "License");
+you may not use this file except in compliance with the License.
+You may obtain a copy of the License at
+
+    http://www.apache.org/licenses/LICENSE-2.0
+
+Unless required by applicable law or agreed to in writing, software
+distributed under the License is distributed on an "AS IS" BASIS,
+WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+See the License for the specific language governing permissions and
+limitations under the License.
+*/
+
+// Code generated by lister-gen. DO NOT EDIT.
+
+package v1
+
+import (
+	v1 "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/client-go/tools/cache"
+)
+
+// TaskRoleScaleLister helps list TaskRoleScales.
+type TaskRoleScaleLister interface {
+	// List lists all TaskRoleScales in the indexer.
+	List(selector labels.Selector) (ret []*v1.TaskRoleScale, err error)
+	// TaskRoleScales returns an object that can list and get TaskRoleScales.
+	TaskRoleScales(namespace string) TaskRoleScaleNamespaceLister
+	TaskRoleScaleListerExpansion
+}
+
+// taskRoleScaleLister implements the TaskRoleScaleLister interface.
+type taskRoleScaleLister struct {
+	indexer cache.Indexer
+}
+
+// NewTaskRoleScaleLister returns a new TaskRoleScaleLister.
+func NewTaskRoleScaleLister(indexer cache.Indexer) TaskRoleScaleLister {
+	return &taskRoleScaleLister{indexer: indexer}
+}
+
+// List lists all TaskRoleScales in the indexer.
+func (s *taskRoleScaleLister) List(selector labels.Selector) (ret []*v1.TaskRoleScale, err error) {
+	err = cache.ListAll(s.indexer, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.TaskRoleScale))
+	})
+	return ret, err
+}
+
+// TaskRoleScales returns an object that can list and get TaskRoleScales.
+func (s *taskRoleScaleLister) TaskRoleScales(namespace string) TaskRoleScaleNamespaceLister {
+	return taskRoleScaleNamespaceLister{indexer: s.indexer, namespace: namespace}
+}
+
+// TaskRoleScaleNamespaceLister helps list and get TaskRoleScales.
+type TaskRoleScaleNamespaceLister interface {
+	// List lists all TaskRoleScales in the indexer for a given namespace.
+	List(selector labels.Selector) (ret []*v1.TaskRoleScale, err error)
+	// Get retrieves the TaskRoleScale from the indexer for a given namespace and name.
+	Get(name string) (*v1.TaskRoleScale, error)
+	TaskRoleScaleNamespaceListerExpansion
+}
+
+// taskRoleScaleNamespaceLister implements the TaskRoleScaleNamespaceLister
+// interface.
+type taskRoleScaleNamespaceLister struct {
+	indexer   cache.Indexer
+	namespace string
+}
+
+// List lists all TaskRoleScales in the indexer for a given namespace.
+func (s taskRoleScaleNamespaceLister) List(selector labels.Selector) (ret []*v1.TaskRoleScale, err error) {
+	err = cache.ListAllByNamespace(s.indexer, s.namespace, selector, func(m interface{}) {
+		ret = append(ret, m.(*v1.TaskRoleScale))
+	})
+	return ret, err
+}
+
+// Get retrieves the TaskRoleScale from the indexer for a given namespace and name.
+func (s taskRoleScaleNamespaceLister) Get(name string) (*v1.TaskRoleScale, error) {
+	obj, exists, err := s.indexer.GetByKey(s.namespace + "/" + name)
+	if err != nil {
+		return nil, err
+	}
+	if !exists {
+		return nil, errors.NewNotFound(v1.Resource("taskrolescale"), name)
+	}
+	return obj.(*v1.TaskRoleScale), nil
+}
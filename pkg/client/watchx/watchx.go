@@ -0,0 +1,264 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package watchx
+
+import (
+	"context"
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	frameworkClient "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned"
+	frameworkInformer "github.com/microsoft/frameworkcontroller/pkg/client/informers/externalversions/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/internal"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+	"sync"
+)
+
+// Watch the Frameworks and deliver their typed state change Events over a
+// channel, so that the external Go services do not need to diff the Framework
+// Status by themselves:
+//   events, err := watchx.Watch(ctx, fClient, &watchx.Options{Namespace: "default"})
+//   for event := range events {
+//     switch e := event.(type) {
+//     case *watchx.FrameworkTransitioned:
+//       ... e.From, e.To ...
+//     }
+//   }
+//
+// The Events of a Framework are delivered in order, and the Framework with a
+// different UID, i.e. deleted and then recreated with the same name, is
+// treated as a new Framework, so its Events start from the empty state.
+// The Events are deduplicated, i.e. an Event is only delivered once for each
+// observed change, even if the Framework is resynced or its unrelated fields
+// are updated. However, the intermediate states may be skipped if they are
+// not observed.
+
+type Event interface {
+	// The Framework after the change.
+	GetFramework() *ci.Framework
+}
+
+// The FrameworkState is changed, and From is empty for the newly observed
+// Framework.
+type FrameworkTransitioned struct {
+	Framework *ci.Framework
+	From      ci.FrameworkState
+	To        ci.FrameworkState
+}
+
+// The TaskState is changed, and From is empty for the newly observed Task, such
+// as in a new FrameworkAttempt.
+type TaskTransitioned struct {
+	Framework    *ci.Framework
+	TaskRoleName string
+	TaskIndex    int32
+	From         ci.TaskState
+	To           ci.TaskState
+}
+
+// A FrameworkAttempt is completed.
+type AttemptCompleted struct {
+	Framework          *ci.Framework
+	FrameworkAttemptID int32
+	CompletionStatus   *ci.FrameworkAttemptCompletionStatus
+}
+
+// The Framework is deleted, and it is the last Event of the Framework.
+type FrameworkDeleted struct {
+	Framework *ci.Framework
+}
+
+func (e *FrameworkTransitioned) GetFramework() *ci.Framework { return e.Framework }
+func (e *TaskTransitioned) GetFramework() *ci.Framework      { return e.Framework }
+func (e *AttemptCompleted) GetFramework() *ci.Framework      { return e.Framework }
+func (e *FrameworkDeleted) GetFramework() *ci.Framework      { return e.Framework }
+
+type Options struct {
+	// The namespace to watch.
+	// Default to empty, i.e. all namespaces.
+	Namespace string
+	// The label selector of the Frameworks to watch.
+	// Default to empty, i.e. all Frameworks.
+	LabelSelector string
+	// The capacity of the returned channel.
+	// Default to 0, i.e. unbuffered.
+	BufferSize int
+}
+
+type watcher struct {
+	ctx    context.Context
+	events chan Event
+
+	// Protect the events from being closed while sending.
+	lock   sync.RWMutex
+	closed bool
+}
+
+// Watch starts to watch the Frameworks until the ctx is done, and then the
+// returned channel is closed.
+// The existing Frameworks are also delivered as the newly observed ones, and
+// the caller should keep receiving from the channel, otherwise the Framework
+// watch will be blocked.
+func Watch(
+	ctx context.Context, fClient frameworkClient.Interface,
+	opts *Options) (<-chan Event, error) {
+	if opts == nil {
+		opts = &Options{}
+	}
+	if opts.BufferSize < 0 {
+		return nil, fmt.Errorf(
+			"Failed to watch Frameworks: BufferSize %v should not be negative",
+			opts.BufferSize)
+	}
+
+	w := &watcher{
+		ctx:    ctx,
+		events: make(chan Event, opts.BufferSize),
+	}
+
+	fInformer := frameworkInformer.NewFilteredFrameworkInformer(
+		fClient, opts.Namespace, 0, cache.Indexers{},
+		func(options *meta.ListOptions) {
+			options.LabelSelector = opts.LabelSelector
+		})
+	fInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			w.diff(nil, internal.ToFramework(obj))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			w.diff(internal.ToFramework(oldObj), internal.ToFramework(newObj))
+		},
+		DeleteFunc: func(obj interface{}) {
+			w.send(&FrameworkDeleted{Framework: internal.ToFramework(obj)})
+		},
+	})
+
+	go fInformer.Run(ctx.Done())
+	go func() {
+		<-ctx.Done()
+		w.lock.Lock()
+		defer w.lock.Unlock()
+		w.closed = true
+		close(w.events)
+	}()
+
+	return w.events, nil
+}
+
+func (w *watcher) send(event Event) {
+	w.lock.RLock()
+	defer w.lock.RUnlock()
+	if w.closed {
+		return
+	}
+	select {
+	case w.events <- event:
+	case <-w.ctx.Done():
+	}
+}
+
+// The Framework from the informer should not be modified, so it is copied
+// before decompressed.
+func decompressedCopy(f *ci.Framework) *ci.Framework {
+	if f == nil {
+		return nil
+	}
+	f = f.DeepCopy()
+	if err := f.Decompress(); err != nil {
+		klog.Warningf("[%v]: Failed to decompress Framework: %v", f.Key(), err)
+	}
+	return f
+}
+
+func (w *watcher) diff(oldF *ci.Framework, newF *ci.Framework) {
+	if oldF != nil && oldF.ResourceVersion == newF.ResourceVersion {
+		// Resync
+		return
+	}
+	if oldF != nil && oldF.UID != newF.UID {
+		w.send(&FrameworkDeleted{Framework: oldF})
+		oldF = nil
+	}
+	if newF.Status == nil {
+		return
+	}
+	oldF, newF = decompressedCopy(oldF), decompressedCopy(newF)
+
+	var oldStatus *ci.FrameworkStatus
+	if oldF != nil {
+		oldStatus = oldF.Status
+	}
+
+	// Not yet changed FrameworkAttempt
+	oldAttemptF := oldF
+	if oldStatus == nil ||
+		oldF.FrameworkAttemptID() != newF.FrameworkAttemptID() {
+		oldAttemptF = nil
+	}
+
+	if oldAttemptF == nil ||
+		oldAttemptF.Status.AttemptStatus.CompletionStatus == nil {
+		if newF.Status.AttemptStatus.CompletionStatus != nil {
+			w.send(&AttemptCompleted{
+				Framework:          newF,
+				FrameworkAttemptID: newF.FrameworkAttemptID(),
+				CompletionStatus:   newF.Status.AttemptStatus.CompletionStatus,
+			})
+		}
+	}
+
+	for _, taskRoleStatus := range newF.TaskRoleStatuses() {
+		for _, taskStatus := range taskRoleStatus.TaskStatuses {
+			var oldState ci.TaskState
+			if oldAttemptF != nil &&
+				oldAttemptF.GetTaskRoleStatus(taskRoleStatus.Name) != nil {
+				if oldTaskStatus := oldAttemptF.GetTaskStatus(
+					taskRoleStatus.Name, taskStatus.Index); oldTaskStatus != nil {
+					oldState = oldTaskStatus.State
+				}
+			}
+			if oldState != taskStatus.State {
+				w.send(&TaskTransitioned{
+					Framework:    newF,
+					TaskRoleName: taskRoleStatus.Name,
+					TaskIndex:    taskStatus.Index,
+					From:         oldState,
+					To:           taskStatus.State,
+				})
+			}
+		}
+	}
+
+	var oldState ci.FrameworkState
+	if oldStatus != nil {
+		oldState = oldStatus.State
+	}
+	if oldState != newF.Status.State {
+		w.send(&FrameworkTransitioned{
+			Framework: newF,
+			From:      oldState,
+			To:        newF.Status.State,
+		})
+	}
+}
@@ -0,0 +1,115 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package common
+
+import (
+	"testing"
+	"time"
+)
+
+func TestParseCronSchedule(t *testing.T) {
+	cases := []struct {
+		spec    string
+		wantErr bool
+	}{
+		{"* * * * *", false},
+		{"*/15 0-6/2 1,15 * 1-5", false},
+		{"0 0 * * 7", false},
+		{"@daily", false},
+		{" @hourly ", false},
+		{"* * * *", true},
+		{"* * * * * *", true},
+		{"60 * * * *", true},
+		{"* 24 * * *", true},
+		{"* * 0 * *", true},
+		{"* * * 13 *", true},
+		{"* * * * 8", true},
+		{"5-1 * * * *", true},
+		{"*/0 * * * *", true},
+		{"a * * * *", true},
+		{"@reboot", true},
+	}
+
+	for _, c := range cases {
+		_, err := ParseCronSchedule(c.spec)
+		if (err != nil) != c.wantErr {
+			t.Errorf("ParseCronSchedule(%q): got err %v, want err %v",
+				c.spec, err, c.wantErr)
+		}
+	}
+}
+
+func TestCronScheduleNext(t *testing.T) {
+	// 2021-01-01 is a Friday.
+	from := time.Date(2021, 1, 1, 10, 30, 20, 0, time.UTC)
+	cases := []struct {
+		spec string
+		from time.Time
+		want time.Time
+	}{
+		{"* * * * *", from,
+			time.Date(2021, 1, 1, 10, 31, 0, 0, time.UTC)},
+		{"*/15 * * * *", from,
+			time.Date(2021, 1, 1, 10, 45, 0, 0, time.UTC)},
+		{"0 * * * *", from,
+			time.Date(2021, 1, 1, 11, 0, 0, 0, time.UTC)},
+		{"@daily", from,
+			time.Date(2021, 1, 2, 0, 0, 0, 0, time.UTC)},
+		{"0 9 * * *", from,
+			time.Date(2021, 1, 2, 9, 0, 0, 0, time.UTC)},
+		// Sunday as 7.
+		{"0 0 * * 7", from,
+			time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"@weekly", from,
+			time.Date(2021, 1, 3, 0, 0, 0, 0, time.UTC)},
+		{"@monthly", from,
+			time.Date(2021, 2, 1, 0, 0, 0, 0, time.UTC)},
+		{"@yearly", from,
+			time.Date(2022, 1, 1, 0, 0, 0, 0, time.UTC)},
+		// Either DayOfMonth or DayOfWeek matches if both are restricted.
+		{"0 0 15 * 1", from,
+			time.Date(2021, 1, 4, 0, 0, 0, 0, time.UTC)},
+		// Both DayOfMonth and DayOfWeek should match if either is *.
+		{"0 0 */10 * *", from,
+			time.Date(2021, 1, 11, 0, 0, 0, 0, time.UTC)},
+		// Leap day.
+		{"0 0 29 2 *", from,
+			time.Date(2024, 2, 29, 0, 0, 0, 0, time.UTC)},
+		// Never matches.
+		{"0 0 30 2 *", from, time.Time{}},
+		// Strictly after the given time.
+		{"30 10 * * *", time.Date(2021, 1, 1, 10, 30, 0, 0, time.UTC),
+			time.Date(2021, 1, 2, 10, 30, 0, 0, time.UTC)},
+	}
+
+	for _, c := range cases {
+		s, err := ParseCronSchedule(c.spec)
+		if err != nil {
+			t.Fatalf("ParseCronSchedule(%q): %v", c.spec, err)
+		}
+		if got := s.Next(c.from); !got.Equal(c.want) {
+			t.Errorf("ParseCronSchedule(%q).Next(%v): got %v, want %v",
+				c.spec, c.from, got, c.want)
+		}
+	}
+}
@@ -0,0 +1,79 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package common
+
+import (
+	"bytes"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"encoding/base64"
+	"encoding/binary"
+	"encoding/pem"
+	"math/big"
+)
+
+const sshKeyBits = 2048
+
+// Generate a RSA SSH keypair, and returns the private key in PEM format and the
+// public key in OpenSSH authorized_keys format.
+// The golang.org/x/crypto/ssh is not vendored, so the public key is encoded
+// according to RFC 4253.
+func GenerateSSHKeyPair() (privateKey []byte, authorizedKey []byte, err error) {
+	key, err := rsa.GenerateKey(rand.Reader, sshKeyBits)
+	if err != nil {
+		return nil, nil, err
+	}
+
+	privateKey = pem.EncodeToMemory(&pem.Block{
+		Type:  "RSA PRIVATE KEY",
+		Bytes: x509.MarshalPKCS1PrivateKey(key),
+	})
+
+	keyType := "ssh-rsa"
+	wire := &bytes.Buffer{}
+	writeSSHString(wire, []byte(keyType))
+	writeSSHString(wire, toSSHMPInt(big.NewInt(int64(key.PublicKey.E))))
+	writeSSHString(wire, toSSHMPInt(key.PublicKey.N))
+	authorizedKey = []byte(
+		keyType + " " + base64.StdEncoding.EncodeToString(wire.Bytes()) + "\n")
+
+	return privateKey, authorizedKey, nil
+}
+
+func writeSSHString(buf *bytes.Buffer, s []byte) {
+	length := make([]byte, 4)
+	binary.BigEndian.PutUint32(length, uint32(len(s)))
+	buf.Write(length)
+	buf.Write(s)
+}
+
+// The positive mpint should be prefixed with a zero byte if its most
+// significant bit is set.
+func toSSHMPInt(n *big.Int) []byte {
+	b := n.Bytes()
+	if len(b) > 0 && b[0]&0x80 != 0 {
+		b = append([]byte{0}, b...)
+	}
+	return b
+}
@@ -32,6 +32,7 @@ import (
 	"io/ioutil"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
 	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/clock"
 	"k8s.io/klog"
 	"log"
 	"math/rand"
@@ -41,6 +42,14 @@ import (
 	"time"
 )
 
+// Clock is the source of the current time for the status timestamps and the
+// timeout checks, so that it can be replaced by a fake clock in tests.
+var Clock clock.Clock = clock.RealClock{}
+
+func Now() meta.Time {
+	return meta.NewTime(Clock.Now())
+}
+
 func Quote(s string) string {
 	return `"` + s + `"`
 }
@@ -134,7 +143,7 @@ func PtrTime(o meta.Time) *meta.Time {
 }
 
 func PtrNow() *meta.Time {
-	now := meta.Now()
+	now := Now()
 	return &now
 }
 
@@ -148,7 +157,7 @@ func IsTimeout(leftDuration time.Duration) bool {
 }
 
 func CurrentLeftDuration(startTime meta.Time, timeoutSec *int64) time.Duration {
-	currentDuration := time.Since(startTime.Time)
+	currentDuration := Clock.Since(startTime.Time)
 	timeoutDuration := SecToDuration(timeoutSec)
 	leftDuration := timeoutDuration - currentDuration
 	return leftDuration
@@ -0,0 +1,164 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"io/ioutil"
+	"net/http"
+	"net/url"
+	"os"
+	"path"
+	"path/filepath"
+)
+
+// FrameworkArchiver persists the final snapshot of a completed Framework to
+// external storage.
+// See FrameworkArchiveConfig.
+type FrameworkArchiver interface {
+	// It may be invoked more than once for the same Framework, such as retried
+	// after failure, so it should be idempotent.
+	Archive(f *ci.Framework) error
+}
+
+// Return nil if the FrameworkArchive is disabled.
+func NewFrameworkArchiver(aConfig *ci.FrameworkArchiveConfig) FrameworkArchiver {
+	client := &http.Client{Timeout: common.SecToDuration(aConfig.TimeoutSec)}
+	switch *aConfig.Type {
+	case ci.FrameworkArchiveFile:
+		return &fileArchiver{dir: *aConfig.FileDir}
+	case ci.FrameworkArchiveHTTP:
+		return &httpArchiver{client: client, url: *aConfig.HTTPURL}
+	case ci.FrameworkArchiveAzureBlob:
+		containerURL, err := url.Parse(*aConfig.AzureBlobContainerSASURL)
+		if err != nil {
+			panic(fmt.Errorf(
+				"Failed to parse FrameworkArchive.AzureBlobContainerSASURL: %v", err))
+		}
+		return &azureBlobArchiver{client: client, containerURL: containerURL}
+	default:
+		return nil
+	}
+}
+
+// {FrameworkNamespace}/{FrameworkName}-{FrameworkUID}.json
+// The FrameworkUID distinguishes different Frameworks with the same name.
+func getFrameworkArchiveName(f *ci.Framework) string {
+	return path.Join(f.Namespace, fmt.Sprintf("%v-%v.json", f.Name, f.UID))
+}
+
+type fileArchiver struct {
+	dir string
+}
+
+func (a *fileArchiver) Archive(f *ci.Framework) error {
+	filePath := filepath.Join(a.dir, filepath.FromSlash(getFrameworkArchiveName(f)))
+	errPfx := fmt.Sprintf(
+		"[%v]: Failed to archive Framework %v to file %v: ",
+		f.Key(), f.UID, filePath)
+
+	err := os.MkdirAll(filepath.Dir(filePath), 0755)
+	if err != nil {
+		return fmt.Errorf(errPfx+"%v", err)
+	}
+
+	// Write to a temp file and then rename it, so that a partially written
+	// archive is never exposed.
+	tmpFilePath := filePath + ".tmp"
+	err = ioutil.WriteFile(tmpFilePath, []byte(common.ToJson(f)), 0644)
+	if err != nil {
+		return fmt.Errorf(errPfx+"%v", err)
+	}
+	err = os.Rename(tmpFilePath, filePath)
+	if err != nil {
+		return fmt.Errorf(errPfx+"%v", err)
+	}
+	return nil
+}
+
+type httpArchiver struct {
+	client *http.Client
+	url    string
+}
+
+func (a *httpArchiver) Archive(f *ci.Framework) error {
+	errPfx := fmt.Sprintf(
+		"[%v]: Failed to archive Framework %v to HTTP endpoint %v: ",
+		f.Key(), f.UID, a.url)
+
+	req, err := http.NewRequest(
+		http.MethodPost, a.url, bytes.NewReader([]byte(common.ToJson(f))))
+	if err != nil {
+		return fmt.Errorf(errPfx+"%v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	return doArchiveRequest(a.client, req, errPfx)
+}
+
+type azureBlobArchiver struct {
+	client       *http.Client
+	containerURL *url.URL
+}
+
+func (a *azureBlobArchiver) Archive(f *ci.Framework) error {
+	// Keep the SAS token in the query, and only append the blob name to the path.
+	blobURL := *a.containerURL
+	blobURL.Path = path.Join(blobURL.Path, getFrameworkArchiveName(f))
+	// Do not log the SAS token.
+	errPfx := fmt.Sprintf(
+		"[%v]: Failed to archive Framework %v to Azure Blob %v%v: ",
+		f.Key(), f.UID, blobURL.Host, blobURL.Path)
+
+	req, err := http.NewRequest(
+		http.MethodPut, blobURL.String(), bytes.NewReader([]byte(common.ToJson(f))))
+	if err != nil {
+		return fmt.Errorf(errPfx+"%v", err)
+	}
+	req.Header.Set("Content-Type", "application/json")
+	req.Header.Set("x-ms-blob-type", "BlockBlob")
+
+	return doArchiveRequest(a.client, req, errPfx)
+}
+
+func doArchiveRequest(client *http.Client, req *http.Request, errPfx string) error {
+	resp, err := client.Do(req)
+	if err != nil {
+		// The url.Error includes the full URL which may contain the SAS token,
+		// and the errPfx already includes the safe part of the URL.
+		if urlErr, ok := err.(*url.Error); ok {
+			err = urlErr.Err
+		}
+		return fmt.Errorf(errPfx+"%v", err)
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf(errPfx+"Unexpected response: %v: %v", resp.Status, string(body))
+	}
+	return nil
+}
@@ -0,0 +1,280 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"io/ioutil"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+	"net/http"
+	"sync"
+	"time"
+)
+
+// TaskRoleAutoscaler is periodically consulted with the metrics of the
+// Preparing or Running FrameworkAttempt, and may return a new TaskNumber for
+// its TaskRoles, which is applied as the TaskRoleStatus AutoscaledTaskNumber,
+// i.e. a Spec independent scale adjustment.
+// Downstream builds can compile in custom TaskRoleAutoscalers by
+// RegisterTaskRoleAutoscaler in their init(), and then select it by
+// Config.TaskRoleAutoscaler.Name.
+type TaskRoleAutoscaler interface {
+	// Return the new TaskNumber of the TaskRoles to be adjusted, and the
+	// TaskRoles not in the result are kept unchanged.
+	Autoscale(ac *AutoscaleContext) (map[string]int32, error)
+}
+
+type AutoscaleContext struct {
+	Framework       *ci.Framework
+	TaskRoleMetrics []*TaskRoleMetrics
+}
+
+// TaskRoleMetrics is the metrics of a TaskRole in current FrameworkAttempt,
+// and DeletionPending (ScaleDown) Tasks are not counted.
+type TaskRoleMetrics struct {
+	TaskRoleName string `json:"taskRoleName"`
+	// The TaskRoleSpec TaskNumber.
+	SpecTaskNumber int32 `json:"specTaskNumber"`
+	// The effective TaskNumber, which may be adjusted by the TaskRoleAutoscaler.
+	TaskNumber int32 `json:"taskNumber"`
+
+	RunningTaskCount int32 `json:"runningTaskCount"`
+	// The Tasks whose current TaskAttempt is not yet Running.
+	PendingTaskCount int32 `json:"pendingTaskCount"`
+	// The longest time that a pending TaskAttempt has been waiting.
+	MaxPendingSec int64 `json:"maxPendingSec"`
+
+	CompletedTaskCount int32 `json:"completedTaskCount"`
+	// The CompletedTaskCount per minute since the FrameworkAttempt started.
+	CompletedTaskRatePerMin float64 `json:"completedTaskRatePerMin"`
+}
+
+func NewAutoscaleContext(f *ci.Framework) *AutoscaleContext {
+	now := time.Now()
+	attemptMin := now.Sub(f.Status.AttemptStatus.StartTime.Time).Minutes()
+
+	trms := []*TaskRoleMetrics{}
+	for _, taskRoleSpec := range f.Spec.TaskRoles {
+		taskRoleStatus := f.GetTaskRoleStatus(taskRoleSpec.Name)
+		if taskRoleStatus == nil {
+			continue
+		}
+
+		trm := &TaskRoleMetrics{
+			TaskRoleName:   taskRoleSpec.Name,
+			SpecTaskNumber: taskRoleSpec.TaskNumber,
+			TaskNumber:     f.GetTaskNumber(taskRoleSpec),
+		}
+		for _, taskStatus := range taskRoleStatus.TaskStatuses {
+			if taskStatus.DeletionPending {
+				continue
+			}
+			switch taskStatus.State {
+			case ci.TaskAttemptRunning:
+				trm.RunningTaskCount++
+			case ci.TaskCompleted:
+				trm.CompletedTaskCount++
+			case ci.TaskAttemptCreationPending,
+				ci.TaskAttemptCreationRequested,
+				ci.TaskAttemptPreparing:
+				trm.PendingTaskCount++
+				pendingSec := int64(
+					now.Sub(taskStatus.AttemptStatus.StartTime.Time).Seconds())
+				if pendingSec > trm.MaxPendingSec {
+					trm.MaxPendingSec = pendingSec
+				}
+			}
+		}
+		if attemptMin > 0 {
+			trm.CompletedTaskRatePerMin = float64(trm.CompletedTaskCount) / attemptMin
+		}
+		trms = append(trms, trm)
+	}
+
+	return &AutoscaleContext{Framework: f, TaskRoleMetrics: trms}
+}
+
+var taskRoleAutoscalers = &sync.Map{}
+
+// It panics if the name is already registered.
+func RegisterTaskRoleAutoscaler(name string, autoscaler TaskRoleAutoscaler) {
+	if _, loaded := taskRoleAutoscalers.LoadOrStore(name, autoscaler); loaded {
+		panic(fmt.Errorf("TaskRoleAutoscaler %v is already registered", name))
+	}
+}
+
+// Return nil if the name is not registered.
+func GetTaskRoleAutoscaler(name string) TaskRoleAutoscaler {
+	if autoscaler, ok := taskRoleAutoscalers.Load(name); ok {
+		return autoscaler.(TaskRoleAutoscaler)
+	}
+	return nil
+}
+
+// Return nil if the TaskRoleAutoscaler is disabled.
+// It panics if the TaskRoleAutoscaler is not registered.
+func NewTaskRoleAutoscaler(taConfig *ci.TaskRoleAutoscalerConfig) TaskRoleAutoscaler {
+	switch *taConfig.Name {
+	case "":
+		return nil
+	case ci.WebhookTaskRoleAutoscalerName:
+		return &WebhookTaskRoleAutoscaler{
+			client: &http.Client{Timeout: common.SecToDuration(taConfig.TimeoutSec)},
+			url:    *taConfig.URL,
+		}
+	}
+
+	autoscaler := GetTaskRoleAutoscaler(*taConfig.Name)
+	if autoscaler == nil {
+		panic(fmt.Errorf("TaskRoleAutoscaler %v is not registered", *taConfig.Name))
+	}
+	return autoscaler
+}
+
+// TaskRoleAutoscaleRequest is POSTed to the TaskRoleAutoscaler webhook.
+type TaskRoleAutoscaleRequest struct {
+	FrameworkNamespace string             `json:"frameworkNamespace"`
+	FrameworkName      string             `json:"frameworkName"`
+	FrameworkAttemptID int32              `json:"frameworkAttemptID"`
+	TaskRoleMetrics    []*TaskRoleMetrics `json:"taskRoleMetrics"`
+}
+
+// TaskRoleAutoscaleResponse is expected from the TaskRoleAutoscaler webhook.
+type TaskRoleAutoscaleResponse struct {
+	// TaskRoleName -> TaskNumber
+	// The TaskRoles not in it are kept unchanged.
+	TaskNumbers map[string]int32 `json:"taskNumbers"`
+}
+
+// WebhookTaskRoleAutoscaler calls the TaskRoleAutoscaler webhook.
+// See TaskRoleAutoscalerConfig.
+type WebhookTaskRoleAutoscaler struct {
+	client *http.Client
+	url    string
+}
+
+func (w *WebhookTaskRoleAutoscaler) Autoscale(
+	ac *AutoscaleContext) (map[string]int32, error) {
+	f := ac.Framework
+	errPfx := fmt.Sprintf(
+		"Failed to autoscale Framework %v by TaskRoleAutoscaler %v: ", f.Key(), w.url)
+
+	req := &TaskRoleAutoscaleRequest{
+		FrameworkNamespace: f.Namespace,
+		FrameworkName:      f.Name,
+		FrameworkAttemptID: f.FrameworkAttemptID(),
+		TaskRoleMetrics:    ac.TaskRoleMetrics,
+	}
+	httpReq, err := http.NewRequest(
+		http.MethodPost, w.url, bytes.NewReader([]byte(common.ToJson(req))))
+	if err != nil {
+		return nil, fmt.Errorf(errPfx+"%v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := w.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf(errPfx+"%v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf(errPfx+"%v", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf(errPfx+"Unexpected response: %v: %v", resp.Status, string(body))
+	}
+
+	taResp := &TaskRoleAutoscaleResponse{}
+	if err := json.Unmarshal(body, taResp); err != nil {
+		return nil, fmt.Errorf(errPfx+"Invalid response: %v: %v", err, string(body))
+	}
+	return taResp.TaskNumbers, nil
+}
+
+// Consult the TaskRoleAutoscaler at most once per IntervalSec for the
+// Preparing or Running FrameworkAttempt, and apply its result to the
+// TaskRoleStatus AutoscaledTaskNumber, which will be rescaled by the following
+// syncFrameworkScale.
+func (c *FrameworkController) syncTaskRoleAutoscale(f *ci.Framework) {
+	logPfx := fmt.Sprintf("[%v]: syncTaskRoleAutoscale: ", f.Key())
+
+	if c.tAutoscaler == nil || f.IsCompleting() ||
+		(f.Status.State != ci.FrameworkAttemptPreparing &&
+			f.Status.State != ci.FrameworkAttemptRunning) {
+		return
+	}
+
+	intervalSec := c.config().TaskRoleAutoscaler.IntervalSec
+	if f.Status.AttemptStatus.LastAutoscaleTime != nil &&
+		c.enqueueFrameworkTimeoutCheck(
+			f, *f.Status.AttemptStatus.LastAutoscaleTime, intervalSec,
+			true, "TaskRoleAutoscaleIntervalCheck") {
+		return
+	}
+
+	span := c.tracer.StartSpan(f.Key(), "AutoscaleTaskRoles", nil)
+	taskNumbers, err := c.tAutoscaler.Autoscale(NewAutoscaleContext(f))
+	span.End(err)
+
+	// Even if it failed, wait for the next interval to avoid overwhelming the
+	// TaskRoleAutoscaler.
+	now := meta.Now()
+	f.Status.AttemptStatus.LastAutoscaleTime = &now
+	c.enqueueFrameworkTimeoutCheck(
+		f, now, intervalSec, false, "TaskRoleAutoscaleIntervalCheck")
+	if err != nil {
+		klog.Warningf(logPfx+"Skipped: %v", err)
+		return
+	}
+
+	for taskRoleName, taskNumber := range taskNumbers {
+		taskRoleSpec := f.GetTaskRoleSpec(taskRoleName)
+		taskRoleStatus := f.GetTaskRoleStatus(taskRoleName)
+		if taskRoleSpec == nil || taskRoleStatus == nil {
+			klog.Warningf(logPfx+
+				"Ignored TaskRole %v: It does not exist", taskRoleName)
+			continue
+		}
+		if taskNumber < 0 {
+			klog.Warningf(logPfx+
+				"Ignored TaskRole %v: TaskNumber %v should not be negative",
+				taskRoleName, taskNumber)
+			continue
+		}
+
+		oldTaskNumber := f.GetTaskNumber(taskRoleSpec)
+		if taskNumber == oldTaskNumber {
+			continue
+		}
+		klog.Infof(logPfx+"TaskRole %v: TaskNumber: %v -> %v",
+			taskRoleName, oldTaskNumber, taskNumber)
+		taskRoleStatus.AutoscaledTaskNumber = common.PtrInt32(taskNumber)
+	}
+}
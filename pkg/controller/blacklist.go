@@ -0,0 +1,150 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	core "k8s.io/api/core/v1"
+	"k8s.io/klog"
+	"sort"
+)
+
+// Record the node of the failed TaskAttempt into the Framework BlacklistedNodes
+// if the failure is due to the infrastructure.
+// It is idempotent for the same TaskAttempt, since the failure is only counted
+// once for the same LastFailedTime.
+func (c *FrameworkController) recordBlacklistedNode(
+	f *ci.Framework, taskStatus *ci.TaskStatus) {
+	if !*c.config().NodeBlacklist.Enabled {
+		return
+	}
+
+	cs := taskStatus.AttemptStatus.CompletionStatus
+	nodeName := taskStatus.AttemptStatus.PodNodeName
+	if cs == nil || !cs.IsInfrastructureFailed() ||
+		nodeName == nil || *nodeName == "" ||
+		taskStatus.AttemptStatus.CompletionTime == nil {
+		return
+	}
+	failedTime := *taskStatus.AttemptStatus.CompletionTime
+
+	c.pruneBlacklistedNodes(f)
+
+	node := findBlacklistedNode(f, *nodeName)
+	if node == nil {
+		node = &ci.BlacklistedNode{NodeName: *nodeName}
+		f.Status.BlacklistedNodes = append(f.Status.BlacklistedNodes, node)
+	} else if !failedTime.After(node.LastFailedTime.Time) {
+		return
+	}
+	node.LastFailedTime = failedTime
+	node.FailedCount++
+	node.CompletionCode = cs.Code
+
+	sort.SliceStable(f.Status.BlacklistedNodes, func(i, j int) bool {
+		return f.Status.BlacklistedNodes[i].LastFailedTime.Before(
+			&f.Status.BlacklistedNodes[j].LastFailedTime)
+	})
+	maxNodeCount := int(*c.config().NodeBlacklist.MaxNodeCount)
+	if len(f.Status.BlacklistedNodes) > maxNodeCount {
+		f.Status.BlacklistedNodes = f.Status.BlacklistedNodes[len(
+			f.Status.BlacklistedNodes)-maxNodeCount:]
+	}
+
+	klog.Infof(
+		"[%v]: Blacklisted node %v due to TaskAttempt failed with "+
+			"CompletionCode %v, %v", f.Key(), *nodeName, cs.Code, cs.Phrase)
+}
+
+func findBlacklistedNode(f *ci.Framework, nodeName string) *ci.BlacklistedNode {
+	for _, node := range f.Status.BlacklistedNodes {
+		if node.NodeName == nodeName {
+			return node
+		}
+	}
+	return nil
+}
+
+// Remove the expired BlacklistedNodes.
+func (c *FrameworkController) pruneBlacklistedNodes(f *ci.Framework) {
+	nodes := []*ci.BlacklistedNode{}
+	for _, node := range f.Status.BlacklistedNodes {
+		leftDuration := common.CurrentLeftDuration(
+			node.LastFailedTime, c.config().NodeBlacklist.TTLSec)
+		if !common.IsTimeout(leftDuration) {
+			nodes = append(nodes, node)
+		}
+	}
+	if len(nodes) == 0 {
+		nodes = nil
+	}
+	f.Status.BlacklistedNodes = nodes
+}
+
+// Inject the NodeAffinity to avoid the not expired BlacklistedNodes.
+// The requirement is ANDed into each existing required NodeSelectorTerm, since
+// the terms are ORed.
+func (c *FrameworkController) setNodeBlacklist(f *ci.Framework, pod *core.Pod) {
+	if !*c.config().NodeBlacklist.Enabled {
+		return
+	}
+
+	c.pruneBlacklistedNodes(f)
+	if len(f.Status.BlacklistedNodes) == 0 {
+		return
+	}
+
+	nodeNames := []string{}
+	for _, node := range f.Status.BlacklistedNodes {
+		nodeNames = append(nodeNames, node.NodeName)
+	}
+	requirement := core.NodeSelectorRequirement{
+		Key:      ci.NodeNameFieldPath,
+		Operator: core.NodeSelectorOpNotIn,
+		Values:   nodeNames,
+	}
+
+	if pod.Spec.Affinity == nil {
+		pod.Spec.Affinity = &core.Affinity{}
+	}
+	if pod.Spec.Affinity.NodeAffinity == nil {
+		pod.Spec.Affinity.NodeAffinity = &core.NodeAffinity{}
+	}
+	nodeAffinity := pod.Spec.Affinity.NodeAffinity
+	if nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution == nil {
+		nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution = &core.NodeSelector{}
+	}
+	required := nodeAffinity.RequiredDuringSchedulingIgnoredDuringExecution
+	if len(required.NodeSelectorTerms) == 0 {
+		required.NodeSelectorTerms = []core.NodeSelectorTerm{{}}
+	}
+	for i := range required.NodeSelectorTerms {
+		required.NodeSelectorTerms[i].MatchFields = append(
+			required.NodeSelectorTerms[i].MatchFields, requirement)
+	}
+
+	klog.Infof(
+		"[%v]: Pod %v avoids BlacklistedNodes %v",
+		f.Key(), pod.Name, common.ToJson(nodeNames))
+}
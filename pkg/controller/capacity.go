@@ -0,0 +1,147 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Get the message of why the FrameworkAttempt cannot possibly fit into the
+// schedulable capacity of the cluster, or empty if it may fit.
+// See Config CapacityCheck.
+func (c *FrameworkController) getInsufficientCapacityMessage(
+	f *ci.Framework) (string, error) {
+	nodes, err := c.getSchedulableNodes()
+	if err != nil {
+		return "", err
+	}
+
+	nodeFrees := []core.ResourceList{}
+	totalFree := core.ResourceList{}
+	for _, node := range nodes {
+		free, err := c.getNodeFreeResources(node)
+		if err != nil {
+			return "", err
+		}
+		nodeFrees = append(nodeFrees, free)
+		addResourceList(totalFree, free)
+	}
+
+	totalRequests := core.ResourceList{}
+	for _, taskRole := range f.Spec.TaskRoles {
+		taskNumber := f.GetTaskNumber(taskRole)
+		if taskNumber == 0 {
+			continue
+		}
+
+		podRequests := ci.GetPodResourceRequests(&taskRole.Task.Pod.Spec)
+		fitsAnyNode := false
+		for _, free := range nodeFrees {
+			if fitsResourceList(podRequests, free) {
+				fitsAnyNode = true
+				break
+			}
+		}
+		if !fitsAnyNode {
+			return fmt.Sprintf(
+				"TaskRole %v Pod resource requests %v cannot fit into any of the "+
+					"%v schedulable nodes", taskRole.Name, common.ToJson(podRequests),
+				len(nodeFrees)), nil
+		}
+
+		for i := int32(0); i < taskNumber; i++ {
+			addResourceList(totalRequests, podRequests)
+		}
+	}
+
+	if !fitsResourceList(totalRequests, totalFree) {
+		return fmt.Sprintf(
+			"FrameworkAttempt total resource requests %v exceed the schedulable "+
+				"capacity %v of the %v schedulable nodes",
+			common.ToJson(totalRequests), common.ToJson(totalFree),
+			len(nodeFrees)), nil
+	}
+	return "", nil
+}
+
+// The Ready and not Unschedulable nodes.
+func (c *FrameworkController) getSchedulableNodes() ([]*core.Node, error) {
+	nodes, err := c.nodeLister.List(labels.Everything())
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Failed to list Nodes from local cache: %v", err)
+	}
+
+	schedulableNodes := []*core.Node{}
+	for _, node := range nodes {
+		if node.Spec.Unschedulable || getNodeNotReadyCondition(node) != nil {
+			continue
+		}
+		schedulableNodes = append(schedulableNodes, node)
+	}
+	return schedulableNodes, nil
+}
+
+// The allocatable resources of the node minus the resource requests of all its
+// not completed Pods.
+func (c *FrameworkController) getNodeFreeResources(
+	node *core.Node) (core.ResourceList, error) {
+	free := node.Status.Allocatable.DeepCopy()
+	if free == nil {
+		free = core.ResourceList{}
+	}
+
+	pods, err := c.podInformer.GetIndexer().ByIndex(podNodeNameIndex, node.Name)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"[%v]: Failed to get Pods on the node from local cache: %v",
+			node.Name, err)
+	}
+	for _, obj := range pods {
+		pod := obj.(*core.Pod)
+		if pod.Status.Phase == core.PodSucceeded ||
+			pod.Status.Phase == core.PodFailed {
+			continue
+		}
+		subResourceList(free, ci.GetPodResourceRequests(&pod.Spec))
+	}
+	return free, nil
+}
+
+// Whether the requests can fit into the free resources, and the resources
+// which are not requested are ignored.
+func fitsResourceList(requests core.ResourceList, free core.ResourceList) bool {
+	for name, request := range requests {
+		if request.IsZero() {
+			continue
+		}
+		available := free[name]
+		if request.Cmp(available) > 0 {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,209 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"bytes"
+	"encoding/json"
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"io/ioutil"
+	core "k8s.io/api/core/v1"
+	"k8s.io/klog"
+	"net/http"
+)
+
+// FailureClassificationRequest is POSTed to the FailureClassifier webhook for
+// each failed Pod.
+type FailureClassificationRequest struct {
+	FrameworkNamespace string    `json:"frameworkNamespace"`
+	FrameworkName      string    `json:"frameworkName"`
+	TaskRoleName       string    `json:"taskRoleName"`
+	TaskIndex          int32     `json:"taskIndex"`
+	Pod                *core.Pod `json:"pod"`
+	// All the CompletionStatuses matched by the PodFailureSpec, and the first one
+	// will be used if the webhook does not override it.
+	// If the TerminationMessageCompletion is reported, it is the first one.
+	Candidates []*ci.CompletionStatus `json:"candidates"`
+}
+
+// FailureClassificationResponse is expected from the FailureClassifier webhook.
+type FailureClassificationResponse struct {
+	// Nil means not to override the first candidate.
+	// Otherwise, the CompletionStatus.Code and CompletionStatus.Type.Name must be
+	// specified, and the CompletionStatus.Diagnostics is appended to the Pod
+	// failure diagnostics.
+	CompletionStatus *ci.CompletionStatus `json:"completionStatus"`
+}
+
+// FailureClassifier calls the FailureClassifier webhook.
+// See FailureClassifierConfig.
+type FailureClassifier struct {
+	client *http.Client
+	url    string
+}
+
+// Return nil if the FailureClassifier is disabled.
+func NewFailureClassifier(fcConfig *ci.FailureClassifierConfig) *FailureClassifier {
+	if *fcConfig.URL == "" {
+		return nil
+	}
+	return &FailureClassifier{
+		client: &http.Client{Timeout: common.SecToDuration(fcConfig.TimeoutSec)},
+		url:    *fcConfig.URL,
+	}
+}
+
+func NewFailureClassificationRequest(
+	f *ci.Framework, taskRoleName string, taskIndex int32,
+	pod *core.Pod, results []ci.PodMatchResult) *FailureClassificationRequest {
+	candidates := []*ci.CompletionStatus{}
+	for _, result := range results {
+		candidates = append(candidates, &ci.CompletionStatus{
+			Code:        *result.CodeInfo.Code,
+			Phrase:      result.CodeInfo.Phrase,
+			Type:        result.CodeInfo.Type,
+			Diagnostics: result.Diagnostics,
+		})
+	}
+	return &FailureClassificationRequest{
+		FrameworkNamespace: f.Namespace,
+		FrameworkName:      f.Name,
+		TaskRoleName:       taskRoleName,
+		TaskIndex:          taskIndex,
+		Pod:                pod,
+		Candidates:         candidates,
+	}
+}
+
+// Return nil CompletionStatus if the webhook does not override it.
+func (fc *FailureClassifier) Classify(
+	req *FailureClassificationRequest) (*ci.CompletionStatus, error) {
+	errPfx := fmt.Sprintf(
+		"Failed to classify Pod %v by FailureClassifier %v: ", req.Pod.Name, fc.url)
+
+	httpReq, err := http.NewRequest(
+		http.MethodPost, fc.url, bytes.NewReader([]byte(common.ToJson(req))))
+	if err != nil {
+		return nil, fmt.Errorf(errPfx+"%v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := fc.client.Do(httpReq)
+	if err != nil {
+		return nil, fmt.Errorf(errPfx+"%v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return nil, fmt.Errorf(errPfx+"%v", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return nil, fmt.Errorf(errPfx+"Unexpected response: %v: %v", resp.Status, string(body))
+	}
+
+	fcResp := &FailureClassificationResponse{}
+	if err := json.Unmarshal(body, fcResp); err != nil {
+		return nil, fmt.Errorf(errPfx+"Invalid response: %v: %v", err, string(body))
+	}
+
+	cs := fcResp.CompletionStatus
+	if cs == nil {
+		return nil, nil
+	}
+	if cs.Type.Name != ci.CompletionTypeNameSucceeded &&
+		cs.Type.Name != ci.CompletionTypeNameFailed {
+		return nil, fmt.Errorf(errPfx+
+			"Invalid response: CompletionType.Name %v is not supported: %v",
+			cs.Type.Name, string(body))
+	}
+	return cs, nil
+}
+
+// Return the CompletionStatus of the failed Pod, which is overridden by the
+// FailureClassifier if it is enabled.
+func (c *FrameworkController) classifyPodFailure(
+	f *ci.Framework, taskRoleName string, taskIndex int32,
+	pod *core.Pod) (*ci.CompletionStatus, error) {
+	logPfx := fmt.Sprintf("[%v][%v][%v]: classifyPodFailure: ",
+		f.Key(), taskRoleName, taskIndex)
+
+	result := ci.MatchCompletionCodeInfos(pod)
+	var tmResult *ci.PodMatchResult
+	if *c.config().TerminationMessageCompletionEnabled {
+		tmResult = ci.MatchTerminationMessageCompletion(pod)
+		if tmResult != nil {
+			klog.Infof(logPfx+"CompletionCode %v is overridden to %v by TerminationMessage",
+				*result.CodeInfo.Code, *tmResult.CodeInfo.Code)
+			result = *tmResult
+		}
+	}
+	cs := &ci.CompletionStatus{
+		Code:        *result.CodeInfo.Code,
+		Phrase:      result.CodeInfo.Phrase,
+		Type:        result.CodeInfo.Type,
+		Diagnostics: fmt.Sprintf("Pod failed: %v", result.Diagnostics),
+	}
+	if c.fClassifier == nil {
+		return cs, nil
+	}
+
+	results := ci.MatchAllCompletionCodeInfos(pod)
+	if tmResult != nil {
+		results = append([]ci.PodMatchResult{*tmResult}, results...)
+	}
+	req := NewFailureClassificationRequest(f, taskRoleName, taskIndex,
+		pod, results)
+	span := c.tracer.StartSpan(f.Key(), "ClassifyPodFailure",
+		map[string]string{"object.name": pod.Name})
+	classified, err := c.fClassifier.Classify(req)
+	span.End(err)
+
+	if err != nil {
+		if *c.config().FailureClassifier.FailurePolicy ==
+			ci.FailureClassifierFailurePolicyFail {
+			return nil, fmt.Errorf(logPfx+"%v", err)
+		}
+		klog.Warningf(logPfx+
+			"Fall back to the PodFailureSpec CompletionCode %v: %v", cs.Code, err)
+		return cs, nil
+	}
+	if classified == nil {
+		return cs, nil
+	}
+
+	klog.Infof(logPfx+"CompletionCode %v is overridden to %v",
+		cs.Code, classified.Code)
+	diag := "Pod failed: FailureClassifier classified"
+	if classified.Diagnostics != "" {
+		diag += ": " + classified.Diagnostics
+	}
+	return &ci.CompletionStatus{
+		Code:        classified.Code,
+		Phrase:      classified.Phrase,
+		Type:        classified.Type,
+		Diagnostics: diag,
+	}, nil
+}
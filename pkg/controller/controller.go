@@ -34,11 +34,14 @@ import (
 	core "k8s.io/api/core/v1"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
 	"k8s.io/apimachinery/pkg/types"
 	errorAgg "k8s.io/apimachinery/pkg/util/errors"
 	"k8s.io/apimachinery/pkg/util/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
-	kubeInformer "k8s.io/client-go/informers"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/dynamic/dynamiclister"
 	kubeClient "k8s.io/client-go/kubernetes"
 	coreLister "k8s.io/client-go/listers/core/v1"
 	"k8s.io/client-go/rest"
@@ -47,16 +50,27 @@ import (
 	"k8s.io/client-go/util/workqueue"
 	"k8s.io/klog"
 	"reflect"
+	"sort"
+	"strings"
 	"sync"
+	"sync/atomic"
 	"time"
 )
 
+// The CompletionCodeInfos are process global, so the Config PodFailureSpec is
+// only appended once, i.e. by the first constructed FrameworkController, since
+// appending a CompletionCode twice panics.
+var appendPodFailureSpecOnce sync.Once
+
 // FrameworkController maintains the lifecycle for all Frameworks in the cluster.
 // It is the engine to transition the Framework.Status and other Framework related
 // objects to satisfy the Framework.Spec eventually.
 type FrameworkController struct {
 	kConfig *rest.Config
-	cConfig *ci.Config
+
+	// cConfig stores the current effective *ci.Config, which may be hot reloaded.
+	// Always read it by config(), and do not modify the returned one.
+	cConfig *atomic.Value
 
 	// Client is used to write remote objects in ApiServer.
 	// Remote objects are up-to-date and is writable.
@@ -74,6 +88,8 @@ type FrameworkController struct {
 	// Client.
 	kClient kubeClient.Interface
 	fClient frameworkClient.Interface
+	// dClient manages the objects whose clients are not vendored, such as PodGroup.
+	dClient dynamic.Interface
 
 	// Informer is used to sync remote objects to local cached objects, and then
 	// deliver corresponding events of the object changes.
@@ -88,6 +104,11 @@ type FrameworkController struct {
 	cmInformer  cache.SharedIndexInformer
 	podInformer cache.SharedIndexInformer
 	fInformer   cache.SharedIndexInformer
+	// wlInformer is nil if Kueue is not enabled.
+	wlInformer cache.SharedIndexInformer
+	// nodeInformer is nil if none of NodeLost, SpotInterruption and
+	// CapacityCheck is enabled.
+	nodeInformer cache.SharedIndexInformer
 
 	// Lister is used to read local cached objects in Informer.
 	// Local cached objects may be outdated and is not writable.
@@ -110,6 +131,11 @@ type FrameworkController struct {
 	cmLister  coreLister.ConfigMapLister
 	podLister coreLister.PodLister
 	fLister   frameworkLister.FrameworkLister
+	// wlLister is nil if Kueue is not enabled.
+	wlLister dynamiclister.Lister
+	// nodeLister is nil if none of NodeLost, SpotInterruption and CapacityCheck
+	// is enabled.
+	nodeLister coreLister.NodeLister
 
 	// Queue is used to decouple items delivery and processing, i.e. control
 	// how items are scheduled and distributed to process.
@@ -179,6 +205,74 @@ type FrameworkController struct {
 	// Using sync.Map instead of RWMutex + map[string]*ExpectedFrameworkStatusInfo,
 	// because we can ensure the same item will not be processed concurrently.
 	fExpectedStatusInfos *sync.Map
+
+	// drainCh is closed once the shutdown signal is received, to stop workers
+	// from starting to sync new Frameworks.
+	// workerGroup is used to wait for the running syncs to finish.
+	drainCh     chan struct{}
+	workerGroup *sync.WaitGroup
+
+	// shardManager decides which Frameworks should be synced by current instance.
+	// Frameworks which do not belong to current shard are never synced.
+	shardManager *ShardManager
+
+	// fArchiver archives the completed Framework before it is deleted.
+	// It is nil if the FrameworkArchive is disabled.
+	fArchiver FrameworkArchiver
+
+	// retryDecider decides whether and how to retry the completed attempts.
+	retryDecider RetryDecider
+
+	// fClassifier overrides the CompletionStatus of the failed Pods.
+	// It is nil if the FailureClassifier is disabled.
+	fClassifier *FailureClassifier
+
+	// podDecorators mutate every created Pod in order.
+	podDecorators []PodDecorator
+
+	// tAutoscaler adjusts the TaskNumber of the TaskRoles.
+	// It is nil if the TaskRoleAutoscaler is disabled.
+	tAutoscaler TaskRoleAutoscaler
+
+	// eventSink publishes the persisted state transitions.
+	// It is nil if the EventSink is disabled.
+	eventSink *EventSink
+
+	// cacheDropper drops the local cache entries to inject the faults.
+	// It is nil if the FaultInjection is disabled.
+	cacheDropper *CacheDropper
+
+	// tracer traces the sync pipeline.
+	// It is nil if the Tracing is disabled.
+	tracer *internal.Tracer
+
+	// sfController creates Frameworks for the ScheduledFrameworks.
+	// It is nil if the ScheduledFramework is disabled.
+	sfController *ScheduledFrameworkController
+
+	// gController creates Frameworks for the FrameworkGroups.
+	// It is nil if the FrameworkGroup is disabled.
+	gController *FrameworkGroupController
+
+	// qController admits the queuing Frameworks into their Queues.
+	// It is nil if the Queue is disabled.
+	qController *QueueController
+
+	// fqController admits the Frameworks under the FrameworkQuotas in their
+	// namespaces.
+	// It is nil if the FrameworkQuota is disabled.
+	fqController *FrameworkQuotaController
+
+	// tsController syncs the TaskRoleScales to the TaskRole TaskNumbers.
+	// It is nil if the TaskRoleScale is disabled.
+	tsController *TaskRoleScaleController
+
+	// kjController materializes the Kubeflow Jobs as Frameworks.
+	// It is nil if the KubeflowJob is disabled.
+	kjController *KubeflowJobController
+
+	// portAllocator allocates the host ports for the Tasks.
+	portAllocator *PortAllocator
 }
 
 type ExpectedFrameworkStatusInfo struct {
@@ -208,22 +302,59 @@ func NewFrameworkController() *FrameworkController {
 
 	cConfig := ci.NewConfig()
 	klog.Infof("With Config: \n%v", common.ToYaml(cConfig))
-	ci.AppendCompletionCodeInfos(cConfig.PodFailureSpec)
 
 	kConfig := ci.BuildKubeConfig(cConfig)
+	if *cConfig.DryRunEnabled {
+		klog.Warningf("Running in the DryRun mode, so all writes will not be persisted")
+		internal.EnableDryRun(kConfig)
+	}
+	if *cConfig.FaultInjection.Enabled {
+		klog.Warningf("Running with FaultInjection, which should never be enabled in production")
+		internal.EnableFaultInjection(kConfig, &cConfig.FaultInjection)
+	}
 	kClient, fClient := internal.CreateClients(kConfig)
+	dClient, err := dynamic.NewForConfig(kConfig)
+	if err != nil {
+		panic(fmt.Errorf("Failed to create DynamicClient: %v", err))
+	}
+
+	return NewFrameworkControllerWithClients(
+		cConfig, kConfig, kClient, fClient, dClient)
+}
+
+// Same as NewFrameworkController, but with the given Config and clients, such
+// as the fake clientsets in tests.
+// The kConfig is only used to put CRDs in Run, so it can be nil if Run is never
+// called.
+func NewFrameworkControllerWithClients(
+	cConfig *ci.Config,
+	kConfig *rest.Config,
+	kClient kubeClient.Interface,
+	fClient frameworkClient.Interface,
+	dClient dynamic.Interface) *FrameworkController {
+	appendPodFailureSpecOnce.Do(func() {
+		ci.AppendCompletionCodeInfos(cConfig.PodFailureSpec)
+	})
 
 	// Informer resync will periodically replay the event of all objects stored in its cache.
 	// However, by design, Informer and Controller should not miss any event.
 	// So, we should disable resync to avoid hiding missing event bugs inside Controller.
-	cmListerInformer := kubeInformer.NewSharedInformerFactory(kClient, 0).Core().V1().ConfigMaps()
-	podListerInformer := kubeInformer.NewSharedInformerFactory(kClient, 0).Core().V1().Pods()
-	fListerInformer := frameworkInformer.NewSharedInformerFactory(fClient, 0).Frameworkcontroller().V1().Frameworks()
-	cmInformer := cmListerInformer.Informer()
-	podInformer := podListerInformer.Informer()
+	cmListWatch := internal.NewConfigMapListWatch(kClient)
+	podListWatch := internal.NewPodListWatch(kClient)
+	var transformer *internal.ObjectTransformer
+	if *cConfig.LocalCacheObjectTransform {
+		transformer = internal.NewObjectTransformer(cConfig)
+		cmListWatch = internal.NewTransformedListWatch(cmListWatch, transformer.TransformConfigMap)
+		podListWatch = internal.NewTransformedListWatch(podListWatch, transformer.TransformPod)
+	}
+	namespaceIndexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+	fInformerFactory := frameworkInformer.NewSharedInformerFactory(fClient, 0)
+	fListerInformer := fInformerFactory.Frameworkcontroller().V1().Frameworks()
+	cmInformer := cache.NewSharedIndexInformer(cmListWatch, &core.ConfigMap{}, 0, namespaceIndexers)
+	podInformer := cache.NewSharedIndexInformer(podListWatch, &core.Pod{}, 0, namespaceIndexers)
 	fInformer := fListerInformer.Informer()
-	cmLister := cmListerInformer.Lister()
-	podLister := podListerInformer.Lister()
+	cmLister := coreLister.NewConfigMapLister(cmInformer.GetIndexer())
+	podLister := coreLister.NewPodLister(podInformer.GetIndexer())
 	fLister := fListerInformer.Lister()
 
 	// Using DefaultControllerRateLimiter to rate limit on both particular items and overall items.
@@ -231,9 +362,10 @@ func NewFrameworkController() *FrameworkController {
 
 	c := &FrameworkController{
 		kConfig:              kConfig,
-		cConfig:              cConfig,
+		cConfig:              &atomic.Value{},
 		kClient:              kClient,
 		fClient:              fClient,
+		dClient:              dClient,
 		cmInformer:           cmInformer,
 		podInformer:          podInformer,
 		fInformer:            fInformer,
@@ -242,6 +374,74 @@ func NewFrameworkController() *FrameworkController {
 		fLister:              fLister,
 		fQueue:               fQueue,
 		fExpectedStatusInfos: &sync.Map{},
+		drainCh:              make(chan struct{}),
+		workerGroup:          &sync.WaitGroup{},
+	}
+	c.cConfig.Store(cConfig)
+	c.shardManager = NewShardManager(kClient, &cConfig.Sharding, c.rebalanceFrameworks)
+	if !*cConfig.DryRunEnabled {
+		c.fArchiver = NewFrameworkArchiver(&cConfig.FrameworkArchive)
+		c.eventSink = NewEventSink(&cConfig.EventSink)
+	}
+	c.fClassifier = NewFailureClassifier(&cConfig.FailureClassifier)
+	c.retryDecider = GetRetryDecider(*cConfig.RetryDecider)
+	if c.retryDecider == nil {
+		panic(fmt.Errorf("RetryDecider %v is not registered", *cConfig.RetryDecider))
+	}
+	c.podDecorators = NewPodDecorators(cConfig.PodDecorators)
+	c.tAutoscaler = NewTaskRoleAutoscaler(&cConfig.TaskRoleAutoscaler)
+	c.tracer = internal.NewTracer(&cConfig.Tracing)
+	if *cConfig.FaultInjection.Enabled {
+		c.cacheDropper = NewCacheDropper(
+			kClient, podInformer.GetIndexer(), cmInformer.GetIndexer(),
+			&cConfig.FaultInjection, transformer)
+	}
+	c.portAllocator = NewPortAllocator(cConfig.PortAllocationRange)
+	if *cConfig.ScheduledFrameworkEnabled {
+		c.sfController = NewScheduledFrameworkController(
+			fClient,
+			fInformerFactory.Frameworkcontroller().V1().ScheduledFrameworks(),
+			fInformer, fLister, c.shardManager,
+			*cConfig.ScheduledFrameworkWorkerNumber)
+	}
+	if *cConfig.FrameworkGroupEnabled {
+		c.gController = NewFrameworkGroupController(
+			fClient,
+			fInformerFactory.Frameworkcontroller().V1().FrameworkGroups(),
+			fInformer, fLister, c.shardManager,
+			*cConfig.FrameworkGroupWorkerNumber)
+	}
+	if *cConfig.QueueEnabled {
+		c.qController = NewQueueController(
+			fClient,
+			fInformerFactory.Frameworkcontroller().V1().Queues(),
+			fInformer, fLister, c.shardManager,
+			*cConfig.QueueWorkerNumber,
+			func(f *ci.Framework) {
+				c.enqueueFrameworkObj(f, "Framework is admitted or preempted by Queue")
+			})
+	}
+	if *cConfig.FrameworkQuotaEnabled {
+		c.fqController = NewFrameworkQuotaController(
+			fClient,
+			fInformerFactory.Frameworkcontroller().V1().FrameworkQuotas(),
+			fInformer, fLister, c.shardManager,
+			*cConfig.FrameworkQuotaWorkerNumber,
+			func(f *ci.Framework) {
+				c.enqueueFrameworkObj(f, "Framework is admitted by FrameworkQuota")
+			})
+	}
+	if *cConfig.TaskRoleScaleEnabled {
+		c.tsController = NewTaskRoleScaleController(
+			fClient,
+			fInformerFactory.Frameworkcontroller().V1().TaskRoleScales(),
+			fInformer, fLister, c.shardManager,
+			*cConfig.TaskRoleScaleWorkerNumber)
+	}
+	if *cConfig.KubeflowJobEnabled {
+		c.kjController = NewKubeflowJobController(
+			fClient, dClient, fInformer, fLister, c.shardManager,
+			*cConfig.KubeflowJobWorkerNumber)
 	}
 
 	fInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
@@ -262,11 +462,48 @@ func NewFrameworkController() *FrameworkController {
 		DeleteFunc: c.deletePodObj,
 	})
 
+	if *cConfig.Kueue.Enabled {
+		// Only the Workloads created by FrameworkController are cached.
+		wlListerInformer := dynamicinformer.NewFilteredDynamicInformer(
+			dClient, ci.KueueWorkloadGroupVersionResource, core.NamespaceAll, 0,
+			namespaceIndexers, func(options *meta.ListOptions) {
+				options.LabelSelector = ci.LabelKeyFrameworkName
+			})
+		c.wlInformer = wlListerInformer.Informer()
+		c.wlLister = dynamiclister.New(
+			c.wlInformer.GetIndexer(), ci.KueueWorkloadGroupVersionResource)
+		c.wlInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addKueueWorkloadObj,
+			UpdateFunc: c.updateKueueWorkloadObj,
+			DeleteFunc: c.deleteKueueWorkloadObj,
+		})
+	}
+
+	if *cConfig.NodeLost.Enabled || *cConfig.SpotInterruption.Enabled ||
+		*cConfig.CapacityCheck.Enabled {
+		nodeListWatch := internal.NewNodeListWatch(kClient)
+		if *cConfig.LocalCacheObjectTransform {
+			nodeListWatch = internal.NewTransformedListWatch(nodeListWatch, transformer.TransformNode)
+		}
+		c.nodeInformer = cache.NewSharedIndexInformer(
+			nodeListWatch, &core.Node{}, 0, cache.Indexers{})
+		c.nodeLister = coreLister.NewNodeLister(c.nodeInformer.GetIndexer())
+		err := podInformer.AddIndexers(cache.Indexers{podNodeNameIndex: podNodeNameIndexFunc})
+		if err != nil {
+			panic(fmt.Errorf("Failed to add Pod indexers: %v", err))
+		}
+		c.nodeInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc:    c.addNodeObj,
+			UpdateFunc: c.updateNodeObj,
+		})
+	}
+
 	return c
 }
 
 func (c *FrameworkController) addFrameworkObj(obj interface{}) {
 	f := internal.ToFramework(obj)
+	c.syncUnownedFrameworkPorts(f)
 	c.enqueueFrameworkObj(f, "Framework Added "+string(f.UID))
 }
 
@@ -281,6 +518,12 @@ func (c *FrameworkController) updateFrameworkObj(oldObj, newObj interface{}) {
 		return
 	}
 
+	// The Framework.Status update is only cared by the ports occupation of the
+	// Framework owned by other shards.
+	if oldF.ResourceVersion != newF.ResourceVersion {
+		c.syncUnownedFrameworkPorts(newF)
+	}
+
 	// Only care about Framework.Spec update.
 	if !reflect.DeepEqual(oldF.Spec, newF.Spec) {
 		c.enqueueFrameworkObj(newF, "Framework.Spec Updated")
@@ -290,9 +533,12 @@ func (c *FrameworkController) updateFrameworkObj(oldObj, newObj interface{}) {
 func (c *FrameworkController) deleteFrameworkObj(obj interface{}) {
 	f := internal.ToFramework(obj)
 	logSfx := ""
-	if *c.cConfig.LogObjectSnapshot.Framework.OnFrameworkDeletion {
+	if *c.config().LogObjectSnapshot.Framework.OnFrameworkDeletion {
 		logSfx = ci.GetFrameworkSnapshotLogTail(f)
 	}
+	if !c.shardManager.Owns(f) {
+		c.portAllocator.Release(f.Key())
+	}
 	c.enqueueFrameworkObj(f, "Framework Deleted "+string(f.UID)+logSfx)
 }
 
@@ -342,7 +588,7 @@ func (c *FrameworkController) updatePodObj(oldObj, newObj interface{}) {
 func (c *FrameworkController) deletePodObj(obj interface{}) {
 	pod := internal.ToPod(obj)
 	logSfx := ""
-	if *c.cConfig.LogObjectSnapshot.Pod.OnPodDeletion {
+	if *c.config().LogObjectSnapshot.Pod.OnPodDeletion {
 		logSfx = ci.GetPodSnapshotLogTail(pod)
 	}
 	c.enqueuePodObj(pod, "Framework Pod Deleted "+string(pod.UID)+logSfx)
@@ -421,6 +667,10 @@ func (c *FrameworkController) enqueueConfigMapObj(cm *core.ConfigMap, logSfx str
 }
 
 func (c *FrameworkController) enqueueFrameworkObj(f *ci.Framework, logSfx string) {
+	if !c.shardManager.Owns(f) {
+		return
+	}
+
 	c.fQueue.Add(f.Key())
 	klog.Infof("[%v]: enqueueFrameworkObj: %v", f.Key(), logSfx)
 }
@@ -434,32 +684,265 @@ func (c *FrameworkController) Run(stopCh <-chan struct{}) {
 	internal.PutCRD(
 		c.kConfig,
 		ci.BuildFrameworkCRD(),
-		c.cConfig.CRDEstablishedCheckIntervalSec,
-		c.cConfig.CRDEstablishedCheckTimeoutSec)
+		c.config().CRDEstablishedCheckIntervalSec,
+		c.config().CRDEstablishedCheckTimeoutSec)
+	if *c.config().FrameworkAttemptHistoryEnabled {
+		internal.PutCRD(
+			c.kConfig,
+			ci.BuildFrameworkAttemptHistoryCRD(),
+			c.config().CRDEstablishedCheckIntervalSec,
+			c.config().CRDEstablishedCheckTimeoutSec)
+	}
+	if c.sfController != nil {
+		internal.PutCRD(
+			c.kConfig,
+			ci.BuildScheduledFrameworkCRD(),
+			c.config().CRDEstablishedCheckIntervalSec,
+			c.config().CRDEstablishedCheckTimeoutSec)
+	}
+	if c.gController != nil {
+		internal.PutCRD(
+			c.kConfig,
+			ci.BuildFrameworkGroupCRD(),
+			c.config().CRDEstablishedCheckIntervalSec,
+			c.config().CRDEstablishedCheckTimeoutSec)
+	}
+	if c.qController != nil {
+		internal.PutCRD(
+			c.kConfig,
+			ci.BuildQueueCRD(),
+			c.config().CRDEstablishedCheckIntervalSec,
+			c.config().CRDEstablishedCheckTimeoutSec)
+	}
+	if c.fqController != nil {
+		internal.PutCRD(
+			c.kConfig,
+			ci.BuildFrameworkQuotaCRD(),
+			c.config().CRDEstablishedCheckIntervalSec,
+			c.config().CRDEstablishedCheckTimeoutSec)
+	}
+	if c.tsController != nil {
+		internal.PutCRD(
+			c.kConfig,
+			ci.BuildTaskRoleScaleCRD(),
+			c.config().CRDEstablishedCheckIntervalSec,
+			c.config().CRDEstablishedCheckTimeoutSec)
+	}
+
+	if c.eventSink != nil {
+		go c.eventSink.Run(stopCh)
+	}
+	go c.tracer.Run(stopCh)
+
+	// Decide the initial shard before any Framework is enqueued.
+	c.shardManager.Run(stopCh)
+	defer c.shardManager.Leave()
+
+	c.RunInformers(stopCh)
+	if c.cacheDropper != nil {
+		go c.cacheDropper.Run(stopCh)
+	}
+
+	klog.Infof("Running %v with %v workers",
+		ci.ComponentName, *c.config().WorkerNumber)
+
+	c.startWorkers(0, *c.config().WorkerNumber, stopCh)
+
+	if c.sfController != nil {
+		go c.sfController.Run(stopCh)
+	}
+	if c.gController != nil {
+		go c.gController.Run(stopCh)
+	}
+	if c.qController != nil {
+		go c.qController.Run(stopCh)
+	}
+	if c.fqController != nil {
+		go c.fqController.Run(stopCh)
+	}
+	if c.tsController != nil {
+		go c.tsController.Run(stopCh)
+	}
+	if c.kjController != nil {
+		go c.kjController.Run(stopCh)
+	}
+
+	if *c.config().ConfigReloadIntervalSec > 0 {
+		go wait.Until(func() { c.reloadConfig(stopCh) },
+			common.SecToDuration(c.config().ConfigReloadIntervalSec), stopCh)
+	}
+
+	<-stopCh
+	c.drain()
+}
 
+// Run the informers, wait for their caches synced and then recover the local
+// states from the caches, without putting CRDs or starting the workers.
+// It is called by Run, and it can also be called alone to drive the controller
+// by SyncFramework, such as in tests.
+func (c *FrameworkController) RunInformers(stopCh <-chan struct{}) {
 	// The recovery order is not important, since all Frameworks will be enqueued
 	// to sync in any case.
 	go c.fInformer.Run(stopCh)
 	go c.cmInformer.Run(stopCh)
 	go c.podInformer.Run(stopCh)
-	if !cache.WaitForCacheSync(
-		stopCh,
+	cacheSyncs := []cache.InformerSynced{
 		c.fInformer.HasSynced,
 		c.cmInformer.HasSynced,
-		c.podInformer.HasSynced) {
+		c.podInformer.HasSynced,
+	}
+	if c.wlInformer != nil {
+		go c.wlInformer.Run(stopCh)
+		cacheSyncs = append(cacheSyncs, c.wlInformer.HasSynced)
+	}
+	if c.nodeInformer != nil {
+		go c.nodeInformer.Run(stopCh)
+		cacheSyncs = append(cacheSyncs, c.nodeInformer.HasSynced)
+	}
+	if !cache.WaitForCacheSync(stopCh, cacheSyncs...) {
 		panic(fmt.Errorf("Failed to WaitForCacheSync"))
 	}
+	c.recoverPortAllocations()
+}
 
-	klog.Infof("Running %v with %v workers",
-		ci.ComponentName, *c.cConfig.WorkerNumber)
+// Sync the Framework with the given key once, without the fQueue.
+// The returned error is the same as the one which will be retried by the
+// workers, and the Framework may also be enqueued again by the sync itself.
+func (c *FrameworkController) SyncFramework(key string) error {
+	return c.syncFramework(key)
+}
 
-	for i := int32(0); i < *c.cConfig.WorkerNumber; i++ {
+// The listers of the local caches, such as to check whether the caches have
+// caught up with the ApiServer in tests.
+func (c *FrameworkController) FrameworkLister() frameworkLister.FrameworkLister {
+	return c.fLister
+}
+
+func (c *FrameworkController) PodLister() coreLister.PodLister {
+	return c.podLister
+}
+
+func (c *FrameworkController) ConfigMapLister() coreLister.ConfigMapLister {
+	return c.cmLister
+}
+
+func (c *FrameworkController) config() *ci.Config {
+	return c.cConfig.Load().(*ci.Config)
+}
+
+// Start workers with id in range [startID, endID).
+func (c *FrameworkController) startWorkers(
+	startID int32, endID int32, stopCh <-chan struct{}) {
+	for i := startID; i < endID; i++ {
 		// id is dedicated for each iteration, while i is not.
 		id := i
-		go wait.Until(func() { c.worker(id) }, time.Second, stopCh)
+		c.workerGroup.Add(1)
+		go func() {
+			defer c.workerGroup.Done()
+			wait.Until(func() { c.worker(id) }, time.Second, stopCh)
+		}()
 	}
+}
 
-	<-stopCh
+// Hot reload the Config if the config file is changed.
+// It should not be invoked concurrently.
+func (c *FrameworkController) reloadConfig(stopCh <-chan struct{}) {
+	logPfx := "reloadConfig: "
+	oldConfig := c.config()
+
+	newConfig, err := ci.TryNewConfig()
+	if err != nil {
+		klog.Warningf(logPfx+"Skipped: Config file is invalid: %v", err)
+		return
+	}
+
+	reloadedConfig := oldConfig.HotReload(newConfig)
+	if reflect.DeepEqual(oldConfig, reloadedConfig) {
+		return
+	}
+	if *newConfig.WorkerNumber < *oldConfig.WorkerNumber {
+		klog.Warningf(logPfx+
+			"WorkerNumber cannot be decreased from %v to %v without restart",
+			*oldConfig.WorkerNumber, *newConfig.WorkerNumber)
+	}
+
+	c.cConfig.Store(reloadedConfig)
+	klog.Infof(logPfx+"Reloaded Config: \n%v", common.ToYaml(reloadedConfig))
+
+	if *reloadedConfig.WorkerNumber > *oldConfig.WorkerNumber {
+		klog.Infof(logPfx+"Increase workers from %v to %v",
+			*oldConfig.WorkerNumber, *reloadedConfig.WorkerNumber)
+		c.startWorkers(*oldConfig.WorkerNumber, *reloadedConfig.WorkerNumber, stopCh)
+	}
+}
+
+// Enqueue all Frameworks after the shard is rebalanced or the membership is
+// recovered from stale, so that the newly owned or dropped Frameworks are
+// recovered and the no longer owned Frameworks are forgotten.
+func (c *FrameworkController) rebalanceFrameworks() {
+	fs, err := c.fLister.List(labels.Everything())
+	if err != nil {
+		// Unreachable
+		panic(fmt.Errorf("Failed to list Frameworks from local cache: %v", err))
+	}
+
+	for _, f := range fs {
+		c.fQueue.Add(f.Key())
+	}
+	klog.Infof("rebalanceFrameworks: Enqueued %v Frameworks", len(fs))
+
+	if c.sfController != nil {
+		c.sfController.Rebalance()
+	}
+	if c.gController != nil {
+		c.gController.Rebalance()
+	}
+	if c.qController != nil {
+		c.qController.Rebalance()
+	}
+	if c.fqController != nil {
+		c.fqController.Rebalance()
+	}
+	if c.tsController != nil {
+		c.tsController.Rebalance()
+	}
+	if c.kjController != nil {
+		c.kjController.Rebalance()
+	}
+}
+
+// Stop to sync new Frameworks, wait for the running syncs to finish within
+// ShutdownDrainTimeoutSec, and then flush the not yet persisted expected
+// Framework.Status to remote.
+func (c *FrameworkController) drain() {
+	timeout := common.SecToDuration(c.config().ShutdownDrainTimeoutSec)
+	logPfx := "drain: "
+	klog.Infof(logPfx+"Started: Waiting running syncs to finish within %v", timeout)
+
+	close(c.drainCh)
+	// Unblock workers which are waiting for new items.
+	c.fQueue.ShutDown()
+
+	drainedCh := make(chan struct{})
+	go func() {
+		c.workerGroup.Wait()
+		close(drainedCh)
+	}()
+
+	select {
+	case <-drainedCh:
+		klog.Infof(logPfx + "All running syncs are finished")
+		c.flushExpectedFrameworkStatusInfos()
+	case <-time.After(timeout):
+		// The running syncs may still modify the expected Framework.Status, so it
+		// is not safe to flush them. The unpersisted ones will be recovered from
+		// the remote Framework.Status after restart.
+		klog.Warningf(logPfx+
+			"Skip to flush the expected Framework.Status: "+
+			"Running syncs cannot be finished within %v", timeout)
+	}
+
+	klog.Infof(logPfx + "Completed")
 }
 
 func (c *FrameworkController) worker(id int32) {
@@ -476,6 +959,16 @@ func (c *FrameworkController) processNextWorkItem(id int32) bool {
 	if quit {
 		return false
 	}
+
+	select {
+	case <-c.drainCh:
+		// Do not start new sync during drain, and the item will be recovered
+		// after restart.
+		c.fQueue.Done(key)
+		klog.Infof("[%v]: Skipped to assign to worker-%v: Draining", key, id)
+		return false
+	default:
+	}
 	klog.Infof("[%v]: Assigned to worker-%v", key, id)
 
 	// Remove the item from the current processing items to unblock getting the
@@ -504,7 +997,9 @@ func (c *FrameworkController) syncFramework(key string) (returnedErr error) {
 	startTime := time.Now()
 	logPfx := fmt.Sprintf("[%v]: syncFramework: ", key)
 	klog.Infof(logPfx + "Started")
+	span := c.tracer.StartSpan(key, "syncFramework", nil)
 	defer func() {
+		span.End(returnedErr)
 		if returnedErr != nil {
 			// returnedErr is already prefixed with logPfx
 			klog.Warning(returnedErr.Error())
@@ -524,6 +1019,7 @@ func (c *FrameworkController) syncFramework(key string) (returnedErr error) {
 			klog.Infof(logPfx+
 				"Skipped: Framework cannot be found in local cache: %v", err)
 			c.deleteExpectedFrameworkStatusInfo(key)
+			c.portAllocator.Release(key)
 			return nil
 		} else {
 			return fmt.Errorf(logPfx+
@@ -536,6 +1032,17 @@ func (c *FrameworkController) syncFramework(key string) (returnedErr error) {
 		// cached one, and it may be different from the original one.
 		klog.Infof(logPfx+"UID %v", f.UID)
 
+		if !c.shardManager.Owns(f) {
+			// Forget the expected Framework.Status, so that it will be recovered
+			// from the remote one if the Framework is owned again.
+			// Keep its ports occupied, so that they are not allocated by current
+			// shard while the Framework is owned by another shard.
+			klog.Infof(logPfx + "Skipped: Framework does not belong to current shard")
+			c.deleteExpectedFrameworkStatusInfo(key)
+			c.syncUnownedFrameworkPorts(f)
+			return nil
+		}
+
 		expected := c.getExpectedFrameworkStatusInfo(f.Key())
 		if expected == nil || expected.uid != f.UID {
 			if f.Status != nil {
@@ -576,21 +1083,38 @@ func (c *FrameworkController) syncFramework(key string) (returnedErr error) {
 		if decompressErr != nil {
 			return decompressErr
 		}
+		c.portAllocator.Sync(f)
+		span.SetAttribute("framework.uid", f.UID)
+		if f.Status != nil {
+			span.SetAttribute("framework.attempt_id", f.FrameworkAttemptID())
+		}
 		remoteRawF := f.DeepCopy()
 
 		errs := []error{}
 		syncErr := c.syncFrameworkStatus(f)
 		errs = append(errs, syncErr)
+		f.UpdateProgress()
 
 		if !reflect.DeepEqual(remoteRawF.Status, f.Status) {
 			// Always update the expected and remote Framework.Status even if sync
 			// error, since f.Status should never be corrupted due to any Platform
 			// Transient Error, so no need to rollback to the one before sync, and
 			// no need to DeepCopy between f.Status and the expected one.
+			var events []*CloudEvent
+			if c.eventSink != nil {
+				// Generate before compress, since the compress drops the raw
+				// TaskRoleStatuses.
+				events = NewStateTransitionEvents(remoteRawF, f)
+			}
+
 			c.compressFramework(f)
 			updateErr := c.updateRemoteFrameworkStatus(f)
 			c.updateExpectedFrameworkStatusInfo(f.Key(), f.Status, f.UID, updateErr == nil)
 
+			if updateErr == nil && c.eventSink != nil {
+				c.eventSink.Enqueue(events)
+			}
+
 			errs = append(errs, updateErr)
 		} else {
 			klog.Infof(logPfx +
@@ -609,10 +1133,52 @@ func (c *FrameworkController) enqueueFrameworkCompletedRetainTimeoutCheck(
 	}
 
 	return c.enqueueFrameworkTimeoutCheck(
-		f, f.Status.TransitionTime, c.cConfig.FrameworkCompletedRetainSec,
+		f, f.Status.TransitionTime, c.getFrameworkCompletedRetainSec(f),
 		failIfTimeout, "FrameworkCompletedRetainTimeoutCheck")
 }
 
+// Get the retain duration of the completed Framework according to whether it
+// is Succeeded or Failed, and the Framework Spec overrides the Config.
+func (c *FrameworkController) getFrameworkCompletedRetainSec(f *ci.Framework) *int64 {
+	if f.IsSucceeded() {
+		if f.Spec.SucceededRetainSec != nil {
+			return f.Spec.SucceededRetainSec
+		}
+		return c.config().FrameworkSucceededRetainSec
+	}
+	if f.Spec.FailedRetainSec != nil {
+		return f.Spec.FailedRetainSec
+	}
+	return c.config().FrameworkFailedRetainSec
+}
+
+// Get the PropagationPolicy to delete the Framework and its ConfigMap
+// respectively, and the Framework Spec overrides the Config.
+// Nil means the K8S default PropagationPolicy of the object.
+func (c *FrameworkController) getFrameworkDeletionPropagationPolicy(
+	f *ci.Framework) *meta.DeletionPropagation {
+	policy := c.config().FrameworkDeletionPropagationPolicy
+	if f.Spec.FrameworkDeletionPropagationPolicy != nil {
+		policy = f.Spec.FrameworkDeletionPropagationPolicy
+	}
+	if *policy == "" {
+		return nil
+	}
+	return common.PtrDeletionPropagation(*policy)
+}
+
+func (c *FrameworkController) getConfigMapDeletionPropagationPolicy(
+	f *ci.Framework) *meta.DeletionPropagation {
+	policy := c.config().ConfigMapDeletionPropagationPolicy
+	if f.Spec.ConfigMapDeletionPropagationPolicy != nil {
+		policy = f.Spec.ConfigMapDeletionPropagationPolicy
+	}
+	if *policy == "" {
+		return nil
+	}
+	return common.PtrDeletionPropagation(*policy)
+}
+
 func (c *FrameworkController) enqueueFrameworkAttemptCreationTimeoutCheck(
 	f *ci.Framework, failIfTimeout bool) bool {
 	if f.Status.State != ci.FrameworkAttemptCreationRequested {
@@ -620,7 +1186,7 @@ func (c *FrameworkController) enqueueFrameworkAttemptCreationTimeoutCheck(
 	}
 
 	return c.enqueueFrameworkTimeoutCheck(
-		f, f.Status.TransitionTime, c.cConfig.ObjectLocalCacheCreationTimeoutSec,
+		f, f.Status.TransitionTime, c.config().ObjectLocalCacheCreationTimeoutSec,
 		failIfTimeout, "FrameworkAttemptCreationTimeoutCheck")
 }
 
@@ -633,7 +1199,7 @@ func (c *FrameworkController) enqueueTaskAttemptCreationTimeoutCheck(
 	}
 
 	return c.enqueueFrameworkTimeoutCheck(
-		f, taskStatus.TransitionTime, c.cConfig.ObjectLocalCacheCreationTimeoutSec,
+		f, taskStatus.TransitionTime, c.config().ObjectLocalCacheCreationTimeoutSec,
 		failIfTimeout, "TaskAttemptCreationTimeoutCheck")
 }
 
@@ -661,6 +1227,55 @@ func (c *FrameworkController) enqueueTaskRetryDelayTimeoutCheck(
 		failIfTimeout, "TaskRetryDelayTimeoutCheck")
 }
 
+func (c *FrameworkController) enqueueTaskAttemptRunTimeoutCheck(
+	f *ci.Framework, taskRoleName string, taskIndex int32,
+	timeoutSec *int64, failIfTimeout bool) bool {
+	taskStatus := f.TaskStatus(taskRoleName, taskIndex)
+	if taskStatus.State != ci.TaskAttemptRunning {
+		return false
+	}
+
+	return c.enqueueFrameworkTimeoutCheck(
+		f, *taskStatus.AttemptStatus.RunTime, timeoutSec,
+		failIfTimeout, "TaskAttemptRunTimeoutCheck")
+}
+
+func (c *FrameworkController) enqueueFrameworkAttemptFailedRetainTimeoutCheck(
+	f *ci.Framework, failIfTimeout bool) bool {
+	if f.Status.State != ci.FrameworkAttemptDeletionPending ||
+		!c.shouldDebugRetainFailed(f, f.Status.AttemptStatus.CompletionStatus.CompletionStatus) {
+		return false
+	}
+
+	return c.enqueueFrameworkTimeoutCheck(
+		f, f.Status.TransitionTime, f.Spec.DebugRetainFailedPodSec,
+		failIfTimeout, "FrameworkAttemptFailedRetainTimeoutCheck")
+}
+
+func (c *FrameworkController) enqueueTaskAttemptFailedRetainTimeoutCheck(
+	f *ci.Framework, taskRoleName string, taskIndex int32,
+	failIfTimeout bool) bool {
+	taskStatus := f.TaskStatus(taskRoleName, taskIndex)
+	if taskStatus.State != ci.TaskAttemptDeletionPending ||
+		!c.shouldDebugRetainFailed(f, taskStatus.AttemptStatus.CompletionStatus.CompletionStatus) {
+		return false
+	}
+
+	return c.enqueueFrameworkTimeoutCheck(
+		f, taskStatus.TransitionTime, f.Spec.DebugRetainFailedPodSec,
+		failIfTimeout, "TaskAttemptFailedRetainTimeoutCheck")
+}
+
+// Whether the objects of the attempt completed with the completionStatus should
+// be retained for debugging, see Framework DebugRetainFailedPodSec.
+func (c *FrameworkController) shouldDebugRetainFailed(
+	f *ci.Framework, completionStatus *ci.CompletionStatus) bool {
+	return f.Spec.DebugRetainFailedPodSec != nil &&
+		f.Spec.ExecutionType != ci.ExecutionStop && f.DeletionTimestamp == nil &&
+		completionStatus != nil &&
+		completionStatus.Type.Name == ci.CompletionTypeNameFailed
+}
+
 func (c *FrameworkController) enqueuePodGracefulDeletionTimeoutCheck(
 	f *ci.Framework, timeoutSec *int64,
 	failIfTimeout bool, pod *core.Pod) bool {
@@ -708,13 +1323,16 @@ func (c *FrameworkController) syncFrameworkStatus(f *ci.Framework) error {
 	if f.Status == nil {
 		f.Status = f.NewFrameworkStatus()
 
-		// To ensure FrameworkAttemptCreationPending is persisted before creating
-		// its cm, we need to wait until next sync to create the cm, so manually
-		// enqueue a sync.
-		c.enqueueFrameworkSync(f, "FrameworkAttemptCreationPending")
-		klog.Infof(logPfx + "Waiting FrameworkAttemptCreationPending to be persisted")
+		// To ensure FrameworkQueuing or FrameworkAttemptCreationPending is persisted
+		// before creating its cm, we need to wait until next sync to create the cm,
+		// so manually enqueue a sync.
+		c.enqueueFrameworkSync(f, string(f.Status.State))
+		klog.Infof(logPfx+"Waiting %v to be persisted", f.Status.State)
 		return nil
 	} else {
+		c.syncSpecChangeHistory(f)
+		c.syncTaskRoleAutoscale(f)
+
 		if c.syncFrameworkScale(f) || c.compactFrameworkScale(f) {
 			// To ensure TaskAttemptCreationPending is persisted before creating
 			// its pod, we need to wait until next sync to create the pod, so manually
@@ -743,6 +1361,40 @@ func (c *FrameworkController) syncFrameworkStatus(f *ci.Framework) error {
 	return c.syncFrameworkState(f)
 }
 
+// Record the f.Spec changes since last observed into f.Status, so that
+// operators can tell when and how the Framework is changed, such as rescaled.
+func (c *FrameworkController) syncSpecChangeHistory(f *ci.Framework) {
+	logPfx := fmt.Sprintf("[%v]: syncSpecChangeHistory: ", f.Key())
+
+	newSummary := f.NewSpecSummary()
+	if f.Status.ObservedSpecSummary == nil {
+		// The Framework.Status was created before the history is supported, so
+		// just start to observe from now on.
+		f.Status.ObservedSpecSummary = newSummary
+		return
+	}
+
+	changes := f.Status.ObservedSpecSummary.Diff(newSummary)
+	if len(changes) == 0 {
+		return
+	}
+
+	record := &ci.SpecChangeRecord{
+		ObservedTime:    common.Now(),
+		ResourceVersion: f.ResourceVersion,
+		ChangeCause:     f.Annotations[ci.AnnotationKeyChangeCause],
+		Changes:         changes,
+	}
+	klog.Infof(logPfx+"Spec changed: %v", common.ToJson(record))
+
+	f.Status.SpecChangeHistory = append(f.Status.SpecChangeHistory, record)
+	if len(f.Status.SpecChangeHistory) > ci.SpecChangeHistoryMaxLength {
+		f.Status.SpecChangeHistory = f.Status.SpecChangeHistory[len(
+			f.Status.SpecChangeHistory)-ci.SpecChangeHistoryMaxLength:]
+	}
+	f.Status.ObservedSpecSummary = newSummary
+}
+
 // Rescale not Completing/Completed Framework according to its current f.Spec.
 // After this, all ScaleUp TaskRoles and Tasks are added, and all ScaleDown Tasks
 // are marked as DeletionPending for later lazy graceful deletion, thus:
@@ -775,7 +1427,7 @@ func (c *FrameworkController) syncFrameworkScale(
 
 	for _, taskRoleSpec := range f.Spec.TaskRoles {
 		taskRoleName := taskRoleSpec.Name
-		taskCountSpec := taskRoleSpec.TaskNumber
+		taskCountSpec := f.GetTaskNumber(taskRoleSpec)
 		taskRoleStatus := f.GetTaskRoleStatus(taskRoleName)
 
 		if taskRoleStatus == nil {
@@ -877,7 +1529,7 @@ func (c *FrameworkController) compactFrameworkScale(
 		if taskRoleSpec == nil {
 			taskCountSpec = 0
 		} else {
-			taskCountSpec = taskRoleSpec.TaskNumber
+			taskCountSpec = f.GetTaskNumber(taskRoleSpec)
 		}
 
 		for taskIndex := taskCountStatus - 1; taskIndex >= taskCountSpec; taskIndex-- {
@@ -906,7 +1558,7 @@ func (c *FrameworkController) compactFrameworkScale(
 
 		// Start deletion
 		logSfx := ""
-		if *c.cConfig.LogObjectSnapshot.Framework.OnFrameworkRescale {
+		if *c.config().LogObjectSnapshot.Framework.OnFrameworkRescale {
 			// Ensure the FrameworkSnapshot is exposed before the deletion.
 			logSfx = ci.GetFrameworkSnapshotLogTail(f)
 		}
@@ -936,7 +1588,7 @@ func (c *FrameworkController) compactFrameworkScale(
 		taskRoleSpec := f.GetTaskRoleSpec(taskRoleName)
 
 		if taskRoleSpec != nil {
-			taskCountSpec := taskRoleSpec.TaskNumber
+			taskCountSpec := f.GetTaskNumber(taskRoleSpec)
 			taskCountStatusAndSpec := common.MinInt32(taskCountStatus, taskCountSpec)
 			for taskIndex := taskCountStatusAndSpec - 1; taskIndex >= 0; taskIndex-- {
 				taskStatus := taskRoleStatus.TaskStatuses[taskIndex]
@@ -944,7 +1596,7 @@ func (c *FrameworkController) compactFrameworkScale(
 				if taskStatus.DeletionPending && taskStatus.State == ci.TaskCompleted {
 					// Replace the Completed DeletionPending Task with new instance
 					logSfx := ""
-					if *c.cConfig.LogObjectSnapshot.Framework.OnFrameworkRescale {
+					if *c.config().LogObjectSnapshot.Framework.OnFrameworkRescale {
 						// Ensure the FrameworkSnapshot is exposed before the deletion.
 						logSfx = ci.GetFrameworkSnapshotLogTail(f)
 					}
@@ -1011,20 +1663,31 @@ func (c *FrameworkController) syncFrameworkState(f *ci.Framework) (err error) {
 			return nil
 		}
 
+		if c.fArchiver != nil {
+			// Ensure the Framework is archived before the deletion, and if the
+			// archive failed, the deletion will be retried with the archive later.
+			err := c.fArchiver.Archive(f)
+			if err != nil {
+				return err
+			}
+			klog.Infof(logPfx + "Succeeded to archive Framework")
+		}
+
 		// deleteFramework
 		logSfx := ""
-		if *c.cConfig.LogObjectSnapshot.Framework.OnFrameworkDeletion {
+		if *c.config().LogObjectSnapshot.Framework.OnFrameworkDeletion {
 			// Ensure the FrameworkSnapshot is exposed before the deletion.
 			logSfx = ci.GetFrameworkSnapshotLogTail(f)
 		}
 		klog.Info(logPfx + fmt.Sprintf("Framework will be deleted due to "+
 			"FrameworkCompletedRetainSec %v is expired",
-			common.SecToDuration(c.cConfig.FrameworkCompletedRetainSec)) + logSfx)
+			common.SecToDuration(c.getFrameworkCompletedRetainSec(f))) + logSfx)
 		return c.deleteFramework(f, true)
 	}
 
 	var cm *core.ConfigMap
-	if f.Status.State != ci.FrameworkAttemptCompleted {
+	if f.Status.State != ci.FrameworkAttemptCompleted &&
+		f.Status.State != ci.FrameworkSuspended {
 		// ConfigMap may have been creation requested successfully and may exist in
 		// remote, so need to sync against it.
 		cm, err = c.getOrCleanupConfigMap(f, false)
@@ -1036,12 +1699,10 @@ func (c *FrameworkController) syncFrameworkState(f *ci.Framework) (err error) {
 			// Avoid sync with outdated object:
 			// cm is remote creation requested but not found in the local cache.
 			if f.Status.State == ci.FrameworkAttemptCreationRequested {
-				var diag string
-				var code ci.CompletionCode
-				if f.Spec.ExecutionType == ci.ExecutionStop {
-					diag = "User has requested to stop the Framework"
-					code = ci.CompletionCodeStopFrameworkRequested
-					klog.Info(logPfx + diag)
+				var completionStatus *ci.FrameworkAttemptCompletionStatus
+				if f.IsStopOrSuspendRequested() {
+					completionStatus = f.NewStopOrSuspendRequestedCompletionStatus()
+					klog.Info(logPfx + completionStatus.Diagnostics)
 				} else {
 					if c.enqueueFrameworkAttemptCreationTimeoutCheck(f, true) {
 						klog.Infof(logPfx +
@@ -1049,11 +1710,12 @@ func (c *FrameworkController) syncFrameworkState(f *ci.Framework) (err error) {
 						return nil
 					}
 
-					diag = fmt.Sprintf(
+					diag := fmt.Sprintf(
 						"ConfigMap does not appear in the local cache within timeout %v, "+
 							"so consider it was deleted and explicitly delete it",
-						common.SecToDuration(c.cConfig.ObjectLocalCacheCreationTimeoutSec))
-					code = ci.CompletionCodeConfigMapCreationTimeout
+						common.SecToDuration(c.config().ObjectLocalCacheCreationTimeoutSec))
+					completionStatus = ci.CompletionCodeConfigMapCreationTimeout.
+						NewFrameworkAttemptCompletionStatus(diag, nil)
 					klog.Warning(logPfx + diag)
 				}
 
@@ -1064,12 +1726,12 @@ func (c *FrameworkController) syncFrameworkState(f *ci.Framework) (err error) {
 					return err
 				}
 
-				c.completeFrameworkAttempt(f, true,
-					code.NewFrameworkAttemptCompletionStatus(diag, nil))
+				c.completeFrameworkAttempt(f, true, completionStatus)
 				return nil
 			}
 
-			if f.Status.State != ci.FrameworkAttemptCreationPending {
+			if f.Status.State != ci.FrameworkQueuing &&
+				f.Status.State != ci.FrameworkAttemptCreationPending {
 				if f.Status.AttemptStatus.CompletionStatus == nil {
 					diag := fmt.Sprintf("ConfigMap was deleted by others")
 					klog.Warning(logPfx + diag)
@@ -1085,9 +1747,25 @@ func (c *FrameworkController) syncFrameworkState(f *ci.Framework) (err error) {
 		} else {
 			if cm.DeletionTimestamp == nil {
 				if f.Status.State == ci.FrameworkAttemptDeletionPending {
+					completed, err := c.syncPostAttemptHook(f, cm)
+					if err != nil {
+						return err
+					}
+					if !completed {
+						klog.Infof(logPfx + "Waiting PostAttempt hook to complete")
+						return nil
+					}
+
+					if c.enqueueFrameworkAttemptFailedRetainTimeoutCheck(f, true) {
+						klog.Infof(logPfx +
+							"Waiting ConfigMap of the failed FrameworkAttempt to be retained " +
+							"for debugging")
+						return nil
+					}
+
 					// The CompletionStatus has been persisted, so it is safe to delete the
 					// cm now.
-					err := c.deleteConfigMap(f, *f.ConfigMapUID(), false)
+					err = c.deleteConfigMap(f, *f.ConfigMapUID(), false)
 					if err != nil {
 						return err
 					}
@@ -1126,20 +1804,35 @@ func (c *FrameworkController) syncFrameworkState(f *ci.Framework) (err error) {
 		}
 	}
 	// At this point, f.Status.State must be in:
-	// {FrameworkAttemptCreationPending, FrameworkAttemptPreparing,
+	// {FrameworkQueuing, FrameworkAttemptCreationPending, FrameworkAttemptPreparing,
 	// FrameworkAttemptRunning, FrameworkAttemptDeletionRequested,
-	// FrameworkAttemptDeleting, FrameworkAttemptCompleted}
+	// FrameworkAttemptDeleting, FrameworkAttemptCompleted, FrameworkSuspended}
 
-	if f.Status.State == ci.FrameworkAttemptCompleted {
+	if f.Status.State == ci.FrameworkAttemptCompleted ||
+		f.Status.State == ci.FrameworkSuspended {
 		// attemptToRetryFramework
-		retryDecision := f.Spec.RetryPolicy.ShouldRetry(
-			f.Status.RetryPolicyStatus,
-			f.Status.AttemptStatus.CompletionStatus.CompletionStatus,
-			*c.cConfig.FrameworkMinRetryDelaySecForTransientConflictFailed,
-			*c.cConfig.FrameworkMaxRetryDelaySecForTransientConflictFailed)
+		retryDecision := c.retryDecider.ShouldRetry(&RetryContext{
+			Framework:         f,
+			RetryPolicy:       f.Spec.RetryPolicy,
+			RetryPolicyStatus: f.Status.RetryPolicyStatus,
+			CompletionStatus:  f.Status.AttemptStatus.CompletionStatus.CompletionStatus,
+			MinDelaySecForTransientConflictFailed: *c.config().
+				FrameworkMinRetryDelaySecForTransientConflictFailed,
+			MaxDelaySecForTransientConflictFailed: *c.config().
+				FrameworkMaxRetryDelaySecForTransientConflictFailed,
+		})
 
 		if f.Status.RetryPolicyStatus.RetryDelaySec == nil {
 			// RetryFramework is not yet scheduled, so need to be decided.
+			if *c.config().FrameworkAttemptHistoryEnabled {
+				// The completed FrameworkAttempt has been persisted, so it is safe to
+				// also record it as one history.
+				err := c.createFrameworkAttemptHistory(f)
+				if err != nil {
+					return err
+				}
+			}
+
 			if retryDecision.ShouldRetry {
 				// scheduleToRetryFramework
 				klog.Infof(logPfx+
@@ -1166,9 +1859,28 @@ func (c *FrameworkController) syncFrameworkState(f *ci.Framework) (err error) {
 			// RetryFramework is already scheduled, so just need to check whether it
 			// should be executed now.
 			if f.Spec.ExecutionType == ci.ExecutionStop {
+				if f.IsSuspended() {
+					// completeFramework
+					klog.Infof(logPfx +
+						"User has requested to stop the suspended Framework, " +
+						"so immediately complete it")
+
+					f.TransitionFrameworkState(ci.FrameworkCompleted)
+
+					c.enqueueFrameworkCompletedRetainTimeoutCheck(f, false)
+					klog.Infof(logPfx +
+						"Waiting Framework to be deleted after FrameworkCompletedRetainSec")
+					return nil
+				}
+
 				klog.Infof(logPfx +
 					"User has requested to stop the Framework, " +
 					"so immediately retry without delay")
+			} else if f.Spec.ExecutionType == ci.ExecutionSuspend {
+				// suspendFramework
+				f.TransitionFrameworkState(ci.FrameworkSuspended)
+				klog.Infof(logPfx + "Waiting Framework to be resumed")
+				return nil
 			} else {
 				if c.enqueueFrameworkRetryDelayTimeoutCheck(f, true) {
 					klog.Infof(logPfx + "Waiting Framework to retry after delay")
@@ -1178,7 +1890,7 @@ func (c *FrameworkController) syncFrameworkState(f *ci.Framework) (err error) {
 
 			// retryFramework
 			logSfx := ""
-			if *c.cConfig.LogObjectSnapshot.Framework.OnFrameworkRetry {
+			if *c.config().LogObjectSnapshot.Framework.OnFrameworkRetry {
 				// The completed FrameworkAttempt has been persisted, so it is safe to
 				// also expose it as one history snapshot.
 				logSfx = ci.GetFrameworkSnapshotLogTail(f)
@@ -1190,19 +1902,68 @@ func (c *FrameworkController) syncFrameworkState(f *ci.Framework) (err error) {
 				f.Status.RetryPolicyStatus.AccountableRetriedCount++
 			}
 			f.Status.RetryPolicyStatus.RetryDelaySec = nil
+			requeue := f.Status.AttemptStatus.CompletionStatus.Code ==
+				ci.CompletionCodeFrameworkPreempted || f.IsSuspended()
 			f.Status.AttemptStatus = f.NewFrameworkAttemptStatus(
 				f.Status.RetryPolicyStatus.TotalRetriedCount)
-			f.TransitionFrameworkState(ci.FrameworkAttemptCreationPending)
+			if requeue && f.Spec.Queue != "" {
+				// Requeue the preempted or resumed Framework, so that it will be admitted
+				// again.
+				f.TransitionFrameworkState(ci.FrameworkQueuing)
+			} else {
+				f.TransitionFrameworkState(ci.FrameworkAttemptCreationPending)
+			}
 
-			// To ensure FrameworkAttemptCreationPending is persisted before creating
-			// its cm, we need to wait until next sync to create the cm, so manually
-			// enqueue a sync.
-			c.enqueueFrameworkSync(f, "FrameworkAttemptCreationPending")
-			klog.Infof(logPfx + "Waiting FrameworkAttemptCreationPending to be persisted")
+			// To ensure FrameworkQueuing or FrameworkAttemptCreationPending is persisted
+			// before creating its cm, we need to wait until next sync to create the cm,
+			// so manually enqueue a sync.
+			c.enqueueFrameworkSync(f, string(f.Status.State))
+			klog.Infof(logPfx+"Waiting %v to be persisted", f.Status.State)
 			return nil
 		}
 	}
 	// At this point, f.Status.State must be in:
+	// {FrameworkQueuing, FrameworkAttemptCreationPending, FrameworkAttemptPreparing,
+	// FrameworkAttemptRunning, FrameworkAttemptDeletionRequested,
+	// FrameworkAttemptDeleting}
+
+	if f.Status.State == ci.FrameworkQueuing {
+		if f.DeletionTimestamp != nil {
+			klog.Infof(logPfx + "Skip to admitFramework: " +
+				"Framework is deleting")
+			return nil
+		}
+
+		if f.IsStopOrSuspendRequested() {
+			completionStatus := f.NewStopOrSuspendRequestedCompletionStatus()
+			klog.Info(logPfx + completionStatus.Diagnostics)
+
+			// No cm has ever been created, so no need to clean up it.
+			c.completeFrameworkAttempt(f, true, completionStatus)
+			return nil
+		}
+
+		if c.qController != nil && !c.qController.IsAdmitted(f) {
+			// The Framework will be enqueued again once it is admitted.
+			klog.Infof(logPfx+"Waiting Framework to be admitted by Queue %v",
+				f.Spec.Queue)
+			return nil
+		}
+
+		// admitFramework
+		// The FrameworkAttempt starts after the Framework is admitted, so the
+		// queuing time is not counted into it.
+		f.Status.AttemptStatus.StartTime = common.Now()
+		f.TransitionFrameworkState(ci.FrameworkAttemptCreationPending)
+
+		// To ensure FrameworkAttemptCreationPending is persisted before creating
+		// its cm, we need to wait until next sync to create the cm, so manually
+		// enqueue a sync.
+		c.enqueueFrameworkSync(f, "FrameworkAttemptCreationPending")
+		klog.Infof(logPfx + "Waiting FrameworkAttemptCreationPending to be persisted")
+		return nil
+	}
+	// At this point, f.Status.State must be in:
 	// {FrameworkAttemptCreationPending, FrameworkAttemptPreparing,
 	// FrameworkAttemptRunning, FrameworkAttemptDeletionRequested,
 	// FrameworkAttemptDeleting}
@@ -1214,9 +1975,9 @@ func (c *FrameworkController) syncFrameworkState(f *ci.Framework) (err error) {
 			return nil
 		}
 
-		if f.Spec.ExecutionType == ci.ExecutionStop {
-			diag := "User has requested to stop the Framework"
-			klog.Info(logPfx + diag)
+		if f.IsStopOrSuspendRequested() {
+			completionStatus := f.NewStopOrSuspendRequestedCompletionStatus()
+			klog.Info(logPfx + completionStatus.Diagnostics)
 
 			// Ensure cm is deleted in remote to avoid managed cm leak after
 			// FrameworkAttemptCompleted.
@@ -1225,12 +1986,56 @@ func (c *FrameworkController) syncFrameworkState(f *ci.Framework) (err error) {
 				return err
 			}
 
-			c.completeFrameworkAttempt(f, true,
-				ci.CompletionCodeStopFrameworkRequested.
-					NewFrameworkAttemptCompletionStatus(diag, nil))
+			c.completeFrameworkAttempt(f, true, completionStatus)
 			return nil
 		}
 
+		if c.fqController != nil {
+			admitted, message := c.fqController.GetAdmission(f)
+			if admitted != nil && !*admitted {
+				// The Framework will be enqueued again once it is admitted.
+				f.SetFrameworkCondition(ci.FrameworkQuotaAdmitted,
+					core.ConditionFalse, "QuotaExceeded", message)
+				klog.Infof(logPfx+"Waiting Framework to be admitted by "+
+					"FrameworkQuota: %v", message)
+				return nil
+			}
+			if admitted != nil {
+				f.SetFrameworkCondition(ci.FrameworkQuotaAdmitted,
+					core.ConditionTrue, "QuotaAdmitted", message)
+			}
+		}
+
+		if *c.config().CapacityCheck.Enabled {
+			reason := "InsufficientCapacity"
+			message, err := c.getInsufficientCapacityMessage(f)
+			if err != nil {
+				return err
+			}
+			if message == "" && *c.config().FairShare.Enabled {
+				reason = "FairShareWaiting"
+				message, err = c.getFairShareWaitingMessage(f)
+				if err != nil {
+					return err
+				}
+			}
+			if message != "" {
+				f.SetFrameworkCondition(ci.FrameworkCapacitySufficient,
+					core.ConditionFalse, reason, message)
+				// The capacity changes are not watched, so check again later.
+				c.fQueue.AddAfter(f.Key(),
+					common.SecToDuration(c.config().CapacityCheck.RecheckIntervalSec))
+				klog.Infof(logPfx+"Waiting cluster capacity to be sufficient: %v",
+					message)
+				return nil
+			}
+			if f.GetFrameworkCondition(ci.FrameworkCapacitySufficient) != nil {
+				f.SetFrameworkCondition(ci.FrameworkCapacitySufficient,
+					core.ConditionTrue, "CapacitySufficient",
+					"FrameworkAttempt may fit into the schedulable capacity")
+			}
+		}
+
 		// createFrameworkAttempt
 		cm, err = c.createConfigMap(f)
 		if err != nil {
@@ -1262,11 +2067,29 @@ func (c *FrameworkController) syncFrameworkState(f *ci.Framework) (err error) {
 		f.Status.State == ci.FrameworkAttemptDeletionRequested ||
 		f.Status.State == ci.FrameworkAttemptDeleting {
 		if !f.IsCompleting() {
-			if f.Spec.ExecutionType == ci.ExecutionStop {
-				diag := "User has requested to stop the Framework"
+			if f.IsStopOrSuspendRequested() {
+				completionStatus := f.NewStopOrSuspendRequestedCompletionStatus()
+				klog.Info(logPfx + completionStatus.Diagnostics)
+				c.completeFrameworkAttempt(f, false, completionStatus)
+			} else if f.IsRestartAttemptRequested() {
+				diag := fmt.Sprintf(
+					"User has requested to restart the FrameworkAttempt %v",
+					f.FrameworkAttemptID())
+				klog.Info(logPfx + diag)
+				c.completeFrameworkAttempt(f, false,
+					ci.CompletionCodeRestartFrameworkRequested.
+						NewFrameworkAttemptCompletionStatus(diag, nil))
+			}
+		}
+
+		if !f.IsCompleting() {
+			if c.qController != nil && c.qController.IsPreempting(f) {
+				diag := fmt.Sprintf(
+					"Framework is preempted by a higher priority Framework in Queue %v",
+					f.Spec.Queue)
 				klog.Info(logPfx + diag)
 				c.completeFrameworkAttempt(f, false,
-					ci.CompletionCodeStopFrameworkRequested.
+					ci.CompletionCodeFrameworkPreempted.
 						NewFrameworkAttemptCompletionStatus(diag, nil))
 			}
 		}
@@ -1275,12 +2098,100 @@ func (c *FrameworkController) syncFrameworkState(f *ci.Framework) (err error) {
 			c.syncFrameworkAttemptCompletionPolicy(f)
 		}
 
+		if !f.IsCompleting() {
+			// Hold the Pods creation until the Workload is admitted by Kueue.
+			admitted, err := c.syncKueueWorkload(f, cm)
+			if err != nil {
+				return err
+			}
+			if !admitted && !f.IsCompleting() {
+				klog.Infof(logPfx + "Waiting Workload to be admitted by Kueue")
+				return nil
+			}
+		}
+
+		if !f.IsCompleting() {
+			// Ensure the PodGroup exists before any Pod is created.
+			err := c.syncPodGroup(f, cm)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !f.IsCompleting() {
+			// Ensure the headless Services exist before any Pod is created, so that
+			// the Pods can resolve each other as soon as they are started.
+			err := c.syncHeadlessServices(f, cm)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !f.IsCompleting() {
+			err := c.syncExposedServices(f, cm)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !f.IsCompleting() {
+			err := c.syncPodDisruptionBudgets(f, cm)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !f.IsCompleting() {
+			err := c.syncNetworkPolicy(f, cm)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !f.IsCompleting() {
+			// Ensure the SSH keypair exists before any Pod is created, since it is
+			// mounted by the Pods.
+			err := c.syncSSHSecret(f, cm)
+			if err != nil {
+				return err
+			}
+		}
+
+		if !f.IsCompleting() {
+			// Hold the Pods creation until the PreAttempt hook is completed.
+			completed, err := c.syncPreAttemptHook(f, cm)
+			if err != nil {
+				return err
+			}
+			if !completed && !f.IsCompleting() {
+				klog.Infof(logPfx + "Waiting PreAttempt hook to complete")
+				return nil
+			}
+		}
+
 		err := c.syncTaskRoleStatuses(f, cm)
 
+		if err == nil && !f.IsCompleting() {
+			err = c.syncHostfile(f, cm)
+		}
+
+		if err == nil && !f.IsCompleting() {
+			err = c.syncPodReadinessGates(f)
+		}
+
 		if f.Status.State == ci.FrameworkAttemptPreparing {
-			if f.IsAnyTaskRunning(true) {
+			if f.IsAnyTaskRunning(true) && f.GetMinTaskNumberUnsatisfiedReason() == "" {
 				f.TransitionFrameworkState(ci.FrameworkAttemptRunning)
 			}
+		} else if f.Status.State == ci.FrameworkAttemptRunning {
+			if err == nil && !f.IsCompleting() {
+				if diag := f.GetMinTaskNumberUnsatisfiedReason(); diag != "" {
+					klog.Info(logPfx + diag)
+					c.completeFrameworkAttempt(f, false,
+						ci.CompletionCodeMinTaskNumberUnsatisfied.
+							NewFrameworkAttemptCompletionStatus(diag, nil))
+				}
+			}
 		}
 
 		return err
@@ -1301,11 +2212,14 @@ func (c *FrameworkController) deleteFramework(
 		"[%v]: Failed to delete Framework %v: confirm: %v: ",
 		f.Key(), f.UID, confirm)
 
+	span := c.tracer.StartSpan(f.Key(), "DeleteFramework",
+		map[string]string{"object.name": f.Name})
 	deleteErr := c.fClient.FrameworkcontrollerV1().Frameworks(f.Namespace).Delete(
 		f.Name, &meta.DeleteOptions{
 			Preconditions:     &meta.Preconditions{UID: &f.UID},
-			PropagationPolicy: common.PtrDeletionPropagation(meta.DeletePropagationForeground),
+			PropagationPolicy: c.getFrameworkDeletionPropagationPolicy(f),
 		})
+	span.End(deleteErr)
 	if deleteErr != nil {
 		if !apiErrors.IsNotFound(deleteErr) {
 			return fmt.Errorf(errPfx+"%v", deleteErr)
@@ -1313,8 +2227,11 @@ func (c *FrameworkController) deleteFramework(
 	} else {
 		if confirm {
 			// Confirm it is deleted instead of still deleting.
+			span := c.tracer.StartSpan(f.Key(), "GetFramework",
+				map[string]string{"object.name": f.Name})
 			remoteF, getErr := c.fClient.FrameworkcontrollerV1().Frameworks(f.Namespace).Get(
 				f.Name, meta.GetOptions{})
+			span.End(getErr)
 			if getErr != nil {
 				if !apiErrors.IsNotFound(getErr) {
 					return fmt.Errorf(errPfx+
@@ -1348,8 +2265,11 @@ func (c *FrameworkController) getOrCleanupConfigMap(
 	cmName := f.ConfigMapName()
 
 	if confirm {
+		span := c.tracer.StartSpan(f.Key(), "GetConfigMap",
+			map[string]string{"object.name": cmName})
 		cm, err = c.kClient.CoreV1().ConfigMaps(f.Namespace).Get(cmName,
 			meta.GetOptions{})
+		span.End(err)
 	} else {
 		cm, err = c.cmLister.ConfigMaps(f.Namespace).Get(cmName)
 	}
@@ -1400,8 +2320,14 @@ func (c *FrameworkController) deleteConfigMap(
 		"[%v]: Failed to delete ConfigMap %v, %v: confirm: %v: ",
 		f.Key(), cmName, cmUID, confirm)
 
+	span := c.tracer.StartSpan(f.Key(), "DeleteConfigMap",
+		map[string]string{"object.name": cmName})
 	deleteErr := c.kClient.CoreV1().ConfigMaps(f.Namespace).Delete(cmName,
-		&meta.DeleteOptions{Preconditions: &meta.Preconditions{UID: &cmUID}})
+		&meta.DeleteOptions{
+			Preconditions:     &meta.Preconditions{UID: &cmUID},
+			PropagationPolicy: c.getConfigMapDeletionPropagationPolicy(f),
+		})
+	span.End(deleteErr)
 	if deleteErr != nil {
 		if !apiErrors.IsNotFound(deleteErr) {
 			return fmt.Errorf(errPfx+"%v", deleteErr)
@@ -1409,8 +2335,11 @@ func (c *FrameworkController) deleteConfigMap(
 	} else {
 		if confirm {
 			// Confirm it is deleted instead of still deleting.
+			span := c.tracer.StartSpan(f.Key(), "GetConfigMap",
+				map[string]string{"object.name": cmName})
 			cm, getErr := c.kClient.CoreV1().ConfigMaps(f.Namespace).Get(cmName,
 				meta.GetOptions{})
+			span.End(getErr)
 			if getErr != nil {
 				if !apiErrors.IsNotFound(getErr) {
 					return fmt.Errorf(errPfx+
@@ -1439,7 +2368,10 @@ func (c *FrameworkController) createConfigMap(
 		"[%v]: Failed to create ConfigMap %v: ",
 		f.Key(), cm.Name)
 
+	span := c.tracer.StartSpan(f.Key(), "CreateConfigMap",
+		map[string]string{"object.name": cm.Name})
 	remoteCM, createErr := c.kClient.CoreV1().ConfigMaps(f.Namespace).Create(cm)
+	span.End(createErr)
 	if createErr != nil {
 		if apiErrors.IsAlreadyExists(createErr) {
 			// Best effort to judge if conflict with a not controlled object.
@@ -1462,6 +2394,50 @@ func (c *FrameworkController) createConfigMap(
 	}
 }
 
+// The creation is idempotent, so it is safe to be retried for the same
+// FrameworkAttempt.
+func (c *FrameworkController) createFrameworkAttemptHistory(f *ci.Framework) error {
+	h := f.NewFrameworkAttemptHistory()
+	errPfx := fmt.Sprintf(
+		"[%v]: Failed to create FrameworkAttemptHistory %v: ",
+		f.Key(), h.Name)
+
+	span := c.tracer.StartSpan(f.Key(), "CreateFrameworkAttemptHistory",
+		map[string]string{"object.name": h.Name})
+	_, createErr := c.fClient.FrameworkcontrollerV1().FrameworkAttemptHistories(
+		f.Namespace).Create(h)
+	span.End(createErr)
+	if createErr != nil {
+		if !apiErrors.IsAlreadyExists(createErr) {
+			return fmt.Errorf(errPfx+"%v", createErr)
+		}
+
+		span := c.tracer.StartSpan(f.Key(), "GetFrameworkAttemptHistory",
+			map[string]string{"object.name": h.Name})
+		remoteH, getErr := c.fClient.FrameworkcontrollerV1().FrameworkAttemptHistories(
+			f.Namespace).Get(h.Name, meta.GetOptions{})
+		span.End(getErr)
+		if getErr != nil {
+			return fmt.Errorf(errPfx+
+				"FrameworkAttemptHistory cannot be got from remote: %v", getErr)
+		}
+		if !meta.IsControlledBy(remoteH, f) {
+			// The history is only for inspection, so do not block the Framework.
+			klog.Warningf(errPfx+
+				"FrameworkAttemptHistory naming conflicts with others: "+
+				"Existing FrameworkAttemptHistory %v is not controlled by "+
+				"current Framework %v, %v: %v",
+				remoteH.UID, f.Name, f.UID, createErr)
+			return nil
+		}
+	}
+
+	klog.Infof(
+		"[%v]: Succeeded to create FrameworkAttemptHistory %v",
+		f.Key(), h.Name)
+	return nil
+}
+
 // FrameworkAttemptCompletionPolicy can be triggered by not only completed Tasks
 // increased in f.Status, but also FrameworkAttemptCompletionPolicy or TotalTaskCount
 // decreased in f.Spec, so full sync here is needed.
@@ -1545,7 +2521,7 @@ func (c *FrameworkController) syncFrameworkAttemptCompletionPolicy(
 				continue
 			}
 
-			roleTotalTaskCount := taskRoleSpec.TaskNumber
+			roleTotalTaskCount := f.GetTaskNumber(taskRoleSpec)
 			if roleTotalTaskCount == 0 {
 				continue
 			}
@@ -1611,7 +2587,14 @@ func (c *FrameworkController) syncTaskState(
 	logPfx := fmt.Sprintf("[%v][%v][%v]: syncTaskState: ",
 		f.Key(), taskRoleName, taskIndex)
 	klog.Infof(logPfx + "Started")
-	defer func() { klog.Infof(logPfx + "Completed") }()
+	span := c.tracer.StartSpan(f.Key(), "syncTaskState", map[string]string{
+		"taskrole.name": taskRoleName,
+		"task.index":    fmt.Sprint(taskIndex),
+	})
+	defer func() {
+		span.End(err)
+		klog.Infof(logPfx + "Completed")
+	}()
 
 	taskRoleSpec := f.GetTaskRoleSpec(taskRoleName)
 	taskRoleStatus := f.TaskRoleStatus(taskRoleName)
@@ -1649,6 +2632,10 @@ func (c *FrameworkController) syncTaskState(
 					diag = "User has requested to delete the Task by Framework ScaleDown"
 					code = ci.CompletionCodeDeleteTaskRequested
 					klog.Info(logPfx + diag)
+				} else if taskRoleSpec.IsTaskStopRequested(taskIndex) {
+					diag = "User has requested to stop the Task"
+					code = ci.CompletionCodeStopTaskRequested
+					klog.Info(logPfx + diag)
 				} else {
 					if c.enqueueTaskAttemptCreationTimeoutCheck(f, taskRoleName, taskIndex, true) {
 						klog.Infof(logPfx +
@@ -1659,7 +2646,7 @@ func (c *FrameworkController) syncTaskState(
 					diag = fmt.Sprintf(
 						"Pod does not appear in the local cache within timeout %v, "+
 							"so consider it was deleted and explicitly delete it",
-						common.SecToDuration(c.cConfig.ObjectLocalCacheCreationTimeoutSec))
+						common.SecToDuration(c.config().ObjectLocalCacheCreationTimeoutSec))
 					code = ci.CompletionCodePodCreationTimeout
 					klog.Warning(logPfx + diag)
 				}
@@ -1692,9 +2679,17 @@ func (c *FrameworkController) syncTaskState(
 		} else {
 			if pod.DeletionTimestamp == nil {
 				if taskStatus.State == ci.TaskAttemptDeletionPending {
+					if c.enqueueTaskAttemptFailedRetainTimeoutCheck(
+						f, taskRoleName, taskIndex, true) {
+						klog.Infof(logPfx +
+							"Waiting Pod of the failed TaskAttempt to be retained for debugging")
+						return nil
+					}
+
 					// The CompletionStatus has been persisted, so it is safe to delete the
 					// pod now.
-					err := c.deletePod(f, taskRoleName, taskIndex, *taskStatus.PodUID(), false, false)
+					err := c.deletePod(f, taskRoleName, taskIndex, *taskStatus.PodUID(),
+						false, c.shouldForceDeleteNodeLostPod(taskStatus))
 					if err != nil {
 						return err
 					}
@@ -1724,7 +2719,46 @@ func (c *FrameworkController) syncTaskState(
 				taskStatus.AttemptStatus.PodIP = &pod.Status.PodIP
 				taskStatus.AttemptStatus.PodHostIP = &pod.Status.HostIP
 
-				if pod.Status.Phase == core.PodUnknown {
+				// The TaskRoleSpec may be already deleted while its Pods are still
+				// running.
+				completionContainer := ""
+				if taskRoleSpec != nil {
+					completionContainer = taskRoleSpec.CompletionContainer
+				}
+				podPhase := ci.GetPodCompletionPhase(pod, completionContainer)
+				if podPhase != core.PodSucceeded && podPhase != core.PodFailed {
+					if taskRoleSpec != nil && taskRoleSpec.SpotInterruption != nil &&
+						taskRoleSpec.SpotInterruption.DrainOnNotice {
+						if signal := c.getNodeSpotInterruption(pod.Spec.NodeName); signal != "" {
+							diag := fmt.Sprintf(
+								"Pod is drained since its Node %v is being interrupted: %v",
+								pod.Spec.NodeName, signal)
+							klog.Warning(logPfx + diag)
+							c.completeTaskAttempt(f, taskRoleName, taskIndex, false,
+								ci.CompletionCodePodSpotInterrupted.NewTaskAttemptCompletionStatus(
+									diag, ci.ExtractPodCompletionStatus(pod)))
+							return nil
+						}
+					}
+
+					if cond := c.getNodeNotReadyCondition(pod.Spec.NodeName); cond != nil {
+						if !c.enqueueFrameworkTimeoutCheck(
+							f, cond.LastTransitionTime, c.config().NodeLost.NotReadyGraceSec,
+							true, "NodeNotReadyTimeoutCheck") {
+							diag := fmt.Sprintf(
+								"Pod Node %v has been NotReady longer than NotReadyGraceSec %vs: %v",
+								pod.Spec.NodeName, *c.config().NodeLost.NotReadyGraceSec,
+								cond.Message)
+							klog.Warning(logPfx + diag)
+							c.completeTaskAttempt(f, taskRoleName, taskIndex, false,
+								ci.CompletionCodePodNodeNotReady.NewTaskAttemptCompletionStatus(
+									diag, ci.ExtractPodCompletionStatus(pod)))
+							return nil
+						}
+					}
+				}
+
+				if podPhase == core.PodUnknown {
 					// Possibly due to the NodeController has not heard from the kubelet who
 					// manages the Pod for more than node-monitor-grace-period but less than
 					// pod-eviction-timeout.
@@ -1734,30 +2768,105 @@ func (c *FrameworkController) syncTaskState(
 					klog.Infof(logPfx+
 						"Waiting Pod to be deleted or deleting or transitioned from %v",
 						pod.Status.Phase)
-				} else if pod.Status.Phase == core.PodPending {
+				} else if podPhase == core.PodPending {
 					f.TransitionTaskState(taskRoleName, taskIndex, ci.TaskAttemptPreparing)
-				} else if pod.Status.Phase == core.PodRunning {
+
+					if taskRoleSpec != nil &&
+						(taskRoleSpec.OS != "" || taskRoleSpec.Arch != "") {
+						if cond := ci.GetPodNodeUnmatchedCondition(pod); cond != nil {
+							if !c.enqueueFrameworkTimeoutCheck(
+								f, cond.LastTransitionTime, c.config().PodNodeUnmatchedTimeoutSec,
+								true, "PodNodeUnmatchedTimeoutCheck") {
+								diag := fmt.Sprintf(
+									"Pod cannot be scheduled within %vs since no node matches "+
+										"OS %v and Arch %v: %v",
+									*c.config().PodNodeUnmatchedTimeoutSec,
+									taskRoleSpec.OS, taskRoleSpec.Arch, cond.Message)
+								klog.Info(logPfx + diag)
+								c.completeTaskAttempt(f, taskRoleName, taskIndex, false,
+									ci.CompletionCodePodNodeUnmatched.NewTaskAttemptCompletionStatus(
+										diag, ci.ExtractPodCompletionStatus(pod)))
+								return nil
+							}
+						}
+					}
+
+					if f.Spec.AttemptSchedulingTimeoutSec != nil && !f.IsCompleting() {
+						if cond := ci.GetPodUnschedulableCondition(pod); cond != nil {
+							if !c.enqueueFrameworkTimeoutCheck(
+								f, cond.LastTransitionTime, f.Spec.AttemptSchedulingTimeoutSec,
+								true, "FrameworkAttemptSchedulingTimeoutCheck") {
+								diag := fmt.Sprintf(
+									"Pod cannot be scheduled within AttemptSchedulingTimeoutSec %vs: %v",
+									*f.Spec.AttemptSchedulingTimeoutSec, cond.Message)
+								klog.Info(logPfx + diag)
+								c.completeFrameworkAttempt(f, false,
+									ci.NewSchedulingTimeoutCompletionStatus(taskStatus, taskRoleName, diag))
+								return nil
+							}
+						}
+					}
+				} else if podPhase == core.PodRunning {
 					f.TransitionTaskState(taskRoleName, taskIndex, ci.TaskAttemptRunning)
-				} else if pod.Status.Phase == core.PodSucceeded {
+
+					// The allocated devices will not be changed during the whole lifetime
+					// of the Pod.
+					if taskStatus.AttemptStatus.PodAllocatedDevices == nil {
+						taskStatus.AttemptStatus.PodAllocatedDevices = ci.GetPodAllocatedDevices(
+							pod, c.config().PodDeviceIDAnnotationKeys)
+					}
+
+					if taskRoleSpec != nil && taskRoleSpec.Task.AttemptMaxRunDurationSec != nil {
+						if !c.enqueueTaskAttemptRunTimeoutCheck(f, taskRoleName, taskIndex,
+							taskRoleSpec.Task.AttemptMaxRunDurationSec, true) {
+							diag := fmt.Sprintf(
+								"Pod has been running longer than AttemptMaxRunDurationSec %vs",
+								*taskRoleSpec.Task.AttemptMaxRunDurationSec)
+							klog.Info(logPfx + diag)
+							c.completeTaskAttempt(f, taskRoleName, taskIndex, false,
+								ci.CompletionCodeTaskAttemptTimeExceeded.NewTaskAttemptCompletionStatus(
+									diag, ci.ExtractPodCompletionStatus(pod)))
+							return nil
+						}
+					}
+
+					if taskRoleSpec != nil && taskRoleSpec.Task.HeartbeatTimeoutSec != nil {
+						lastHeartbeatTime := *taskStatus.AttemptStatus.RunTime
+						if t := ci.GetPodHeartbeatTime(pod); t != nil && t.After(lastHeartbeatTime.Time) {
+							lastHeartbeatTime = *t
+						}
+						if !c.enqueueFrameworkTimeoutCheck(
+							f, lastHeartbeatTime, taskRoleSpec.Task.HeartbeatTimeoutSec,
+							true, "TaskHeartbeatTimeoutCheck") {
+							diag := fmt.Sprintf(
+								"Pod has not reported heartbeat within HeartbeatTimeoutSec %vs "+
+									"since %v", *taskRoleSpec.Task.HeartbeatTimeoutSec,
+								lastHeartbeatTime.Format(time.RFC3339))
+							klog.Info(logPfx + diag)
+							c.completeTaskAttempt(f, taskRoleName, taskIndex, false,
+								ci.CompletionCodeTaskHeartbeatTimeout.NewTaskAttemptCompletionStatus(
+									diag, ci.ExtractPodCompletionStatus(pod)))
+							return nil
+						}
+					}
+				} else if podPhase == core.PodSucceeded {
 					diag := fmt.Sprintf("Pod succeeded")
 					klog.Info(logPfx + diag)
 					c.completeTaskAttempt(f, taskRoleName, taskIndex, false,
 						ci.CompletionCodeSucceeded.NewTaskAttemptCompletionStatus(
 							diag, ci.ExtractPodCompletionStatus(pod)))
 					return nil
-				} else if pod.Status.Phase == core.PodFailed {
-					result := ci.MatchCompletionCodeInfos(pod)
-					diag := fmt.Sprintf("Pod failed: %v", result.Diagnostics)
-					klog.Info(logPfx + diag)
+				} else if podPhase == core.PodFailed {
+					cs, err := c.classifyPodFailure(f, taskRoleName, taskIndex, pod)
+					if err != nil {
+						return err
+					}
+					klog.Info(logPfx + cs.Diagnostics)
 					c.completeTaskAttempt(f, taskRoleName, taskIndex, false,
 						&ci.TaskAttemptCompletionStatus{
-							CompletionStatus: &ci.CompletionStatus{
-								Code:        *result.CodeInfo.Code,
-								Phrase:      result.CodeInfo.Phrase,
-								Type:        result.CodeInfo.Type,
-								Diagnostics: diag,
-							},
-							Pod: ci.ExtractPodCompletionStatus(pod),
+							CompletionStatus: cs,
+							Pod:              ci.ExtractPodCompletionStatus(pod),
+							PodFailurePolicy: matchPodFailurePolicy(taskRoleSpec, pod),
 						},
 					)
 					return nil
@@ -1767,11 +2876,27 @@ func (c *FrameworkController) syncTaskState(
 				}
 			} else {
 				if taskStatus.AttemptStatus.CompletionStatus == nil {
-					diag := fmt.Sprintf("Pod is being deleted by others")
-					klog.Warning(logPfx + diag)
-					taskStatus.AttemptStatus.CompletionStatus =
-						ci.CompletionCodePodExternalDeleted.
-							NewTaskAttemptCompletionStatus(diag, nil)
+					if ci.IsHiveDPreemptedPod(pod) {
+						diag := fmt.Sprintf("Pod is being preempted by HiveD")
+						klog.Warning(logPfx + diag)
+						taskStatus.AttemptStatus.CompletionStatus =
+							ci.CompletionCodePodHiveDPreempted.
+								NewTaskAttemptCompletionStatus(diag, nil)
+					} else if ci.IsVolcanoEvictedPod(pod) {
+						diag := fmt.Sprintf("Pod is being evicted by Volcano")
+						klog.Warning(logPfx + diag)
+						taskStatus.AttemptStatus.CompletionStatus =
+							ci.CompletionCodePodVolcanoEvicted.
+								NewTaskAttemptCompletionStatus(diag, nil)
+					} else {
+						diag := fmt.Sprintf("Pod is being deleted by others")
+						klog.Warning(logPfx + diag)
+						taskStatus.AttemptStatus.CompletionStatus =
+							ci.CompletionCodePodExternalDeleted.
+								NewTaskAttemptCompletionStatus(diag, nil)
+					}
+					taskStatus.AttemptStatus.CompletionStatus.PodFailurePolicy =
+						matchPodFailurePolicy(taskRoleSpec, pod)
 				}
 
 				f.TransitionTaskState(taskRoleName, taskIndex, ci.TaskAttemptDeleting)
@@ -1791,6 +2916,28 @@ func (c *FrameworkController) syncTaskState(
 			c.completeTaskAttempt(f, taskRoleName, taskIndex, false,
 				ci.CompletionCodeDeleteTaskRequested.
 					NewTaskAttemptCompletionStatus(diag, nil))
+		} else if taskRoleSpec.IsTaskStopRequested(taskIndex) {
+			diag := "User has requested to stop the Task"
+			klog.Info(logPfx + diag)
+			c.completeTaskAttempt(f, taskRoleName, taskIndex, false,
+				ci.CompletionCodeStopTaskRequested.
+					NewTaskAttemptCompletionStatus(diag, nil))
+		} else if taskRoleSpec.IsTaskRestartRequested(
+			taskIndex, taskStatus.TaskAttemptID()) {
+			diag := fmt.Sprintf(
+				"User has requested to restart the TaskAttempt %v",
+				taskStatus.TaskAttemptID())
+			klog.Info(logPfx + diag)
+			c.completeTaskAttempt(f, taskRoleName, taskIndex, false,
+				ci.CompletionCodeRestartTaskRequested.
+					NewTaskAttemptCompletionStatus(diag, nil))
+		} else if c.shouldRollingUpdateTask(
+			f, taskRoleName, taskIndex, taskRoleSpec, pod) {
+			diag := "TaskAttempt is outdated by the updated Pod template"
+			klog.Info(logPfx + diag)
+			c.completeTaskAttempt(f, taskRoleName, taskIndex, false,
+				ci.CompletionCodePodTemplateUpdated.
+					NewTaskAttemptCompletionStatus(diag, nil))
 		}
 		return nil
 	}
@@ -1805,20 +2952,74 @@ func (c *FrameworkController) syncTaskState(
 				ShouldRetry: false, IsAccountable: true,
 				DelaySec: 0, Reason: "TaskRoleSpec is already deleted"}
 		} else {
-			retryDecision = taskRoleSpec.Task.RetryPolicy.ShouldRetry(
-				taskStatus.RetryPolicyStatus,
-				taskStatus.AttemptStatus.CompletionStatus.CompletionStatus,
-				0, 0)
+			retryDecision = c.retryDecider.ShouldRetry(&RetryContext{
+				Framework:         f,
+				TaskRoleName:      taskRoleName,
+				TaskStatus:        taskStatus,
+				RetryPolicy:       taskRoleSpec.Task.RetryPolicy,
+				RetryPolicyStatus: taskStatus.RetryPolicyStatus,
+				CompletionStatus:  taskStatus.AttemptStatus.CompletionStatus.CompletionStatus,
+			})
+			if taskRoleSpec.SpotInterruption != nil && retryDecision.IsAccountable &&
+				taskStatus.AttemptStatus.CompletionStatus.Code ==
+					ci.CompletionCodePodSpotInterrupted {
+				retryDecision = ci.RetryDecision{
+					ShouldRetry: true, IsAccountable: false,
+					DelaySec: 0, Reason: "Spot interruption is tolerated by the TaskRole"}
+			}
+			// The PodFailurePolicy takes precedence over the Task RetryPolicy, except
+			// for the built-in always-on RetryPolicy.
+			if pfp := taskStatus.AttemptStatus.CompletionStatus.PodFailurePolicy; pfp != nil &&
+				retryDecision.IsAccountable {
+				retryDecision = pfp.RetryDecision(
+					taskRoleSpec.Task.RetryPolicy, taskStatus.RetryPolicyStatus)
+			}
+			if retryDecision.ShouldRetry {
+				if reason := f.GetPodTemplateUpdateRejectedReason(
+					taskRoleName, taskIndex); reason != "" {
+					retryDecision = ci.RetryDecision{
+						ShouldRetry: false, IsAccountable: true,
+						DelaySec: 0, Reason: reason}
+				}
+			}
 		}
 
 		if taskStatus.RetryPolicyStatus.RetryDelaySec == nil {
 			// RetryTask is not yet scheduled, so need to be decided.
+			c.recordBlacklistedNode(f, taskStatus)
+
+			if pfp := taskStatus.AttemptStatus.CompletionStatus.PodFailurePolicy; pfp != nil &&
+				pfp.Action == ci.PodFailurePolicyActionFailFramework && !f.IsCompleting() {
+				klog.Infof(logPfx+"Will complete FrameworkAttempt: RetryDecision: %v",
+					retryDecision)
+				c.completeFrameworkAttempt(f, false,
+					ci.NewPodFailurePolicyTriggeredCompletionStatus(taskStatus, taskRoleName))
+				return nil
+			}
+
+			if retryDecision.ShouldRetry && !f.IsCompleting() {
+				if reason := f.GetRetryBudgetExceededReason(taskStatus); reason != "" {
+					klog.Info(logPfx + reason)
+					c.completeFrameworkAttempt(f, false,
+						ci.NewRetryBudgetExceededCompletionStatus(
+							taskStatus, taskRoleName, reason))
+					return nil
+				}
+			}
+
 			if retryDecision.ShouldRetry {
 				// scheduleToRetryTask
 				klog.Infof(logPfx+
 					"Will retry Task with new TaskAttempt: RetryDecision: %v",
 					retryDecision)
 
+				if taskRoleSpec != nil &&
+					taskStatus.EscalateMemory(taskRoleSpec.MemoryEscalation) {
+					klog.Infof(logPfx+
+						"Will escalate memory of the new TaskAttempt since OOMKilled: %v",
+						common.ToJson(taskStatus.MemoryEscalation))
+				}
+
 				taskStatus.RetryPolicyStatus.RetryDelaySec = &retryDecision.DelaySec
 			} else {
 				// completeTask
@@ -1837,6 +3038,10 @@ func (c *FrameworkController) syncTaskState(
 				klog.Infof(logPfx +
 					"User has requested to delete the Task by Framework ScaleDown, " +
 					"so immediately retry without delay")
+			} else if taskRoleSpec.IsTaskStopRequested(taskIndex) {
+				klog.Infof(logPfx +
+					"User has requested to stop the Task, " +
+					"so immediately retry without delay")
 			} else {
 				if c.enqueueTaskRetryDelayTimeoutCheck(f, taskRoleName, taskIndex, true) {
 					klog.Infof(logPfx + "Waiting Task to retry after delay")
@@ -1846,7 +3051,7 @@ func (c *FrameworkController) syncTaskState(
 
 			// retryTask
 			logSfx := ""
-			if *c.cConfig.LogObjectSnapshot.Framework.OnTaskRetry {
+			if *c.config().LogObjectSnapshot.Framework.OnTaskRetry {
 				// The completed TaskAttempt has been persisted, so it is safe to also
 				// expose it as one history snapshot.
 				logSfx = ci.GetFrameworkSnapshotLogTail(f)
@@ -1897,6 +3102,43 @@ func (c *FrameworkController) syncTaskState(
 			return nil
 		}
 
+		if taskRoleSpec.IsTaskStopRequested(taskIndex) {
+			diag := "User has requested to stop the Task"
+			klog.Info(logPfx + diag)
+
+			// Ensure pod is deleted in remote to avoid managed pod leak after
+			// TaskAttemptCompleted.
+			_, err = c.getOrCleanupPod(f, cm, taskRoleName, taskIndex, true)
+			if err != nil {
+				return err
+			}
+
+			c.completeTaskAttempt(f, taskRoleName, taskIndex, true,
+				ci.CompletionCodeStopTaskRequested.
+					NewTaskAttemptCompletionStatus(diag, nil))
+			return nil
+		}
+
+		if reason := f.GetTaskRoleDependencyUnsatisfiedReason(
+			taskRoleSpec); reason != "" {
+			// The Framework will be resynced once the prerequisite Tasks are changed.
+			klog.Infof(logPfx+"Waiting TaskRole dependencies: %v", reason)
+			return nil
+		}
+
+		if taskStatus.AllocatedPorts == nil && taskRoleSpec.PortNumber > 0 {
+			err = c.allocateTaskPorts(f, taskRoleName, taskIndex)
+			if err != nil {
+				return err
+			}
+
+			// To ensure AllocatedPorts is persisted before creating its pod, we need
+			// to wait until next sync to create the pod, so manually enqueue a sync.
+			c.enqueueFrameworkSync(f, "TaskPortsAllocated")
+			klog.Infof(logPfx + "Waiting AllocatedPorts to be persisted")
+			return nil
+		}
+
 		// createTaskAttempt
 		pod, err = c.createPod(f, cm, taskRoleName, taskIndex)
 		if err != nil {
@@ -1923,6 +3165,8 @@ func (c *FrameworkController) syncTaskState(
 		}
 
 		taskStatus.AttemptStatus.PodUID = &pod.UID
+		taskStatus.AttemptStatus.PodFQDN = ci.GetPodFQDN(pod, *c.config().ClusterDomain)
+		taskStatus.AttemptStatus.PodTemplateHash = ci.GetPodTemplateHashAnnotation(pod)
 		taskStatus.AttemptStatus.InstanceUID = ci.GetTaskAttemptInstanceUID(
 			taskStatus.TaskAttemptID(), taskStatus.PodUID())
 		f.TransitionTaskState(taskRoleName, taskIndex, ci.TaskAttemptCreationRequested)
@@ -2059,8 +3303,11 @@ func (c *FrameworkController) getOrCleanupPod(
 	podName := taskStatus.PodName()
 
 	if confirm {
+		span := c.tracer.StartSpan(f.Key(), "GetPod",
+			map[string]string{"object.name": podName})
 		pod, err = c.kClient.CoreV1().Pods(f.Namespace).Get(podName,
 			meta.GetOptions{})
+		span.End(err)
 	} else {
 		pod, err = c.podLister.Pods(f.Namespace).Get(podName)
 	}
@@ -2125,7 +3372,10 @@ func (c *FrameworkController) deletePod(
 	if force {
 		deleteOptions.GracePeriodSeconds = common.PtrInt64(0)
 	}
+	span := c.tracer.StartSpan(f.Key(), "DeletePod",
+		map[string]string{"object.name": podName})
 	deleteErr := c.kClient.CoreV1().Pods(f.Namespace).Delete(podName, deleteOptions)
+	span.End(deleteErr)
 	if deleteErr != nil {
 		if !apiErrors.IsNotFound(deleteErr) {
 			return fmt.Errorf(errPfx+"%v", deleteErr)
@@ -2133,8 +3383,11 @@ func (c *FrameworkController) deletePod(
 	} else {
 		if confirm {
 			// Confirm it is deleted instead of still deleting.
+			span := c.tracer.StartSpan(f.Key(), "GetPod",
+				map[string]string{"object.name": podName})
 			pod, getErr := c.kClient.CoreV1().Pods(f.Namespace).Get(podName,
 				meta.GetOptions{})
+			span.End(getErr)
 			if getErr != nil {
 				if !apiErrors.IsNotFound(getErr) {
 					return fmt.Errorf(errPfx+
@@ -2159,12 +3412,43 @@ func (c *FrameworkController) deletePod(
 func (c *FrameworkController) createPod(
 	f *ci.Framework, cm *core.ConfigMap,
 	taskRoleName string, taskIndex int32) (*core.Pod, error) {
-	pod := f.NewPod(cm, taskRoleName, taskIndex)
 	errPfx := fmt.Sprintf(
 		"[%v][%v][%v]: Failed to create Pod %v",
-		f.Key(), taskRoleName, taskIndex, pod.Name)
+		f.Key(), taskRoleName, taskIndex,
+		f.TaskStatus(taskRoleName, taskIndex).PodName())
+	pod, err := f.NewPod(cm, taskRoleName, taskIndex)
+	if err != nil {
+		// The invalid Pod is rejected as BadRequest before it is sent to ApiServer.
+		return nil, errorWrap.Wrapf(apiErrors.NewBadRequest(err.Error()), errPfx)
+	}
+	c.setPodDefaults(pod)
+	c.setNodeBlacklist(f, pod)
+	c.setPodGroup(f, pod)
+	c.setTaskHostname(f, pod, taskRoleName, taskIndex)
+	c.setPeerEnvs(f, pod)
+	c.setPodReadinessGate(pod)
+	setTaskPorts(pod, f.TaskStatus(taskRoleName, taskIndex).AllocatedPorts)
+	err = c.decoratePod(&PodDecorateContext{
+		Framework:    f,
+		TaskRoleName: taskRoleName,
+		TaskIndex:    taskIndex,
+		Pod:          pod,
+	})
+	if err != nil {
+		return nil, errorWrap.Wrapf(apiErrors.NewBadRequest(err.Error()), errPfx)
+	}
 
-	remotePod, createErr := c.kClient.CoreV1().Pods(f.Namespace).Create(pod)
+	span := c.tracer.StartSpan(f.Key(), "CreatePod",
+		map[string]string{"object.name": pod.Name})
+	var remotePod *core.Pod
+	var createErr error
+	if constraints := f.TaskRoleSpec(taskRoleName).TopologySpreadConstraints; len(constraints) > 0 {
+		remotePod, createErr = c.createPodWithTopologySpread(
+			f, taskRoleName, pod, constraints)
+	} else {
+		remotePod, createErr = c.kClient.CoreV1().Pods(f.Namespace).Create(pod)
+	}
+	span.End(createErr)
 	if createErr != nil {
 		if apiErrors.IsAlreadyExists(createErr) {
 			// Best effort to judge if conflict with a not controlled object.
@@ -2197,6 +3481,9 @@ func (c *FrameworkController) completeTaskAttempt(
 
 	// CompletionStatus should be immutable after set.
 	if taskStatus.AttemptStatus.CompletionStatus == nil {
+		completionStatus = c.classifySpotInterruption(f, taskStatus, completionStatus)
+		completionStatus = c.attachPodEventDiagnostics(f, taskStatus, completionStatus)
+		completionStatus = c.attachContainerLogTails(f, taskStatus, completionStatus)
 		taskStatus.AttemptStatus.CompletionStatus = completionStatus
 	}
 
@@ -2231,6 +3518,144 @@ func (c *FrameworkController) completeTaskAttempt(
 	}
 }
 
+// Best effort to append the recent Warning Events of the TaskAttempt's Pod to
+// the failed completionStatus, so that they are still visible after the Events
+// are expired or the Pod is deleted.
+func (c *FrameworkController) attachPodEventDiagnostics(
+	f *ci.Framework, taskStatus *ci.TaskStatus,
+	completionStatus *ci.TaskAttemptCompletionStatus) *ci.TaskAttemptCompletionStatus {
+	maxCount := int(*c.config().PodEventDiagnosticsMaxCount)
+	maxBytes := int(*c.config().PodEventDiagnosticsMaxBytes)
+	podUID := taskStatus.AttemptStatus.PodUID
+	if maxCount == 0 || podUID == nil ||
+		completionStatus == nil || completionStatus.CompletionStatus == nil ||
+		!completionStatus.Type.IsFailed() {
+		return completionStatus
+	}
+
+	logPfx := fmt.Sprintf("[%v]: attachPodEventDiagnostics: ", f.Key())
+	eventList, err := c.kClient.CoreV1().Events(f.Namespace).List(meta.ListOptions{
+		FieldSelector: fmt.Sprintf(
+			"involvedObject.uid=%v,type=%v", *podUID, core.EventTypeWarning),
+	})
+	if err != nil {
+		klog.Warningf(logPfx+"Failed to list Events of Pod %v: %v", *podUID, err)
+		return completionStatus
+	}
+
+	events := []core.Event{}
+	for _, event := range eventList.Items {
+		if event.InvolvedObject.UID == *podUID &&
+			event.Type == core.EventTypeWarning {
+			events = append(events, event)
+		}
+	}
+	if len(events) == 0 {
+		return completionStatus
+	}
+
+	// Keep the latest maxCount Events, in chronological order.
+	sort.SliceStable(events, func(i, j int) bool {
+		return getEventLastTime(&events[i]).Before(getEventLastTime(&events[j]))
+	})
+	if len(events) > maxCount {
+		events = events[len(events)-maxCount:]
+	}
+
+	var summary strings.Builder
+	summary.WriteString("Recent Pod Warning Events:")
+	for i := range events {
+		event := &events[i]
+		summary.WriteString(fmt.Sprintf("\n[%v] %v: %v",
+			getEventLastTime(event).Format(time.RFC3339),
+			event.Reason, strings.TrimSpace(event.Message)))
+		if event.Count > 1 {
+			summary.WriteString(fmt.Sprintf(" (x%v)", event.Count))
+		}
+	}
+	summaryStr := summary.String()
+	if len(summaryStr) > maxBytes {
+		summaryStr = summaryStr[:maxBytes] + "..."
+	}
+
+	// Copy to avoid mutating the shared CompletionStatus.
+	cs := *completionStatus.CompletionStatus
+	if cs.Diagnostics == "" {
+		cs.Diagnostics = summaryStr
+	} else {
+		cs.Diagnostics += "\n" + summaryStr
+	}
+	attached := *completionStatus
+	attached.CompletionStatus = &cs
+	return &attached
+}
+
+// Best effort to capture the logs tail of the failed containers in the failed
+// completionStatus, since the Pod and its logs will be deleted shortly after.
+// The logs are fetched synchronously, so all the fetches of a TaskAttempt share
+// one FailedContainerLogTailTimeoutSec to bound the blocking of the worker.
+func (c *FrameworkController) attachContainerLogTails(
+	f *ci.Framework, taskStatus *ci.TaskStatus,
+	completionStatus *ci.TaskAttemptCompletionStatus) *ci.TaskAttemptCompletionStatus {
+	tailLines := *c.config().FailedContainerLogTailLines
+	maxBytes := int(*c.config().FailedContainerLogTailMaxBytes)
+	if tailLines == 0 || taskStatus.AttemptStatus.PodUID == nil ||
+		completionStatus == nil || completionStatus.CompletionStatus == nil ||
+		!completionStatus.Type.IsFailed() || completionStatus.Pod == nil {
+		return completionStatus
+	}
+
+	logPfx := fmt.Sprintf("[%v]: attachContainerLogTails: ", f.Key())
+	podName := taskStatus.AttemptStatus.PodName
+
+	// Use the wall clock instead of common.Clock, since it bounds the real calls.
+	timeoutSec := c.config().FailedContainerLogTailTimeoutSec
+	deadline := time.Now().Add(common.SecToDuration(timeoutSec))
+
+	// Copy to avoid mutating the shared PodCompletionStatus.
+	pcs := completionStatus.Pod.DeepCopy()
+	for _, ccs := range pcs.Containers {
+		if ccs.Code == 0 {
+			continue
+		}
+
+		timeout := time.Until(deadline)
+		if timeout <= 0 {
+			klog.Warningf(logPfx+
+				"Skip to get logs of Pod %v Container %v: Exceeded %vs timeout",
+				podName, ccs.Name, *timeoutSec)
+			continue
+		}
+
+		logs, err := c.kClient.CoreV1().Pods(f.Namespace).GetLogs(podName,
+			&core.PodLogOptions{Container: ccs.Name, TailLines: &tailLines}).
+			Timeout(timeout).DoRaw()
+		if err != nil {
+			klog.Warningf(logPfx+
+				"Failed to get logs of Pod %v Container %v: %v", podName, ccs.Name, err)
+			continue
+		}
+		if len(logs) > maxBytes {
+			logs = logs[len(logs)-maxBytes:]
+		}
+		ccs.LogTail = string(logs)
+	}
+
+	attached := *completionStatus
+	attached.Pod = pcs
+	return &attached
+}
+
+func getEventLastTime(event *core.Event) time.Time {
+	if !event.LastTimestamp.IsZero() {
+		return event.LastTimestamp.Time
+	}
+	if !event.EventTime.IsZero() {
+		return event.EventTime.Time
+	}
+	return event.FirstTimestamp.Time
+}
+
 func (c *FrameworkController) completeFrameworkAttempt(
 	f *ci.Framework, force bool, completionStatus *ci.FrameworkAttemptCompletionStatus) {
 	logPfx := fmt.Sprintf(
@@ -2301,7 +3726,7 @@ func (c *FrameworkController) completeFrameworkAttempt(
 // Best effort to compress and no need to requeue if failed, since the
 // updateRemoteFrameworkStatus may still succeed if compress failed.
 func (c *FrameworkController) compressFramework(f *ci.Framework) {
-	if *c.cConfig.LargeFrameworkCompression {
+	if *c.config().LargeFrameworkCompression {
 		logPfx := fmt.Sprintf("[%v]: compressFramework: ", f.Key())
 		klog.Infof(logPfx + "Started")
 		defer func() { klog.Infof(logPfx + "Completed") }()
@@ -2362,7 +3787,10 @@ func (c *FrameworkController) updateRemoteFrameworkStatus(f *ci.Framework) error
 			}
 		}
 
+		span := c.tracer.StartSpan(f.Key(), "UpdateFramework",
+			map[string]string{"object.name": updateF.Name})
 		_, updateErr := c.fClient.FrameworkcontrollerV1().Frameworks(updateF.Namespace).Update(updateF)
+		span.End(updateErr)
 		return updateErr
 	})
 
@@ -2374,6 +3802,44 @@ func (c *FrameworkController) updateRemoteFrameworkStatus(f *ci.Framework) error
 	}
 }
 
+// Best effort to persist the expected Framework.Status which is not yet remote
+// synced, so that it does not need to be recovered after restart.
+// It should only be invoked after all workers are stopped.
+func (c *FrameworkController) flushExpectedFrameworkStatusInfos() {
+	c.fExpectedStatusInfos.Range(func(k, v interface{}) bool {
+		key := k.(string)
+		expected := v.(*ExpectedFrameworkStatusInfo)
+		if expected.remoteSynced {
+			return true
+		}
+
+		logPfx := fmt.Sprintf("[%v]: flushExpectedFrameworkStatusInfo: ", key)
+		fNamespace, fName := ci.SplitFrameworkKey(key)
+		localF, err := c.fLister.Frameworks(fNamespace).Get(fName)
+		if err != nil {
+			klog.Warningf(logPfx+
+				"Skipped: Framework cannot be got from local cache: %v", err)
+			return true
+		}
+		if localF.UID != expected.uid {
+			klog.Warningf(logPfx+
+				"Skipped: Framework UID mismatch: Expected UID %v, Local Cached UID %v",
+				expected.uid, localF.UID)
+			return true
+		}
+
+		f := localF.DeepCopy()
+		f.Status = expected.status
+		c.compressFramework(f)
+		updateErr := c.updateRemoteFrameworkStatus(f)
+		c.updateExpectedFrameworkStatusInfo(key, f.Status, f.UID, updateErr == nil)
+		if updateErr != nil {
+			klog.Warning(updateErr.Error())
+		}
+		return true
+	})
+}
+
 func (c *FrameworkController) getExpectedFrameworkStatusInfo(key string) *ExpectedFrameworkStatusInfo {
 	if value, ok := c.fExpectedStatusInfos.Load(key); ok {
 		return value.(*ExpectedFrameworkStatusInfo)
@@ -2398,3 +3864,64 @@ func (c *FrameworkController) updateExpectedFrameworkStatusInfo(key string,
 		remoteSynced: remoteSynced,
 	})
 }
+
+func matchPodFailurePolicy(
+	taskRoleSpec *ci.TaskRoleSpec, pod *core.Pod) *ci.PodFailurePolicyStatus {
+	if taskRoleSpec == nil {
+		return nil
+	}
+	return taskRoleSpec.PodFailurePolicy.Match(pod)
+}
+
+// shouldRollingUpdateTask checks whether the Task's Preparing or Running
+// TaskAttempt should be completed for the TaskRole Rolling UpdateStrategy, i.e.
+// its Pod was created from an outdated Pod template, and completing it does not
+// exceed the TaskRole MaxUnavailable.
+func (c *FrameworkController) shouldRollingUpdateTask(
+	f *ci.Framework, taskRoleName string, taskIndex int32,
+	taskRoleSpec *ci.TaskRoleSpec, pod *core.Pod) bool {
+	logPfx := fmt.Sprintf("[%v][%v][%v]: shouldRollingUpdateTask: ",
+		f.Key(), taskRoleName, taskIndex)
+
+	if !taskRoleSpec.IsRollingUpdate() {
+		return false
+	}
+
+	// The Pod created before the hash is recorded is not known to be outdated,
+	// so it is kept until the Task is retried.
+	podTemplateHash := ci.GetPodTemplateHashAnnotation(pod)
+	if podTemplateHash == nil {
+		return false
+	}
+	currentPodTemplateHash, err := f.GetTaskPodTemplateHash(taskRoleName, taskIndex)
+	if err != nil {
+		klog.Warningf(logPfx+"Skipped: Failed to get the Pod template hash: %v", err)
+		return false
+	}
+	if *podTemplateHash == currentPodTemplateHash {
+		return false
+	}
+
+	taskStatus := f.TaskStatus(taskRoleName, taskIndex)
+	if taskStatus.State != ci.TaskAttemptRunning {
+		return true
+	}
+
+	// The Tasks which are completed or to be deleted will not become available
+	// again, so they are not counted.
+	unavailableTaskCount := int32(0)
+	for _, ts := range f.TaskRoleStatus(taskRoleName).TaskStatuses {
+		if ts.State != ci.TaskAttemptRunning &&
+			ts.State != ci.TaskCompleted && !ts.DeletionPending {
+			unavailableTaskCount++
+		}
+	}
+	maxUnavailable := taskRoleSpec.GetUpdateMaxUnavailable()
+	if unavailableTaskCount >= maxUnavailable {
+		klog.Infof(logPfx+
+			"Waiting: %v Tasks are unavailable, which reaches MaxUnavailable %v",
+			unavailableTaskCount, maxUnavailable)
+		return false
+	}
+	return true
+}
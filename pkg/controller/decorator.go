@@ -0,0 +1,100 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	core "k8s.io/api/core/v1"
+	"sync"
+)
+
+// PodDecorator mutates every Pod created by FrameworkController, such as to
+// inject the site-specific labels, environment variables, volumes or sidecars
+// centrally, instead of patching the controller for each mutation.
+// Downstream builds can compile in custom PodDecorators by
+// RegisterPodDecorator in their init(), and then enable them by
+// Config.PodDecorators, which are invoked in the configured order.
+type PodDecorator interface {
+	// It is invoked right before the Pod is created, i.e. after all the
+	// built-in mutations, so it should not have side effects, since the Pod may
+	// be failed to create and then decorated again.
+	// If it returns an error, the Pod is considered as invalid and will never be
+	// created, such as the TaskAttempt is completed with
+	// CompletionCodePodSpecPermanentError.
+	Decorate(dc *PodDecorateContext) error
+}
+
+type PodDecorateContext struct {
+	Framework *ci.Framework
+	// Empty if it is a hook Pod.
+	TaskRoleName string
+	// Only valid if it is a Task Pod.
+	TaskIndex int32
+	// Empty if it is a Task Pod.
+	HookName string
+
+	// The Pod to be decorated in place.
+	Pod *core.Pod
+}
+
+var podDecorators = &sync.Map{}
+
+// It panics if the name is already registered.
+func RegisterPodDecorator(name string, decorator PodDecorator) {
+	if _, loaded := podDecorators.LoadOrStore(name, decorator); loaded {
+		panic(fmt.Errorf("PodDecorator %v is already registered", name))
+	}
+}
+
+// Return nil if the name is not registered.
+func GetPodDecorator(name string) PodDecorator {
+	if decorator, ok := podDecorators.Load(name); ok {
+		return decorator.(PodDecorator)
+	}
+	return nil
+}
+
+// It panics if any PodDecorator is not registered.
+func NewPodDecorators(names []string) []PodDecorator {
+	decorators := []PodDecorator{}
+	for _, name := range names {
+		decorator := GetPodDecorator(name)
+		if decorator == nil {
+			panic(fmt.Errorf("PodDecorator %v is not registered", name))
+		}
+		decorators = append(decorators, decorator)
+	}
+	return decorators
+}
+
+// Apply the enabled PodDecorators to the Pod in order.
+func (c *FrameworkController) decoratePod(dc *PodDecorateContext) error {
+	for i, decorator := range c.podDecorators {
+		if err := decorator.Decorate(dc); err != nil {
+			return fmt.Errorf("Failed to decorate Pod %v by PodDecorator %v: %v",
+				dc.Pod.Name, c.config().PodDecorators[i], err)
+		}
+	}
+	return nil
+}
@@ -0,0 +1,410 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"bufio"
+	"bytes"
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"io/ioutil"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"net"
+	"net/http"
+	"strings"
+	"time"
+)
+
+const (
+	CloudEventSpecVersion          = "1.0"
+	EventTypeFrameworkTransitioned = "com.microsoft.frameworkcontroller.framework.transitioned"
+	EventTypeTaskTransitioned      = "com.microsoft.frameworkcontroller.task.transitioned"
+)
+
+// CloudEvent in the structured JSON format.
+// See https://github.com/cloudevents/spec/blob/v1.0/json-format.md
+type CloudEvent struct {
+	SpecVersion     string              `json:"specversion"`
+	ID              string              `json:"id"`
+	Source          string              `json:"source"`
+	Type            string              `json:"type"`
+	Subject         string              `json:"subject"`
+	Time            meta.Time           `json:"time"`
+	DataContentType string              `json:"datacontenttype"`
+	Data            StateTransitionData `json:"data"`
+}
+
+type StateTransitionData struct {
+	FrameworkNamespace string    `json:"frameworkNamespace"`
+	FrameworkName      string    `json:"frameworkName"`
+	FrameworkUID       types.UID `json:"frameworkUID"`
+	FrameworkAttemptID int32     `json:"frameworkAttemptID"`
+
+	// Only for the Task state transition.
+	TaskRoleName  string `json:"taskRoleName,omitempty"`
+	TaskIndex     *int32 `json:"taskIndex,omitempty"`
+	TaskAttemptID *int32 `json:"taskAttemptID,omitempty"`
+
+	// SrcState is empty if the Framework or the Task is just created.
+	SrcState string `json:"srcState"`
+	DstState string `json:"dstState"`
+
+	// Only for the completed states.
+	CompletionStatus *ci.CompletionStatus `json:"completionStatus,omitempty"`
+}
+
+// Generate the persisted Framework and Task state transitions from oldF to newF.
+// The intermediate states which are not persisted are skipped.
+func NewStateTransitionEvents(oldF *ci.Framework, newF *ci.Framework) []*CloudEvent {
+	if newF.Status == nil {
+		return nil
+	}
+
+	source := fmt.Sprintf("/apis/%v/%v/namespaces/%v/%v/%v",
+		ci.GroupName, ci.Version, newF.Namespace, ci.FrameworkPlural, newF.Name)
+	newEvent := func(
+		eventType string, id string, subject string, t meta.Time,
+		data StateTransitionData) *CloudEvent {
+		data.FrameworkNamespace = newF.Namespace
+		data.FrameworkName = newF.Name
+		data.FrameworkUID = newF.UID
+		data.FrameworkAttemptID = newF.FrameworkAttemptID()
+		return &CloudEvent{
+			SpecVersion:     CloudEventSpecVersion,
+			ID:              id,
+			Source:          source,
+			Type:            eventType,
+			Subject:         subject,
+			Time:            t,
+			DataContentType: "application/json",
+			Data:            data,
+		}
+	}
+
+	events := []*CloudEvent{}
+	sameAttempt := oldF.Status != nil &&
+		oldF.FrameworkAttemptID() == newF.FrameworkAttemptID()
+
+	srcState := ""
+	if sameAttempt {
+		srcState = string(oldF.Status.State)
+	}
+	if srcState != string(newF.Status.State) {
+		data := StateTransitionData{
+			SrcState: srcState,
+			DstState: string(newF.Status.State),
+		}
+		if newF.Status.AttemptStatus.CompletionStatus != nil {
+			data.CompletionStatus =
+				newF.Status.AttemptStatus.CompletionStatus.CompletionStatus
+		}
+		events = append(events, newEvent(EventTypeFrameworkTransitioned,
+			fmt.Sprintf("%v-%v-%v", newF.UID, newF.FrameworkAttemptID(), newF.Status.State),
+			newF.Key(), newF.Status.TransitionTime, data))
+	}
+
+	for _, taskRoleStatus := range newF.TaskRoleStatuses() {
+		taskRoleName := taskRoleStatus.Name
+		for _, taskStatus := range taskRoleStatus.TaskStatuses {
+			taskIndex := taskStatus.Index
+			taskAttemptID := taskStatus.TaskAttemptID()
+
+			srcState := ""
+			if sameAttempt {
+				oldTaskStatus := oldF.GetTaskStatus(taskRoleName, taskIndex)
+				if oldTaskStatus != nil && oldTaskStatus.TaskAttemptID() == taskAttemptID {
+					srcState = string(oldTaskStatus.State)
+				}
+			}
+			if srcState == string(taskStatus.State) {
+				continue
+			}
+
+			data := StateTransitionData{
+				TaskRoleName:  taskRoleName,
+				TaskIndex:     common.PtrInt32(taskIndex),
+				TaskAttemptID: common.PtrInt32(taskAttemptID),
+				SrcState:      srcState,
+				DstState:      string(taskStatus.State),
+			}
+			if taskStatus.AttemptStatus.CompletionStatus != nil {
+				data.CompletionStatus =
+					taskStatus.AttemptStatus.CompletionStatus.CompletionStatus
+			}
+			events = append(events, newEvent(EventTypeTaskTransitioned,
+				fmt.Sprintf("%v-%v-%v-%v-%v-%v",
+					newF.UID, newF.FrameworkAttemptID(),
+					taskRoleName, taskIndex, taskAttemptID, taskStatus.State),
+				fmt.Sprintf("%v/%v/%v", newF.Key(), taskRoleName, taskIndex),
+				taskStatus.TransitionTime, data))
+		}
+	}
+
+	return events
+}
+
+// EventSink publishes the CloudEvents asynchronously and best effort.
+// See EventSinkConfig.
+type EventSink struct {
+	publisher eventPublisher
+	eventCh   chan *CloudEvent
+}
+
+type eventPublisher interface {
+	// It should be able to recover from previous failures.
+	Publish(events []*CloudEvent) error
+	Close()
+}
+
+// Return nil if the EventSink is disabled.
+func NewEventSink(esConfig *ci.EventSinkConfig) *EventSink {
+	timeout := common.SecToDuration(esConfig.TimeoutSec)
+	var publisher eventPublisher
+	switch *esConfig.Type {
+	case ci.EventSinkNATS:
+		publisher = &natsPublisher{
+			address: *esConfig.NATSAddress,
+			subject: *esConfig.NATSSubject,
+			timeout: timeout,
+		}
+	case ci.EventSinkKafkaRESTProxy:
+		publisher = &kafkaRESTProxyPublisher{
+			client: &http.Client{Timeout: timeout},
+			url: strings.TrimSuffix(*esConfig.KafkaRESTProxyURL, "/") +
+				"/topics/" + *esConfig.KafkaTopic,
+		}
+	default:
+		return nil
+	}
+
+	return &EventSink{
+		publisher: publisher,
+		eventCh:   make(chan *CloudEvent, *esConfig.BufferSize),
+	}
+}
+
+func (s *EventSink) Run(stopCh <-chan struct{}) {
+	defer s.publisher.Close()
+
+	for {
+		var events []*CloudEvent
+		select {
+		case <-stopCh:
+			return
+		case event := <-s.eventCh:
+			events = append(events, event)
+		}
+
+		// Batch all the currently buffered events.
+	batch:
+		for {
+			select {
+			case event := <-s.eventCh:
+				events = append(events, event)
+			default:
+				break batch
+			}
+		}
+
+		err := s.publisher.Publish(events)
+		if err != nil {
+			klog.Warningf("EventSink: Dropped %v events: %v", len(events), err)
+		}
+	}
+}
+
+// It never blocks, and drops the events if the buffer is full.
+func (s *EventSink) Enqueue(events []*CloudEvent) {
+	for i, event := range events {
+		select {
+		case s.eventCh <- event:
+		default:
+			klog.Warningf("EventSink: Dropped %v events: Buffer is full",
+				len(events)-i)
+			return
+		}
+	}
+}
+
+// A minimal publisher based on the NATS client protocol, so that no NATS
+// client library is needed.
+// See https://docs.nats.io/reference/reference-protocols/nats-protocol
+type natsPublisher struct {
+	address string
+	subject string
+	timeout time.Duration
+
+	conn   net.Conn
+	reader *bufio.Reader
+}
+
+func (p *natsPublisher) Publish(events []*CloudEvent) error {
+	err := p.publish(events)
+	if err != nil {
+		// The connection may be closed by the server, such as idle timeout, so
+		// reconnect and retry once.
+		p.Close()
+		err = p.publish(events)
+		if err != nil {
+			p.Close()
+		}
+	}
+	return err
+}
+
+func (p *natsPublisher) publish(events []*CloudEvent) error {
+	if p.conn == nil {
+		err := p.connect()
+		if err != nil {
+			return err
+		}
+	}
+
+	err := p.conn.SetDeadline(time.Now().Add(p.timeout))
+	if err != nil {
+		return err
+	}
+
+	buf := &bytes.Buffer{}
+	for _, event := range events {
+		payload := common.ToJson(event)
+		fmt.Fprintf(buf, "PUB %v %v\r\n%v\r\n", p.subject, len(payload), payload)
+	}
+	// Flush with a PING, so the PONG confirms all above PUBs are processed.
+	buf.WriteString("PING\r\n")
+	_, err = p.conn.Write(buf.Bytes())
+	if err != nil {
+		return err
+	}
+
+	for {
+		line, err := p.reader.ReadString('\n')
+		if err != nil {
+			return err
+		}
+		line = strings.TrimSpace(line)
+		switch {
+		case line == "PONG":
+			return nil
+		case line == "PING":
+			_, err = p.conn.Write([]byte("PONG\r\n"))
+			if err != nil {
+				return err
+			}
+		case strings.HasPrefix(line, "-ERR"):
+			return fmt.Errorf("NATS server returns error: %v", line)
+		}
+	}
+}
+
+func (p *natsPublisher) connect() error {
+	conn, err := net.DialTimeout("tcp", p.address, p.timeout)
+	if err != nil {
+		return err
+	}
+	p.conn = conn
+	p.reader = bufio.NewReader(conn)
+
+	err = conn.SetDeadline(time.Now().Add(p.timeout))
+	if err != nil {
+		return err
+	}
+
+	// The server sends INFO first once connected.
+	line, err := p.reader.ReadString('\n')
+	if err != nil {
+		return err
+	}
+	if !strings.HasPrefix(line, "INFO") {
+		return fmt.Errorf("NATS server returns unexpected greeting: %v", line)
+	}
+
+	_, err = conn.Write([]byte(fmt.Sprintf(
+		"CONNECT {\"verbose\":false,\"pedantic\":false,\"name\":%v}\r\n",
+		common.Quote(ci.ComponentName))))
+	return err
+}
+
+func (p *natsPublisher) Close() {
+	if p.conn != nil {
+		p.conn.Close()
+		p.conn = nil
+		p.reader = nil
+	}
+}
+
+// Produce to Kafka through the Confluent Kafka REST Proxy v2 API, so that no
+// Kafka client library is needed.
+// See https://docs.confluent.io/platform/current/kafka-rest/api.html
+type kafkaRESTProxyPublisher struct {
+	client *http.Client
+	url    string
+}
+
+type kafkaRecords struct {
+	Records []kafkaRecord `json:"records"`
+}
+
+type kafkaRecord struct {
+	// Records with the same key, i.e. the same Framework, go to the same
+	// partition, so that they are ordered.
+	Key   string      `json:"key"`
+	Value *CloudEvent `json:"value"`
+}
+
+func (p *kafkaRESTProxyPublisher) Publish(events []*CloudEvent) error {
+	records := kafkaRecords{Records: []kafkaRecord{}}
+	for _, event := range events {
+		records.Records = append(records.Records, kafkaRecord{
+			Key:   event.Data.FrameworkNamespace + "/" + event.Data.FrameworkName,
+			Value: event,
+		})
+	}
+
+	req, err := http.NewRequest(
+		http.MethodPost, p.url, bytes.NewReader([]byte(common.ToJson(records))))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/vnd.kafka.json.v2+json")
+	req.Header.Set("Accept", "application/vnd.kafka.v2+json")
+
+	resp, err := p.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("Kafka REST Proxy returns unexpected response: %v: %v",
+			resp.Status, string(body))
+	}
+	return nil
+}
+
+func (p *kafkaRESTProxyPublisher) Close() {
+}
@@ -0,0 +1,141 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"testing"
+)
+
+func newEventFramework(
+	attemptID int32, state ci.FrameworkState,
+	taskAttemptIDs []int32, taskStates []ci.TaskState) *ci.Framework {
+	taskStatuses := []*ci.TaskStatus{}
+	for i := range taskStates {
+		taskStatuses = append(taskStatuses, &ci.TaskStatus{
+			Index:         int32(i),
+			State:         taskStates[i],
+			AttemptStatus: ci.TaskAttemptStatus{ID: taskAttemptIDs[i]},
+		})
+	}
+	return &ci.Framework{
+		ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: "f", UID: "uid"},
+		Status: &ci.FrameworkStatus{
+			State: state,
+			AttemptStatus: ci.FrameworkAttemptStatus{
+				ID: attemptID,
+				TaskRoleStatuses: []*ci.TaskRoleStatus{
+					{Name: "worker", TaskStatuses: taskStatuses},
+				},
+			},
+		},
+	}
+}
+
+func TestNewStateTransitionEvents(t *testing.T) {
+	running := newEventFramework(0, ci.FrameworkAttemptRunning,
+		[]int32{0, 0}, []ci.TaskState{ci.TaskAttemptRunning, ci.TaskAttemptRunning})
+
+	cases := []struct {
+		name string
+		oldF *ci.Framework
+		newF *ci.Framework
+		// The event IDs in order.
+		want []string
+	}{
+		{"NoStatus", &ci.Framework{}, &ci.Framework{}, nil},
+		{"Created", &ci.Framework{},
+			newEventFramework(0, ci.FrameworkAttemptCreationPending,
+				[]int32{}, []ci.TaskState{}),
+			[]string{"uid-0-AttemptCreationPending"}},
+		{"NotChanged", running, running, []string{}},
+		{"TaskTransitioned", running,
+			newEventFramework(0, ci.FrameworkAttemptRunning,
+				[]int32{0, 0}, []ci.TaskState{ci.TaskAttemptRunning, ci.TaskCompleted}),
+			[]string{"uid-0-worker-1-0-Completed"}},
+		// The new TaskAttempt transitions from the empty state.
+		{"TaskRetried", running,
+			newEventFramework(0, ci.FrameworkAttemptRunning,
+				[]int32{0, 1}, []ci.TaskState{ci.TaskAttemptRunning, ci.TaskAttemptRunning}),
+			[]string{"uid-0-worker-1-1-AttemptRunning"}},
+		// All the states of the new FrameworkAttempt transition from the empty
+		// state.
+		{"FrameworkRetried", running,
+			newEventFramework(1, ci.FrameworkAttemptRunning,
+				[]int32{0, 0}, []ci.TaskState{ci.TaskAttemptRunning, ci.TaskAttemptRunning}),
+			[]string{
+				"uid-1-AttemptRunning",
+				"uid-1-worker-0-0-AttemptRunning",
+				"uid-1-worker-1-0-AttemptRunning",
+			}},
+	}
+
+	for _, c := range cases {
+		events := NewStateTransitionEvents(c.oldF, c.newF)
+		var got []string
+		if events != nil {
+			got = []string{}
+			for _, event := range events {
+				got = append(got, event.ID)
+			}
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%v: got %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestNewStateTransitionEventsData(t *testing.T) {
+	oldF := newEventFramework(0, ci.FrameworkAttemptRunning,
+		[]int32{0}, []ci.TaskState{ci.TaskAttemptRunning})
+	newF := newEventFramework(0, ci.FrameworkAttemptRunning,
+		[]int32{0}, []ci.TaskState{ci.TaskCompleted})
+	cs := &ci.CompletionStatus{Code: 1, Phrase: "Failed"}
+	newF.TaskStatus("worker", 0).AttemptStatus.CompletionStatus =
+		&ci.TaskAttemptCompletionStatus{CompletionStatus: cs}
+
+	events := NewStateTransitionEvents(oldF, newF)
+	if len(events) != 1 {
+		t.Fatalf("got %v events, want 1", len(events))
+	}
+	event := events[0]
+	if event.Type != EventTypeTaskTransitioned ||
+		event.Subject != "default/f/worker/0" ||
+		event.Source != "/apis/"+ci.GroupName+"/"+ci.Version+
+			"/namespaces/default/"+ci.FrameworkPlural+"/f" {
+		t.Errorf("got event %+v", *event)
+	}
+
+	data := event.Data
+	if data.FrameworkNamespace != "default" || data.FrameworkName != "f" ||
+		data.FrameworkUID != "uid" || data.FrameworkAttemptID != 0 ||
+		data.TaskRoleName != "worker" || *data.TaskIndex != 0 ||
+		*data.TaskAttemptID != 0 ||
+		data.SrcState != string(ci.TaskAttemptRunning) ||
+		data.DstState != string(ci.TaskCompleted) ||
+		data.CompletionStatus != cs {
+		t.Errorf("got event data %+v", data)
+	}
+}
@@ -0,0 +1,147 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+)
+
+// Get the message of which Framework should be dispatched before the given
+// one in the weighted fair share order, or empty if the given one is the next.
+// The given Framework should already fit into the schedulable capacity.
+// See Config FairShare.
+func (c *FrameworkController) getFairShareWaitingMessage(
+	f *ci.Framework) (string, error) {
+	nodes, err := c.getSchedulableNodes()
+	if err != nil {
+		return "", err
+	}
+	allocatable := core.ResourceList{}
+	for _, node := range nodes {
+		addResourceList(allocatable, node.Status.Allocatable)
+	}
+
+	fs, err := c.fLister.List(labels.Everything())
+	if err != nil {
+		return "", fmt.Errorf(
+			"Failed to list Frameworks from local cache: %v", err)
+	}
+
+	// Group -> Total resource requests of started and not completed Frameworks
+	groupUsed := map[string]core.ResourceList{}
+	// Group -> Waiting Frameworks
+	groupWaiting := map[string][]*ci.Framework{
+		c.getFairShareGroup(f): {f},
+	}
+	for _, localF := range fs {
+		// The local cached one may be outdated, so take the given one instead.
+		if localF.UID == f.UID || localF.Status == nil ||
+			isFrameworkCompleted(localF) || isFrameworkSuspended(localF) ||
+			localF.Status.State == ci.FrameworkQueuing {
+			continue
+		}
+
+		group := c.getFairShareGroup(localF)
+		if localF.Status.State == ci.FrameworkAttemptCreationPending {
+			if isFrameworkWaitingCapacity(localF) &&
+				fitsResourceList(localF.GetResourceRequests(), allocatable) {
+				groupWaiting[group] = append(groupWaiting[group], localF)
+			}
+			continue
+		}
+
+		if groupUsed[group] == nil {
+			groupUsed[group] = core.ResourceList{}
+		}
+		addResourceList(groupUsed[group], localF.GetResourceRequests())
+	}
+
+	var nextGroup string
+	var nextF *ci.Framework
+	var nextShare float64
+	for group, waitingFs := range groupWaiting {
+		sortQueuingFrameworks(waitingFs, ci.QueueOrderFIFO)
+		headF := waitingFs[0]
+		share := c.getWeightedDominantShare(group, groupUsed[group], allocatable)
+		if nextF == nil || share < nextShare ||
+			(share == nextShare && isFrameworkCreatedBefore(headF, nextF)) {
+			nextGroup, nextF, nextShare = group, headF, share
+		}
+	}
+
+	if nextF.UID == f.UID {
+		return "", nil
+	}
+	return fmt.Sprintf(
+		"Waiting Framework %v in group [%v] with lower weighted dominant share "+
+			"%.4f to be dispatched first", nextF.Key(), nextGroup, nextShare), nil
+}
+
+func (c *FrameworkController) getFairShareGroup(f *ci.Framework) string {
+	if *c.config().FairShare.GroupBy == ci.FairShareGroupByQueue {
+		return f.Spec.Queue
+	}
+	return f.Namespace
+}
+
+// The max ratio of the used resources to the allocatable resources, divided by
+// the group weight.
+func (c *FrameworkController) getWeightedDominantShare(
+	group string, used core.ResourceList, allocatable core.ResourceList) float64 {
+	share := float64(0)
+	for name, quantity := range used {
+		total := allocatable[name]
+		if total.IsZero() {
+			continue
+		}
+		ratio := float64(quantity.MilliValue()) / float64(total.MilliValue())
+		if ratio > share {
+			share = ratio
+		}
+	}
+
+	weight := int32(1)
+	if w, ok := c.config().FairShare.Weights[group]; ok {
+		weight = w
+	}
+	return share / float64(weight)
+}
+
+// The Framework which has been found to be unable to fit by the CapacityCheck,
+// and has not yet created its FrameworkAttempt.
+func isFrameworkWaitingCapacity(f *ci.Framework) bool {
+	if f.DeletionTimestamp != nil {
+		return false
+	}
+	condition := f.GetFrameworkCondition(ci.FrameworkCapacitySufficient)
+	return condition != nil && condition.Status == core.ConditionFalse
+}
+
+func isFrameworkCreatedBefore(f1 *ci.Framework, f2 *ci.Framework) bool {
+	if !f1.CreationTimestamp.Equal(&f2.CreationTimestamp) {
+		return f1.CreationTimestamp.Before(&f2.CreationTimestamp)
+	}
+	return f1.Key() < f2.Key()
+}
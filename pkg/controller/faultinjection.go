@@ -0,0 +1,144 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"github.com/microsoft/frameworkcontroller/pkg/internal"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeClient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+	"math/rand"
+)
+
+// CacheDropper randomly drops the Pods and ConfigMaps from the local cache, and
+// restores them from the ApiServer later, to simulate the stale local cache.
+// See FaultInjectionConfig.
+type CacheDropper struct {
+	kClient    kubeClient.Interface
+	podIndexer cache.Indexer
+	cmIndexer  cache.Indexer
+	fiConfig   *ci.FaultInjectionConfig
+	// nil if the LocalCacheObjectTransform is disabled.
+	transformer *internal.ObjectTransformer
+
+	// The keys dropped in last round, which are restored in next round.
+	droppedPodKeys []string
+	droppedCMKeys  []string
+}
+
+func NewCacheDropper(
+	kClient kubeClient.Interface,
+	podIndexer cache.Indexer,
+	cmIndexer cache.Indexer,
+	fiConfig *ci.FaultInjectionConfig,
+	transformer *internal.ObjectTransformer) *CacheDropper {
+	return &CacheDropper{
+		kClient:     kClient,
+		podIndexer:  podIndexer,
+		cmIndexer:   cmIndexer,
+		fiConfig:    fiConfig,
+		transformer: transformer,
+	}
+}
+
+func (d *CacheDropper) Run(stopCh <-chan struct{}) {
+	klog.Warningf("Running FaultInjection CacheDropper")
+	wait.Until(d.sync,
+		common.SecToDuration(d.fiConfig.CacheDropIntervalSec), stopCh)
+}
+
+func (d *CacheDropper) sync() {
+	d.droppedPodKeys = d.restore(d.podIndexer, d.droppedPodKeys,
+		func(namespace, name string) (interface{}, error) {
+			pod, err := d.kClient.CoreV1().Pods(namespace).Get(name, meta.GetOptions{})
+			if err == nil && d.transformer != nil {
+				d.transformer.TransformPod(pod)
+			}
+			return pod, err
+		})
+	d.droppedCMKeys = d.restore(d.cmIndexer, d.droppedCMKeys,
+		func(namespace, name string) (interface{}, error) {
+			cm, err := d.kClient.CoreV1().ConfigMaps(namespace).Get(name, meta.GetOptions{})
+			if err == nil && d.transformer != nil {
+				d.transformer.TransformConfigMap(cm)
+			}
+			return cm, err
+		})
+
+	d.droppedPodKeys = append(d.droppedPodKeys, d.drop(d.podIndexer)...)
+	d.droppedCMKeys = append(d.droppedCMKeys, d.drop(d.cmIndexer)...)
+	klog.Warningf("FaultInjection: Dropped %v Pods and %v ConfigMaps from local cache",
+		len(d.droppedPodKeys), len(d.droppedCMKeys))
+}
+
+func (d *CacheDropper) drop(indexer cache.Indexer) (droppedKeys []string) {
+	for _, obj := range indexer.List() {
+		if rand.Int31n(100) >= *d.fiConfig.CacheDropPercent {
+			continue
+		}
+
+		key, err := cache.MetaNamespaceKeyFunc(obj)
+		if err != nil {
+			continue
+		}
+		if err := indexer.Delete(obj); err == nil {
+			droppedKeys = append(droppedKeys, key)
+		}
+	}
+	return droppedKeys
+}
+
+// Restore the dropped objects which are still absent in the local cache, i.e.
+// the informer has not delivered any newer event for them, and return the keys
+// which failed to be restored.
+func (d *CacheDropper) restore(
+	indexer cache.Indexer, droppedKeys []string,
+	get func(namespace, name string) (interface{}, error)) (failedKeys []string) {
+	for _, key := range droppedKeys {
+		if _, exists, _ := indexer.GetByKey(key); exists {
+			continue
+		}
+
+		namespace, name, err := cache.SplitMetaNamespaceKey(key)
+		if err != nil {
+			continue
+		}
+		obj, err := get(namespace, name)
+		if err != nil {
+			if !apiErrors.IsNotFound(err) {
+				klog.Warningf("FaultInjection: Failed to restore %v: %v", key, err)
+				failedKeys = append(failedKeys, key)
+			}
+			continue
+		}
+		if _, exists, _ := indexer.GetByKey(key); !exists {
+			indexer.Add(obj)
+		}
+	}
+	return failedKeys
+}
@@ -0,0 +1,532 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	frameworkClient "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned"
+	frameworkInformer "github.com/microsoft/frameworkcontroller/pkg/client/informers/externalversions/frameworkcontroller/v1"
+	frameworkLister "github.com/microsoft/frameworkcontroller/pkg/client/listers/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"github.com/microsoft/frameworkcontroller/pkg/internal"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+	"reflect"
+	"time"
+)
+
+// FrameworkGroupController creates the member Frameworks of FrameworkGroups
+// once their dependencies are satisfied, and tracks the FrameworkGroup state.
+// See FrameworkGroup.
+type FrameworkGroupController struct {
+	fClient frameworkClient.Interface
+
+	gInformer cache.SharedIndexInformer
+	fInformer cache.SharedIndexInformer
+	gLister   frameworkLister.FrameworkGroupLister
+	fLister   frameworkLister.FrameworkLister
+
+	// FrameworkGroup Key -> FrameworkGroup
+	gQueue workqueue.RateLimitingInterface
+
+	shardManager *ShardManager
+	workerNumber int32
+}
+
+func NewFrameworkGroupController(
+	fClient frameworkClient.Interface,
+	gListerInformer frameworkInformer.FrameworkGroupInformer,
+	fInformer cache.SharedIndexInformer,
+	fLister frameworkLister.FrameworkLister,
+	shardManager *ShardManager,
+	workerNumber int32) *FrameworkGroupController {
+	c := &FrameworkGroupController{
+		fClient:      fClient,
+		gInformer:    gListerInformer.Informer(),
+		fInformer:    fInformer,
+		gLister:      gListerInformer.Lister(),
+		fLister:      fLister,
+		gQueue:       workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		shardManager: shardManager,
+		workerNumber: workerNumber,
+	}
+
+	c.gInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueFrameworkGroupObj(internal.ToFrameworkGroup(obj))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.enqueueFrameworkGroupObj(internal.ToFrameworkGroup(newObj))
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.enqueueFrameworkGroupObj(internal.ToFrameworkGroup(obj))
+		},
+	})
+
+	// Only the member Framework creation, completion and deletion impact the
+	// FrameworkGroup.
+	c.fInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueFrameworkOwner(internal.ToFramework(obj))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldF := internal.ToFramework(oldObj)
+			newF := internal.ToFramework(newObj)
+			if isFrameworkCompleted(oldF) != isFrameworkCompleted(newF) {
+				c.enqueueFrameworkOwner(newF)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.enqueueFrameworkOwner(internal.ToFramework(obj))
+		},
+	})
+
+	return c
+}
+
+func (c *FrameworkGroupController) enqueueFrameworkGroupObj(g *ci.FrameworkGroup) {
+	if !c.shardManager.Owns(g) {
+		return
+	}
+	c.gQueue.Add(g.Key())
+}
+
+func (c *FrameworkGroupController) enqueueFrameworkOwner(f *ci.Framework) {
+	owner := meta.GetControllerOf(f)
+	if owner == nil || owner.Kind != ci.FrameworkGroupKind {
+		return
+	}
+
+	g, err := c.gLister.FrameworkGroups(f.Namespace).Get(owner.Name)
+	if err != nil || g.UID != owner.UID {
+		// The owner has been deleted, and the Framework will be garbage collected.
+		return
+	}
+	c.enqueueFrameworkGroupObj(g)
+}
+
+// Enqueue all FrameworkGroups, so that the newly owned ones are synced after
+// the shards are rebalanced.
+func (c *FrameworkGroupController) Rebalance() {
+	gs, err := c.gLister.List(labels.Everything())
+	if err != nil {
+		klog.Warningf("FrameworkGroup: Rebalance: "+
+			"Failed to list FrameworkGroups from local cache: %v", err)
+		return
+	}
+	for _, g := range gs {
+		c.enqueueFrameworkGroupObj(g)
+	}
+}
+
+// It should be invoked after the Framework Informer is started.
+func (c *FrameworkGroupController) Run(stopCh <-chan struct{}) {
+	defer c.gQueue.ShutDown()
+
+	go c.gInformer.Run(stopCh)
+	if !cache.WaitForCacheSync(
+		stopCh,
+		c.gInformer.HasSynced,
+		c.fInformer.HasSynced) {
+		panic(fmt.Errorf("Failed to WaitForCacheSync for FrameworkGroup"))
+	}
+
+	klog.Infof("Running FrameworkGroupController with %v workers",
+		c.workerNumber)
+	for i := int32(0); i < c.workerNumber; i++ {
+		go wait.Until(func() {
+			for c.processNextWorkItem() {
+			}
+		}, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (c *FrameworkGroupController) processNextWorkItem() bool {
+	key, quit := c.gQueue.Get()
+	if quit {
+		return false
+	}
+	defer c.gQueue.Done(key)
+
+	err := c.syncFrameworkGroup(key.(string))
+	if err == nil {
+		c.gQueue.Forget(key)
+	} else {
+		c.gQueue.AddRateLimited(key)
+	}
+
+	return true
+}
+
+// It should not be invoked concurrently with the same key.
+//
+// Return error only for Platform Transient Error, so that the key
+// can be enqueued again after rate limited delay.
+func (c *FrameworkGroupController) syncFrameworkGroup(
+	key string) (returnedErr error) {
+	startTime := time.Now()
+	logPfx := fmt.Sprintf("[%v]: syncFrameworkGroup: ", key)
+	klog.Infof(logPfx + "Started")
+	defer func() {
+		if returnedErr != nil {
+			klog.Warning(logPfx + returnedErr.Error())
+			klog.Warning(logPfx +
+				"Failed to due to Platform Transient Error. " +
+				"Will enqueue it again after rate limited delay")
+		}
+		klog.Infof(logPfx+"Completed: Duration %v", time.Since(startTime))
+	}()
+
+	gNamespace, gName := ci.SplitFrameworkKey(key)
+	localG, err := c.gLister.FrameworkGroups(gNamespace).Get(gName)
+	if err != nil {
+		if apiErrors.IsNotFound(err) {
+			// GarbageCollectionController will handle the dependent object
+			// deletion according to the ownerReferences.
+			klog.Infof(logPfx+
+				"Skipped: FrameworkGroup cannot be found in local cache: %v", err)
+			return nil
+		} else {
+			return fmt.Errorf(
+				"Failed: FrameworkGroup cannot be got from local cache: %v", err)
+		}
+	}
+
+	g := localG.DeepCopy()
+	if !c.shardManager.Owns(g) {
+		klog.Infof(logPfx + "Skipped: FrameworkGroup does not belong to current shard")
+		return nil
+	}
+	if g.DeletionTimestamp != nil {
+		klog.Infof(logPfx + "Skipped: FrameworkGroup is deleting")
+		return nil
+	}
+	if g.Status != nil && g.Status.IsCompleted() {
+		klog.Infof(logPfx+"Skipped: FrameworkGroup is already %v", g.Status.State)
+		return nil
+	}
+
+	var remoteStatus *ci.FrameworkGroupStatus
+	if g.Status == nil {
+		g.Status = g.NewFrameworkGroupStatus()
+		if err := validateFrameworkGroupSpec(&g.Spec); err != nil {
+			// User Error will not be fixed by retry.
+			klog.Warningf(logPfx+"FrameworkGroup Spec is invalid: %v", err)
+			c.completeFrameworkGroup(g, ci.FrameworkGroupFailed,
+				fmt.Sprintf("FrameworkGroup Spec is invalid: %v", err))
+		}
+	} else {
+		remoteStatus = g.Status.DeepCopy()
+	}
+
+	var syncErr error
+	if !g.Status.IsCompleted() {
+		syncErr = c.syncMemberStatuses(g)
+		c.syncFrameworkGroupState(g)
+	}
+
+	if !reflect.DeepEqual(remoteStatus, g.Status) {
+		_, updateErr := c.fClient.FrameworkcontrollerV1().FrameworkGroups(
+			g.Namespace).Update(g)
+		if updateErr != nil {
+			// The created Frameworks will be adopted in next sync, since they are
+			// controlled by the FrameworkGroup.
+			return fmt.Errorf(
+				"Failed to update FrameworkGroup.Status: %v", updateErr)
+		}
+		klog.Infof(logPfx+"Succeeded to update FrameworkGroup.Status: State %v",
+			g.Status.State)
+	}
+
+	return syncErr
+}
+
+// The MemberNames are unique, all the dependencies are on other existing
+// members, and there is no cyclic dependency.
+func validateFrameworkGroupSpec(spec *ci.FrameworkGroupSpec) error {
+	members := map[string]*ci.FrameworkGroupMemberSpec{}
+	for i := range spec.Members {
+		member := &spec.Members[i]
+		if _, ok := members[member.Name]; ok {
+			return fmt.Errorf("MemberName %v is duplicated", member.Name)
+		}
+		members[member.Name] = member
+	}
+
+	for _, member := range spec.Members {
+		for _, dep := range member.DependsOn {
+			if _, ok := members[dep.Name]; !ok {
+				return fmt.Errorf(
+					"Member %v depends on a nonexistent member %v",
+					member.Name, dep.Name)
+			}
+		}
+	}
+
+	// Kahn's algorithm: the dependencies are acyclic iff all members can be
+	// sorted topologically.
+	pendingDepNumbers := map[string]int{}
+	downstreams := map[string][]string{}
+	for _, member := range spec.Members {
+		pendingDepNumbers[member.Name] = len(member.DependsOn)
+		for _, dep := range member.DependsOn {
+			downstreams[dep.Name] = append(downstreams[dep.Name], member.Name)
+		}
+	}
+	readyNames := []string{}
+	for _, member := range spec.Members {
+		if pendingDepNumbers[member.Name] == 0 {
+			readyNames = append(readyNames, member.Name)
+		}
+	}
+	sortedNumber := 0
+	for len(readyNames) > 0 {
+		name := readyNames[0]
+		readyNames = readyNames[1:]
+		sortedNumber++
+		for _, downstream := range downstreams[name] {
+			pendingDepNumbers[downstream]--
+			if pendingDepNumbers[downstream] == 0 {
+				readyNames = append(readyNames, downstream)
+			}
+		}
+	}
+	if sortedNumber != len(spec.Members) {
+		return fmt.Errorf("Members have cyclic dependencies")
+	}
+
+	return nil
+}
+
+// Transition the member states until no member can be transitioned, so that a
+// Skipped member can skip its downstream members in the same sync.
+func (c *FrameworkGroupController) syncMemberStatuses(g *ci.FrameworkGroup) error {
+	fs, err := c.getMemberFrameworks(g)
+	if err != nil {
+		return err
+	}
+
+	memberStatuses := map[string]*ci.FrameworkGroupMemberStatus{}
+	for i := range g.Status.MemberStatuses {
+		memberStatuses[g.Status.MemberStatuses[i].Name] = &g.Status.MemberStatuses[i]
+	}
+
+	for changed := true; changed; {
+		changed = false
+		for i := range g.Spec.Members {
+			member := &g.Spec.Members[i]
+			memberStatus, ok := memberStatuses[member.Name]
+			if !ok || memberStatus.State.IsCompleted() {
+				continue
+			}
+
+			oldState := memberStatus.State
+			switch memberStatus.State {
+			case ci.MemberWaiting:
+				err = c.syncWaitingMember(g, member, memberStatus, memberStatuses)
+			case ci.MemberCreated:
+				err = c.syncCreatedMember(g, memberStatus, fs)
+			}
+			if err != nil {
+				return err
+			}
+			if memberStatus.State != oldState {
+				changed = true
+			}
+		}
+	}
+
+	return nil
+}
+
+func (c *FrameworkGroupController) getMemberFrameworks(
+	g *ci.FrameworkGroup) (map[string]*ci.Framework, error) {
+	selector := labels.SelectorFromSet(labels.Set{
+		ci.LabelKeyFrameworkGroupName: g.Name})
+	fs, err := c.fLister.Frameworks(g.Namespace).List(selector)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Failed to list Frameworks from local cache: %v", err)
+	}
+
+	controlledFs := map[string]*ci.Framework{}
+	for _, f := range fs {
+		if meta.IsControlledBy(f, g) {
+			controlledFs[f.Name] = f
+		}
+	}
+	return controlledFs, nil
+}
+
+func (c *FrameworkGroupController) syncWaitingMember(
+	g *ci.FrameworkGroup, member *ci.FrameworkGroupMemberSpec,
+	memberStatus *ci.FrameworkGroupMemberStatus,
+	memberStatuses map[string]*ci.FrameworkGroupMemberStatus) error {
+	logPfx := fmt.Sprintf("[%v]: syncWaitingMember: ", g.Key())
+
+	for _, dep := range member.DependsOn {
+		depState := memberStatuses[dep.Name].State
+		if !depState.IsCompleted() {
+			return nil
+		}
+		if !dep.Condition.IsSatisfiedBy(depState) {
+			memberStatus.State = ci.MemberSkipped
+			memberStatus.Diagnostics = fmt.Sprintf(
+				"Dependency on member %v with condition %v is not satisfied: "+
+					"member %v is %v",
+				dep.Name, dep.Condition, dep.Name, depState)
+			klog.Infof(logPfx+"Member %v is skipped: %v",
+				member.Name, memberStatus.Diagnostics)
+			return nil
+		}
+	}
+
+	f := g.NewFramework(member)
+	remoteF, createErr := c.fClient.FrameworkcontrollerV1().Frameworks(
+		f.Namespace).Create(f)
+	if createErr != nil {
+		if !apiErrors.IsAlreadyExists(createErr) {
+			return fmt.Errorf(
+				"Failed to create Framework %v for member %v: %v",
+				f.Name, member.Name, createErr)
+		}
+
+		// The Framework may have been created in previous sync but the
+		// FrameworkGroup.Status was failed to persist.
+		var err error
+		remoteF, err = c.fClient.FrameworkcontrollerV1().Frameworks(
+			f.Namespace).Get(f.Name, meta.GetOptions{})
+		if err != nil {
+			return fmt.Errorf(
+				"Failed to get existing Framework %v for member %v: %v",
+				f.Name, member.Name, err)
+		}
+		if !meta.IsControlledBy(remoteF, g) {
+			memberStatus.State = ci.MemberFailed
+			memberStatus.Diagnostics = fmt.Sprintf(
+				"Framework %v already exists and is not controlled by the "+
+					"FrameworkGroup", f.Name)
+			klog.Warningf(logPfx+"Member %v is failed: %v",
+				member.Name, memberStatus.Diagnostics)
+			return nil
+		}
+	}
+
+	memberStatus.State = ci.MemberCreated
+	memberStatus.FrameworkName = f.Name
+	memberStatus.FrameworkUID = remoteF.UID
+	klog.Infof(logPfx+"Succeeded to create Framework %v for member %v",
+		f.Name, member.Name)
+	return nil
+}
+
+func (c *FrameworkGroupController) syncCreatedMember(
+	g *ci.FrameworkGroup, memberStatus *ci.FrameworkGroupMemberStatus,
+	fs map[string]*ci.Framework) error {
+	logPfx := fmt.Sprintf("[%v]: syncCreatedMember: ", g.Key())
+
+	f, ok := fs[memberStatus.FrameworkName]
+	if !ok || f.UID != memberStatus.FrameworkUID {
+		// The local cache may be outdated, so double check the remote one.
+		remoteF, err := c.fClient.FrameworkcontrollerV1().Frameworks(
+			g.Namespace).Get(memberStatus.FrameworkName, meta.GetOptions{})
+		if err != nil && !apiErrors.IsNotFound(err) {
+			return fmt.Errorf(
+				"Failed to get Framework %v for member %v: %v",
+				memberStatus.FrameworkName, memberStatus.Name, err)
+		}
+		if err == nil && remoteF.UID == memberStatus.FrameworkUID {
+			// Wait for the local cache to catch up.
+			return nil
+		}
+
+		memberStatus.State = ci.MemberFailed
+		memberStatus.Diagnostics = fmt.Sprintf(
+			"Framework %v was deleted before it completed",
+			memberStatus.FrameworkName)
+		klog.Warningf(logPfx+"Member %v is failed: %v",
+			memberStatus.Name, memberStatus.Diagnostics)
+		return nil
+	}
+
+	if !isFrameworkCompleted(f) {
+		return nil
+	}
+	if f.IsSucceeded() {
+		memberStatus.State = ci.MemberSucceeded
+	} else {
+		memberStatus.State = ci.MemberFailed
+		memberStatus.Diagnostics = fmt.Sprintf(
+			"Framework %v is failed: %v", f.Name,
+			f.Status.AttemptStatus.CompletionStatus.Diagnostics)
+	}
+	klog.Infof(logPfx+"Member %v is %v", memberStatus.Name, memberStatus.State)
+	return nil
+}
+
+func (c *FrameworkGroupController) syncFrameworkGroupState(g *ci.FrameworkGroup) {
+	handledMembers := map[string]bool{}
+	for _, member := range g.Spec.Members {
+		for _, dep := range member.DependsOn {
+			if dep.Condition == ci.DependencyFailed ||
+				dep.Condition == ci.DependencyCompleted {
+				handledMembers[dep.Name] = true
+			}
+		}
+	}
+
+	unhandledFailedMembers := []string{}
+	for _, memberStatus := range g.Status.MemberStatuses {
+		if !memberStatus.State.IsCompleted() {
+			return
+		}
+		if memberStatus.State == ci.MemberFailed &&
+			!handledMembers[memberStatus.Name] {
+			unhandledFailedMembers = append(
+				unhandledFailedMembers, memberStatus.Name)
+		}
+	}
+
+	if len(unhandledFailedMembers) == 0 {
+		c.completeFrameworkGroup(g, ci.FrameworkGroupSucceeded, "")
+	} else {
+		c.completeFrameworkGroup(g, ci.FrameworkGroupFailed, fmt.Sprintf(
+			"Members %v are failed", unhandledFailedMembers))
+	}
+}
+
+func (c *FrameworkGroupController) completeFrameworkGroup(
+	g *ci.FrameworkGroup, state ci.FrameworkGroupState, diagnostics string) {
+	g.Status.State = state
+	g.Status.Diagnostics = diagnostics
+	g.Status.CompletionTime = common.PtrNow()
+	klog.Infof("[%v]: FrameworkGroup is %v: %v", g.Key(), state, diagnostics)
+}
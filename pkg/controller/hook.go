@@ -0,0 +1,321 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"bytes"
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"io/ioutil"
+	core "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+	"net/http"
+)
+
+// HookRequest is POSTed to the hook Webhook.
+// See FrameworkHooksSpec.
+type HookRequest struct {
+	FrameworkNamespace string `json:"frameworkNamespace"`
+	FrameworkName      string `json:"frameworkName"`
+	FrameworkAttemptID int32  `json:"frameworkAttemptID"`
+	HookName           string `json:"hookName"`
+	// It is nil for the PreAttempt hook.
+	CompletionStatus *ci.FrameworkAttemptCompletionStatus `json:"completionStatus"`
+}
+
+func callHookWebhook(
+	hook *ci.HookSpec, req *HookRequest) error {
+	url := hook.Webhook.URL
+	errPfx := fmt.Sprintf("Failed to call hook Webhook %v: ", url)
+
+	// The Webhook is called on the sync worker, so its timeout is capped to not
+	// block the worker for long.
+	timeoutSec := hook.TimeoutSec
+	if timeoutSec == nil {
+		timeoutSec = common.PtrInt64(ci.HookWebhookDefaultTimeoutSec)
+	} else if *timeoutSec > ci.HookWebhookMaxTimeoutSec {
+		timeoutSec = common.PtrInt64(ci.HookWebhookMaxTimeoutSec)
+	}
+	client := &http.Client{Timeout: common.SecToDuration(timeoutSec)}
+
+	httpReq, err := http.NewRequest(
+		http.MethodPost, url, bytes.NewReader([]byte(common.ToJson(req))))
+	if err != nil {
+		return fmt.Errorf(errPfx+"%v", err)
+	}
+	httpReq.Header.Set("Content-Type", "application/json")
+
+	resp, err := client.Do(httpReq)
+	if err != nil {
+		return fmt.Errorf(errPfx+"%v", err)
+	}
+	defer resp.Body.Close()
+
+	body, err := ioutil.ReadAll(resp.Body)
+	if err != nil {
+		return fmt.Errorf(errPfx+"%v", err)
+	}
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		return fmt.Errorf(errPfx+"Unexpected response: %v: %v", resp.Status, string(body))
+	}
+	return nil
+}
+
+// Hold the Pods creation until the PreAttempt hook is completed.
+// Return whether the hook is completed, and it is always true if the hook is
+// not specified.
+func (c *FrameworkController) syncPreAttemptHook(
+	f *ci.Framework, cm *core.ConfigMap) (bool, error) {
+	completed, err := c.syncHook(f, cm, ci.HookNamePreAttempt)
+	if err != nil || !completed {
+		return completed, err
+	}
+
+	hook := f.HookSpec(ci.HookNamePreAttempt)
+	hookStatus := f.HookStatus(ci.HookNamePreAttempt)
+	if hook != nil && !hookStatus.Succeeded &&
+		hook.FailurePolicy != ci.HookFailurePolicyIgnore {
+		diag := fmt.Sprintf("PreAttempt hook failed: %v", hookStatus.Diagnostics)
+		klog.Infof("[%v]: syncPreAttemptHook: %v", f.Key(), diag)
+		c.completeFrameworkAttempt(f, false,
+			ci.CompletionCodeFrameworkPreAttemptHookFailed.
+				NewFrameworkAttemptCompletionStatus(diag, nil))
+	}
+	return true, nil
+}
+
+// Hold the ConfigMap deletion until the PostAttempt hook is completed.
+// Return whether the hook is completed and its result is persisted, and it is
+// always true if the hook is not specified or the Framework is being deleted.
+func (c *FrameworkController) syncPostAttemptHook(
+	f *ci.Framework, cm *core.ConfigMap) (bool, error) {
+	logPfx := fmt.Sprintf("[%v]: syncPostAttemptHook: ", f.Key())
+
+	hook := f.HookSpec(ci.HookNamePostAttempt)
+	if hook == nil || f.DeletionTimestamp != nil {
+		return true, nil
+	}
+	hookStatus := f.HookStatus(ci.HookNamePostAttempt)
+	if hookStatus != nil && hookStatus.CompletionTime != nil {
+		return true, nil
+	}
+
+	completed, err := c.syncHook(f, cm, ci.HookNamePostAttempt)
+	if err != nil || !completed {
+		return completed, err
+	}
+
+	hookStatus = f.HookStatus(ci.HookNamePostAttempt)
+	if !hookStatus.Succeeded && hook.FailurePolicy != ci.HookFailurePolicyIgnore &&
+		f.CompletionType().IsSucceeded() {
+		diag := fmt.Sprintf("PostAttempt hook failed: %v", hookStatus.Diagnostics)
+		klog.Infof(logPfx + diag)
+		f.Status.AttemptStatus.CompletionStatus =
+			ci.CompletionCodeFrameworkPostAttemptHookFailed.
+				NewFrameworkAttemptCompletionStatus(diag, nil)
+	}
+
+	// To ensure the hook result is persisted before deleting the cm, we need to
+	// wait until next sync to delete the cm, so manually enqueue a sync.
+	c.enqueueFrameworkSync(f, "PostAttemptHookCompleted")
+	klog.Infof(logPfx + "Waiting PostAttempt hook result to be persisted")
+	return false, nil
+}
+
+// Drive the hook of current FrameworkAttempt to be completed and record the
+// result in its HookStatus.
+// Return whether the hook is completed, and it is always true if the hook is
+// not specified.
+func (c *FrameworkController) syncHook(
+	f *ci.Framework, cm *core.ConfigMap, hookName string) (bool, error) {
+	logPfx := fmt.Sprintf("[%v][%v]: syncHook: ", f.Key(), hookName)
+
+	hook := f.HookSpec(hookName)
+	if hook == nil {
+		return true, nil
+	}
+	hookStatus := f.HookStatus(hookName)
+	if hookStatus != nil && hookStatus.CompletionTime != nil {
+		return true, nil
+	}
+
+	if hook.Webhook != nil {
+		hookStatus = &ci.HookStatus{StartTime: common.Now()}
+		f.SetHookStatus(hookName, hookStatus)
+
+		req := &HookRequest{
+			FrameworkNamespace: f.Namespace,
+			FrameworkName:      f.Name,
+			FrameworkAttemptID: f.FrameworkAttemptID(),
+			HookName:           hookName,
+			CompletionStatus:   f.Status.AttemptStatus.CompletionStatus,
+		}
+		span := c.tracer.StartSpan(f.Key(), "CallHookWebhook",
+			map[string]string{"hook.name": hookName})
+		err := callHookWebhook(hook, req)
+		span.End(err)
+		if err != nil {
+			c.completeHook(f, hookName, false, err.Error())
+		} else {
+			c.completeHook(f, hookName, true, "Webhook succeeded")
+		}
+		return true, nil
+	}
+
+	if hookStatus == nil {
+		pod, err := f.NewHookPod(cm, hookName)
+		if err == nil {
+			c.setPodDefaults(pod)
+			err = c.decoratePod(&PodDecorateContext{
+				Framework: f,
+				HookName:  hookName,
+				Pod:       pod,
+			})
+		}
+		if err != nil {
+			// The invalid Pod can never be created, so just fail the hook.
+			c.completeHook(f, hookName, false, err.Error())
+			return true, nil
+		}
+
+		span := c.tracer.StartSpan(f.Key(), "CreateHookPod",
+			map[string]string{"object.name": pod.Name})
+		remotePod, createErr := c.kClient.CoreV1().Pods(f.Namespace).Create(pod)
+		span.End(createErr)
+		if createErr != nil {
+			if apiErrors.IsAlreadyExists(createErr) {
+				// The hook Pod may be created but failed to persist its HookStatus.
+				localPod, getErr := c.podLister.Pods(f.Namespace).Get(pod.Name)
+				if getErr == nil && meta.IsControlledBy(localPod, cm) {
+					remotePod = localPod
+				} else {
+					return false, fmt.Errorf(logPfx+
+						"Failed to create hook Pod %v: Pod naming conflicts with others "+
+						"or does not appear in the local cache: %v", pod.Name, createErr)
+				}
+			} else if apiErrors.IsBadRequest(createErr) || apiErrors.IsInvalid(createErr) {
+				c.completeHook(f, hookName, false, fmt.Sprintf(
+					"Failed to create hook Pod %v: %v", pod.Name, createErr))
+				return true, nil
+			} else {
+				return false, fmt.Errorf(logPfx+
+					"Failed to create hook Pod %v: %v", pod.Name, createErr)
+			}
+		} else {
+			klog.Infof(logPfx+"Succeeded to create hook Pod %v", pod.Name)
+		}
+
+		f.SetHookStatus(hookName, &ci.HookStatus{
+			StartTime: common.Now(),
+			PodName:   &remotePod.Name,
+			PodUID:    &remotePod.UID,
+		})
+		c.enqueueHookTimeoutCheck(f, hookName, false)
+		return false, nil
+	}
+
+	pod, err := c.podLister.Pods(f.Namespace).Get(*hookStatus.PodName)
+	if err != nil && !apiErrors.IsNotFound(err) {
+		return false, fmt.Errorf(logPfx+
+			"Failed to get hook Pod %v: %v", *hookStatus.PodName, err)
+	}
+	if pod == nil || pod.UID != *hookStatus.PodUID {
+		// The created Pod may not yet appear in the local cache.
+		if c.enqueueFrameworkTimeoutCheck(
+			f, hookStatus.StartTime, c.config().ObjectLocalCacheCreationTimeoutSec,
+			true, "HookPodCreationTimeoutCheck") {
+			klog.Infof(logPfx+"Waiting hook Pod %v to appear in the local cache",
+				*hookStatus.PodName)
+			return false, nil
+		}
+		c.completeHook(f, hookName, false, "Hook Pod was deleted by others")
+		return true, nil
+	}
+
+	if pod.Status.Phase == core.PodSucceeded {
+		c.completeHook(f, hookName, true, "Hook Pod succeeded")
+		return true, nil
+	}
+	if pod.Status.Phase == core.PodFailed {
+		result := ci.MatchCompletionCodeInfos(pod)
+		c.completeHook(f, hookName, false,
+			fmt.Sprintf("Hook Pod failed: %v", result.Diagnostics))
+		return true, nil
+	}
+
+	if c.enqueueHookTimeoutCheck(f, hookName, true) {
+		klog.Infof(logPfx+"Waiting hook Pod %v to complete", pod.Name)
+		return false, nil
+	}
+
+	// Best effort to stop the timed out hook Pod, and it is also deleted together
+	// with the ConfigMap.
+	span := c.tracer.StartSpan(f.Key(), "DeleteHookPod",
+		map[string]string{"object.name": pod.Name})
+	deleteErr := c.kClient.CoreV1().Pods(f.Namespace).Delete(pod.Name,
+		&meta.DeleteOptions{Preconditions: &meta.Preconditions{UID: &pod.UID}})
+	span.End(deleteErr)
+	if deleteErr != nil && !apiErrors.IsNotFound(deleteErr) {
+		klog.Warningf(logPfx+"Failed to delete timed out hook Pod %v: %v",
+			pod.Name, deleteErr)
+	}
+	c.completeHook(f, hookName, false, fmt.Sprintf(
+		"Hook Pod has not completed within timeout %v",
+		common.SecToDuration(f.HookSpec(hookName).TimeoutSec)))
+	return true, nil
+}
+
+func (c *FrameworkController) enqueueHookTimeoutCheck(
+	f *ci.Framework, hookName string, failIfTimeout bool) bool {
+	hookStatus := f.HookStatus(hookName)
+	timeoutSec := f.HookSpec(hookName).TimeoutSec
+	if hookStatus == nil || hookStatus.CompletionTime != nil || timeoutSec == nil {
+		// Never timeout.
+		return true
+	}
+
+	return c.enqueueFrameworkTimeoutCheck(
+		f, hookStatus.StartTime, timeoutSec, failIfTimeout, "HookTimeoutCheck")
+}
+
+func (c *FrameworkController) completeHook(
+	f *ci.Framework, hookName string, succeeded bool, diag string) {
+	hookStatus := f.HookStatus(hookName)
+	if hookStatus == nil {
+		hookStatus = &ci.HookStatus{StartTime: common.Now()}
+		f.SetHookStatus(hookName, hookStatus)
+	}
+	hookStatus.CompletionTime = common.PtrNow()
+	hookStatus.Succeeded = succeeded
+	hookStatus.Diagnostics = diag
+
+	logPfx := fmt.Sprintf("[%v][%v]: completeHook: ", f.Key(), hookName)
+	if succeeded {
+		klog.Infof(logPfx+"Hook succeeded: %v", diag)
+	} else {
+		klog.Warningf(logPfx+"Hook failed: %v", diag)
+	}
+}
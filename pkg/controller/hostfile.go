@@ -0,0 +1,75 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+)
+
+// Write the hostfile and the peer list into the ConfigMap of current
+// FrameworkAttemptInstance, once all Tasks to be included have been assigned
+// PodIPs.
+// The write is idempotent, so it is safe to write again if the
+// HostfileGenerated is failed to persist.
+func (c *FrameworkController) syncHostfile(
+	f *ci.Framework, cm *core.ConfigMap) error {
+	if f.Status.AttemptStatus.HostfileGenerated || !f.IsHostfileEnabled() {
+		return nil
+	}
+	logPfx := fmt.Sprintf("[%v]: syncHostfile: ", f.Key())
+
+	hostfile, peerList, ready := f.NewHostfile()
+	if !ready {
+		klog.Infof(logPfx + "Waiting all Tasks in the hostfile to be assigned PodIPs")
+		return nil
+	}
+
+	patch := common.ToJson(map[string]interface{}{
+		"data": map[string]string{
+			ci.ConfigMapKeyHostfile: hostfile,
+			ci.ConfigMapKeyPeerList: peerList,
+		},
+	})
+
+	span := c.tracer.StartSpan(f.Key(), "PatchConfigMap",
+		map[string]string{"object.name": cm.Name})
+	_, err := c.kClient.CoreV1().ConfigMaps(f.Namespace).Patch(
+		cm.Name, types.MergePatchType, []byte(patch))
+	span.End(err)
+	if err != nil {
+		return fmt.Errorf(
+			"[%v]: Failed to write hostfile into ConfigMap %v: %v",
+			f.Key(), cm.Name, err)
+	}
+
+	klog.Infof(
+		"[%v]: Succeeded to write hostfile into ConfigMap %v",
+		f.Key(), cm.Name)
+	f.Status.AttemptStatus.HostfileGenerated = true
+	return nil
+}
@@ -0,0 +1,738 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"encoding/json"
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	frameworkClient "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned"
+	frameworkLister "github.com/microsoft/frameworkcontroller/pkg/client/listers/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"github.com/microsoft/frameworkcontroller/pkg/internal"
+	core "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kubeRuntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/dynamic"
+	"k8s.io/client-go/dynamic/dynamicinformer"
+	"k8s.io/client-go/dynamic/dynamiclister"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+	"reflect"
+	"sort"
+	"strings"
+	"time"
+)
+
+// KubeflowJobController materializes the Kubeflow TFJobs and PyTorchJobs as
+// the equivalent Frameworks, and reports the Framework Status back to them, so
+// that the existing Kubeflow manifests can be run by FrameworkController.
+// The Kubeflow clients are not vendored, so the Kubeflow Jobs are managed by
+// the dynamic client.
+// See KubeflowJobEnabled.
+type KubeflowJobController struct {
+	fClient frameworkClient.Interface
+	dClient dynamic.Interface
+
+	fInformer cache.SharedIndexInformer
+	fLister   frameworkLister.FrameworkLister
+	// Kubeflow Job Kind -> Informer and Lister
+	jobInformers map[string]cache.SharedIndexInformer
+	jobLister    map[string]dynamiclister.Lister
+
+	// {Kind}/{Namespace}/{Name} -> Kubeflow Job
+	jobQueue workqueue.RateLimitingInterface
+
+	shardManager *ShardManager
+	workerNumber int32
+}
+
+type kubeflowJobType struct {
+	gvr schema.GroupVersionResource
+	// The field name of the replica specs in the Kubeflow Job Spec.
+	replicaSpecsField string
+}
+
+var kubeflowJobTypes = map[string]kubeflowJobType{
+	ci.KubeflowTFJobKind: {
+		gvr:               ci.KubeflowTFJobGroupVersionResource,
+		replicaSpecsField: "tfReplicaSpecs",
+	},
+	ci.KubeflowPyTorchJobKind: {
+		gvr:               ci.KubeflowPyTorchJobGroupVersionResource,
+		replicaSpecsField: "pytorchReplicaSpecs",
+	},
+}
+
+func NewKubeflowJobController(
+	fClient frameworkClient.Interface,
+	dClient dynamic.Interface,
+	fInformer cache.SharedIndexInformer,
+	fLister frameworkLister.FrameworkLister,
+	shardManager *ShardManager,
+	workerNumber int32) *KubeflowJobController {
+	c := &KubeflowJobController{
+		fClient:      fClient,
+		dClient:      dClient,
+		fInformer:    fInformer,
+		fLister:      fLister,
+		jobInformers: map[string]cache.SharedIndexInformer{},
+		jobLister:    map[string]dynamiclister.Lister{},
+		jobQueue:     workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		shardManager: shardManager,
+		workerNumber: workerNumber,
+	}
+
+	namespaceIndexers := cache.Indexers{cache.NamespaceIndex: cache.MetaNamespaceIndexFunc}
+	for kind, jobType := range kubeflowJobTypes {
+		kind := kind
+		jobListerInformer := dynamicinformer.NewFilteredDynamicInformer(
+			dClient, jobType.gvr, core.NamespaceAll, 0, namespaceIndexers, nil)
+		c.jobInformers[kind] = jobListerInformer.Informer()
+		c.jobLister[kind] = dynamiclister.New(
+			c.jobInformers[kind].GetIndexer(), jobType.gvr)
+
+		// The Framework will be deleted by the GarbageCollectionController
+		// together with the Kubeflow Job, so the deletion is not watched.
+		c.jobInformers[kind].AddEventHandler(cache.ResourceEventHandlerFuncs{
+			AddFunc: func(obj interface{}) {
+				c.enqueueKubeflowJobObj(kind, toUnstructured(obj))
+			},
+			UpdateFunc: func(oldObj, newObj interface{}) {
+				c.enqueueKubeflowJobObj(kind, toUnstructured(newObj))
+			},
+		})
+	}
+
+	// The Framework Status changes should be reported to its Kubeflow Job.
+	c.fInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueFrameworkKubeflowJob(internal.ToFramework(obj))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.enqueueFrameworkKubeflowJob(internal.ToFramework(newObj))
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.enqueueFrameworkKubeflowJob(internal.ToFramework(obj))
+		},
+	})
+
+	return c
+}
+
+func getKubeflowJobKey(kind string, namespace string, name string) string {
+	return strings.Join([]string{kind, namespace, name}, "/")
+}
+
+func splitKubeflowJobKey(key string) (kind, namespace, name string) {
+	parts := strings.SplitN(key, "/", 3)
+	return parts[0], parts[1], parts[2]
+}
+
+func (c *KubeflowJobController) enqueueKubeflowJobObj(
+	kind string, job *unstructured.Unstructured) {
+	if !c.shardManager.Owns(job) {
+		return
+	}
+	c.jobQueue.Add(getKubeflowJobKey(kind, job.GetNamespace(), job.GetName()))
+}
+
+func (c *KubeflowJobController) enqueueFrameworkKubeflowJob(f *ci.Framework) {
+	fOwner := meta.GetControllerOf(f)
+	if fOwner == nil {
+		return
+	}
+	if _, ok := kubeflowJobTypes[fOwner.Kind]; !ok {
+		return
+	}
+	if !c.shardManager.Owns(f) {
+		return
+	}
+	c.jobQueue.Add(getKubeflowJobKey(fOwner.Kind, f.Namespace, fOwner.Name))
+}
+
+// Enqueue all Kubeflow Jobs, so that the newly owned ones are synced after
+// the shards are rebalanced.
+func (c *KubeflowJobController) Rebalance() {
+	for kind, lister := range c.jobLister {
+		jobs, err := lister.List(nil)
+		if err != nil {
+			klog.Warningf("KubeflowJob: Rebalance: "+
+				"Failed to list %vs from local cache: %v", kind, err)
+			continue
+		}
+		for _, job := range jobs {
+			c.enqueueKubeflowJobObj(kind, job)
+		}
+	}
+}
+
+// It should be invoked after the Framework Informer is started.
+func (c *KubeflowJobController) Run(stopCh <-chan struct{}) {
+	defer c.jobQueue.ShutDown()
+
+	cacheSyncs := []cache.InformerSynced{c.fInformer.HasSynced}
+	for _, jobInformer := range c.jobInformers {
+		go jobInformer.Run(stopCh)
+		cacheSyncs = append(cacheSyncs, jobInformer.HasSynced)
+	}
+	if !cache.WaitForCacheSync(stopCh, cacheSyncs...) {
+		panic(fmt.Errorf("Failed to WaitForCacheSync for KubeflowJob"))
+	}
+
+	klog.Infof("Running KubeflowJobController with %v workers",
+		c.workerNumber)
+	for i := int32(0); i < c.workerNumber; i++ {
+		go wait.Until(func() {
+			for c.processNextWorkItem() {
+			}
+		}, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (c *KubeflowJobController) processNextWorkItem() bool {
+	key, quit := c.jobQueue.Get()
+	if quit {
+		return false
+	}
+	defer c.jobQueue.Done(key)
+
+	err := c.syncKubeflowJob(key.(string))
+	if err == nil {
+		c.jobQueue.Forget(key)
+	} else {
+		c.jobQueue.AddRateLimited(key)
+	}
+
+	return true
+}
+
+// It should not be invoked concurrently with the same key.
+//
+// Return error only for Platform Transient Error, so that the key
+// can be enqueued again after rate limited delay.
+func (c *KubeflowJobController) syncKubeflowJob(
+	key string) (returnedErr error) {
+	startTime := time.Now()
+	logPfx := fmt.Sprintf("[%v]: syncKubeflowJob: ", key)
+	klog.Infof(logPfx + "Started")
+	defer func() {
+		if returnedErr != nil {
+			klog.Warning(logPfx + returnedErr.Error())
+			klog.Warning(logPfx +
+				"Failed to due to Platform Transient Error. " +
+				"Will enqueue it again after rate limited delay")
+		}
+		klog.Infof(logPfx+"Completed: Duration %v", time.Since(startTime))
+	}()
+
+	kind, jobNamespace, jobName := splitKubeflowJobKey(key)
+	localJob, err := c.jobLister[kind].Namespace(jobNamespace).Get(jobName)
+	if err != nil {
+		if apiErrors.IsNotFound(err) {
+			klog.Infof(logPfx+
+				"Skipped: %v cannot be found in local cache: %v", kind, err)
+			return nil
+		} else {
+			return fmt.Errorf(
+				"Failed: %v cannot be got from local cache: %v", kind, err)
+		}
+	}
+
+	job := localJob.DeepCopy()
+	if !c.shardManager.Owns(job) {
+		klog.Infof(logPfx + "Skipped: " + kind + " does not belong to current shard")
+		return nil
+	}
+	if job.GetDeletionTimestamp() != nil {
+		klog.Infof(logPfx + "Skipped: " + kind + " is deleting")
+		return nil
+	}
+
+	expectedF, err := newFrameworkFromKubeflowJob(kind, job)
+	if err != nil {
+		// The Kubeflow Job will be synced again once it is updated.
+		klog.Warningf(logPfx+"Skipped: "+
+			"%v cannot be converted to Framework: %v", kind, err)
+		return nil
+	}
+
+	f, err := c.fLister.Frameworks(jobNamespace).Get(jobName)
+	if err != nil {
+		if !apiErrors.IsNotFound(err) {
+			return fmt.Errorf(
+				"Failed to get Framework from local cache: %v", err)
+		}
+		if isKubeflowJobCompleted(job) {
+			// The completed Framework may be already deleted after its retain
+			// time, so it should not be created again.
+			klog.Infof(logPfx + "Skipped: " + kind + " is already completed")
+			return nil
+		}
+		f, err = c.fClient.FrameworkcontrollerV1().Frameworks(
+			jobNamespace).Create(expectedF)
+		if err != nil {
+			// The Framework may be not yet reflected in the local cache, so
+			// retry later.
+			return fmt.Errorf("Failed to create Framework: %v", err)
+		}
+		klog.Infof(logPfx+"Succeeded to create Framework %v", f.UID)
+	}
+
+	if !meta.IsControlledBy(f, job) {
+		klog.Warningf(logPfx+"Skipped: "+
+			"Framework %v is not controlled by current %v %v",
+			f.Key(), kind, job.GetUID())
+		return nil
+	}
+
+	if err := c.syncFrameworkTaskNumbers(f, expectedF); err != nil {
+		return err
+	}
+
+	remoteStatus, _, _ := unstructured.NestedMap(job.Object, "status")
+	status := newKubeflowJobStatus(f, remoteStatus)
+	if !reflect.DeepEqual(remoteStatus, status) {
+		if err := unstructured.SetNestedMap(job.Object, status, "status"); err != nil {
+			return fmt.Errorf("Failed to set %v.Status: %v", kind, err)
+		}
+		_, updateErr := c.dClient.Resource(kubeflowJobTypes[kind].gvr).
+			Namespace(jobNamespace).UpdateStatus(job, meta.UpdateOptions{})
+		if updateErr != nil {
+			return fmt.Errorf(
+				"Failed to update %v.Status: %v", kind, updateErr)
+		}
+		klog.Infof(logPfx+"Succeeded to update %v.Status", kind)
+	}
+
+	return nil
+}
+
+// Scale the Framework TaskRoles to the Kubeflow Job replicas, and the other
+// Kubeflow Job changes only take effect on the newly created Framework.
+func (c *KubeflowJobController) syncFrameworkTaskNumbers(
+	f *ci.Framework, expectedF *ci.Framework) error {
+	if f.Status != nil && f.IsCompleted() {
+		return nil
+	}
+
+	updatedF := f.DeepCopy()
+	changed := false
+	for _, taskRole := range updatedF.Spec.TaskRoles {
+		for _, expectedTaskRole := range expectedF.Spec.TaskRoles {
+			if taskRole.Name == expectedTaskRole.Name &&
+				taskRole.TaskNumber != expectedTaskRole.TaskNumber {
+				taskRole.TaskNumber = expectedTaskRole.TaskNumber
+				changed = true
+			}
+		}
+	}
+	if !changed {
+		return nil
+	}
+
+	// The Update is rejected if the Framework is changed since it is got from
+	// the local cache, so it will be retried with the latest Framework.
+	_, err := c.fClient.FrameworkcontrollerV1().Frameworks(f.Namespace).Update(updatedF)
+	if err != nil {
+		return fmt.Errorf(
+			"Failed to update Framework %v TaskNumbers: %v", f.Key(), err)
+	}
+	klog.Infof("[%v]: Succeeded to update Framework TaskNumbers", f.Key())
+	return nil
+}
+
+func isKubeflowJobCompleted(job *unstructured.Unstructured) bool {
+	conditions, _, _ := unstructured.NestedSlice(job.Object, "status", "conditions")
+	for _, condition := range conditions {
+		conditionMap, ok := condition.(map[string]interface{})
+		if !ok || conditionMap["status"] != string(core.ConditionTrue) {
+			continue
+		}
+		if conditionMap["type"] == ci.KubeflowJobConditionSucceeded ||
+			conditionMap["type"] == ci.KubeflowJobConditionFailed {
+			return true
+		}
+	}
+	return false
+}
+
+type kubeflowReplicaSpec struct {
+	Replicas      *int32               `json:"replicas"`
+	RestartPolicy string               `json:"restartPolicy"`
+	Template      core.PodTemplateSpec `json:"template"`
+}
+
+// The Chief and Master replica types are ordered first, so that they are the
+// first TaskRoles.
+func getKubeflowReplicaTypes(replicaSpecs map[string]interface{}) []string {
+	replicaTypes := []string{}
+	for replicaType := range replicaSpecs {
+		replicaTypes = append(replicaTypes, replicaType)
+	}
+	sort.Slice(replicaTypes, func(i, j int) bool {
+		iLeading := isKubeflowLeadingReplicaType(replicaTypes[i])
+		jLeading := isKubeflowLeadingReplicaType(replicaTypes[j])
+		if iLeading != jLeading {
+			return iLeading
+		}
+		return replicaTypes[i] < replicaTypes[j]
+	})
+	return replicaTypes
+}
+
+func isKubeflowLeadingReplicaType(replicaType string) bool {
+	taskRoleName := strings.ToLower(replicaType)
+	return taskRoleName == "chief" || taskRoleName == "master"
+}
+
+// Convert the Kubeflow Job to the Framework with the same name:
+// 1. Each replica type is converted to a TaskRole with the lower case name,
+//    such as Worker to worker, and its replicas is the TaskNumber.
+// 2. The replica RestartPolicy is converted to the Task RetryPolicy, i.e. no
+//    retry for Never, always retry on failure within the RetryBudget for
+//    OnFailure and Always, and retry on the Transient Failed exit codes, i.e.
+//    FancyRetryPolicy, for ExitCode.
+// 3. The RunPolicy BackoffLimit is the RetryBudget MaxTaskRetryCount.
+// 4. The Framework succeeds once all Tasks of the chief or master TaskRole
+//    succeeded, or the worker TaskRole if there is no chief or master or the
+//    SuccessPolicy is AllWorkers, and fails once any Task failed.
+// 5. The TF_CONFIG for the TFJob, and the MASTER_ADDR, MASTER_PORT, WORLD_SIZE
+//    and RANK for the PyTorchJob are injected, which refer to the Task
+//    hostnames, see TaskRoleHeadlessServiceEnabled.
+func newFrameworkFromKubeflowJob(
+	kind string, job *unstructured.Unstructured) (*ci.Framework, error) {
+	jobType := kubeflowJobTypes[kind]
+	replicaSpecs, found, err := unstructured.NestedMap(
+		job.Object, "spec", jobType.replicaSpecsField)
+	if err != nil {
+		return nil, err
+	}
+	if !found || len(replicaSpecs) == 0 {
+		return nil, fmt.Errorf("Spec.%v is empty", jobType.replicaSpecsField)
+	}
+
+	backoffLimit, found, _ := unstructured.NestedInt64(
+		job.Object, "spec", "runPolicy", "backoffLimit")
+	if !found {
+		backoffLimit, found, _ = unstructured.NestedInt64(
+			job.Object, "spec", "backoffLimit")
+	}
+	successPolicy, _, _ := unstructured.NestedString(
+		job.Object, "spec", "successPolicy")
+
+	f := &ci.Framework{
+		ObjectMeta: meta.ObjectMeta{
+			Name:        job.GetName(),
+			Namespace:   job.GetNamespace(),
+			Labels:      job.GetLabels(),
+			Annotations: job.GetAnnotations(),
+			OwnerReferences: []meta.OwnerReference{{
+				APIVersion:         job.GetAPIVersion(),
+				Kind:               kind,
+				Name:               job.GetName(),
+				UID:                job.GetUID(),
+				Controller:         common.PtrBool(true),
+				BlockOwnerDeletion: common.PtrBool(true),
+			}},
+		},
+		Spec: ci.FrameworkSpec{
+			ExecutionType: ci.ExecutionStart,
+			RetryPolicy:   ci.RetryPolicySpec{MaxRetryCount: 0},
+		},
+	}
+	if found {
+		f.Spec.RetryBudget = &ci.RetryBudgetSpec{
+			MaxTaskRetryCount: common.PtrInt32(int32(backoffLimit)),
+		}
+	}
+
+	for _, replicaType := range getKubeflowReplicaTypes(replicaSpecs) {
+		replicaSpecObj, ok := replicaSpecs[replicaType].(map[string]interface{})
+		if !ok {
+			return nil, fmt.Errorf("Replica %v is not an object", replicaType)
+		}
+		replicaSpec := kubeflowReplicaSpec{}
+		err := kubeRuntime.DefaultUnstructuredConverter.FromUnstructured(
+			replicaSpecObj, &replicaSpec)
+		if err != nil {
+			return nil, fmt.Errorf("Replica %v is invalid: %v", replicaType, err)
+		}
+
+		taskNumber := int32(1)
+		if replicaSpec.Replicas != nil {
+			taskNumber = *replicaSpec.Replicas
+		}
+		taskRole := &ci.TaskRoleSpec{
+			Name:       strings.ToLower(replicaType),
+			TaskNumber: taskNumber,
+			FrameworkAttemptCompletionPolicy: ci.CompletionPolicySpec{
+				MinFailedTaskCount:    1,
+				MinSucceededTaskCount: ci.UnlimitedValue,
+			},
+			Task: ci.TaskSpec{Pod: replicaSpec.Template},
+		}
+
+		pod := &taskRole.Task.Pod
+		switch core.RestartPolicy(replicaSpec.RestartPolicy) {
+		case core.RestartPolicyNever:
+			taskRole.Task.RetryPolicy.MaxRetryCount = 0
+		case core.RestartPolicyAlways, core.RestartPolicyOnFailure:
+			taskRole.Task.RetryPolicy.MaxRetryCount = ci.UnlimitedValue
+		default:
+			taskRole.Task.RetryPolicy.FancyRetryPolicy = true
+			taskRole.Task.RetryPolicy.MaxRetryCount = ci.UnlimitedValue
+		}
+		// The Task is retried by a new Pod instead of the Pod restart.
+		pod.Spec.RestartPolicy = core.RestartPolicyNever
+
+		f.Spec.TaskRoles = append(f.Spec.TaskRoles, taskRole)
+	}
+
+	successTaskRole := f.Spec.TaskRoles[0]
+	if !isKubeflowLeadingReplicaType(successTaskRole.Name) ||
+		successPolicy == "AllWorkers" {
+		for _, taskRole := range f.Spec.TaskRoles {
+			if taskRole.Name == "worker" {
+				successTaskRole = taskRole
+			}
+		}
+	}
+	successTaskRole.FrameworkAttemptCompletionPolicy.MinSucceededTaskCount =
+		successTaskRole.TaskNumber
+
+	switch kind {
+	case ci.KubeflowTFJobKind:
+		err = injectTFConfig(f)
+	case ci.KubeflowPyTorchJobKind:
+		err = injectPyTorchEnvs(f)
+	}
+	if err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// Returns the port of the container with the given port name, or the default
+// port if not found.
+func getKubeflowPort(pod *core.PodTemplateSpec, portName string, defaultPort int32) int32 {
+	for _, container := range pod.Spec.Containers {
+		for _, port := range container.Ports {
+			if port.Name == portName {
+				return port.ContainerPort
+			}
+		}
+	}
+	return defaultPort
+}
+
+func getKubeflowTaskAddress(
+	f *ci.Framework, taskRoleName string, taskIndex int32, port int32) string {
+	return fmt.Sprintf("%v.%v:%v",
+		ci.GetTaskHostname(taskRoleName, taskIndex),
+		ci.GetHeadlessServiceName(f.Name, taskRoleName), port)
+}
+
+// The injected env is prepended, so that the user specified one takes
+// precedence.
+func prependKubeflowEnvs(pod *core.PodTemplateSpec, envs []core.EnvVar) {
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Env = append(append([]core.EnvVar{},
+			envs...), pod.Spec.Containers[i].Env...)
+	}
+}
+
+func injectTFConfig(f *ci.Framework) error {
+	cluster := map[string][]string{}
+	for _, taskRole := range f.Spec.TaskRoles {
+		port := getKubeflowPort(&taskRole.Task.Pod,
+			ci.KubeflowTFJobDefaultPortName, ci.KubeflowTFJobDefaultPort)
+		addresses := []string{}
+		for i := int32(0); i < taskRole.TaskNumber; i++ {
+			addresses = append(addresses,
+				getKubeflowTaskAddress(f, taskRole.Name, i, port))
+		}
+		cluster[taskRole.Name] = addresses
+	}
+	clusterBytes, err := json.Marshal(cluster)
+	if err != nil {
+		return err
+	}
+
+	for _, taskRole := range f.Spec.TaskRoles {
+		tfConfig := fmt.Sprintf(
+			`{"cluster":%v,"task":{"type":"%v","index":{{%v}}},"environment":"cloud"}`,
+			string(clusterBytes), taskRole.Name, ci.PlaceholderTaskIndex)
+		prependKubeflowEnvs(&taskRole.Task.Pod,
+			[]core.EnvVar{{Name: "TF_CONFIG", Value: tfConfig}})
+	}
+	return nil
+}
+
+func injectPyTorchEnvs(f *ci.Framework) error {
+	master := f.Spec.TaskRoles[0]
+	if master.Name != "master" {
+		return fmt.Errorf("Spec.pytorchReplicaSpecs.Master is not specified")
+	}
+	port := getKubeflowPort(&master.Task.Pod,
+		ci.KubeflowPyTorchJobDefaultPortName, ci.KubeflowPyTorchJobDefaultPort)
+	worldSize := int32(0)
+	for _, taskRole := range f.Spec.TaskRoles {
+		worldSize += taskRole.TaskNumber
+	}
+
+	rank := int32(0)
+	for _, taskRole := range f.Spec.TaskRoles {
+		prependKubeflowEnvs(&taskRole.Task.Pod, []core.EnvVar{
+			{Name: "MASTER_ADDR", Value: strings.Split(
+				getKubeflowTaskAddress(f, master.Name, 0, port), ":")[0]},
+			{Name: "MASTER_PORT", Value: fmt.Sprint(port)},
+			{Name: "WORLD_SIZE", Value: fmt.Sprint(worldSize)},
+			{Name: "RANK", Value: fmt.Sprint(rank)},
+		})
+
+		// The RANK is global across the TaskRoles, so it is overridden for each
+		// TaskIndex.
+		for i := int32(1); i < taskRole.TaskNumber; i++ {
+			containers := []interface{}{}
+			for _, container := range taskRole.Task.Pod.Spec.Containers {
+				containers = append(containers, map[string]interface{}{
+					"name": container.Name,
+					"env": []interface{}{map[string]interface{}{
+						"name": "RANK", "value": fmt.Sprint(rank + i)}},
+				})
+			}
+			patch, err := json.Marshal(map[string]interface{}{
+				"spec": map[string]interface{}{"containers": containers}})
+			if err != nil {
+				return err
+			}
+			taskRole.TaskOverrides = append(taskRole.TaskOverrides,
+				ci.TaskOverrideSpec{
+					MinTaskIndex: i,
+					MaxTaskIndex: i,
+					Pod:          kubeRuntime.RawExtension{Raw: patch},
+				})
+		}
+		rank += taskRole.TaskNumber
+	}
+	return nil
+}
+
+// Build the Kubeflow Job Status from the Framework Status, and the existing
+// conditions are kept if they are not changed, to preserve their times.
+func newKubeflowJobStatus(
+	f *ci.Framework, remoteStatus map[string]interface{}) map[string]interface{} {
+	status := map[string]interface{}{}
+	replicaStatuses := map[string]interface{}{}
+	status["replicaStatuses"] = replicaStatuses
+	if f.Status == nil {
+		status["conditions"] = newKubeflowJobConditions(
+			remoteStatus, ci.KubeflowJobConditionCreated, "FrameworkCreated")
+		return status
+	}
+
+	for _, taskRole := range f.Spec.TaskRoles {
+		active, succeeded, failed := int64(0), int64(0), int64(0)
+		if taskRoleStatus := f.GetTaskRoleStatus(taskRole.Name); taskRoleStatus != nil {
+			for _, taskStatus := range taskRoleStatus.TaskStatuses {
+				if taskStatus.IsSucceeded(true) {
+					succeeded++
+				} else if taskStatus.IsFailed(true) {
+					failed++
+				} else if taskStatus.IsRunning(true) {
+					active++
+				}
+			}
+		}
+		replicaStatuses[strings.Title(taskRole.Name)] = map[string]interface{}{
+			"active":    active,
+			"succeeded": succeeded,
+			"failed":    failed,
+		}
+	}
+
+	conditionType := ci.KubeflowJobConditionCreated
+	if f.IsSucceeded() {
+		conditionType = ci.KubeflowJobConditionSucceeded
+	} else if f.IsFailed() {
+		conditionType = ci.KubeflowJobConditionFailed
+	} else if f.IsRunning() {
+		conditionType = ci.KubeflowJobConditionRunning
+	}
+	status["conditions"] = newKubeflowJobConditions(
+		remoteStatus, conditionType, "Framework"+string(f.Status.State))
+
+	status["startTime"] = f.Status.StartTime.UTC().Format(time.RFC3339)
+	if f.Status.CompletionTime != nil {
+		status["completionTime"] = f.Status.CompletionTime.UTC().Format(time.RFC3339)
+	}
+	return status
+}
+
+// The condition with the given type is True and all others are False.
+func newKubeflowJobConditions(
+	remoteStatus map[string]interface{},
+	conditionType string, reason string) []interface{} {
+	now := time.Now().UTC().Format(time.RFC3339)
+	remoteConditions, _, _ := unstructured.NestedSlice(remoteStatus, "conditions")
+
+	conditions := []interface{}{}
+	found := false
+	for _, condition := range remoteConditions {
+		conditionMap, ok := condition.(map[string]interface{})
+		if !ok {
+			continue
+		}
+		expectedStatus := string(core.ConditionFalse)
+		if conditionMap["type"] == conditionType {
+			found = true
+			expectedStatus = string(core.ConditionTrue)
+		}
+		if conditionMap["status"] != expectedStatus {
+			conditionMap["status"] = expectedStatus
+			conditionMap["reason"] = reason
+			conditionMap["lastUpdateTime"] = now
+			conditionMap["lastTransitionTime"] = now
+		}
+		conditions = append(conditions, conditionMap)
+	}
+	if !found {
+		conditions = append(conditions, map[string]interface{}{
+			"type":               conditionType,
+			"status":             string(core.ConditionTrue),
+			"reason":             reason,
+			"message":            fmt.Sprintf("Job is %v", strings.ToLower(conditionType)),
+			"lastUpdateTime":     now,
+			"lastTransitionTime": now,
+		})
+	}
+	return conditions
+}
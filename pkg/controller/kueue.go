@@ -0,0 +1,239 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	core "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	kubeRuntime "k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/klog"
+)
+
+// The Kueue clients are not vendored, so the Workloads are built as
+// unstructured objects and managed by the dynamic client.
+// See KueueConfig.
+func isKueueManaged(f *ci.Framework) bool {
+	_, ok := f.Labels[ci.LabelKeyKueueQueueName]
+	return ok
+}
+
+func (c *FrameworkController) addKueueWorkloadObj(obj interface{}) {
+	wl := toUnstructured(obj)
+	c.enqueueKueueWorkloadObj(wl, "Framework Workload Added "+string(wl.GetUID()))
+}
+
+func (c *FrameworkController) updateKueueWorkloadObj(oldObj, newObj interface{}) {
+	c.enqueueKueueWorkloadObj(toUnstructured(newObj), "Framework Workload Updated")
+}
+
+func (c *FrameworkController) deleteKueueWorkloadObj(obj interface{}) {
+	wl := toUnstructured(obj)
+	c.enqueueKueueWorkloadObj(wl, "Framework Workload Deleted "+string(wl.GetUID()))
+}
+
+func toUnstructured(obj interface{}) *unstructured.Unstructured {
+	if deletedFinalStateUnknown, ok := obj.(cache.DeletedFinalStateUnknown); ok {
+		obj = deletedFinalStateUnknown.Obj
+	}
+	return obj.(*unstructured.Unstructured)
+}
+
+func (c *FrameworkController) enqueueKueueWorkloadObj(
+	wl *unstructured.Unstructured, logSfx string) {
+	wlOwner := meta.GetControllerOf(wl)
+	if wlOwner == nil || wlOwner.Kind != ci.ConfigMapKind {
+		return
+	}
+
+	cm, err := c.cmLister.ConfigMaps(wl.GetNamespace()).Get(wlOwner.Name)
+	if err != nil || cm.UID != wlOwner.UID {
+		// GarbageCollectionController will handle the dependent object
+		// deletion according to the ownerReferences.
+		return
+	}
+
+	c.enqueueConfigMapObj(cm, logSfx)
+}
+
+func newKueueWorkload(
+	f *ci.Framework, cm *core.ConfigMap) (*unstructured.Unstructured, error) {
+	podSets := []interface{}{}
+	priorityClassName := ""
+	for _, taskRole := range f.Spec.TaskRoles {
+		template, err := kubeRuntime.DefaultUnstructuredConverter.ToUnstructured(
+			&taskRole.Task.Pod)
+		if err != nil {
+			return nil, err
+		}
+		podSets = append(podSets, map[string]interface{}{
+			"name":     taskRole.Name,
+			"count":    int64(taskRole.TaskNumber),
+			"template": template,
+		})
+		if priorityClassName == "" {
+			priorityClassName = taskRole.Task.Pod.Spec.PriorityClassName
+		}
+	}
+
+	spec := map[string]interface{}{
+		"queueName": f.Labels[ci.LabelKeyKueueQueueName],
+		"podSets":   podSets,
+	}
+	if priorityClassName != "" {
+		spec["priorityClassName"] = priorityClassName
+	}
+
+	wl := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": spec,
+	}}
+	wl.SetAPIVersion(ci.KueueWorkloadGroupVersionResource.GroupVersion().String())
+	wl.SetKind(ci.KueueWorkloadKind)
+	wl.SetNamespace(f.Namespace)
+	wl.SetName(ci.GetKueueWorkloadName(f.Name, f.FrameworkAttemptID()))
+	wl.SetLabels(map[string]string{ci.LabelKeyFrameworkName: f.Name})
+	wl.SetOwnerReferences([]meta.OwnerReference{
+		*meta.NewControllerRef(cm, ci.ConfigMapGroupVersionKind)})
+	return wl, nil
+}
+
+// Returns the Status of the Workload condition with the given type, or empty
+// if the condition does not exist.
+func getKueueWorkloadCondition(
+	wl *unstructured.Unstructured, conditionType string) (string, string) {
+	conditions, _, _ := unstructured.NestedSlice(wl.Object, "status", "conditions")
+	for _, condition := range conditions {
+		conditionMap, ok := condition.(map[string]interface{})
+		if !ok || conditionMap["type"] != conditionType {
+			continue
+		}
+		status, _ := conditionMap["status"].(string)
+		message, _ := conditionMap["message"].(string)
+		return status, message
+	}
+	return "", ""
+}
+
+// Ensure the Workload of current FrameworkAttemptInstance exists, and returns
+// whether it is admitted by Kueue, i.e. whether its Pods can be created.
+// If the Workload is evicted by Kueue, current FrameworkAttempt will be
+// completed.
+func (c *FrameworkController) syncKueueWorkload(
+	f *ci.Framework, cm *core.ConfigMap) (admitted bool, err error) {
+	if c.wlLister == nil || !isKueueManaged(f) {
+		return true, nil
+	}
+	logPfx := fmt.Sprintf("[%v]: syncKueueWorkload: ", f.Key())
+	wlName := ci.GetKueueWorkloadName(f.Name, f.FrameworkAttemptID())
+
+	if f.Status.AttemptStatus.KueueWorkloadUID == nil {
+		// The Workload creation is idempotent, so it is safe to create again if
+		// the KueueWorkloadUID is failed to persist.
+		uid, err := c.createKueueWorkload(f, cm)
+		if err != nil {
+			return false, err
+		}
+		f.Status.AttemptStatus.KueueWorkloadUID = uid
+	}
+
+	obj, getErr := c.wlLister.Namespace(f.Namespace).Get(wlName)
+	if getErr != nil {
+		if !apiErrors.IsNotFound(getErr) {
+			return false, fmt.Errorf(logPfx+
+				"Failed to get Workload %v from local cache: %v", wlName, getErr)
+		}
+		// The Workload may be not yet reflected in the local cache or deleted by
+		// others, so ensure it exists in next sync, which is safe since the
+		// Workload creation is idempotent.
+		klog.Infof(logPfx+
+			"Waiting Workload %v to appear in the local cache", wlName)
+		f.Status.AttemptStatus.KueueWorkloadUID = nil
+		return false, nil
+	}
+	if obj.GetUID() != *f.Status.AttemptStatus.KueueWorkloadUID {
+		klog.Infof(logPfx+
+			"Waiting Workload %v to be updated in the local cache: "+
+			"Expected UID %v, Current UID %v", wlName,
+			*f.Status.AttemptStatus.KueueWorkloadUID, obj.GetUID())
+		return false, nil
+	}
+
+	if status, message := getKueueWorkloadCondition(
+		obj, ci.KueueWorkloadConditionEvicted); status == string(core.ConditionTrue) {
+		diag := fmt.Sprintf("Workload %v is evicted by Kueue: %v", wlName, message)
+		klog.Info(logPfx + diag)
+		c.completeFrameworkAttempt(f, false,
+			ci.CompletionCodeFrameworkKueueEvicted.
+				NewFrameworkAttemptCompletionStatus(diag, nil))
+		return false, nil
+	}
+
+	status, _ := getKueueWorkloadCondition(obj, ci.KueueWorkloadConditionAdmitted)
+	return status == string(core.ConditionTrue), nil
+}
+
+func (c *FrameworkController) createKueueWorkload(
+	f *ci.Framework, cm *core.ConfigMap) (*types.UID, error) {
+	wl, err := newKueueWorkload(f, cm)
+	errPfx := fmt.Sprintf("[%v]: Failed to create Workload %v",
+		f.Key(), ci.GetKueueWorkloadName(f.Name, f.FrameworkAttemptID()))
+	if err != nil {
+		return nil, fmt.Errorf(errPfx+": %v", err)
+	}
+	wlClient := c.dClient.Resource(ci.KueueWorkloadGroupVersionResource).
+		Namespace(f.Namespace)
+
+	span := c.tracer.StartSpan(f.Key(), "CreateKueueWorkload",
+		map[string]string{"object.name": wl.GetName()})
+	remoteWL, createErr := wlClient.Create(wl, meta.CreateOptions{})
+	span.End(createErr)
+	if createErr != nil {
+		if !apiErrors.IsAlreadyExists(createErr) {
+			return nil, fmt.Errorf(errPfx+": %v", createErr)
+		}
+
+		var getErr error
+		remoteWL, getErr = wlClient.Get(wl.GetName(), meta.GetOptions{})
+		if getErr != nil {
+			return nil, fmt.Errorf(errPfx+": %v: %v", createErr, getErr)
+		}
+		if !meta.IsControlledBy(remoteWL, cm) {
+			// The Workload of previous FrameworkAttemptInstance may be not yet
+			// garbage collected, so just retry later.
+			return nil, fmt.Errorf(errPfx+": "+
+				"Workload naming conflicts with others: "+
+				"Existing Workload %v is not controlled by current ConfigMap %v, %v",
+				remoteWL.GetUID(), cm.Name, cm.UID)
+		}
+	} else {
+		klog.Infof("[%v]: Succeeded to create Workload %v", f.Key(), wl.GetName())
+	}
+
+	uid := remoteWL.GetUID()
+	return &uid, nil
+}
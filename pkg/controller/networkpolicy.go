@@ -0,0 +1,90 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	errorWrap "github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	networking "k8s.io/api/networking/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+)
+
+// Ensure the NetworkPolicy of current FrameworkAttemptInstance exists before
+// its Pods are created, so that the Pods are never exposed to other Frameworks.
+// The NetworkPolicy creation is idempotent, so it is safe to create again if
+// the NetworkPolicyUID is failed to persist.
+func (c *FrameworkController) syncNetworkPolicy(
+	f *ci.Framework, cm *core.ConfigMap) error {
+	logPfx := fmt.Sprintf("[%v]: syncNetworkPolicy: ", f.Key())
+
+	if f.Status.AttemptStatus.NetworkPolicyUID != nil || f.Spec.NetworkPolicy == nil {
+		return nil
+	}
+
+	np, err := c.createNetworkPolicy(f, cm)
+	if err != nil {
+		return c.handleObjectCreationError(f, logPfx, "NetworkPolicy", err)
+	}
+	f.Status.AttemptStatus.NetworkPolicyUID = &np.UID
+	return nil
+}
+
+func (c *FrameworkController) createNetworkPolicy(
+	f *ci.Framework, cm *core.ConfigMap) (*networking.NetworkPolicy, error) {
+	np := f.NewNetworkPolicy(cm)
+	errPfx := fmt.Sprintf(
+		"[%v]: Failed to create NetworkPolicy %v", f.Key(), np.Name)
+	npClient := c.kClient.NetworkingV1().NetworkPolicies(f.Namespace)
+
+	span := c.tracer.StartSpan(f.Key(), "CreateNetworkPolicy",
+		map[string]string{"object.name": np.Name})
+	remoteNP, createErr := npClient.Create(np)
+	span.End(createErr)
+	if createErr != nil {
+		if !apiErrors.IsAlreadyExists(createErr) {
+			return nil, errorWrap.Wrapf(createErr, errPfx)
+		}
+
+		var getErr error
+		remoteNP, getErr = npClient.Get(np.Name, meta.GetOptions{})
+		if getErr != nil {
+			return nil, fmt.Errorf(errPfx+": %v: %v", createErr, getErr)
+		}
+		if !meta.IsControlledBy(remoteNP, cm) {
+			// The NetworkPolicy of previous FrameworkAttemptInstance may be not yet
+			// garbage collected, so just retry later.
+			return nil, fmt.Errorf(errPfx+": "+
+				"NetworkPolicy naming conflicts with others: "+
+				"Existing NetworkPolicy %v is not controlled by current ConfigMap %v, %v",
+				remoteNP.UID, cm.Name, cm.UID)
+		}
+	} else {
+		klog.Infof("[%v]: Succeeded to create NetworkPolicy %v", f.Key(), np.Name)
+	}
+
+	return remoteNP, nil
+}
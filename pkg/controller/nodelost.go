@@ -0,0 +1,144 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	core "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/klog"
+)
+
+// The Pod index by its node name, so that the Pods on a NotReady node can be
+// found once the node readiness is changed.
+const podNodeNameIndex = "nodeName"
+
+func podNodeNameIndexFunc(obj interface{}) ([]string, error) {
+	pod, ok := obj.(*core.Pod)
+	if !ok || pod.Spec.NodeName == "" {
+		return []string{}, nil
+	}
+	return []string{pod.Spec.NodeName}, nil
+}
+
+func (c *FrameworkController) addNodeObj(obj interface{}) {
+	node := obj.(*core.Node)
+	if getNodeNotReadyCondition(node) != nil {
+		c.enqueueNodePods(node, "Pod Node NotReady")
+	} else if c.getSpotInterruption(node) != "" {
+		c.enqueueNodePods(node, "Pod Node Interrupted")
+	}
+}
+
+func (c *FrameworkController) updateNodeObj(oldObj, newObj interface{}) {
+	oldNode := oldObj.(*core.Node)
+	newNode := newObj.(*core.Node)
+	oldNotReady := getNodeNotReadyCondition(oldNode) != nil
+	newNotReady := getNodeNotReadyCondition(newNode) != nil
+	oldInterrupted := c.getSpotInterruption(oldNode) != ""
+	newInterrupted := c.getSpotInterruption(newNode) != ""
+	if oldNotReady != newNotReady {
+		if newNotReady {
+			c.enqueueNodePods(newNode, "Pod Node NotReady")
+		} else {
+			c.enqueueNodePods(newNode, "Pod Node Ready")
+		}
+	} else if !oldInterrupted && newInterrupted {
+		c.enqueueNodePods(newNode, "Pod Node Interrupted")
+	}
+}
+
+func (c *FrameworkController) enqueueNodePods(node *core.Node, logSfx string) {
+	pods, err := c.podInformer.GetIndexer().ByIndex(podNodeNameIndex, node.Name)
+	if err != nil {
+		// Unreachable
+		klog.Errorf("[%v]: Failed to get Pods on the node: %v", node.Name, err)
+		return
+	}
+
+	for _, obj := range pods {
+		c.enqueuePodObj(obj.(*core.Pod), logSfx+" "+node.Name)
+	}
+}
+
+// Get the NodeReady condition if the node is NotReady, otherwise nil, such as
+// the node is Ready, not found or NodeLost is not enabled.
+func (c *FrameworkController) getNodeNotReadyCondition(
+	nodeName string) *core.NodeCondition {
+	if !*c.config().NodeLost.Enabled {
+		return nil
+	}
+
+	node := c.getNode(nodeName)
+	if node == nil {
+		// The Pods on the deleted node will be deleted by the PodGC.
+		return nil
+	}
+	return getNodeNotReadyCondition(node)
+}
+
+// Get the node from the local cache, or nil if it is not found or the Nodes are
+// not watched.
+func (c *FrameworkController) getNode(nodeName string) *core.Node {
+	if c.nodeLister == nil || nodeName == "" {
+		return nil
+	}
+
+	node, err := c.nodeLister.Get(nodeName)
+	if err != nil {
+		if !apiErrors.IsNotFound(err) {
+			// Unreachable
+			klog.Errorf("[%v]: Failed to get node from local cache: %v", nodeName, err)
+		}
+		return nil
+	}
+	return node
+}
+
+func getNodeNotReadyCondition(node *core.Node) *core.NodeCondition {
+	for i := range node.Status.Conditions {
+		cond := &node.Status.Conditions[i]
+		if cond.Type == core.NodeReady {
+			if cond.Status != core.ConditionTrue {
+				return cond
+			}
+			return nil
+		}
+	}
+	return nil
+}
+
+func (c *FrameworkController) shouldForceDeleteNodeLostPod(
+	taskStatus *ci.TaskStatus) bool {
+	cs := taskStatus.AttemptStatus.CompletionStatus
+	if !*c.config().NodeLost.ForceDeletePod || cs == nil || cs.CompletionStatus == nil {
+		return false
+	}
+	if cs.Code == ci.CompletionCodePodNodeNotReady {
+		return true
+	}
+	// The NotReady node may be also being interrupted.
+	nodeName := taskStatus.AttemptStatus.PodNodeName
+	return cs.Code == ci.CompletionCodePodSpotInterrupted && nodeName != nil &&
+		c.getNodeNotReadyCondition(*nodeName) != nil
+}
@@ -0,0 +1,149 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	errorWrap "github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/intstr"
+	"k8s.io/klog"
+)
+
+// Ensure the PodDisruptionBudget of each TaskRole in current
+// FrameworkAttemptInstance exists.
+// The creation is idempotent, so it is safe to create again if the UIDs are
+// failed to persist.
+func (c *FrameworkController) syncPodDisruptionBudgets(
+	f *ci.Framework, cm *core.ConfigMap) error {
+	logPfx := fmt.Sprintf("[%v]: syncPodDisruptionBudgets: ", f.Key())
+
+	for _, taskRoleStatus := range f.TaskRoleStatuses() {
+		taskRoleSpec := f.GetTaskRoleSpec(taskRoleStatus.Name)
+		if taskRoleSpec == nil || taskRoleSpec.DisruptionBudget == nil ||
+			taskRoleStatus.PodDisruptionBudgetUID != nil {
+			continue
+		}
+
+		uid, err := c.createPodDisruptionBudget(f, cm, taskRoleSpec)
+		if err != nil {
+			return c.handleObjectCreationError(f, logPfx, "PodDisruptionBudget", err)
+		}
+		taskRoleStatus.PodDisruptionBudgetUID = uid
+	}
+
+	return nil
+}
+
+func toUnstructuredIntOrString(v *intstr.IntOrString) interface{} {
+	if v.Type == intstr.Int {
+		return int64(v.IntVal)
+	}
+	return v.StrVal
+}
+
+func newPodDisruptionBudget(
+	f *ci.Framework, cm *core.ConfigMap,
+	taskRoleSpec *ci.TaskRoleSpec) *unstructured.Unstructured {
+	budget := taskRoleSpec.DisruptionBudget
+
+	spec := map[string]interface{}{
+		"selector": map[string]interface{}{
+			"matchLabels": map[string]interface{}{
+				ci.LabelKeyFrameworkName: f.Name,
+				ci.LabelKeyTaskRoleName:  taskRoleSpec.Name,
+			},
+		},
+	}
+	if budget.MaxUnavailable != nil {
+		spec["maxUnavailable"] = toUnstructuredIntOrString(budget.MaxUnavailable)
+	}
+	if budget.MinAvailable != nil {
+		spec["minAvailable"] = toUnstructuredIntOrString(budget.MinAvailable)
+	}
+	if budget.MaxUnavailable == nil && budget.MinAvailable == nil {
+		spec["minAvailable"] = "100%"
+	}
+
+	pdb := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": spec,
+	}}
+	pdb.SetAPIVersion(ci.PodDisruptionBudgetGroupVersionResource.GroupVersion().String())
+	pdb.SetKind(ci.PodDisruptionBudgetKind)
+	pdb.SetNamespace(f.Namespace)
+	pdb.SetName(ci.GetPodDisruptionBudgetName(f.Name, taskRoleSpec.Name))
+	pdb.SetLabels(map[string]string{
+		ci.LabelKeyFrameworkName: f.Name,
+		ci.LabelKeyTaskRoleName:  taskRoleSpec.Name,
+	})
+	pdb.SetOwnerReferences([]meta.OwnerReference{
+		*meta.NewControllerRef(cm, ci.ConfigMapGroupVersionKind)})
+	return pdb
+}
+
+func (c *FrameworkController) createPodDisruptionBudget(
+	f *ci.Framework, cm *core.ConfigMap,
+	taskRoleSpec *ci.TaskRoleSpec) (*types.UID, error) {
+	pdb := newPodDisruptionBudget(f, cm, taskRoleSpec)
+	errPfx := fmt.Sprintf(
+		"[%v][%v]: Failed to create PodDisruptionBudget %v",
+		f.Key(), taskRoleSpec.Name, pdb.GetName())
+	pdbClient := c.dClient.Resource(ci.PodDisruptionBudgetGroupVersionResource).
+		Namespace(f.Namespace)
+
+	span := c.tracer.StartSpan(f.Key(), "CreatePodDisruptionBudget",
+		map[string]string{"object.name": pdb.GetName()})
+	remotePDB, createErr := pdbClient.Create(pdb, meta.CreateOptions{})
+	span.End(createErr)
+	if createErr != nil {
+		if !apiErrors.IsAlreadyExists(createErr) {
+			return nil, errorWrap.Wrapf(createErr, errPfx)
+		}
+
+		var getErr error
+		remotePDB, getErr = pdbClient.Get(pdb.GetName(), meta.GetOptions{})
+		if getErr != nil {
+			return nil, fmt.Errorf(errPfx+": %v: %v", createErr, getErr)
+		}
+		if !meta.IsControlledBy(remotePDB, cm) {
+			// The PodDisruptionBudget of previous FrameworkAttemptInstance may be
+			// not yet garbage collected, so just retry later.
+			return nil, fmt.Errorf(errPfx+": "+
+				"PodDisruptionBudget naming conflicts with others: "+
+				"Existing PodDisruptionBudget %v is not controlled by current ConfigMap %v, %v",
+				remotePDB.GetUID(), cm.Name, cm.UID)
+		}
+	} else {
+		klog.Infof(
+			"[%v][%v]: Succeeded to create PodDisruptionBudget %v",
+			f.Key(), taskRoleSpec.Name, pdb.GetName())
+	}
+
+	uid := remotePDB.GetUID()
+	return &uid, nil
+}
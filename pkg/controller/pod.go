@@ -0,0 +1,133 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	core "k8s.io/api/core/v1"
+	"strings"
+)
+
+// Apply the Config PodDefaults to the Pod fields omitted by its Pod template.
+func (c *FrameworkController) setPodDefaults(pod *core.Pod) {
+	podDefaults := &c.config().PodDefaults
+
+	if pod.Spec.SchedulerName == "" {
+		pod.Spec.SchedulerName = *podDefaults.SchedulerName
+	}
+
+	if pod.Spec.RuntimeClassName == nil && *podDefaults.RuntimeClassName != "" {
+		pod.Spec.RuntimeClassName = common.PtrString(*podDefaults.RuntimeClassName)
+	}
+
+	if *podDefaults.SeccompProfile != "" {
+		if _, ok := pod.Annotations[core.SeccompPodAnnotationKey]; !ok {
+			pod.Annotations[core.SeccompPodAnnotationKey] = *podDefaults.SeccompProfile
+		}
+	}
+
+	setImagePullSecrets(pod, podDefaults.ImagePullSecrets)
+	rewriteImageRegistries(pod, podDefaults.ImageRegistryRewrites)
+
+	sc := &podDefaults.SecurityContext
+	if sc.RunAsNonRoot == nil && sc.RunAsUser == nil &&
+		sc.RunAsGroup == nil && sc.FSGroup == nil {
+		return
+	}
+	if pod.Spec.SecurityContext == nil {
+		pod.Spec.SecurityContext = &core.PodSecurityContext{}
+	}
+	psc := pod.Spec.SecurityContext
+	if psc.RunAsNonRoot == nil && sc.RunAsNonRoot != nil {
+		psc.RunAsNonRoot = common.PtrBool(*sc.RunAsNonRoot)
+	}
+	if psc.RunAsUser == nil && sc.RunAsUser != nil {
+		psc.RunAsUser = common.PtrInt64(*sc.RunAsUser)
+	}
+	if psc.RunAsGroup == nil && sc.RunAsGroup != nil {
+		psc.RunAsGroup = common.PtrInt64(*sc.RunAsGroup)
+	}
+	if psc.FSGroup == nil && sc.FSGroup != nil {
+		psc.FSGroup = common.PtrInt64(*sc.FSGroup)
+	}
+}
+
+func setImagePullSecrets(pod *core.Pod, secretNames []string) {
+	for _, secretName := range secretNames {
+		found := false
+		for _, secret := range pod.Spec.ImagePullSecrets {
+			if secret.Name == secretName {
+				found = true
+				break
+			}
+		}
+		if !found {
+			pod.Spec.ImagePullSecrets = append(pod.Spec.ImagePullSecrets,
+				core.LocalObjectReference{Name: secretName})
+		}
+	}
+}
+
+func rewriteImageRegistries(
+	pod *core.Pod, rewrites []ci.ImageRegistryRewriteConfig) {
+	if len(rewrites) == 0 {
+		return
+	}
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Image = rewriteImageRegistry(
+			pod.Spec.Containers[i].Image, rewrites)
+	}
+	for i := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[i].Image = rewriteImageRegistry(
+			pod.Spec.InitContainers[i].Image, rewrites)
+	}
+}
+
+// Return the image as it is if no rewrite is matched.
+func rewriteImageRegistry(
+	image string, rewrites []ci.ImageRegistryRewriteConfig) string {
+	fullImage := getFullImageName(image)
+	for _, rewrite := range rewrites {
+		from := strings.TrimSuffix(rewrite.From, "/")
+		if strings.HasPrefix(fullImage, from+"/") {
+			return strings.TrimSuffix(rewrite.To, "/") + fullImage[len(from):]
+		}
+	}
+	return image
+}
+
+// Complete the image name with the implicit docker.io registry and library
+// repository path, following the Docker reference convention.
+func getFullImageName(image string) string {
+	slashIndex := strings.Index(image, "/")
+	if slashIndex < 0 {
+		return "docker.io/library/" + image
+	}
+	// The first component is the registry only if it looks like a host.
+	registry := image[:slashIndex]
+	if !strings.ContainsAny(registry, ".:") && registry != "localhost" {
+		return "docker.io/" + image
+	}
+	return image
+}
@@ -0,0 +1,204 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	core "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/klog"
+)
+
+// The PodGroup clients are not vendored, so the PodGroups are built as
+// unstructured objects and managed by the dynamic client.
+// See GangSchedulingConfig.
+type PodGroupType string
+
+const (
+	PodGroupNone PodGroupType = ""
+	// The PodGroup of the coscheduling plugin in scheduler-plugins.
+	PodGroupCoscheduling PodGroupType = "Coscheduling"
+	// The PodGroup of Volcano.
+	PodGroupVolcano PodGroupType = "Volcano"
+)
+
+func (c *FrameworkController) getPodGroupType(f *ci.Framework) PodGroupType {
+	gsConfig := &c.config().GangScheduling
+	if *gsConfig.VolcanoEnabled {
+		for _, taskRole := range f.Spec.TaskRoles {
+			if c.getPodSchedulerName(taskRole, &taskRole.Task.Pod.Spec) ==
+				*gsConfig.VolcanoSchedulerName {
+				return PodGroupVolcano
+			}
+		}
+	}
+	if *gsConfig.PodGroupEnabled {
+		return PodGroupCoscheduling
+	}
+	return PodGroupNone
+}
+
+// The taskRole can be nil if the podSpec has already been completed by the
+// TaskRole SchedulerName.
+func (c *FrameworkController) getPodSchedulerName(
+	taskRole *ci.TaskRoleSpec, podSpec *core.PodSpec) string {
+	gsConfig := &c.config().GangScheduling
+	if *gsConfig.PodGroupEnabled && *gsConfig.SchedulerName != "" {
+		return *gsConfig.SchedulerName
+	}
+	if podSpec.SchedulerName != "" {
+		return podSpec.SchedulerName
+	}
+	if taskRole != nil && taskRole.SchedulerName != "" {
+		return taskRole.SchedulerName
+	}
+	return *c.config().PodDefaults.SchedulerName
+}
+
+// Associate the Pod with the PodGroup of its FrameworkAttempt.
+func (c *FrameworkController) setPodGroup(f *ci.Framework, pod *core.Pod) {
+	pgType := c.getPodGroupType(f)
+	if pgType == PodGroupNone {
+		return
+	}
+
+	pgName := ci.GetPodGroupName(f.Name, f.FrameworkAttemptID())
+	pod.Spec.SchedulerName = c.getPodSchedulerName(nil, &pod.Spec)
+	if pgType == PodGroupVolcano {
+		if pod.Annotations == nil {
+			pod.Annotations = map[string]string{}
+		}
+		pod.Annotations[ci.AnnotationKeyVolcanoPodGroupName] = pgName
+		if queue, ok := f.Annotations[ci.AnnotationKeyVolcanoQueueName]; ok {
+			pod.Annotations[ci.AnnotationKeyVolcanoQueueName] = queue
+		}
+	} else {
+		pod.Labels[ci.LabelKeyPodGroupName] = pgName
+	}
+}
+
+func newPodGroup(
+	f *ci.Framework, cm *core.ConfigMap, pgType PodGroupType,
+	scheduleTimeoutSec *int64) (*unstructured.Unstructured, schema.GroupVersionResource) {
+	minMember := int64(0)
+	for _, taskRole := range f.Spec.TaskRoles {
+		minMember += int64(taskRole.GetMinTaskNumber())
+	}
+
+	minResources := map[string]interface{}{}
+	for name, quantity := range f.GetResourceRequests() {
+		minResources[string(name)] = quantity.String()
+	}
+
+	spec := map[string]interface{}{
+		"minMember":    minMember,
+		"minResources": minResources,
+	}
+
+	var gvr schema.GroupVersionResource
+	if pgType == PodGroupVolcano {
+		gvr = ci.VolcanoPodGroupGroupVersionResource
+		if queue, ok := f.Annotations[ci.AnnotationKeyVolcanoQueueName]; ok {
+			spec["queue"] = queue
+		}
+		for _, taskRole := range f.Spec.TaskRoles {
+			if taskRole.Task.Pod.Spec.PriorityClassName != "" {
+				spec["priorityClassName"] = taskRole.Task.Pod.Spec.PriorityClassName
+				break
+			}
+		}
+	} else {
+		gvr = ci.PodGroupGroupVersionResource
+		if scheduleTimeoutSec != nil {
+			spec["scheduleTimeoutSeconds"] = *scheduleTimeoutSec
+		}
+	}
+
+	pg := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": spec,
+	}}
+	pg.SetAPIVersion(gvr.GroupVersion().String())
+	pg.SetKind(ci.PodGroupKind)
+	pg.SetNamespace(f.Namespace)
+	pg.SetName(ci.GetPodGroupName(f.Name, f.FrameworkAttemptID()))
+	pg.SetLabels(map[string]string{ci.LabelKeyFrameworkName: f.Name})
+	pg.SetOwnerReferences([]meta.OwnerReference{
+		*meta.NewControllerRef(cm, ci.ConfigMapGroupVersionKind)})
+	return pg, gvr
+}
+
+// Ensure the PodGroup of current FrameworkAttemptInstance exists before its
+// Pods are created.
+// The PodGroup creation is idempotent, so it is safe to create again if the
+// PodGroupUID is failed to persist.
+func (c *FrameworkController) syncPodGroup(
+	f *ci.Framework, cm *core.ConfigMap) error {
+	if f.Status.AttemptStatus.PodGroupUID != nil {
+		return nil
+	}
+	pgType := c.getPodGroupType(f)
+	if pgType == PodGroupNone {
+		return nil
+	}
+
+	pg, gvr := newPodGroup(f, cm, pgType,
+		c.config().GangScheduling.ScheduleTimeoutSec)
+	errPfx := fmt.Sprintf("[%v]: Failed to create %v PodGroup %v",
+		f.Key(), pgType, pg.GetName())
+	pgClient := c.dClient.Resource(gvr).Namespace(f.Namespace)
+
+	span := c.tracer.StartSpan(f.Key(), "CreatePodGroup",
+		map[string]string{"object.name": pg.GetName()})
+	remotePG, createErr := pgClient.Create(pg, meta.CreateOptions{})
+	span.End(createErr)
+	if createErr != nil {
+		if !apiErrors.IsAlreadyExists(createErr) {
+			return fmt.Errorf(errPfx+": %v", createErr)
+		}
+
+		var getErr error
+		remotePG, getErr = pgClient.Get(pg.GetName(), meta.GetOptions{})
+		if getErr != nil {
+			return fmt.Errorf(errPfx+": %v: %v", createErr, getErr)
+		}
+		if !meta.IsControlledBy(remotePG, cm) {
+			// The PodGroup of previous FrameworkAttemptInstance may be not yet
+			// garbage collected, so just retry later.
+			return fmt.Errorf(errPfx+": "+
+				"PodGroup naming conflicts with others: "+
+				"Existing PodGroup %v is not controlled by current ConfigMap %v, %v",
+				remotePG.GetUID(), cm.Name, cm.UID)
+		}
+	} else {
+		klog.Infof("[%v]: Succeeded to create %v PodGroup %v",
+			f.Key(), pgType, pg.GetName())
+	}
+
+	uid := remotePG.GetUID()
+	f.Status.AttemptStatus.PodGroupUID = &uid
+	return nil
+}
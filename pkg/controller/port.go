@@ -0,0 +1,242 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/klog"
+	"sync"
+)
+
+// PortAllocator allocates the host ports in the PortAllocationRange for the
+// Tasks, such that the allocated ports of all running Tasks are not overlapped.
+// The allocation state is not persisted by itself, instead, it is always
+// rebuilt from the AllocatedPorts in the Framework.Status, so it is recovered
+// after FrameworkController restart.
+// The ports of the Frameworks owned by other shards are also occupied, so that
+// the shards never allocate the same ports, but only the Frameworks owned by
+// current shard are allocated ports.
+type PortAllocator struct {
+	lock sync.Mutex
+	min  int32
+	max  int32
+	// Framework Key -> Allocated PortRanges of the Framework
+	fPorts map[string][]ci.PortRange
+	// Port -> Framework Key
+	portOwners map[int32]string
+}
+
+func NewPortAllocator(portRange ci.Int32Range) *PortAllocator {
+	return &PortAllocator{
+		min:        *portRange.Min,
+		max:        *portRange.Max,
+		fPorts:     map[string][]ci.PortRange{},
+		portOwners: map[int32]string{},
+	}
+}
+
+// Sync the ports allocated to the Framework with its Status, i.e. release the
+// ports of the completed Tasks and the previous FrameworkAttempts.
+func (pa *PortAllocator) Sync(f *ci.Framework) {
+	pa.lock.Lock()
+	defer pa.lock.Unlock()
+
+	pa.release(f.Key())
+	if f.Status == nil || f.IsCompleted() {
+		return
+	}
+
+	for _, taskRoleStatus := range f.TaskRoleStatuses() {
+		for _, taskStatus := range taskRoleStatus.TaskStatuses {
+			if taskStatus.AllocatedPorts == nil ||
+				taskStatus.State == ci.TaskCompleted {
+				continue
+			}
+			pa.take(f.Key(), *taskStatus.AllocatedPorts)
+		}
+	}
+}
+
+// Release all the ports allocated to the Framework.
+func (pa *PortAllocator) Release(fKey string) {
+	pa.lock.Lock()
+	defer pa.lock.Unlock()
+
+	pa.release(fKey)
+}
+
+// Allocate the lowest free ports with the given number for the Framework.
+func (pa *PortAllocator) Allocate(fKey string, number int32) (*ci.PortRange, error) {
+	pa.lock.Lock()
+	defer pa.lock.Unlock()
+
+	freeNumber := int32(0)
+	for port := pa.min; port <= pa.max; port++ {
+		if _, ok := pa.portOwners[port]; ok {
+			freeNumber = 0
+			continue
+		}
+
+		freeNumber++
+		if freeNumber == number {
+			portRange := ci.PortRange{Min: port - number + 1, Max: port}
+			pa.take(fKey, portRange)
+			return &portRange, nil
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"No %v free ports in PortAllocationRange [%v, %v]",
+		number, pa.min, pa.max)
+}
+
+func (pa *PortAllocator) take(fKey string, portRange ci.PortRange) {
+	pa.fPorts[fKey] = append(pa.fPorts[fKey], portRange)
+	for port := portRange.Min; port <= portRange.Max; port++ {
+		if owner, ok := pa.portOwners[port]; ok && owner != fKey {
+			// Possible if the PortAllocationRange is changed or the Framework is
+			// managed by another FrameworkController instance which does not share
+			// the same PortAllocationRange, and then the HostPorts ensure the
+			// HostNetwork Pods will not be scheduled to the same node.
+			klog.Warningf(
+				"[%v]: Port %v is also allocated to Framework %v", fKey, port, owner)
+		}
+		pa.portOwners[port] = fKey
+	}
+}
+
+func (pa *PortAllocator) release(fKey string) {
+	for _, portRange := range pa.fPorts[fKey] {
+		for port := portRange.Min; port <= portRange.Max; port++ {
+			if pa.portOwners[port] == fKey {
+				delete(pa.portOwners, port)
+			}
+		}
+	}
+	delete(pa.fPorts, fKey)
+}
+
+// Ensure the ports are allocated for the Task before its TaskAttempt is
+// created.
+func (c *FrameworkController) allocateTaskPorts(
+	f *ci.Framework, taskRoleName string, taskIndex int32) error {
+	taskRoleSpec := f.TaskRoleSpec(taskRoleName)
+	taskStatus := f.TaskStatus(taskRoleName, taskIndex)
+	if taskRoleSpec.PortNumber <= 0 || taskStatus.AllocatedPorts != nil {
+		return nil
+	}
+
+	portRange, err := c.portAllocator.Allocate(f.Key(), taskRoleSpec.PortNumber)
+	if err != nil {
+		return fmt.Errorf(
+			"[%v][%v][%v]: Failed to allocate %v ports: %v",
+			f.Key(), taskRoleName, taskIndex, taskRoleSpec.PortNumber, err)
+	}
+
+	klog.Infof(
+		"[%v][%v][%v]: Succeeded to allocate ports [%v, %v]",
+		f.Key(), taskRoleName, taskIndex, portRange.Min, portRange.Max)
+	taskStatus.AllocatedPorts = portRange
+	return nil
+}
+
+// Expose the allocated ports to the Pod, and declare them as the HostPorts if
+// the Pod uses the HostNetwork.
+func setTaskPorts(pod *core.Pod, portRange *ci.PortRange) {
+	if portRange == nil {
+		return
+	}
+
+	portEnvs := []core.EnvVar{
+		{Name: ci.EnvNameTaskPortMin, Value: fmt.Sprint(portRange.Min)},
+		{Name: ci.EnvNameTaskPortMax, Value: fmt.Sprint(portRange.Max)},
+	}
+	// Prepend portEnvs so that they can be referred by the environment variable
+	// specified in the spec.
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Env = append(append([]core.EnvVar{},
+			portEnvs...), pod.Spec.Containers[i].Env...)
+	}
+	for i := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[i].Env = append(append([]core.EnvVar{},
+			portEnvs...), pod.Spec.InitContainers[i].Env...)
+	}
+
+	if pod.Spec.HostNetwork && len(pod.Spec.Containers) > 0 {
+		declaredPorts := map[int32]bool{}
+		for _, container := range pod.Spec.Containers {
+			for _, containerPort := range container.Ports {
+				declaredPorts[containerPort.ContainerPort] = true
+			}
+		}
+		for port := portRange.Min; port <= portRange.Max; port++ {
+			if declaredPorts[port] {
+				continue
+			}
+			pod.Spec.Containers[0].Ports = append(pod.Spec.Containers[0].Ports,
+				core.ContainerPort{
+					ContainerPort: port,
+					HostPort:      port,
+					Protocol:      core.ProtocolTCP,
+				})
+		}
+	}
+}
+
+// Recover the allocated ports of all Frameworks before any port is allocated,
+// so that the new allocations will not collide with the recovered ones.
+func (c *FrameworkController) recoverPortAllocations() {
+	fs, err := c.fLister.List(labels.Everything())
+	if err != nil {
+		panic(fmt.Errorf("Failed to list Frameworks from local cache: %v", err))
+	}
+
+	for _, localF := range fs {
+		f := localF.DeepCopy()
+		if err := c.decompressFramework(f); err != nil {
+			klog.Warningf("[%v]: Skipped to recover allocated ports: %v", f.Key(), err)
+			continue
+		}
+		c.portAllocator.Sync(f)
+	}
+}
+
+// Occupy the allocated ports of the Framework which is not owned by current
+// shard, since it is never enqueued to sync by current shard.
+// So, its ports will not be allocated again, even if current shard takes over
+// it later, until its Tasks are completed.
+func (c *FrameworkController) syncUnownedFrameworkPorts(localF *ci.Framework) {
+	if c.shardManager.Owns(localF) {
+		return
+	}
+
+	f := localF.DeepCopy()
+	if err := f.Decompress(); err != nil {
+		klog.Warningf("[%v]: Skipped to occupy allocated ports: %v", f.Key(), err)
+		return
+	}
+	c.portAllocator.Sync(f)
+}
@@ -0,0 +1,136 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+)
+
+func newPortFramework(
+	name string, state ci.FrameworkState, taskStates []ci.TaskState,
+	taskPorts []*ci.PortRange) *ci.Framework {
+	taskStatuses := []*ci.TaskStatus{}
+	for i := range taskStates {
+		taskStatuses = append(taskStatuses, &ci.TaskStatus{
+			Index:          int32(i),
+			State:          taskStates[i],
+			AllocatedPorts: taskPorts[i],
+		})
+	}
+	return &ci.Framework{
+		ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: name},
+		Status: &ci.FrameworkStatus{
+			State: state,
+			AttemptStatus: ci.FrameworkAttemptStatus{
+				TaskRoleStatuses: []*ci.TaskRoleStatus{
+					{Name: "worker", TaskStatuses: taskStatuses},
+				},
+			},
+		},
+	}
+}
+
+func TestPortAllocatorAllocate(t *testing.T) {
+	cases := []struct {
+		name    string
+		fKey    string
+		number  int32
+		want    *ci.PortRange
+		wantErr bool
+	}{
+		{"First", "default/a", 3, &ci.PortRange{Min: 100, Max: 102}, false},
+		{"Second", "default/b", 2, &ci.PortRange{Min: 103, Max: 104}, false},
+		{"SameFramework", "default/a", 1, &ci.PortRange{Min: 105, Max: 105}, false},
+		{"Exhausted", "default/c", 5, nil, true},
+		{"Rest", "default/c", 4, &ci.PortRange{Min: 106, Max: 109}, false},
+		{"Full", "default/d", 1, nil, true},
+	}
+
+	pa := NewPortAllocator(ci.Int32Range{
+		Min: common.PtrInt32(100), Max: common.PtrInt32(109)})
+	for _, c := range cases {
+		got, err := pa.Allocate(c.fKey, c.number)
+		if (err != nil) != c.wantErr {
+			t.Fatalf("%v: got err %v, want err %v", c.name, err, c.wantErr)
+		}
+		if (got == nil) != (c.want == nil) || (got != nil && *got != *c.want) {
+			t.Fatalf("%v: got %v, want %v", c.name, got, c.want)
+		}
+	}
+
+	// The lowest free ports are reused after released.
+	pa.Release("default/b")
+	got, err := pa.Allocate("default/d", 2)
+	if err != nil || *got != (ci.PortRange{Min: 103, Max: 104}) {
+		t.Fatalf("After Release: got %v, %v, want [103, 104]", got, err)
+	}
+}
+
+func TestPortAllocatorSync(t *testing.T) {
+	cases := []struct {
+		name string
+		f    *ci.Framework
+		// The first free port after the Framework is synced.
+		wantFirstFree int32
+	}{
+		{"RunningTasks",
+			newPortFramework("a", ci.FrameworkAttemptRunning,
+				[]ci.TaskState{ci.TaskAttemptRunning, ci.TaskAttemptRunning},
+				[]*ci.PortRange{{Min: 100, Max: 101}, {Min: 102, Max: 103}}),
+			104},
+		{"CompletedTaskReleased",
+			newPortFramework("a", ci.FrameworkAttemptRunning,
+				[]ci.TaskState{ci.TaskCompleted, ci.TaskAttemptRunning},
+				[]*ci.PortRange{{Min: 100, Max: 101}, {Min: 102, Max: 103}}),
+			100},
+		{"NotAllocatedTask",
+			newPortFramework("a", ci.FrameworkAttemptRunning,
+				[]ci.TaskState{ci.TaskAttemptRunning, ci.TaskAttemptCreationPending},
+				[]*ci.PortRange{{Min: 100, Max: 101}, nil}),
+			102},
+		{"CompletedFrameworkReleased",
+			newPortFramework("a", ci.FrameworkCompleted,
+				[]ci.TaskState{ci.TaskCompleted, ci.TaskCompleted},
+				[]*ci.PortRange{{Min: 100, Max: 101}, {Min: 102, Max: 103}}),
+			100},
+	}
+
+	for _, c := range cases {
+		pa := NewPortAllocator(ci.Int32Range{
+			Min: common.PtrInt32(100), Max: common.PtrInt32(109)})
+		// The stale allocation is replaced by the Status.
+		if _, err := pa.Allocate(c.f.Key(), 8); err != nil {
+			t.Fatalf("%v: %v", c.name, err)
+		}
+
+		pa.Sync(c.f)
+		got, err := pa.Allocate("default/other", 1)
+		if err != nil || got.Min != c.wantFirstFree {
+			t.Errorf("%v: got first free port %v, %v, want %v",
+				c.name, got, err, c.wantFirstFree)
+		}
+	}
+}
@@ -0,0 +1,521 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	frameworkClient "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned"
+	frameworkInformer "github.com/microsoft/frameworkcontroller/pkg/client/informers/externalversions/frameworkcontroller/v1"
+	frameworkLister "github.com/microsoft/frameworkcontroller/pkg/client/listers/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"github.com/microsoft/frameworkcontroller/pkg/internal"
+	core "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+	"sort"
+	"time"
+)
+
+// QueueController admits the queuing Frameworks into their Queues, and the
+// FrameworkController only creates the first FrameworkAttempt for the admitted
+// Frameworks.
+// It may also preempt the admitted Frameworks for the higher priority ones, and
+// the FrameworkController completes the current FrameworkAttempts of the
+// preempting Frameworks and requeues them.
+// See Queue.
+type QueueController struct {
+	fClient frameworkClient.Interface
+
+	qInformer cache.SharedIndexInformer
+	fInformer cache.SharedIndexInformer
+	qLister   frameworkLister.QueueLister
+	fLister   frameworkLister.FrameworkLister
+
+	// Queue Key -> Queue
+	qQueue workqueue.RateLimitingInterface
+
+	shardManager *ShardManager
+	workerNumber int32
+
+	// notifyFramework is called for each newly admitted or preempting Framework,
+	// so that it can leave the FrameworkQueuing state or be preempted.
+	notifyFramework func(f *ci.Framework)
+}
+
+func NewQueueController(
+	fClient frameworkClient.Interface,
+	qListerInformer frameworkInformer.QueueInformer,
+	fInformer cache.SharedIndexInformer,
+	fLister frameworkLister.FrameworkLister,
+	shardManager *ShardManager,
+	workerNumber int32,
+	notifyFramework func(f *ci.Framework)) *QueueController {
+	c := &QueueController{
+		fClient:         fClient,
+		qInformer:       qListerInformer.Informer(),
+		fInformer:       fInformer,
+		qLister:         qListerInformer.Lister(),
+		fLister:         fLister,
+		qQueue:          workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		shardManager:    shardManager,
+		workerNumber:    workerNumber,
+		notifyFramework: notifyFramework,
+	}
+
+	c.qInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueQueueObj(internal.ToQueue(obj))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldQ := internal.ToQueue(oldObj)
+			newQ := internal.ToQueue(newObj)
+			c.notifyFrameworks(oldQ, newQ)
+			c.enqueueQueueObj(newQ)
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.enqueueQueueObj(internal.ToQueue(obj))
+		},
+	})
+
+	// Only the Framework creation, admission, suspension, completion and
+	// deletion impact the Queue.
+	c.fInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueFrameworkQueue(internal.ToFramework(obj))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldF := internal.ToFramework(oldObj)
+			newF := internal.ToFramework(newObj)
+			if oldF.Spec.Queue != newF.Spec.Queue ||
+				isFrameworkQueuing(oldF) != isFrameworkQueuing(newF) ||
+				isFrameworkCompleted(oldF) != isFrameworkCompleted(newF) ||
+				isFrameworkSuspended(oldF) != isFrameworkSuspended(newF) {
+				c.enqueueFrameworkQueue(oldF)
+				c.enqueueFrameworkQueue(newF)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.enqueueFrameworkQueue(internal.ToFramework(obj))
+		},
+	})
+
+	return c
+}
+
+// The Framework whose Status is not yet initialized will also be queuing.
+func isFrameworkQueuing(f *ci.Framework) bool {
+	return f.Status == nil || f.Status.State == ci.FrameworkQueuing
+}
+
+func isFrameworkSuspended(f *ci.Framework) bool {
+	return f.Status != nil && f.IsSuspended()
+}
+
+func (c *QueueController) enqueueQueueObj(q *ci.Queue) {
+	if !c.shardManager.Owns(q) {
+		return
+	}
+	c.qQueue.Add(q.Name)
+}
+
+func (c *QueueController) enqueueFrameworkQueue(f *ci.Framework) {
+	if f.Spec.Queue == "" {
+		return
+	}
+
+	q, err := c.qLister.Get(f.Spec.Queue)
+	if err != nil {
+		// The Queue will be synced once it is created.
+		return
+	}
+	c.enqueueQueueObj(q)
+}
+
+// Enqueue all Queues, so that the newly owned ones are synced after the shards
+// are rebalanced.
+func (c *QueueController) Rebalance() {
+	qs, err := c.qLister.List(labels.Everything())
+	if err != nil {
+		klog.Warningf("Queue: Rebalance: "+
+			"Failed to list Queues from local cache: %v", err)
+		return
+	}
+	for _, q := range qs {
+		c.enqueueQueueObj(q)
+	}
+}
+
+// Whether the Framework is admitted by its Queue, according to the local cache.
+func (c *QueueController) IsAdmitted(f *ci.Framework) bool {
+	q, err := c.qLister.Get(f.Spec.Queue)
+	if err != nil || q.Status == nil {
+		return false
+	}
+	return containsUID(q.Status.AdmittedFrameworkUIDs, f.UID)
+}
+
+// Whether the Framework is being preempted by its Queue, according to the
+// local cache.
+func (c *QueueController) IsPreempting(f *ci.Framework) bool {
+	q, err := c.qLister.Get(f.Spec.Queue)
+	if err != nil || q.Status == nil {
+		return false
+	}
+	return containsUID(q.Status.PreemptingFrameworkUIDs, f.UID)
+}
+
+func containsUID(uids []types.UID, uid types.UID) bool {
+	for _, u := range uids {
+		if u == uid {
+			return true
+		}
+	}
+	return false
+}
+
+func (c *QueueController) notifyFrameworks(oldQ, newQ *ci.Queue) {
+	if newQ.Status == nil {
+		return
+	}
+
+	newUIDs := map[types.UID]bool{}
+	for _, uid := range newQ.Status.AdmittedFrameworkUIDs {
+		if oldQ.Status == nil ||
+			!containsUID(oldQ.Status.AdmittedFrameworkUIDs, uid) {
+			newUIDs[uid] = true
+		}
+	}
+	for _, uid := range newQ.Status.PreemptingFrameworkUIDs {
+		if oldQ.Status == nil ||
+			!containsUID(oldQ.Status.PreemptingFrameworkUIDs, uid) {
+			newUIDs[uid] = true
+		}
+	}
+	if len(newUIDs) == 0 {
+		return
+	}
+
+	for _, f := range c.getQueueFrameworks(newQ) {
+		if newUIDs[f.UID] {
+			c.notifyFramework(f)
+		}
+	}
+}
+
+// It should be invoked after the Framework Informer is started.
+func (c *QueueController) Run(stopCh <-chan struct{}) {
+	defer c.qQueue.ShutDown()
+
+	go c.qInformer.Run(stopCh)
+	if !cache.WaitForCacheSync(
+		stopCh,
+		c.qInformer.HasSynced,
+		c.fInformer.HasSynced) {
+		panic(fmt.Errorf("Failed to WaitForCacheSync for Queue"))
+	}
+
+	klog.Infof("Running QueueController with %v workers", c.workerNumber)
+	for i := int32(0); i < c.workerNumber; i++ {
+		go wait.Until(func() {
+			for c.processNextWorkItem() {
+			}
+		}, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (c *QueueController) processNextWorkItem() bool {
+	key, quit := c.qQueue.Get()
+	if quit {
+		return false
+	}
+	defer c.qQueue.Done(key)
+
+	err := c.syncQueue(key.(string))
+	if err == nil {
+		c.qQueue.Forget(key)
+	} else {
+		c.qQueue.AddRateLimited(key)
+	}
+
+	return true
+}
+
+// It should not be invoked concurrently with the same key, so that the
+// admission decisions are serialized within each Queue.
+//
+// Return error only for Platform Transient Error, so that the key
+// can be enqueued again after rate limited delay.
+func (c *QueueController) syncQueue(key string) (returnedErr error) {
+	startTime := time.Now()
+	logPfx := fmt.Sprintf("[%v]: syncQueue: ", key)
+	klog.Infof(logPfx + "Started")
+	defer func() {
+		if returnedErr != nil {
+			klog.Warning(logPfx + returnedErr.Error())
+			klog.Warning(logPfx +
+				"Failed to due to Platform Transient Error. " +
+				"Will enqueue it again after rate limited delay")
+		}
+		klog.Infof(logPfx+"Completed: Duration %v", time.Since(startTime))
+	}()
+
+	localQ, err := c.qLister.Get(key)
+	if err != nil {
+		if apiErrors.IsNotFound(err) {
+			klog.Infof(logPfx+
+				"Skipped: Queue cannot be found in local cache: %v", err)
+			return nil
+		} else {
+			return fmt.Errorf(
+				"Failed: Queue cannot be got from local cache: %v", err)
+		}
+	}
+
+	q := localQ.DeepCopy()
+	if !c.shardManager.Owns(q) {
+		klog.Infof(logPfx + "Skipped: Queue does not belong to current shard")
+		return nil
+	}
+
+	oldAdmittedUIDs := map[types.UID]bool{}
+	oldPreemptingUIDs := map[types.UID]bool{}
+	if q.Status != nil {
+		for _, uid := range q.Status.AdmittedFrameworkUIDs {
+			oldAdmittedUIDs[uid] = true
+		}
+		for _, uid := range q.Status.PreemptingFrameworkUIDs {
+			oldPreemptingUIDs[uid] = true
+		}
+	}
+
+	status := &ci.QueueStatus{
+		Allocated:               core.ResourceList{},
+		AdmittedFrameworkUIDs:   []types.UID{},
+		PreemptingFrameworkUIDs: []types.UID{},
+	}
+	queuingFs := []*ci.Framework{}
+	// The admitted Frameworks which have left the FrameworkQueuing state and are
+	// not being preempted.
+	startedFs := []*ci.Framework{}
+	preemptingFs := []*ci.Framework{}
+	for _, f := range c.getQueueFrameworks(q) {
+		// The suspended Framework releases its resources, and it will be requeued
+		// once it is resumed.
+		if isFrameworkCompleted(f) || isFrameworkSuspended(f) {
+			continue
+		}
+		if isFrameworkQueuing(f) {
+			if !oldAdmittedUIDs[f.UID] {
+				queuingFs = append(queuingFs, f)
+				continue
+			}
+			// The admitted Framework which is not yet observed to leave the
+			// FrameworkQueuing state.
+			status.AdmittedFrameworkUIDs = append(status.AdmittedFrameworkUIDs, f.UID)
+		} else if oldPreemptingUIDs[f.UID] {
+			// The preempting Framework which is not yet observed to be requeued.
+			status.PreemptingFrameworkUIDs = append(status.PreemptingFrameworkUIDs, f.UID)
+			preemptingFs = append(preemptingFs, f)
+		} else {
+			startedFs = append(startedFs, f)
+		}
+		status.RunningFrameworks++
+		addResourceList(status.Allocated, f.GetResourceRequests())
+	}
+
+	var blockedF *ci.Framework
+	sortQueuingFrameworks(queuingFs, q.Spec.OrderPolicy)
+	for _, f := range queuingFs {
+		requests := f.GetResourceRequests()
+		if !fitsQueueCapacity(q, requests, core.ResourceList{}) {
+			klog.Warningf(logPfx+
+				"Framework %v can never be admitted: Its resource requests %v "+
+				"exceed the Queue capacity", f.Key(), common.ToJson(requests))
+			status.QueuingFrameworks++
+			continue
+		}
+		if status.QueuingFrameworks > 0 ||
+			(q.Spec.MaxRunningFrameworks != nil &&
+				status.RunningFrameworks >= *q.Spec.MaxRunningFrameworks) ||
+			!fitsQueueCapacity(q, requests, status.Allocated) {
+			// Keep the order, i.e. the later Frameworks cannot be admitted before
+			// the earlier ones.
+			if status.QueuingFrameworks == 0 {
+				blockedF = f
+			}
+			status.QueuingFrameworks++
+			continue
+		}
+
+		klog.Infof(logPfx+"Admit Framework %v with resource requests %v",
+			f.Key(), common.ToJson(requests))
+		status.AdmittedFrameworkUIDs = append(status.AdmittedFrameworkUIDs, f.UID)
+		status.RunningFrameworks++
+		addResourceList(status.Allocated, requests)
+	}
+
+	if blockedF != nil && q.Spec.PreemptionPolicy == ci.QueuePreemptLowerPriority {
+		for _, f := range selectPreemptionVictims(
+			q, blockedF, status, startedFs, preemptingFs) {
+			klog.Infof(logPfx+"Preempt Framework %v with QueuePriority %v for "+
+				"Framework %v with QueuePriority %v", f.Key(), f.Spec.QueuePriority,
+				blockedF.Key(), blockedF.Spec.QueuePriority)
+			status.PreemptingFrameworkUIDs = append(
+				status.PreemptingFrameworkUIDs, f.UID)
+		}
+	}
+
+	sortUIDs(status.AdmittedFrameworkUIDs)
+	sortUIDs(status.PreemptingFrameworkUIDs)
+
+	if common.ToJson(q.Status) != common.ToJson(status) {
+		q.Status = status
+		_, updateErr := c.fClient.FrameworkcontrollerV1().Queues().Update(q)
+		if updateErr != nil {
+			return fmt.Errorf("Failed to update Queue.Status: %v", updateErr)
+		}
+		klog.Infof(logPfx+"Succeeded to update Queue.Status: "+
+			"RunningFrameworks %v, QueuingFrameworks %v",
+			status.RunningFrameworks, status.QueuingFrameworks)
+	}
+
+	return nil
+}
+
+// Select the minimal prefix of the lower QueuePriority started Frameworks, so
+// that the blockedF can fit after they and the preemptingFs are requeued.
+// Return nil if the blockedF can already fit after the preemptingFs are
+// requeued, or cannot fit even if all the candidates are preempted.
+func selectPreemptionVictims(
+	q *ci.Queue, blockedF *ci.Framework, status *ci.QueueStatus,
+	startedFs []*ci.Framework, preemptingFs []*ci.Framework) []*ci.Framework {
+	requests := blockedF.GetResourceRequests()
+	runningFrameworks := status.RunningFrameworks
+	allocated := status.Allocated.DeepCopy()
+	fitsAfterFreed := func(f *ci.Framework) bool {
+		runningFrameworks--
+		subResourceList(allocated, f.GetResourceRequests())
+		return (q.Spec.MaxRunningFrameworks == nil ||
+			runningFrameworks < *q.Spec.MaxRunningFrameworks) &&
+			fitsQueueCapacity(q, requests, allocated)
+	}
+
+	for _, f := range preemptingFs {
+		if fitsAfterFreed(f) {
+			return nil
+		}
+	}
+
+	candidates := []*ci.Framework{}
+	for _, f := range startedFs {
+		if f.Spec.QueuePriority < blockedF.Spec.QueuePriority {
+			candidates = append(candidates, f)
+		}
+	}
+	sort.SliceStable(candidates, func(i, j int) bool {
+		if candidates[i].Spec.QueuePriority != candidates[j].Spec.QueuePriority {
+			return candidates[i].Spec.QueuePriority < candidates[j].Spec.QueuePriority
+		}
+		return candidates[j].CreationTimestamp.Before(&candidates[i].CreationTimestamp)
+	})
+
+	for i, f := range candidates {
+		if fitsAfterFreed(f) {
+			return candidates[:i+1]
+		}
+	}
+	return nil
+}
+
+func (c *QueueController) getQueueFrameworks(q *ci.Queue) []*ci.Framework {
+	fs, err := c.fLister.List(labels.Everything())
+	if err != nil {
+		// Unreachable
+		panic(fmt.Errorf("Failed to list Frameworks from local cache: %v", err))
+	}
+
+	queueFs := []*ci.Framework{}
+	for _, f := range fs {
+		if f.Spec.Queue == q.Name {
+			queueFs = append(queueFs, f)
+		}
+	}
+	return queueFs
+}
+
+func sortQueuingFrameworks(fs []*ci.Framework, orderPolicy ci.QueueOrderPolicy) {
+	sort.SliceStable(fs, func(i, j int) bool {
+		if orderPolicy == ci.QueueOrderPriority &&
+			fs[i].Spec.QueuePriority != fs[j].Spec.QueuePriority {
+			return fs[i].Spec.QueuePriority > fs[j].Spec.QueuePriority
+		}
+		if !fs[i].CreationTimestamp.Equal(&fs[j].CreationTimestamp) {
+			return fs[i].CreationTimestamp.Before(&fs[j].CreationTimestamp)
+		}
+		return fs[i].Key() < fs[j].Key()
+	})
+}
+
+// Whether the Framework with the requests can fit into the Queue capacity,
+// given the total resource requests of admitted and not completed Frameworks.
+func fitsQueueCapacity(
+	q *ci.Queue, requests core.ResourceList, allocated core.ResourceList) bool {
+	for name, capacity := range q.Spec.Capacity {
+		total := allocated[name].DeepCopy()
+		total.Add(requests[name])
+		if total.Cmp(capacity) > 0 {
+			return false
+		}
+	}
+	return true
+}
+
+func sortUIDs(uids []types.UID) {
+	sort.Slice(uids, func(i, j int) bool {
+		return uids[i] < uids[j]
+	})
+}
+
+func subResourceList(total core.ResourceList, delta core.ResourceList) {
+	for name, quantity := range delta {
+		diff := total[name]
+		diff.Sub(quantity)
+		total[name] = diff
+	}
+}
+
+func addResourceList(total core.ResourceList, delta core.ResourceList) {
+	for name, quantity := range delta {
+		sum := total[name]
+		sum.Add(quantity)
+		total[name] = sum
+	}
+}
@@ -0,0 +1,177 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"reflect"
+	"testing"
+	"time"
+)
+
+var queueTestStartTime = time.Now()
+
+func newQueueFramework(
+	name string, priority int32, createdMinute int, cpu string) *ci.Framework {
+	return &ci.Framework{
+		ObjectMeta: meta.ObjectMeta{
+			Namespace: "default",
+			Name:      name,
+			CreationTimestamp: meta.NewTime(
+				queueTestStartTime.Add(time.Duration(createdMinute) * time.Minute)),
+		},
+		Spec: ci.FrameworkSpec{
+			QueuePriority: priority,
+			TaskRoles: []*ci.TaskRoleSpec{{
+				Name:       "worker",
+				TaskNumber: 1,
+				Task: ci.TaskSpec{Pod: core.PodTemplateSpec{Spec: core.PodSpec{
+					Containers: []core.Container{{
+						Name: "main",
+						Resources: core.ResourceRequirements{Requests: core.ResourceList{
+							core.ResourceCPU: resource.MustParse(cpu),
+						}},
+					}},
+				}}},
+			}},
+		},
+	}
+}
+
+func newCPUQueue(cpu string, maxRunningFrameworks *int32) *ci.Queue {
+	return &ci.Queue{Spec: ci.QueueSpec{
+		MaxRunningFrameworks: maxRunningFrameworks,
+		Capacity:             core.ResourceList{core.ResourceCPU: resource.MustParse(cpu)},
+		PreemptionPolicy:     ci.QueuePreemptLowerPriority,
+	}}
+}
+
+func newQueueStatus(fs ...*ci.Framework) *ci.QueueStatus {
+	status := &ci.QueueStatus{Allocated: core.ResourceList{}}
+	for _, f := range fs {
+		status.RunningFrameworks++
+		addResourceList(status.Allocated, f.GetResourceRequests())
+	}
+	return status
+}
+
+func TestFitsQueueCapacity(t *testing.T) {
+	q := &ci.Queue{Spec: ci.QueueSpec{Capacity: core.ResourceList{
+		core.ResourceCPU:    resource.MustParse("4"),
+		core.ResourceMemory: resource.MustParse("8Gi"),
+	}}}
+
+	cases := []struct {
+		name      string
+		q         *ci.Queue
+		requests  core.ResourceList
+		allocated core.ResourceList
+		want      bool
+	}{
+		{"Empty", q,
+			core.ResourceList{core.ResourceCPU: resource.MustParse("4")},
+			core.ResourceList{}, true},
+		{"Exact", q,
+			core.ResourceList{core.ResourceCPU: resource.MustParse("1500m")},
+			core.ResourceList{core.ResourceCPU: resource.MustParse("2500m")}, true},
+		{"ExceedCPU", q,
+			core.ResourceList{core.ResourceCPU: resource.MustParse("2")},
+			core.ResourceList{core.ResourceCPU: resource.MustParse("2001m")}, false},
+		{"ExceedMemory", q,
+			core.ResourceList{core.ResourceMemory: resource.MustParse("9Gi")},
+			core.ResourceList{}, false},
+		// The resources without capacity are not limited.
+		{"NotLimited", q,
+			core.ResourceList{"nvidia.com/gpu": resource.MustParse("8")},
+			core.ResourceList{}, true},
+		{"NoCapacity", &ci.Queue{},
+			core.ResourceList{core.ResourceCPU: resource.MustParse("100")},
+			core.ResourceList{}, true},
+	}
+
+	for _, c := range cases {
+		if got := fitsQueueCapacity(c.q, c.requests, c.allocated); got != c.want {
+			t.Errorf("%v: got %v, want %v", c.name, got, c.want)
+		}
+	}
+}
+
+func TestSelectPreemptionVictims(t *testing.T) {
+	low1 := newQueueFramework("low1", 0, 1, "1")
+	low2 := newQueueFramework("low2", 0, 2, "1")
+	mid := newQueueFramework("mid", 5, 0, "1")
+	high := newQueueFramework("high", 10, 0, "1")
+	blocked := newQueueFramework("blocked", 5, 3, "2")
+
+	cases := []struct {
+		name         string
+		q            *ci.Queue
+		startedFs    []*ci.Framework
+		preemptingFs []*ci.Framework
+		want         []*ci.Framework
+	}{
+		// The lower QueuePriority and then the later created ones are preempted
+		// first.
+		{"LaterCreatedFirst", newCPUQueue("4", nil),
+			[]*ci.Framework{low1, low2, mid, high}, nil,
+			[]*ci.Framework{low2, low1}},
+		{"MinimalPrefix", newCPUQueue("5", nil),
+			[]*ci.Framework{low1, low2, mid, high}, nil,
+			[]*ci.Framework{low2}},
+		// The same or higher QueuePriority ones are never preempted.
+		{"CannotFit", newCPUQueue("3", nil),
+			[]*ci.Framework{low1, low2, mid, high}, nil, nil},
+		// Already fits after the preempting ones are requeued.
+		{"AlreadyPreempting", newCPUQueue("4", nil),
+			[]*ci.Framework{low1, low2, mid, high}, []*ci.Framework{low2, low1}, nil},
+		{"MaxRunningFrameworks", newCPUQueue("100", common.PtrInt32(3)),
+			[]*ci.Framework{low1, low2, mid}, nil,
+			[]*ci.Framework{low2}},
+	}
+
+	for _, c := range cases {
+		status := newQueueStatus(c.startedFs...)
+		got := selectPreemptionVictims(
+			c.q, blocked, status, c.startedFs, c.preemptingFs)
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%v: got %v, want %v", c.name, frameworkNames(got),
+				frameworkNames(c.want))
+		}
+		// The status should not be changed.
+		if !reflect.DeepEqual(status, newQueueStatus(c.startedFs...)) {
+			t.Errorf("%v: status is changed to %v", c.name, status)
+		}
+	}
+}
+
+func frameworkNames(fs []*ci.Framework) []string {
+	names := []string{}
+	for _, f := range fs {
+		names = append(names, f.Name)
+	}
+	return names
+}
@@ -0,0 +1,461 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	frameworkClient "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned"
+	frameworkInformer "github.com/microsoft/frameworkcontroller/pkg/client/informers/externalversions/frameworkcontroller/v1"
+	frameworkLister "github.com/microsoft/frameworkcontroller/pkg/client/listers/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"github.com/microsoft/frameworkcontroller/pkg/internal"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+	"time"
+)
+
+// FrameworkQuotaController admits the FrameworkAttemptCreationPending
+// Frameworks under the FrameworkQuotas in their namespaces, and the
+// FrameworkController only creates the FrameworkAttempts for the admitted
+// Frameworks.
+// See FrameworkQuota.
+type FrameworkQuotaController struct {
+	fClient frameworkClient.Interface
+
+	fqInformer cache.SharedIndexInformer
+	fInformer  cache.SharedIndexInformer
+	fqLister   frameworkLister.FrameworkQuotaLister
+	fLister    frameworkLister.FrameworkLister
+
+	// Namespace -> All FrameworkQuotas in the Namespace
+	fqQueue workqueue.RateLimitingInterface
+
+	shardManager *ShardManager
+	workerNumber int32
+
+	// notifyFramework is called for each newly admitted Framework, and for each
+	// waiting Framework once its namespace has no FrameworkQuota, so that it can
+	// create its FrameworkAttempt.
+	notifyFramework func(f *ci.Framework)
+}
+
+func NewFrameworkQuotaController(
+	fClient frameworkClient.Interface,
+	fqListerInformer frameworkInformer.FrameworkQuotaInformer,
+	fInformer cache.SharedIndexInformer,
+	fLister frameworkLister.FrameworkLister,
+	shardManager *ShardManager,
+	workerNumber int32,
+	notifyFramework func(f *ci.Framework)) *FrameworkQuotaController {
+	c := &FrameworkQuotaController{
+		fClient:         fClient,
+		fqInformer:      fqListerInformer.Informer(),
+		fInformer:       fInformer,
+		fqLister:        fqListerInformer.Lister(),
+		fLister:         fLister,
+		fqQueue:         workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		shardManager:    shardManager,
+		workerNumber:    workerNumber,
+		notifyFramework: notifyFramework,
+	}
+
+	c.fqInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueNamespace(internal.ToFrameworkQuota(obj).Namespace)
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldFQ := internal.ToFrameworkQuota(oldObj)
+			newFQ := internal.ToFrameworkQuota(newObj)
+			c.notifyFrameworks(oldFQ, newFQ)
+			c.enqueueNamespace(newFQ.Namespace)
+		},
+		DeleteFunc: func(obj interface{}) {
+			fq := internal.ToFrameworkQuota(obj)
+			c.notifyWaitingFrameworks(fq.Namespace)
+			c.enqueueNamespace(fq.Namespace)
+		},
+	})
+
+	// Only the Framework creation, FrameworkAttempt creation, suspension,
+	// completion and deletion impact the FrameworkQuota.
+	c.fInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueFrameworkNamespace(internal.ToFramework(obj))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldF := internal.ToFramework(oldObj)
+			newF := internal.ToFramework(newObj)
+			if isFrameworkQuotaPending(oldF) != isFrameworkQuotaPending(newF) ||
+				isFrameworkQueuing(oldF) != isFrameworkQueuing(newF) ||
+				isFrameworkCompleted(oldF) != isFrameworkCompleted(newF) ||
+				isFrameworkSuspended(oldF) != isFrameworkSuspended(newF) {
+				c.enqueueFrameworkNamespace(newF)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.enqueueFrameworkNamespace(internal.ToFramework(obj))
+		},
+	})
+
+	return c
+}
+
+// The Framework which has not yet created its current FrameworkAttempt.
+func isFrameworkQuotaPending(f *ci.Framework) bool {
+	return f.Status != nil && f.Status.State == ci.FrameworkAttemptCreationPending
+}
+
+func (c *FrameworkQuotaController) enqueueNamespace(namespace string) {
+	if !c.shardManager.Owns(&meta.ObjectMeta{Name: namespace}) {
+		return
+	}
+	c.fqQueue.Add(namespace)
+}
+
+func (c *FrameworkQuotaController) enqueueFrameworkNamespace(f *ci.Framework) {
+	fqs, err := c.fqLister.FrameworkQuotas(f.Namespace).List(labels.Everything())
+	if err != nil || len(fqs) == 0 {
+		// The namespace will be synced once any FrameworkQuota is created.
+		return
+	}
+	c.enqueueNamespace(f.Namespace)
+}
+
+// Enqueue all namespaces with FrameworkQuotas, so that the newly owned ones are
+// synced after the shards are rebalanced.
+func (c *FrameworkQuotaController) Rebalance() {
+	fqs, err := c.fqLister.List(labels.Everything())
+	if err != nil {
+		klog.Warningf("FrameworkQuota: Rebalance: "+
+			"Failed to list FrameworkQuotas from local cache: %v", err)
+		return
+	}
+	for _, fq := range fqs {
+		c.enqueueNamespace(fq.Namespace)
+	}
+}
+
+// Whether the Framework is admitted by all FrameworkQuotas in its namespace,
+// according to the local cache, and if not, the message of why it is waiting.
+// Return nil if there is no FrameworkQuota in its namespace.
+func (c *FrameworkQuotaController) GetAdmission(
+	f *ci.Framework) (admitted *bool, message string) {
+	fqs, err := c.fqLister.FrameworkQuotas(f.Namespace).List(labels.Everything())
+	if err != nil || len(fqs) == 0 {
+		return nil, ""
+	}
+
+	for _, fq := range fqs {
+		if fq.Status == nil || !containsUID(fq.Status.AdmittedFrameworkUIDs, f.UID) {
+			return common.PtrBool(false), getFrameworkQuotaWaitingMessage(fq, f)
+		}
+	}
+	return common.PtrBool(true), fmt.Sprintf(
+		"Framework is admitted by %v FrameworkQuotas", len(fqs))
+}
+
+func getFrameworkQuotaWaitingMessage(
+	fq *ci.FrameworkQuota, f *ci.Framework) string {
+	if fq.Status == nil {
+		return fmt.Sprintf(
+			"Waiting FrameworkQuota %v to be synced", fq.Name)
+	}
+
+	tasks := f.GetTaskCountSpec()
+	requests := f.GetResourceRequests()
+	if !fitsFrameworkQuota(fq, tasks, requests, 0, 0, core.ResourceList{}) {
+		return fmt.Sprintf(
+			"Framework can never be admitted by FrameworkQuota %v: "+
+				"Its TaskNumber %v or resource requests %v exceed the FrameworkQuota",
+			fq.Name, tasks, common.ToJson(requests))
+	}
+	if !fitsFrameworkQuota(fq, tasks, requests,
+		fq.Status.Frameworks, fq.Status.Tasks, fq.Status.Used) {
+		return fmt.Sprintf(
+			"Waiting FrameworkQuota %v to be released: "+
+				"Used Frameworks %v, Tasks %v, Resources %v",
+			fq.Name, fq.Status.Frameworks, fq.Status.Tasks,
+			common.ToJson(fq.Status.Used))
+	}
+	return fmt.Sprintf(
+		"Waiting earlier created Frameworks to be admitted by FrameworkQuota %v",
+		fq.Name)
+}
+
+func (c *FrameworkQuotaController) notifyFrameworks(
+	oldFQ, newFQ *ci.FrameworkQuota) {
+	if newFQ.Status == nil {
+		return
+	}
+
+	newUIDs := map[types.UID]bool{}
+	for _, uid := range newFQ.Status.AdmittedFrameworkUIDs {
+		if oldFQ.Status == nil ||
+			!containsUID(oldFQ.Status.AdmittedFrameworkUIDs, uid) {
+			newUIDs[uid] = true
+		}
+	}
+	if len(newUIDs) == 0 {
+		return
+	}
+
+	for _, f := range c.getNamespaceFrameworks(newFQ.Namespace) {
+		if newUIDs[f.UID] {
+			c.notifyFramework(f)
+		}
+	}
+}
+
+// The waiting Frameworks are no longer limited once the namespace has no
+// FrameworkQuota.
+func (c *FrameworkQuotaController) notifyWaitingFrameworks(namespace string) {
+	fqs, err := c.fqLister.FrameworkQuotas(namespace).List(labels.Everything())
+	if err != nil || len(fqs) > 0 {
+		return
+	}
+
+	for _, f := range c.getNamespaceFrameworks(namespace) {
+		if isFrameworkQuotaPending(f) {
+			c.notifyFramework(f)
+		}
+	}
+}
+
+// It should be invoked after the Framework Informer is started.
+func (c *FrameworkQuotaController) Run(stopCh <-chan struct{}) {
+	defer c.fqQueue.ShutDown()
+
+	go c.fqInformer.Run(stopCh)
+	if !cache.WaitForCacheSync(
+		stopCh,
+		c.fqInformer.HasSynced,
+		c.fInformer.HasSynced) {
+		panic(fmt.Errorf("Failed to WaitForCacheSync for FrameworkQuota"))
+	}
+
+	klog.Infof("Running FrameworkQuotaController with %v workers",
+		c.workerNumber)
+	for i := int32(0); i < c.workerNumber; i++ {
+		go wait.Until(func() {
+			for c.processNextWorkItem() {
+			}
+		}, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (c *FrameworkQuotaController) processNextWorkItem() bool {
+	key, quit := c.fqQueue.Get()
+	if quit {
+		return false
+	}
+	defer c.fqQueue.Done(key)
+
+	err := c.syncNamespace(key.(string))
+	if err == nil {
+		c.fqQueue.Forget(key)
+	} else {
+		c.fqQueue.AddRateLimited(key)
+	}
+
+	return true
+}
+
+// It should not be invoked concurrently with the same key, so that the
+// admission decisions are serialized within each namespace.
+//
+// Return error only for Platform Transient Error, so that the key
+// can be enqueued again after rate limited delay.
+func (c *FrameworkQuotaController) syncNamespace(
+	namespace string) (returnedErr error) {
+	startTime := time.Now()
+	logPfx := fmt.Sprintf("[%v]: syncFrameworkQuotas: ", namespace)
+	klog.Infof(logPfx + "Started")
+	defer func() {
+		if returnedErr != nil {
+			klog.Warning(logPfx + returnedErr.Error())
+			klog.Warning(logPfx +
+				"Failed to due to Platform Transient Error. " +
+				"Will enqueue it again after rate limited delay")
+		}
+		klog.Infof(logPfx+"Completed: Duration %v", time.Since(startTime))
+	}()
+
+	if !c.shardManager.Owns(&meta.ObjectMeta{Name: namespace}) {
+		klog.Infof(logPfx + "Skipped: Namespace does not belong to current shard")
+		return nil
+	}
+
+	localFQs, err := c.fqLister.FrameworkQuotas(namespace).List(labels.Everything())
+	if err != nil {
+		return fmt.Errorf(
+			"Failed: FrameworkQuotas cannot be listed from local cache: %v", err)
+	}
+	if len(localFQs) == 0 {
+		klog.Infof(logPfx + "Skipped: Namespace has no FrameworkQuota")
+		return nil
+	}
+
+	// All FrameworkQuotas in the namespace share the same admitted Frameworks,
+	// so any old admission decision is still respected.
+	oldAdmittedUIDs := map[types.UID]bool{}
+	for _, fq := range localFQs {
+		if fq.Status != nil {
+			for _, uid := range fq.Status.AdmittedFrameworkUIDs {
+				oldAdmittedUIDs[uid] = true
+			}
+		}
+	}
+
+	status := &ci.FrameworkQuotaStatus{
+		Used:                  core.ResourceList{},
+		AdmittedFrameworkUIDs: []types.UID{},
+	}
+	pendingFs := []*ci.Framework{}
+	for _, f := range c.getNamespaceFrameworks(namespace) {
+		// The suspended Framework releases its quota, and it will be admitted
+		// again once it is resumed.
+		if isFrameworkQueuing(f) || isFrameworkCompleted(f) ||
+			isFrameworkSuspended(f) {
+			continue
+		}
+		if isFrameworkQuotaPending(f) && !oldAdmittedUIDs[f.UID] {
+			pendingFs = append(pendingFs, f)
+			continue
+		}
+		status.AdmittedFrameworkUIDs = append(status.AdmittedFrameworkUIDs, f.UID)
+		status.Frameworks++
+		status.Tasks += f.GetTaskCountSpec()
+		addResourceList(status.Used, f.GetResourceRequests())
+	}
+
+	blocked := false
+	sortQueuingFrameworks(pendingFs, ci.QueueOrderFIFO)
+	for _, f := range pendingFs {
+		tasks := f.GetTaskCountSpec()
+		requests := f.GetResourceRequests()
+		fits := true
+		for _, fq := range localFQs {
+			if !fitsFrameworkQuota(fq, tasks, requests, 0, 0, core.ResourceList{}) {
+				klog.Warningf(logPfx+
+					"Framework %v can never be admitted: Its TaskNumber %v or "+
+					"resource requests %v exceed the FrameworkQuota %v",
+					f.Key(), tasks, common.ToJson(requests), fq.Name)
+				fits = false
+				break
+			}
+		}
+		if !fits {
+			status.WaitingFrameworks++
+			continue
+		}
+
+		for _, fq := range localFQs {
+			if !fitsFrameworkQuota(fq, tasks, requests,
+				status.Frameworks, status.Tasks, status.Used) {
+				fits = false
+				break
+			}
+		}
+		// Keep the order, i.e. the later Frameworks cannot be admitted before
+		// the earlier ones.
+		if blocked || !fits {
+			blocked = true
+			status.WaitingFrameworks++
+			continue
+		}
+
+		klog.Infof(logPfx+"Admit Framework %v with TaskNumber %v and "+
+			"resource requests %v", f.Key(), tasks, common.ToJson(requests))
+		status.AdmittedFrameworkUIDs = append(status.AdmittedFrameworkUIDs, f.UID)
+		status.Frameworks++
+		status.Tasks += tasks
+		addResourceList(status.Used, requests)
+	}
+
+	sortUIDs(status.AdmittedFrameworkUIDs)
+
+	var errs []error
+	for _, localFQ := range localFQs {
+		if common.ToJson(localFQ.Status) == common.ToJson(status) {
+			continue
+		}
+
+		fq := localFQ.DeepCopy()
+		fq.Status = status.DeepCopy()
+		_, updateErr := c.fClient.FrameworkcontrollerV1().
+			FrameworkQuotas(namespace).Update(fq)
+		if updateErr != nil {
+			errs = append(errs, fmt.Errorf(
+				"Failed to update FrameworkQuota %v Status: %v", fq.Name, updateErr))
+			continue
+		}
+		klog.Infof(logPfx+"Succeeded to update FrameworkQuota %v Status: "+
+			"Frameworks %v, Tasks %v, WaitingFrameworks %v", fq.Name,
+			status.Frameworks, status.Tasks, status.WaitingFrameworks)
+	}
+	if len(errs) > 0 {
+		return fmt.Errorf("%v", errs)
+	}
+
+	return nil
+}
+
+func (c *FrameworkQuotaController) getNamespaceFrameworks(
+	namespace string) []*ci.Framework {
+	fs, err := c.fLister.Frameworks(namespace).List(labels.Everything())
+	if err != nil {
+		// Unreachable
+		panic(fmt.Errorf("Failed to list Frameworks from local cache: %v", err))
+	}
+	return fs
+}
+
+// Whether the Framework with the tasks and requests can fit into the
+// FrameworkQuota, given the usage of admitted and not completed Frameworks.
+func fitsFrameworkQuota(
+	fq *ci.FrameworkQuota, tasks int32, requests core.ResourceList,
+	usedFrameworks int32, usedTasks int32, used core.ResourceList) bool {
+	if fq.Spec.MaxFrameworks != nil &&
+		usedFrameworks >= *fq.Spec.MaxFrameworks {
+		return false
+	}
+	if fq.Spec.MaxTasks != nil && usedTasks+tasks > *fq.Spec.MaxTasks {
+		return false
+	}
+	for name, hard := range fq.Spec.Hard {
+		total := used[name].DeepCopy()
+		total.Add(requests[name])
+		if total.Cmp(hard) > 0 {
+			return false
+		}
+	}
+	return true
+}
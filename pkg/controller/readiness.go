@@ -0,0 +1,152 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	core "k8s.io/api/core/v1"
+	errorAgg "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/klog"
+)
+
+func (c *FrameworkController) setPodReadinessGate(pod *core.Pod) {
+	if !*c.config().PodReadinessGateEnabled {
+		return
+	}
+
+	pod.Spec.ReadinessGates = append(pod.Spec.ReadinessGates, core.PodReadinessGate{
+		ConditionType: ci.PodConditionTypeFrameworkAttemptReady,
+	})
+}
+
+// Return true if all not DeletionPending Tasks in current FrameworkAttempt are
+// assigned PodIPs.
+func isFrameworkAttemptReady(f *ci.Framework) bool {
+	readyTaskCount := int32(0)
+	for _, taskRoleStatus := range f.TaskRoleStatuses() {
+		readyTaskCount += taskRoleStatus.GetTaskCountStatus(
+			func(taskStatus *ci.TaskStatus) bool {
+				return !taskStatus.DeletionPending &&
+					taskStatus.AttemptStatus.PodIP != nil &&
+					*taskStatus.AttemptStatus.PodIP != ""
+			})
+	}
+	return readyTaskCount >= f.GetTaskCountSpec()
+}
+
+// Sync the PodConditionTypeFrameworkAttemptReady condition of all Pods in
+// current FrameworkAttempt, according to whether the whole FrameworkAttempt
+// is ready.
+// Only the Pods with the readiness gate are synced, and the condition is only
+// updated if it is changed, so it is cheap to sync repeatedly.
+func (c *FrameworkController) syncPodReadinessGates(f *ci.Framework) error {
+	if !*c.config().PodReadinessGateEnabled {
+		return nil
+	}
+	status := core.ConditionFalse
+	if isFrameworkAttemptReady(f) {
+		status = core.ConditionTrue
+	}
+
+	errs := []error{}
+	for _, taskRoleStatus := range f.TaskRoleStatuses() {
+		for _, taskStatus := range taskRoleStatus.TaskStatuses {
+			if taskStatus.PodUID() == nil {
+				continue
+			}
+
+			pod, err := c.podLister.Pods(f.Namespace).Get(taskStatus.PodName())
+			if err != nil || pod.UID != *taskStatus.PodUID() ||
+				pod.DeletionTimestamp != nil || !hasPodReadinessGate(pod) {
+				continue
+			}
+			// The missing condition is treated as False by the kubelet.
+			oldStatus := getPodConditionStatus(pod)
+			if oldStatus == status ||
+				(oldStatus == "" && status == core.ConditionFalse) {
+				continue
+			}
+
+			errs = append(errs, c.updatePodReadinessCondition(f, pod, status))
+		}
+	}
+
+	return errorAgg.NewAggregate(errs)
+}
+
+func hasPodReadinessGate(pod *core.Pod) bool {
+	for _, gate := range pod.Spec.ReadinessGates {
+		if gate.ConditionType == ci.PodConditionTypeFrameworkAttemptReady {
+			return true
+		}
+	}
+	return false
+}
+
+// Return empty if the condition does not exist.
+func getPodConditionStatus(pod *core.Pod) core.ConditionStatus {
+	for _, cond := range pod.Status.Conditions {
+		if cond.Type == ci.PodConditionTypeFrameworkAttemptReady {
+			return cond.Status
+		}
+	}
+	return ""
+}
+
+func (c *FrameworkController) updatePodReadinessCondition(
+	f *ci.Framework, pod *core.Pod, status core.ConditionStatus) error {
+	updatedPod := pod.DeepCopy()
+	cond := core.PodCondition{
+		Type:               ci.PodConditionTypeFrameworkAttemptReady,
+		Status:             status,
+		LastTransitionTime: common.Now(),
+	}
+
+	found := false
+	for i := range updatedPod.Status.Conditions {
+		if updatedPod.Status.Conditions[i].Type == cond.Type {
+			updatedPod.Status.Conditions[i] = cond
+			found = true
+		}
+	}
+	if !found {
+		updatedPod.Status.Conditions = append(updatedPod.Status.Conditions, cond)
+	}
+
+	span := c.tracer.StartSpan(f.Key(), "UpdatePodStatus",
+		map[string]string{"object.name": pod.Name})
+	_, err := c.kClient.CoreV1().Pods(f.Namespace).UpdateStatus(updatedPod)
+	span.End(err)
+	if err != nil {
+		return fmt.Errorf(
+			"[%v]: Failed to update Pod %v condition %v to %v: %v",
+			f.Key(), pod.Name, cond.Type, status, err)
+	}
+
+	klog.Infof(
+		"[%v]: Succeeded to update Pod %v condition %v to %v",
+		f.Key(), pod.Name, cond.Type, status)
+	return nil
+}
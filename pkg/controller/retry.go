@@ -0,0 +1,88 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"sync"
+)
+
+// RetryDecider decides whether and how to retry a completed FrameworkAttempt
+// or TaskAttempt.
+// Downstream builds can compile in custom RetryDeciders, such as cost-aware or
+// time-of-day-aware ones, by RegisterRetryDecider in their init(), and then
+// select it by Config.RetryDecider, without forking the sync logic.
+type RetryDecider interface {
+	// It is invoked in every sync of the completed attempt until the retry is
+	// scheduled, so it should be cheap and should not have side effects.
+	ShouldRetry(rc *RetryContext) ci.RetryDecision
+}
+
+type RetryContext struct {
+	Framework *ci.Framework
+	// Empty if it is for the FrameworkAttempt.
+	TaskRoleName string
+	// Nil if it is for the FrameworkAttempt.
+	TaskStatus *ci.TaskStatus
+
+	RetryPolicy       ci.RetryPolicySpec
+	RetryPolicyStatus ci.RetryPolicyStatus
+	CompletionStatus  *ci.CompletionStatus
+
+	MinDelaySecForTransientConflictFailed int64
+	MaxDelaySecForTransientConflictFailed int64
+}
+
+// DefaultRetryDecider applies the RetryPolicySpec as is, i.e. the
+// FancyRetryPolicy if it is enabled.
+type DefaultRetryDecider struct {
+}
+
+func (d DefaultRetryDecider) ShouldRetry(rc *RetryContext) ci.RetryDecision {
+	return rc.RetryPolicy.ShouldRetry(
+		rc.RetryPolicyStatus, rc.CompletionStatus,
+		rc.MinDelaySecForTransientConflictFailed,
+		rc.MaxDelaySecForTransientConflictFailed)
+}
+
+var retryDeciders = &sync.Map{}
+
+func init() {
+	RegisterRetryDecider(ci.DefaultRetryDeciderName, DefaultRetryDecider{})
+}
+
+// It panics if the name is already registered.
+func RegisterRetryDecider(name string, decider RetryDecider) {
+	if _, loaded := retryDeciders.LoadOrStore(name, decider); loaded {
+		panic(fmt.Errorf("RetryDecider %v is already registered", name))
+	}
+}
+
+// Return nil if the name is not registered.
+func GetRetryDecider(name string) RetryDecider {
+	if decider, ok := retryDeciders.Load(name); ok {
+		return decider.(RetryDecider)
+	}
+	return nil
+}
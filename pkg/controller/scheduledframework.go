@@ -0,0 +1,465 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	frameworkClient "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned"
+	frameworkInformer "github.com/microsoft/frameworkcontroller/pkg/client/informers/externalversions/frameworkcontroller/v1"
+	frameworkLister "github.com/microsoft/frameworkcontroller/pkg/client/listers/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"github.com/microsoft/frameworkcontroller/pkg/internal"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	errorAgg "k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+	"reflect"
+	"sort"
+	"time"
+)
+
+// ScheduledFrameworkController creates Frameworks for ScheduledFrameworks on
+// their cron schedules, and cleans up their completed Frameworks beyond the
+// history limits.
+// See ScheduledFramework.
+type ScheduledFrameworkController struct {
+	fClient frameworkClient.Interface
+
+	sfInformer cache.SharedIndexInformer
+	fInformer  cache.SharedIndexInformer
+	sfLister   frameworkLister.ScheduledFrameworkLister
+	fLister    frameworkLister.FrameworkLister
+
+	// ScheduledFramework Key -> ScheduledFramework
+	// It is also used to delay the sync until the next scheduled time.
+	sfQueue workqueue.RateLimitingInterface
+
+	shardManager *ShardManager
+	workerNumber int32
+}
+
+func NewScheduledFrameworkController(
+	fClient frameworkClient.Interface,
+	sfListerInformer frameworkInformer.ScheduledFrameworkInformer,
+	fInformer cache.SharedIndexInformer,
+	fLister frameworkLister.FrameworkLister,
+	shardManager *ShardManager,
+	workerNumber int32) *ScheduledFrameworkController {
+	c := &ScheduledFrameworkController{
+		fClient:      fClient,
+		sfInformer:   sfListerInformer.Informer(),
+		fInformer:    fInformer,
+		sfLister:     sfListerInformer.Lister(),
+		fLister:      fLister,
+		sfQueue:      workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		shardManager: shardManager,
+		workerNumber: workerNumber,
+	}
+
+	c.sfInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueScheduledFrameworkObj(internal.ToScheduledFramework(obj))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.enqueueScheduledFrameworkObj(internal.ToScheduledFramework(newObj))
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.enqueueScheduledFrameworkObj(internal.ToScheduledFramework(obj))
+		},
+	})
+
+	// Only the Framework creation, completion and deletion impact the
+	// ScheduledFramework.
+	c.fInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueFrameworkOwner(internal.ToFramework(obj))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			oldF := internal.ToFramework(oldObj)
+			newF := internal.ToFramework(newObj)
+			if isFrameworkCompleted(oldF) != isFrameworkCompleted(newF) {
+				c.enqueueFrameworkOwner(newF)
+			}
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.enqueueFrameworkOwner(internal.ToFramework(obj))
+		},
+	})
+
+	return c
+}
+
+func isFrameworkCompleted(f *ci.Framework) bool {
+	return f.Status != nil && f.IsCompleted()
+}
+
+func (c *ScheduledFrameworkController) enqueueScheduledFrameworkObj(
+	sf *ci.ScheduledFramework) {
+	if !c.shardManager.Owns(sf) {
+		return
+	}
+	c.sfQueue.Add(sf.Key())
+}
+
+func (c *ScheduledFrameworkController) enqueueFrameworkOwner(f *ci.Framework) {
+	owner := meta.GetControllerOf(f)
+	if owner == nil || owner.Kind != ci.ScheduledFrameworkKind {
+		return
+	}
+
+	sf, err := c.sfLister.ScheduledFrameworks(f.Namespace).Get(owner.Name)
+	if err != nil || sf.UID != owner.UID {
+		// The owner has been deleted, and the Framework will be garbage collected.
+		return
+	}
+	c.enqueueScheduledFrameworkObj(sf)
+}
+
+// Enqueue all ScheduledFrameworks, so that the newly owned ones are synced after
+// the shards are rebalanced.
+func (c *ScheduledFrameworkController) Rebalance() {
+	sfs, err := c.sfLister.List(labels.Everything())
+	if err != nil {
+		klog.Warningf("ScheduledFramework: Rebalance: "+
+			"Failed to list ScheduledFrameworks from local cache: %v", err)
+		return
+	}
+	for _, sf := range sfs {
+		c.enqueueScheduledFrameworkObj(sf)
+	}
+}
+
+// It should be invoked after the Framework Informer is started.
+func (c *ScheduledFrameworkController) Run(stopCh <-chan struct{}) {
+	defer c.sfQueue.ShutDown()
+
+	go c.sfInformer.Run(stopCh)
+	if !cache.WaitForCacheSync(
+		stopCh,
+		c.sfInformer.HasSynced,
+		c.fInformer.HasSynced) {
+		panic(fmt.Errorf("Failed to WaitForCacheSync for ScheduledFramework"))
+	}
+
+	klog.Infof("Running ScheduledFrameworkController with %v workers",
+		c.workerNumber)
+	for i := int32(0); i < c.workerNumber; i++ {
+		go wait.Until(func() {
+			for c.processNextWorkItem() {
+			}
+		}, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (c *ScheduledFrameworkController) processNextWorkItem() bool {
+	key, quit := c.sfQueue.Get()
+	if quit {
+		return false
+	}
+	defer c.sfQueue.Done(key)
+
+	err := c.syncScheduledFramework(key.(string))
+	if err == nil {
+		c.sfQueue.Forget(key)
+	} else {
+		c.sfQueue.AddRateLimited(key)
+	}
+
+	return true
+}
+
+// It should not be invoked concurrently with the same key.
+//
+// Return error only for Platform Transient Error, so that the key
+// can be enqueued again after rate limited delay.
+func (c *ScheduledFrameworkController) syncScheduledFramework(
+	key string) (returnedErr error) {
+	startTime := time.Now()
+	logPfx := fmt.Sprintf("[%v]: syncScheduledFramework: ", key)
+	klog.Infof(logPfx + "Started")
+	defer func() {
+		if returnedErr != nil {
+			klog.Warning(logPfx + returnedErr.Error())
+			klog.Warning(logPfx +
+				"Failed to due to Platform Transient Error. " +
+				"Will enqueue it again after rate limited delay")
+		}
+		klog.Infof(logPfx+"Completed: Duration %v", time.Since(startTime))
+	}()
+
+	sfNamespace, sfName := ci.SplitFrameworkKey(key)
+	localSF, err := c.sfLister.ScheduledFrameworks(sfNamespace).Get(sfName)
+	if err != nil {
+		if apiErrors.IsNotFound(err) {
+			// GarbageCollectionController will handle the dependent object
+			// deletion according to the ownerReferences.
+			klog.Infof(logPfx+
+				"Skipped: ScheduledFramework cannot be found in local cache: %v", err)
+			return nil
+		} else {
+			return fmt.Errorf(
+				"Failed: ScheduledFramework cannot be got from local cache: %v", err)
+		}
+	}
+
+	sf := localSF.DeepCopy()
+	if !c.shardManager.Owns(sf) {
+		klog.Infof(logPfx + "Skipped: ScheduledFramework does not belong to current shard")
+		return nil
+	}
+	if sf.DeletionTimestamp != nil {
+		klog.Infof(logPfx + "Skipped: ScheduledFramework is deleting")
+		return nil
+	}
+
+	schedule, err := common.ParseCronSchedule(sf.Spec.Schedule)
+	if err != nil {
+		// User Error will not be fixed by retry, so just wait for the spec update.
+		klog.Warningf(logPfx+"Skipped: Schedule is invalid: %v", err)
+		return nil
+	}
+	location := time.UTC
+	if sf.Spec.TimeZone != nil {
+		location, err = time.LoadLocation(*sf.Spec.TimeZone)
+		if err != nil {
+			klog.Warningf(logPfx+"Skipped: TimeZone is invalid: %v", err)
+			return nil
+		}
+	}
+
+	fs, err := c.getScheduledFrameworks(sf)
+	if err != nil {
+		return err
+	}
+
+	if sf.Status == nil {
+		sf.Status = &ci.ScheduledFrameworkStatus{}
+	}
+	remoteStatus := sf.Status.DeepCopy()
+
+	activeFs := []*ci.Framework{}
+	succeededFs := []*ci.Framework{}
+	failedFs := []*ci.Framework{}
+	for _, f := range fs {
+		if !isFrameworkCompleted(f) {
+			activeFs = append(activeFs, f)
+		} else if f.IsSucceeded() {
+			succeededFs = append(succeededFs, f)
+		} else {
+			failedFs = append(failedFs, f)
+		}
+	}
+
+	errs := []error{}
+	errs = append(errs, c.deleteFrameworkHistory(sf, succeededFs,
+		sf.Spec.SucceededFrameworksHistoryLimit, 3))
+	errs = append(errs, c.deleteFrameworkHistory(sf, failedFs,
+		sf.Spec.FailedFrameworksHistoryLimit, 1))
+
+	now := time.Now()
+	scheduledTime, nextScheduledTime := c.getScheduledTimes(sf, schedule, location, now)
+	if sf.Spec.Suspend {
+		klog.Infof(logPfx + "Skip to schedule: ScheduledFramework is suspended")
+	} else if scheduledTime != nil {
+		createdF, scheduleErr := c.scheduleFramework(sf, *scheduledTime, now, activeFs)
+		errs = append(errs, scheduleErr)
+		if createdF != nil {
+			activeFs = append(activeFs, createdF)
+		}
+	}
+
+	sf.Status.ActiveFrameworks = []string{}
+	for _, f := range activeFs {
+		sf.Status.ActiveFrameworks = append(sf.Status.ActiveFrameworks, f.Name)
+	}
+	sort.Strings(sf.Status.ActiveFrameworks)
+
+	if !reflect.DeepEqual(remoteStatus, sf.Status) {
+		_, updateErr := c.fClient.FrameworkcontrollerV1().ScheduledFrameworks(
+			sf.Namespace).Update(sf)
+		if updateErr != nil {
+			errs = append(errs, fmt.Errorf(
+				"Failed to update ScheduledFramework.Status: %v", updateErr))
+		}
+	}
+
+	if !sf.Spec.Suspend && !nextScheduledTime.IsZero() {
+		delay := nextScheduledTime.Sub(now)
+		klog.Infof(logPfx+"Waiting next scheduled time %v after %v",
+			nextScheduledTime, delay)
+		c.sfQueue.AddAfter(key, delay)
+	}
+
+	return errorAgg.NewAggregate(errs)
+}
+
+func (c *ScheduledFrameworkController) getScheduledFrameworks(
+	sf *ci.ScheduledFramework) ([]*ci.Framework, error) {
+	selector := labels.SelectorFromSet(labels.Set{
+		ci.LabelKeyScheduledFrameworkName: sf.Name})
+	fs, err := c.fLister.Frameworks(sf.Namespace).List(selector)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Failed to list Frameworks from local cache: %v", err)
+	}
+
+	controlledFs := []*ci.Framework{}
+	for _, f := range fs {
+		if meta.IsControlledBy(f, sf) {
+			controlledFs = append(controlledFs, f)
+		}
+	}
+	return controlledFs, nil
+}
+
+// Return the most recent scheduled time which is not yet handled and not after
+// now, and the next scheduled time which is after now.
+func (c *ScheduledFrameworkController) getScheduledTimes(
+	sf *ci.ScheduledFramework, schedule *common.CronSchedule,
+	location *time.Location, now time.Time) (
+	scheduledTime *time.Time, nextScheduledTime time.Time) {
+	earliestTime := sf.CreationTimestamp.Time
+	if sf.Status.LastScheduleTime != nil {
+		earliestTime = sf.Status.LastScheduleTime.Time
+	}
+	if sf.Spec.StartingDeadlineSec != nil {
+		// The scheduled times before the deadline will be skipped anyway.
+		deadlineTime := now.Add(-common.SecToDuration(sf.Spec.StartingDeadlineSec))
+		if deadlineTime.After(earliestTime) {
+			earliestTime = deadlineTime.Add(-time.Minute)
+		}
+	}
+
+	t := schedule.Next(earliestTime.In(location))
+	for !t.IsZero() && !t.After(now) {
+		missedTime := t
+		scheduledTime = &missedTime
+		t = schedule.Next(t)
+	}
+	return scheduledTime, t
+}
+
+// Return the created Framework if it is created.
+func (c *ScheduledFrameworkController) scheduleFramework(
+	sf *ci.ScheduledFramework, scheduledTime time.Time, now time.Time,
+	activeFs []*ci.Framework) (*ci.Framework, error) {
+	logPfx := fmt.Sprintf("[%v]: scheduleFramework: ", sf.Key())
+
+	if sf.Spec.StartingDeadlineSec != nil &&
+		now.Sub(scheduledTime) > common.SecToDuration(sf.Spec.StartingDeadlineSec) {
+		klog.Warningf(logPfx+
+			"Skip to schedule at %v: StartingDeadlineSec %v is exceeded",
+			scheduledTime, *sf.Spec.StartingDeadlineSec)
+		sf.Status.LastScheduleTime = &meta.Time{Time: scheduledTime}
+		return nil, nil
+	}
+
+	if len(activeFs) > 0 {
+		switch sf.Spec.ConcurrencyPolicy {
+		case ci.ForbidConcurrent:
+			// The scheduled time is not handled, so it will be scheduled once the
+			// active Frameworks are completed, if it is still within the deadline.
+			klog.Infof(logPfx+
+				"Skip to schedule at %v: ConcurrencyPolicy is %v and "+
+				"there are %v active Frameworks",
+				scheduledTime, sf.Spec.ConcurrencyPolicy, len(activeFs))
+			return nil, nil
+		case ci.ReplaceConcurrent:
+			for _, f := range activeFs {
+				err := c.deleteFramework(sf, f)
+				if err != nil {
+					return nil, err
+				}
+			}
+		}
+	}
+
+	f := sf.NewFramework(scheduledTime)
+	remoteF, createErr := c.fClient.FrameworkcontrollerV1().Frameworks(
+		f.Namespace).Create(f)
+	if createErr != nil {
+		if !apiErrors.IsAlreadyExists(createErr) {
+			return nil, fmt.Errorf(
+				"Failed to create Framework %v: %v", f.Name, createErr)
+		}
+		// The Framework has been created in previous sync but the
+		// LastScheduleTime was failed to persist.
+		klog.Infof(logPfx+"Framework %v already exists", f.Name)
+		remoteF = nil
+	} else {
+		klog.Infof(logPfx+"Succeeded to create Framework %v scheduled at %v",
+			f.Name, scheduledTime)
+	}
+
+	sf.Status.LastScheduleTime = &meta.Time{Time: scheduledTime}
+	return remoteF, nil
+}
+
+func (c *ScheduledFrameworkController) deleteFrameworkHistory(
+	sf *ci.ScheduledFramework, completedFs []*ci.Framework,
+	historyLimit *int32, defaultHistoryLimit int32) error {
+	limit := defaultHistoryLimit
+	if historyLimit != nil {
+		limit = *historyLimit
+	}
+	if int32(len(completedFs)) <= limit {
+		return nil
+	}
+
+	// Delete the earliest completed ones.
+	sort.Slice(completedFs, func(i, j int) bool {
+		return completedFs[i].Status.CompletionTime.Before(
+			completedFs[j].Status.CompletionTime)
+	})
+	errs := []error{}
+	for _, f := range completedFs[:int32(len(completedFs))-limit] {
+		errs = append(errs, c.deleteFramework(sf, f))
+	}
+	return errorAgg.NewAggregate(errs)
+}
+
+func (c *ScheduledFrameworkController) deleteFramework(
+	sf *ci.ScheduledFramework, f *ci.Framework) error {
+	if f.DeletionTimestamp != nil {
+		return nil
+	}
+
+	err := c.fClient.FrameworkcontrollerV1().Frameworks(f.Namespace).Delete(
+		f.Name, &meta.DeleteOptions{
+			Preconditions:     &meta.Preconditions{UID: &f.UID},
+			PropagationPolicy: common.PtrDeletionPropagation(meta.DeletePropagationForeground),
+		})
+	if err != nil && !apiErrors.IsNotFound(err) {
+		return fmt.Errorf("Failed to delete Framework %v: %v", f.Name, err)
+	}
+
+	klog.Infof("[%v]: Succeeded to delete Framework %v", sf.Key(), f.Name)
+	return nil
+}
@@ -0,0 +1,161 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	core "k8s.io/api/core/v1"
+	"sync"
+)
+
+// SecretStore resolves the TaskRole SecretRefs of its store, so that the
+// Pods can consume the secrets without copying them into the Pod template.
+// Downstream builds can compile in the external SecretStores, such as Vault,
+// by RegisterSecretStore in their init(), and then select it by the
+// SecretRef Store.
+type SecretStore interface {
+	// Return the EnvVar named ref.EnvName to hold the secret value, such as
+	// referring to a Kubernetes Secret key.
+	ResolveEnv(f *ci.Framework, ref *ci.SecretRefSpec) (*core.EnvVar, error)
+	// Return the VolumeSource which holds the secret value in the file named
+	// ref.Key, such as projecting a Kubernetes Secret key or a CSI secret volume.
+	ResolveVolume(f *ci.Framework, ref *ci.SecretRefSpec) (*core.VolumeSource, error)
+}
+
+// KubernetesSecretStore refers to the Kubernetes Secrets in the Framework
+// namespace, so the secret values are never exposed to the Pod object.
+type KubernetesSecretStore struct {
+}
+
+func (s KubernetesSecretStore) ResolveEnv(
+	f *ci.Framework, ref *ci.SecretRefSpec) (*core.EnvVar, error) {
+	return &core.EnvVar{
+		Name: ref.EnvName,
+		ValueFrom: &core.EnvVarSource{
+			SecretKeyRef: &core.SecretKeySelector{
+				LocalObjectReference: core.LocalObjectReference{Name: ref.Name},
+				Key:                  ref.Key,
+			},
+		},
+	}, nil
+}
+
+func (s KubernetesSecretStore) ResolveVolume(
+	f *ci.Framework, ref *ci.SecretRefSpec) (*core.VolumeSource, error) {
+	return &core.VolumeSource{
+		Secret: &core.SecretVolumeSource{
+			SecretName: ref.Name,
+			Items:      []core.KeyToPath{{Key: ref.Key, Path: ref.Key}},
+		},
+	}, nil
+}
+
+var secretStores = &sync.Map{}
+
+func init() {
+	RegisterSecretStore(ci.SecretStoreKubernetes, KubernetesSecretStore{})
+	RegisterPodDecorator(ci.SecretRefPodDecoratorName, SecretRefPodDecorator{})
+}
+
+// It panics if the name is already registered.
+func RegisterSecretStore(name string, store SecretStore) {
+	if _, loaded := secretStores.LoadOrStore(name, store); loaded {
+		panic(fmt.Errorf("SecretStore %v is already registered", name))
+	}
+}
+
+// Return nil if the name is not registered.
+func GetSecretStore(name string) SecretStore {
+	if store, ok := secretStores.Load(name); ok {
+		return store.(SecretStore)
+	}
+	return nil
+}
+
+// SecretRefPodDecorator injects the TaskRole SecretRefs into the Task Pods as
+// environment variables or mounted files, by their SecretStores.
+type SecretRefPodDecorator struct {
+}
+
+func (d SecretRefPodDecorator) Decorate(dc *PodDecorateContext) error {
+	if dc.TaskRoleName == "" {
+		return nil
+	}
+
+	pod := dc.Pod
+	refEnvs := []core.EnvVar{}
+	refMounts := []core.VolumeMount{}
+	for i := range dc.Framework.TaskRoleSpec(dc.TaskRoleName).SecretRefs {
+		ref := &dc.Framework.TaskRoleSpec(dc.TaskRoleName).SecretRefs[i]
+		storeName := ref.Store
+		if storeName == "" {
+			storeName = ci.SecretStoreKubernetes
+		}
+		store := GetSecretStore(storeName)
+		if store == nil {
+			return fmt.Errorf("SecretRef %v: SecretStore %v is not registered",
+				i, storeName)
+		}
+
+		if ref.EnvName != "" {
+			env, err := store.ResolveEnv(dc.Framework, ref)
+			if err != nil {
+				return fmt.Errorf("SecretRef %v: %v", i, err)
+			}
+			refEnvs = append(refEnvs, *env)
+		} else {
+			volumeSource, err := store.ResolveVolume(dc.Framework, ref)
+			if err != nil {
+				return fmt.Errorf("SecretRef %v: %v", i, err)
+			}
+			volumeName := fmt.Sprintf("%v%v", ci.SecretRefVolumeNamePrefix, i)
+			pod.Spec.Volumes = append(pod.Spec.Volumes, core.Volume{
+				Name:         volumeName,
+				VolumeSource: *volumeSource,
+			})
+			refMounts = append(refMounts, core.VolumeMount{
+				Name:      volumeName,
+				MountPath: ref.MountPath,
+				SubPath:   ref.Key,
+				ReadOnly:  true,
+			})
+		}
+	}
+
+	// Prepend refEnvs so that they can be referred by the environment variable
+	// specified in the spec.
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Env = append(append([]core.EnvVar{},
+			refEnvs...), pod.Spec.Containers[i].Env...)
+		pod.Spec.Containers[i].VolumeMounts = append(
+			pod.Spec.Containers[i].VolumeMounts, refMounts...)
+	}
+	for i := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[i].Env = append(append([]core.EnvVar{},
+			refEnvs...), pod.Spec.InitContainers[i].Env...)
+		pod.Spec.InitContainers[i].VolumeMounts = append(
+			pod.Spec.InitContainers[i].VolumeMounts, refMounts...)
+	}
+	return nil
+}
@@ -0,0 +1,328 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"github.com/microsoft/frameworkcontroller/pkg/internal"
+	errorWrap "github.com/pkg/errors"
+	core "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/klog"
+	"strings"
+)
+
+// Ensure the headless Service of each TaskRole in current FrameworkAttemptInstance
+// exists before its Pods are created.
+// The Service creation is idempotent, so it is safe to create again if the
+// HeadlessServiceUID is failed to persist.
+func (c *FrameworkController) syncHeadlessServices(
+	f *ci.Framework, cm *core.ConfigMap) error {
+	if !*c.config().TaskRoleHeadlessServiceEnabled {
+		return nil
+	}
+	logPfx := fmt.Sprintf("[%v]: syncHeadlessServices: ", f.Key())
+
+	for _, taskRoleStatus := range f.TaskRoleStatuses() {
+		if taskRoleStatus.HeadlessServiceUID != nil {
+			continue
+		}
+
+		svc, err := c.createService(
+			f, cm, taskRoleStatus.Name, f.NewHeadlessService(cm, taskRoleStatus.Name))
+		if err != nil {
+			return c.handleObjectCreationError(f, logPfx, "headless Service", err)
+		}
+		taskRoleStatus.HeadlessServiceUID = &svc.UID
+	}
+
+	return nil
+}
+
+// Ensure the exposed Service and Ingress of each TaskRole in current
+// FrameworkAttemptInstance exist.
+// The creation is idempotent, so it is safe to create again if the UIDs are
+// failed to persist.
+func (c *FrameworkController) syncExposedServices(
+	f *ci.Framework, cm *core.ConfigMap) error {
+	logPfx := fmt.Sprintf("[%v]: syncExposedServices: ", f.Key())
+
+	for _, taskRoleStatus := range f.TaskRoleStatuses() {
+		taskRoleSpec := f.GetTaskRoleSpec(taskRoleStatus.Name)
+		if taskRoleSpec == nil || taskRoleSpec.Expose == nil {
+			continue
+		}
+
+		if taskRoleStatus.ExposedServiceUID == nil {
+			svc, err := c.createService(
+				f, cm, taskRoleStatus.Name, f.NewExposedService(cm, taskRoleStatus.Name))
+			if err != nil {
+				return c.handleObjectCreationError(f, logPfx, "exposed Service", err)
+			}
+			taskRoleStatus.ExposedServiceUID = &svc.UID
+		}
+
+		if taskRoleSpec.Expose.Ingress != nil && taskRoleStatus.IngressUID == nil {
+			uid, err := c.createIngress(f, cm, taskRoleSpec)
+			if err != nil {
+				return c.handleObjectCreationError(f, logPfx, "Ingress", err)
+			}
+			taskRoleStatus.IngressUID = uid
+		}
+	}
+
+	return nil
+}
+
+func (c *FrameworkController) handleObjectCreationError(
+	f *ci.Framework, logPfx string, objectKind string, err error) error {
+	apiErr := errorWrap.Cause(err)
+	if internal.IsPodSpecPermanentError(apiErr) {
+		// Should be Framework Error instead of Platform Transient Error.
+		diag := fmt.Sprintf(
+			"Failed to create %v: %v", objectKind, common.ToJson(apiErr))
+		klog.Info(logPfx + diag)
+		c.completeFrameworkAttempt(f, false,
+			ci.CompletionCodePodSpecPermanentError.
+				NewFrameworkAttemptCompletionStatus(diag, nil))
+		return nil
+	}
+	return err
+}
+
+func (c *FrameworkController) createService(
+	f *ci.Framework, cm *core.ConfigMap, taskRoleName string,
+	svc *core.Service) (*core.Service, error) {
+	errPfx := fmt.Sprintf(
+		"[%v][%v]: Failed to create Service %v",
+		f.Key(), taskRoleName, svc.Name)
+	svcClient := c.kClient.CoreV1().Services(f.Namespace)
+
+	span := c.tracer.StartSpan(f.Key(), "CreateService",
+		map[string]string{"object.name": svc.Name})
+	remoteSvc, createErr := svcClient.Create(svc)
+	span.End(createErr)
+	if createErr != nil {
+		if !apiErrors.IsAlreadyExists(createErr) {
+			return nil, errorWrap.Wrapf(createErr, errPfx)
+		}
+
+		var getErr error
+		remoteSvc, getErr = svcClient.Get(svc.Name, meta.GetOptions{})
+		if getErr != nil {
+			return nil, fmt.Errorf(errPfx+": %v: %v", createErr, getErr)
+		}
+		if !meta.IsControlledBy(remoteSvc, cm) {
+			// The Service of previous FrameworkAttemptInstance may be not yet
+			// garbage collected, so just retry later.
+			return nil, fmt.Errorf(errPfx+": "+
+				"Service naming conflicts with others: "+
+				"Existing Service %v is not controlled by current ConfigMap %v, %v",
+				remoteSvc.UID, cm.Name, cm.UID)
+		}
+	} else {
+		klog.Infof(
+			"[%v][%v]: Succeeded to create Service %v",
+			f.Key(), taskRoleName, svc.Name)
+	}
+
+	return remoteSvc, nil
+}
+
+func newIngress(
+	f *ci.Framework, cm *core.ConfigMap,
+	taskRoleSpec *ci.TaskRoleSpec) *unstructured.Unstructured {
+	ingressSpec := taskRoleSpec.Expose.Ingress
+	svcName := ci.GetExposedServiceName(f.Name, taskRoleSpec.Name)
+
+	path := ingressSpec.Path
+	if path == "" {
+		path = "/"
+	}
+	svcPort := ingressSpec.ServicePort
+	if svcPort == 0 && len(taskRoleSpec.Expose.Ports) > 0 {
+		svcPort = taskRoleSpec.Expose.Ports[0].Port
+	}
+
+	rule := map[string]interface{}{
+		"http": map[string]interface{}{
+			"paths": []interface{}{
+				map[string]interface{}{
+					"path":     path,
+					"pathType": "Prefix",
+					"backend": map[string]interface{}{
+						"service": map[string]interface{}{
+							"name": svcName,
+							"port": map[string]interface{}{
+								"number": int64(svcPort),
+							},
+						},
+					},
+				},
+			},
+		},
+	}
+	if ingressSpec.Host != "" {
+		rule["host"] = ingressSpec.Host
+	}
+	spec := map[string]interface{}{
+		"rules": []interface{}{rule},
+	}
+	if ingressSpec.ClassName != "" {
+		spec["ingressClassName"] = ingressSpec.ClassName
+	}
+
+	ingress := &unstructured.Unstructured{Object: map[string]interface{}{
+		"spec": spec,
+	}}
+	ingress.SetAPIVersion(ci.IngressGroupVersionResource.GroupVersion().String())
+	ingress.SetKind(ci.IngressKind)
+	ingress.SetNamespace(f.Namespace)
+	ingress.SetName(svcName)
+	ingress.SetLabels(map[string]string{
+		ci.LabelKeyFrameworkName: f.Name,
+		ci.LabelKeyTaskRoleName:  taskRoleSpec.Name,
+	})
+	ingress.SetOwnerReferences([]meta.OwnerReference{
+		*meta.NewControllerRef(cm, ci.ConfigMapGroupVersionKind)})
+	return ingress
+}
+
+func (c *FrameworkController) createIngress(
+	f *ci.Framework, cm *core.ConfigMap,
+	taskRoleSpec *ci.TaskRoleSpec) (*types.UID, error) {
+	ingress := newIngress(f, cm, taskRoleSpec)
+	errPfx := fmt.Sprintf(
+		"[%v][%v]: Failed to create Ingress %v",
+		f.Key(), taskRoleSpec.Name, ingress.GetName())
+	ingressClient := c.dClient.Resource(ci.IngressGroupVersionResource).
+		Namespace(f.Namespace)
+
+	span := c.tracer.StartSpan(f.Key(), "CreateIngress",
+		map[string]string{"object.name": ingress.GetName()})
+	remoteIngress, createErr := ingressClient.Create(ingress, meta.CreateOptions{})
+	span.End(createErr)
+	if createErr != nil {
+		if !apiErrors.IsAlreadyExists(createErr) {
+			return nil, errorWrap.Wrapf(createErr, errPfx)
+		}
+
+		var getErr error
+		remoteIngress, getErr = ingressClient.Get(ingress.GetName(), meta.GetOptions{})
+		if getErr != nil {
+			return nil, fmt.Errorf(errPfx+": %v: %v", createErr, getErr)
+		}
+		if !meta.IsControlledBy(remoteIngress, cm) {
+			// The Ingress of previous FrameworkAttemptInstance may be not yet
+			// garbage collected, so just retry later.
+			return nil, fmt.Errorf(errPfx+": "+
+				"Ingress naming conflicts with others: "+
+				"Existing Ingress %v is not controlled by current ConfigMap %v, %v",
+				remoteIngress.GetUID(), cm.Name, cm.UID)
+		}
+	} else {
+		klog.Infof(
+			"[%v][%v]: Succeeded to create Ingress %v",
+			f.Key(), taskRoleSpec.Name, ingress.GetName())
+	}
+
+	uid := remoteIngress.GetUID()
+	return &uid, nil
+}
+
+// Make the Pod resolvable by its stable DNS name in the headless Service of its
+// TaskRole.
+func (c *FrameworkController) setTaskHostname(
+	f *ci.Framework, pod *core.Pod, taskRoleName string, taskIndex int32) {
+	if !*c.config().TaskRoleHeadlessServiceEnabled ||
+		!*c.config().TaskHostnameEnabled {
+		return
+	}
+
+	if pod.Spec.Hostname == "" {
+		pod.Spec.Hostname = ci.GetTaskHostname(taskRoleName, taskIndex)
+	}
+	if pod.Spec.Subdomain == "" {
+		pod.Spec.Subdomain = ci.GetHeadlessServiceName(f.Name, taskRoleName)
+	}
+}
+
+// Inject the peer addressing environment variables of all TaskRoles in the
+// Spec, so that simple data-parallel Tasks can find each other without the
+// FrameworkBarrier.
+// The hosts are only available if the default Task hostname is used, and the
+// variables are not updated for the existing Pods after the Framework rescaled.
+func (c *FrameworkController) setPeerEnvs(f *ci.Framework, pod *core.Pod) {
+	hostsEnabled := *c.config().TaskRoleHeadlessServiceEnabled &&
+		*c.config().TaskHostnameEnabled
+
+	peerEnvs := []core.EnvVar{}
+	for i, taskRole := range f.Spec.TaskRoles {
+		taskNumber := f.GetTaskNumber(taskRole)
+		peerEnvs = append(peerEnvs, core.EnvVar{
+			Name:  ci.GetTaskRoleEnvName(taskRole.Name, ci.EnvNameSuffixTaskNumber),
+			Value: fmt.Sprint(taskNumber),
+		})
+		if !hostsEnabled {
+			continue
+		}
+
+		svcName := ci.GetHeadlessServiceName(f.Name, taskRole.Name)
+		hosts := []string{}
+		for taskIndex := int32(0); taskIndex < taskNumber; taskIndex++ {
+			hosts = append(hosts,
+				ci.GetTaskHostname(taskRole.Name, taskIndex)+"."+svcName)
+		}
+		peerEnvs = append(peerEnvs,
+			core.EnvVar{
+				Name:  ci.GetTaskRoleEnvName(taskRole.Name, ci.EnvNameSuffixServiceName),
+				Value: svcName,
+			},
+			core.EnvVar{
+				Name:  ci.GetTaskRoleEnvName(taskRole.Name, ci.EnvNameSuffixHosts),
+				Value: strings.Join(hosts, ","),
+			})
+		if i == 0 && len(hosts) > 0 {
+			peerEnvs = append(peerEnvs, core.EnvVar{
+				Name:  ci.EnvNameMasterHost,
+				Value: hosts[0],
+			})
+		}
+	}
+
+	// Prepend peerEnvs so that they can be referred by the environment variable
+	// specified in the spec.
+	for i := range pod.Spec.Containers {
+		pod.Spec.Containers[i].Env = append(append([]core.EnvVar{},
+			peerEnvs...), pod.Spec.Containers[i].Env...)
+	}
+	for i := range pod.Spec.InitContainers {
+		pod.Spec.InitContainers[i].Env = append(append([]core.EnvVar{},
+			peerEnvs...), pod.Spec.InitContainers[i].Env...)
+	}
+}
@@ -0,0 +1,313 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"hash/fnv"
+	coordination "k8s.io/api/coordination/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	kubeClient "k8s.io/client-go/kubernetes"
+	"k8s.io/klog"
+	"sort"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// ShardManager decides whether a Framework belongs to the shard owned by
+// current FrameworkController instance.
+// See ShardingConfig.
+type ShardManager struct {
+	kClient kubeClient.Interface
+	sConfig *ci.ShardingConfig
+
+	// Invoked after the dynamic membership is changed, or recovered from stale,
+	// so that all the objects dropped meanwhile can be enqueued again.
+	onRebalance func()
+
+	// Protect below fields, since they are read by Informer handlers and workers
+	// and written by the membership heartbeat.
+	lock        sync.RWMutex
+	shardNumber int32
+	shardIndex  int32
+	// The last time current member Lease is renewed successfully.
+	lastRenewTime time.Time
+	// Handoff fence: Before handoffDeadline, only the objects which also belong
+	// to the previous shard prevShardIndex/prevShardNumber are owned, since the
+	// newly gained ones may be still synced by their previous owners, which only
+	// stop syncing them once they observe the new membership or become stale,
+	// both of which happen within MemberLeaseDurationSec.
+	// prevShardIndex < 0 means no object is owned before handoffDeadline.
+	handoffDeadline time.Time
+	handoffPending  bool
+	prevShardIndex  int32
+	prevShardNumber int32
+}
+
+func NewShardManager(
+	kClient kubeClient.Interface, sConfig *ci.ShardingConfig,
+	onRebalance func()) *ShardManager {
+	return &ShardManager{
+		kClient:     kClient,
+		sConfig:     sConfig,
+		onRebalance: onRebalance,
+		shardNumber: *sConfig.ShardNumber,
+		shardIndex:  *sConfig.ShardIndex,
+	}
+}
+
+// It should be invoked before any Framework is synced, so that the initial
+// membership is already decided.
+func (m *ShardManager) Run(stopCh <-chan struct{}) {
+	if !*m.sConfig.MembershipEnabled {
+		klog.Infof("Running with static shard %v/%v", m.shardIndex, m.shardNumber)
+		return
+	}
+
+	klog.Infof("Running with dynamic shard as member %v", *m.sConfig.MemberID)
+	err := wait.PollImmediate(
+		common.SecToDuration(m.sConfig.MemberRenewIntervalSec),
+		common.SecToDuration(m.sConfig.MemberLeaseDurationSec),
+		func() (bool, error) {
+			if err := m.syncMembership(); err != nil {
+				klog.Warning(err.Error())
+				return false, nil
+			}
+			return true, nil
+		})
+	if err != nil {
+		panic(fmt.Errorf("Failed to join the shard membership: %v", err))
+	}
+
+	go wait.Until(func() {
+		if err := m.syncMembership(); err != nil {
+			klog.Warning(err.Error())
+		}
+	}, common.SecToDuration(m.sConfig.MemberRenewIntervalSec), stopCh)
+}
+
+// Best effort to leave the membership, so that other members can take over
+// current shard without waiting for the Lease to expire.
+func (m *ShardManager) Leave() {
+	if !*m.sConfig.MembershipEnabled {
+		return
+	}
+
+	leaseName := m.memberLeaseName()
+	err := m.kClient.CoordinationV1().Leases(*m.sConfig.MembershipNamespace).Delete(
+		leaseName, &meta.DeleteOptions{})
+	if err != nil && !apiErrors.IsNotFound(err) {
+		klog.Warningf("Failed to delete member Lease %v: %v", leaseName, err)
+	} else {
+		klog.Infof("Succeeded to delete member Lease %v", leaseName)
+	}
+}
+
+// The obj can be a Framework or any other object which is managed by current
+// FrameworkController instance, such as a ScheduledFramework.
+func (m *ShardManager) Owns(obj meta.Object) bool {
+	m.lock.RLock()
+	defer m.lock.RUnlock()
+
+	if *m.sConfig.MembershipEnabled {
+		now := time.Now()
+		leaseDuration := common.SecToDuration(m.sConfig.MemberLeaseDurationSec)
+		if now.Sub(m.lastRenewTime) > leaseDuration {
+			// Current shard may have been taken over by other members.
+			return false
+		}
+		if now.Before(m.handoffDeadline) {
+			// The newly gained objects may be still synced by their previous owners.
+			if m.prevShardIndex < 0 || !m.belongsTo(obj, m.prevShardIndex, m.prevShardNumber) {
+				return false
+			}
+		}
+	}
+	return m.belongsTo(obj, m.shardIndex, m.shardNumber)
+}
+
+func (m *ShardManager) belongsTo(
+	obj meta.Object, shardIndex int32, shardNumber int32) bool {
+	if shardNumber == 1 {
+		return true
+	}
+	return getObjectShard(obj, *m.sConfig.ShardLabelKey, shardNumber) == shardIndex
+}
+
+func getObjectShard(obj meta.Object, shardLabelKey string, shardNumber int32) int32 {
+	if shardLabelKey != "" {
+		if value, ok := obj.GetLabels()[shardLabelKey]; ok {
+			i, err := strconv.ParseInt(value, 10, 64)
+			if err == nil && i >= 0 {
+				return int32(i % int64(shardNumber))
+			}
+		}
+	}
+
+	hash := fnv.New32a()
+	hash.Write([]byte(obj.GetNamespace() + "/" + obj.GetName()))
+	return int32(hash.Sum32() % uint32(shardNumber))
+}
+
+func (m *ShardManager) memberLeaseName() string {
+	return ci.ComponentName + "-shard-" + *m.sConfig.MemberID
+}
+
+func (m *ShardManager) syncMembership() error {
+	logPfx := "syncMembership: "
+	now := time.Now()
+
+	if err := m.renewMemberLease(now); err != nil {
+		return fmt.Errorf(logPfx+"Failed to renew member Lease: %v", err)
+	}
+
+	leaseList, err := m.kClient.CoordinationV1().Leases(*m.sConfig.MembershipNamespace).List(
+		meta.ListOptions{LabelSelector: labels.SelectorFromSet(labels.Set{
+			ci.LabelKeyShardMember: ci.ComponentName}).String()})
+	if err != nil {
+		return fmt.Errorf(logPfx+"Failed to list member Leases: %v", err)
+	}
+
+	memberIDs := []string{}
+	for _, lease := range leaseList.Items {
+		if isMemberLeaseLive(&lease, now) {
+			memberIDs = append(memberIDs, *lease.Spec.HolderIdentity)
+		}
+	}
+	sort.Strings(memberIDs)
+
+	shardIndex := int32(-1)
+	for i, memberID := range memberIDs {
+		if memberID == *m.sConfig.MemberID {
+			shardIndex = int32(i)
+		}
+	}
+	if shardIndex < 0 {
+		// Unreachable
+		return fmt.Errorf(logPfx+
+			"Current member %v is not found in live members %v",
+			*m.sConfig.MemberID, memberIDs)
+	}
+	shardNumber := int32(len(memberIDs))
+
+	leaseDuration := common.SecToDuration(m.sConfig.MemberLeaseDurationSec)
+	m.lock.Lock()
+	oldShardIndex, oldShardNumber := m.shardIndex, m.shardNumber
+	isInitial := m.lastRenewTime.IsZero()
+	// Owns returned false for all objects while the membership was stale, so
+	// they were dropped even if the shard is not changed after recovered.
+	wasStale := !isInitial && now.Sub(m.lastRenewTime) > leaseDuration
+	isRebalanced := oldShardIndex != shardIndex || oldShardNumber != shardNumber
+	if isInitial || wasStale || isRebalanced {
+		// Fence the newly gained objects until their previous owners must have
+		// stopped syncing them.
+		// If the previous fence is still not passed, the previous shard may be
+		// not fully owned yet, so fence all objects.
+		if isInitial || wasStale || now.Before(m.handoffDeadline) {
+			m.prevShardIndex, m.prevShardNumber = -1, 0
+		} else {
+			m.prevShardIndex, m.prevShardNumber = oldShardIndex, oldShardNumber
+		}
+		m.handoffDeadline = now.Add(leaseDuration)
+		m.handoffPending = true
+	}
+	// The fenced objects were dropped, so enqueue them again once the fence is
+	// passed.
+	isHandoffCompleted := m.handoffPending && !now.Before(m.handoffDeadline)
+	if isHandoffCompleted {
+		m.handoffPending = false
+	}
+	m.shardIndex = shardIndex
+	m.shardNumber = shardNumber
+	m.lastRenewTime = now
+	m.lock.Unlock()
+
+	if isInitial {
+		klog.Infof(logPfx+"Joined as shard %v/%v: Live members %v",
+			shardIndex, shardNumber, memberIDs)
+	} else if wasStale {
+		klog.Infof(logPfx+"Recovered from stale membership as shard %v/%v: "+
+			"Live members %v", shardIndex, shardNumber, memberIDs)
+		m.onRebalance()
+	} else if isRebalanced {
+		klog.Infof(logPfx+"Rebalanced from shard %v/%v to %v/%v: Live members %v",
+			oldShardIndex, oldShardNumber, shardIndex, shardNumber, memberIDs)
+		m.onRebalance()
+	} else if isHandoffCompleted {
+		klog.Infof(logPfx+"Completed handoff as shard %v/%v: Live members %v",
+			shardIndex, shardNumber, memberIDs)
+		m.onRebalance()
+	}
+	return nil
+}
+
+func (m *ShardManager) renewMemberLease(now time.Time) error {
+	leases := m.kClient.CoordinationV1().Leases(*m.sConfig.MembershipNamespace)
+	leaseName := m.memberLeaseName()
+	renewTime := meta.NewMicroTime(now)
+	leaseDurationSec := int32(*m.sConfig.MemberLeaseDurationSec)
+
+	lease, err := leases.Get(leaseName, meta.GetOptions{})
+	if err != nil {
+		if !apiErrors.IsNotFound(err) {
+			return err
+		}
+
+		_, err = leases.Create(&coordination.Lease{
+			ObjectMeta: meta.ObjectMeta{
+				Name:   leaseName,
+				Labels: map[string]string{ci.LabelKeyShardMember: ci.ComponentName},
+			},
+			Spec: coordination.LeaseSpec{
+				HolderIdentity:       m.sConfig.MemberID,
+				LeaseDurationSeconds: &leaseDurationSec,
+				AcquireTime:          &renewTime,
+				RenewTime:            &renewTime,
+			},
+		})
+		return err
+	}
+
+	lease.Spec.HolderIdentity = m.sConfig.MemberID
+	lease.Spec.LeaseDurationSeconds = &leaseDurationSec
+	lease.Spec.RenewTime = &renewTime
+	_, err = leases.Update(lease)
+	return err
+}
+
+func isMemberLeaseLive(lease *coordination.Lease, now time.Time) bool {
+	if lease.Spec.HolderIdentity == nil ||
+		lease.Spec.RenewTime == nil ||
+		lease.Spec.LeaseDurationSeconds == nil {
+		return false
+	}
+
+	leaseDuration := time.Duration(*lease.Spec.LeaseDurationSeconds) * time.Second
+	return lease.Spec.RenewTime.Add(leaseDuration).After(now)
+}
@@ -0,0 +1,104 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	core "k8s.io/api/core/v1"
+	"k8s.io/klog"
+)
+
+// Get the description of the interruption signal on the node, or empty if the
+// node is not being interrupted or SpotInterruption is not enabled.
+func (c *FrameworkController) getSpotInterruption(node *core.Node) string {
+	sic := &c.config().SpotInterruption
+	if !*sic.Enabled {
+		return ""
+	}
+
+	for _, key := range sic.NodeTaintKeys {
+		for _, taint := range node.Spec.Taints {
+			if taint.Key == key {
+				return fmt.Sprintf("Taint %v:%v", taint.Key, taint.Effect)
+			}
+		}
+	}
+	for _, condType := range sic.NodeConditionTypes {
+		for _, cond := range node.Status.Conditions {
+			if string(cond.Type) == condType && cond.Status == core.ConditionTrue {
+				return fmt.Sprintf("Condition %v: %v", cond.Type, cond.Message)
+			}
+		}
+	}
+	for _, key := range sic.NodeAnnotationKeys {
+		if value, ok := node.Annotations[key]; ok {
+			return fmt.Sprintf("Annotation %v: %v", key, value)
+		}
+	}
+	return ""
+}
+
+func (c *FrameworkController) getNodeSpotInterruption(nodeName string) string {
+	node := c.getNode(nodeName)
+	if node == nil {
+		return ""
+	}
+	return c.getSpotInterruption(node)
+}
+
+// Override the failed completionStatus to CompletionCodePodSpotInterrupted if
+// its node is being interrupted, since the failure is likely caused by the
+// interruption instead of the application.
+// The completionStatus issued by the user or FrameworkController itself, such
+// as the StopTaskRequested, is kept as is.
+func (c *FrameworkController) classifySpotInterruption(
+	f *ci.Framework, taskStatus *ci.TaskStatus,
+	completionStatus *ci.TaskAttemptCompletionStatus) *ci.TaskAttemptCompletionStatus {
+	nodeName := taskStatus.AttemptStatus.PodNodeName
+	if nodeName == nil || completionStatus == nil ||
+		completionStatus.CompletionStatus == nil ||
+		!completionStatus.Type.IsFailed() {
+		return completionStatus
+	}
+
+	code := completionStatus.Code
+	if code != ci.CompletionCodePodExternalDeleted &&
+		code != ci.CompletionCodePodNodeNotReady &&
+		code != ci.CompletionCodePodFailedWithoutFailedContainer &&
+		ci.CompletionCodeReservedNonPositive.Contains(code) {
+		return completionStatus
+	}
+
+	signal := c.getNodeSpotInterruption(*nodeName)
+	if signal == "" {
+		return completionStatus
+	}
+
+	diag := fmt.Sprintf("Pod Node %v is being interrupted: %v: %v",
+		*nodeName, signal, completionStatus.Diagnostics)
+	klog.Infof("[%v]: classifySpotInterruption: CompletionCode %v is overridden "+
+		"to %v: %v", f.Key(), code, ci.CompletionCodePodSpotInterrupted, diag)
+	return ci.CompletionCodePodSpotInterrupted.NewTaskAttemptCompletionStatus(
+		diag, completionStatus.Pod)
+}
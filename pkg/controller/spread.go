@@ -0,0 +1,98 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime"
+)
+
+// The TopologySpreadConstraints is not in the vendored Pod type, so the Pod is
+// built as an unstructured object and created by the dynamic client.
+// See TaskRoleSpec.TopologySpreadConstraints.
+func newUnstructuredTopologySpreadConstraints(
+	frameworkName string, taskRoleName string,
+	constraints []ci.TopologySpreadConstraintSpec) []interface{} {
+	uConstraints := []interface{}{}
+	for _, constraint := range constraints {
+		maxSkew := constraint.MaxSkew
+		if maxSkew == 0 {
+			maxSkew = 1
+		}
+		whenUnsatisfiable := constraint.WhenUnsatisfiable
+		if whenUnsatisfiable == "" {
+			whenUnsatisfiable = ci.UnsatisfiableDoNotSchedule
+		}
+		uConstraints = append(uConstraints, map[string]interface{}{
+			"maxSkew":           int64(maxSkew),
+			"topologyKey":       constraint.TopologyKey,
+			"whenUnsatisfiable": string(whenUnsatisfiable),
+			"labelSelector": map[string]interface{}{
+				"matchLabels": map[string]interface{}{
+					ci.LabelKeyFrameworkName: frameworkName,
+					ci.LabelKeyTaskRoleName:  taskRoleName,
+				},
+			},
+		})
+	}
+	return uConstraints
+}
+
+// Return the created Pod in the vendored Pod type, i.e. without the
+// TopologySpreadConstraints.
+func (c *FrameworkController) createPodWithTopologySpread(
+	f *ci.Framework, taskRoleName string, pod *core.Pod,
+	constraints []ci.TopologySpreadConstraintSpec) (*core.Pod, error) {
+	obj, err := runtime.DefaultUnstructuredConverter.ToUnstructured(pod)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to convert Pod to unstructured: %v", err)
+	}
+	uPod := &unstructured.Unstructured{Object: obj}
+	uPod.SetAPIVersion(ci.PodGroupVersionKind.GroupVersion().String())
+	uPod.SetKind(ci.PodGroupVersionKind.Kind)
+	err = unstructured.SetNestedSlice(uPod.Object,
+		newUnstructuredTopologySpreadConstraints(f.Name, taskRoleName, constraints),
+		"spec", "topologySpreadConstraints")
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Failed to set Pod TopologySpreadConstraints: %v", err)
+	}
+
+	remoteUPod, err := c.dClient.Resource(ci.PodGroupVersionResource).
+		Namespace(f.Namespace).Create(uPod, meta.CreateOptions{})
+	if err != nil {
+		return nil, err
+	}
+	remotePod := &core.Pod{}
+	err = runtime.DefaultUnstructuredConverter.FromUnstructured(
+		remoteUPod.Object, remotePod)
+	if err != nil {
+		return nil, fmt.Errorf(
+			"Failed to convert unstructured to Pod %v: %v", remoteUPod.GetUID(), err)
+	}
+	return remotePod, nil
+}
@@ -0,0 +1,93 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	core "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/klog"
+)
+
+// Ensure the SSH keypair Secret of current FrameworkAttemptInstance exists
+// before its Pods are created.
+// The Secret creation is idempotent, so it is safe to create again if the
+// SSHSecretUID is failed to persist, and then the existing keypair is kept.
+func (c *FrameworkController) syncSSHSecret(
+	f *ci.Framework, cm *core.ConfigMap) error {
+	if f.Status.AttemptStatus.SSHSecretUID != nil || !f.IsSSHKeyEnabled() {
+		return nil
+	}
+
+	secret, err := c.createSSHSecret(f, cm)
+	if err != nil {
+		return err
+	}
+	f.Status.AttemptStatus.SSHSecretUID = &secret.UID
+	return nil
+}
+
+func (c *FrameworkController) createSSHSecret(
+	f *ci.Framework, cm *core.ConfigMap) (*core.Secret, error) {
+	secretName := ci.GetSSHSecretName(f.Name, f.FrameworkAttemptID())
+	errPfx := fmt.Sprintf(
+		"[%v]: Failed to create SSH Secret %v", f.Key(), secretName)
+
+	privateKey, authorizedKey, err := common.GenerateSSHKeyPair()
+	if err != nil {
+		return nil, fmt.Errorf(errPfx+": Failed to generate SSH keypair: %v", err)
+	}
+	secret := f.NewSSHSecret(cm, privateKey, authorizedKey)
+	secretClient := c.kClient.CoreV1().Secrets(f.Namespace)
+
+	span := c.tracer.StartSpan(f.Key(), "CreateSecret",
+		map[string]string{"object.name": secret.Name})
+	remoteSecret, createErr := secretClient.Create(secret)
+	span.End(createErr)
+	if createErr != nil {
+		if !apiErrors.IsAlreadyExists(createErr) {
+			return nil, fmt.Errorf(errPfx+": %v", createErr)
+		}
+
+		var getErr error
+		remoteSecret, getErr = secretClient.Get(secret.Name, meta.GetOptions{})
+		if getErr != nil {
+			return nil, fmt.Errorf(errPfx+": %v: %v", createErr, getErr)
+		}
+		if !meta.IsControlledBy(remoteSecret, cm) {
+			// The Secret of previous FrameworkAttemptInstance may be not yet
+			// garbage collected, so just retry later.
+			return nil, fmt.Errorf(errPfx+": "+
+				"Secret naming conflicts with others: "+
+				"Existing Secret %v is not controlled by current ConfigMap %v, %v",
+				remoteSecret.UID, cm.Name, cm.UID)
+		}
+	} else {
+		klog.Infof("[%v]: Succeeded to create SSH Secret %v", f.Key(), secret.Name)
+	}
+
+	return remoteSecret, nil
+}
@@ -0,0 +1,309 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package controller
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	frameworkClient "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned"
+	frameworkInformer "github.com/microsoft/frameworkcontroller/pkg/client/informers/externalversions/frameworkcontroller/v1"
+	frameworkLister "github.com/microsoft/frameworkcontroller/pkg/client/listers/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/internal"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/util/workqueue"
+	"k8s.io/klog"
+	"reflect"
+	"time"
+)
+
+// TaskRoleScaleController syncs the Spec.Replicas of TaskRoleScales to their
+// TaskRole TaskNumbers, and exposes the current TaskRole scale in their Status.
+// See TaskRoleScale.
+type TaskRoleScaleController struct {
+	fClient frameworkClient.Interface
+
+	tsInformer cache.SharedIndexInformer
+	fInformer  cache.SharedIndexInformer
+	tsLister   frameworkLister.TaskRoleScaleLister
+	fLister    frameworkLister.FrameworkLister
+
+	// TaskRoleScale Key -> TaskRoleScale
+	tsQueue workqueue.RateLimitingInterface
+
+	shardManager *ShardManager
+	workerNumber int32
+}
+
+func NewTaskRoleScaleController(
+	fClient frameworkClient.Interface,
+	tsListerInformer frameworkInformer.TaskRoleScaleInformer,
+	fInformer cache.SharedIndexInformer,
+	fLister frameworkLister.FrameworkLister,
+	shardManager *ShardManager,
+	workerNumber int32) *TaskRoleScaleController {
+	c := &TaskRoleScaleController{
+		fClient:      fClient,
+		tsInformer:   tsListerInformer.Informer(),
+		fInformer:    fInformer,
+		tsLister:     tsListerInformer.Lister(),
+		fLister:      fLister,
+		tsQueue:      workqueue.NewRateLimitingQueue(workqueue.DefaultControllerRateLimiter()),
+		shardManager: shardManager,
+		workerNumber: workerNumber,
+	}
+
+	c.tsInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueTaskRoleScaleObj(internal.ToTaskRoleScale(obj))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.enqueueTaskRoleScaleObj(internal.ToTaskRoleScale(newObj))
+		},
+	})
+
+	// The Framework Spec and Status changes may impact the TaskRoleScale.
+	c.fInformer.AddEventHandler(cache.ResourceEventHandlerFuncs{
+		AddFunc: func(obj interface{}) {
+			c.enqueueFrameworkTaskRoleScales(internal.ToFramework(obj))
+		},
+		UpdateFunc: func(oldObj, newObj interface{}) {
+			c.enqueueFrameworkTaskRoleScales(internal.ToFramework(newObj))
+		},
+		DeleteFunc: func(obj interface{}) {
+			c.enqueueFrameworkTaskRoleScales(internal.ToFramework(obj))
+		},
+	})
+
+	return c
+}
+
+func (c *TaskRoleScaleController) enqueueTaskRoleScaleObj(ts *ci.TaskRoleScale) {
+	if !c.shardManager.Owns(ts) {
+		return
+	}
+	c.tsQueue.Add(ts.Key())
+}
+
+func (c *TaskRoleScaleController) enqueueFrameworkTaskRoleScales(f *ci.Framework) {
+	tss, err := c.tsLister.TaskRoleScales(f.Namespace).List(labels.Everything())
+	if err != nil {
+		klog.Warningf("[%v]: enqueueFrameworkTaskRoleScales: "+
+			"Failed to list TaskRoleScales from local cache: %v", f.Key(), err)
+		return
+	}
+	for _, ts := range tss {
+		if ts.Spec.FrameworkName == f.Name {
+			c.enqueueTaskRoleScaleObj(ts)
+		}
+	}
+}
+
+// Enqueue all TaskRoleScales, so that the newly owned ones are synced after
+// the shards are rebalanced.
+func (c *TaskRoleScaleController) Rebalance() {
+	tss, err := c.tsLister.List(labels.Everything())
+	if err != nil {
+		klog.Warningf("TaskRoleScale: Rebalance: "+
+			"Failed to list TaskRoleScales from local cache: %v", err)
+		return
+	}
+	for _, ts := range tss {
+		c.enqueueTaskRoleScaleObj(ts)
+	}
+}
+
+// It should be invoked after the Framework Informer is started.
+func (c *TaskRoleScaleController) Run(stopCh <-chan struct{}) {
+	defer c.tsQueue.ShutDown()
+
+	go c.tsInformer.Run(stopCh)
+	if !cache.WaitForCacheSync(
+		stopCh,
+		c.tsInformer.HasSynced,
+		c.fInformer.HasSynced) {
+		panic(fmt.Errorf("Failed to WaitForCacheSync for TaskRoleScale"))
+	}
+
+	klog.Infof("Running TaskRoleScaleController with %v workers",
+		c.workerNumber)
+	for i := int32(0); i < c.workerNumber; i++ {
+		go wait.Until(func() {
+			for c.processNextWorkItem() {
+			}
+		}, time.Second, stopCh)
+	}
+
+	<-stopCh
+}
+
+func (c *TaskRoleScaleController) processNextWorkItem() bool {
+	key, quit := c.tsQueue.Get()
+	if quit {
+		return false
+	}
+	defer c.tsQueue.Done(key)
+
+	err := c.syncTaskRoleScale(key.(string))
+	if err == nil {
+		c.tsQueue.Forget(key)
+	} else {
+		c.tsQueue.AddRateLimited(key)
+	}
+
+	return true
+}
+
+// It should not be invoked concurrently with the same key.
+//
+// Return error only for Platform Transient Error, so that the key
+// can be enqueued again after rate limited delay.
+func (c *TaskRoleScaleController) syncTaskRoleScale(
+	key string) (returnedErr error) {
+	startTime := time.Now()
+	logPfx := fmt.Sprintf("[%v]: syncTaskRoleScale: ", key)
+	klog.Infof(logPfx + "Started")
+	defer func() {
+		if returnedErr != nil {
+			klog.Warning(logPfx + returnedErr.Error())
+			klog.Warning(logPfx +
+				"Failed to due to Platform Transient Error. " +
+				"Will enqueue it again after rate limited delay")
+		}
+		klog.Infof(logPfx+"Completed: Duration %v", time.Since(startTime))
+	}()
+
+	tsNamespace, tsName := ci.SplitFrameworkKey(key)
+	localTS, err := c.tsLister.TaskRoleScales(tsNamespace).Get(tsName)
+	if err != nil {
+		if apiErrors.IsNotFound(err) {
+			klog.Infof(logPfx+
+				"Skipped: TaskRoleScale cannot be found in local cache: %v", err)
+			return nil
+		} else {
+			return fmt.Errorf(
+				"Failed: TaskRoleScale cannot be got from local cache: %v", err)
+		}
+	}
+
+	ts := localTS.DeepCopy()
+	if !c.shardManager.Owns(ts) {
+		klog.Infof(logPfx + "Skipped: TaskRoleScale does not belong to current shard")
+		return nil
+	}
+	if ts.DeletionTimestamp != nil {
+		klog.Infof(logPfx + "Skipped: TaskRoleScale is deleting")
+		return nil
+	}
+
+	remoteStatus := ts.Status.DeepCopy()
+	ts.Status = &ci.TaskRoleScaleStatus{
+		Replicas: 0,
+		Selector: ci.GetTaskRolePodSelector(
+			ts.Spec.FrameworkName, ts.Spec.TaskRoleName),
+	}
+
+	syncErr := c.syncTaskNumber(ts)
+
+	if !reflect.DeepEqual(remoteStatus, ts.Status) {
+		_, updateErr := c.fClient.FrameworkcontrollerV1().TaskRoleScales(
+			ts.Namespace).Update(ts)
+		if updateErr != nil {
+			return fmt.Errorf(
+				"Failed to update TaskRoleScale.Status: %v", updateErr)
+		}
+		klog.Infof(logPfx+"Succeeded to update TaskRoleScale.Status: "+
+			"Replicas %v, Diagnostics %v",
+			ts.Status.Replicas, ts.Status.Diagnostics)
+	}
+
+	return syncErr
+}
+
+// Update the TaskRole TaskNumber to the TaskRoleScale Spec.Replicas, and the
+// TaskRoleScale Status.Replicas to the TaskRole scale in current
+// FrameworkAttempt.
+func (c *TaskRoleScaleController) syncTaskNumber(ts *ci.TaskRoleScale) error {
+	logPfx := fmt.Sprintf("[%v]: syncTaskNumber: ", ts.Key())
+
+	f, err := c.fLister.Frameworks(ts.Namespace).Get(ts.Spec.FrameworkName)
+	if err != nil {
+		if apiErrors.IsNotFound(err) {
+			ts.Status.Diagnostics = fmt.Sprintf(
+				"Framework %v does not exist", ts.Spec.FrameworkName)
+			return nil
+		}
+		return fmt.Errorf(
+			"Failed to get Framework %v from local cache: %v",
+			ts.Spec.FrameworkName, err)
+	}
+
+	if taskRoleStatus := f.GetTaskRoleStatus(ts.Spec.TaskRoleName); taskRoleStatus != nil {
+		ts.Status.Replicas = taskRoleStatus.GetTaskCountStatus(
+			func(taskStatus *ci.TaskStatus) bool {
+				return !taskStatus.DeletionPending
+			})
+	}
+
+	if f.Status != nil && f.Status.State == ci.FrameworkCompleted {
+		ts.Status.Diagnostics = fmt.Sprintf(
+			"Framework %v is already %v", f.Name, f.Status.State)
+		return nil
+	}
+
+	taskRoleIndex := -1
+	for i := range f.Spec.TaskRoles {
+		if f.Spec.TaskRoles[i].Name == ts.Spec.TaskRoleName {
+			taskRoleIndex = i
+			break
+		}
+	}
+	if taskRoleIndex < 0 {
+		ts.Status.Diagnostics = fmt.Sprintf(
+			"TaskRole %v does not exist in Framework %v",
+			ts.Spec.TaskRoleName, f.Name)
+		return nil
+	}
+
+	taskNumber := f.Spec.TaskRoles[taskRoleIndex].TaskNumber
+	if taskNumber == ts.Spec.Replicas {
+		return nil
+	}
+
+	// The Update is rejected if the Framework is changed since it is got from
+	// the local cache, so it will be retried with the latest Framework.
+	updatedF := f.DeepCopy()
+	updatedF.Spec.TaskRoles[taskRoleIndex].TaskNumber = ts.Spec.Replicas
+	_, err = c.fClient.FrameworkcontrollerV1().Frameworks(f.Namespace).Update(updatedF)
+	if err != nil {
+		return fmt.Errorf(
+			"Failed to update Framework %v TaskRole %v TaskNumber: %v -> %v: %v",
+			f.Name, ts.Spec.TaskRoleName, taskNumber, ts.Spec.Replicas, err)
+	}
+	klog.Infof(logPfx+
+		"Succeeded to update Framework %v TaskRole %v TaskNumber: %v -> %v",
+		f.Name, ts.Spec.TaskRoleName, taskNumber, ts.Spec.Replicas)
+	return nil
+}
@@ -0,0 +1,435 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package gateway
+
+import (
+	"crypto/subtle"
+	"encoding/json"
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	frameworkClient "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"github.com/microsoft/frameworkcontroller/pkg/internal"
+	"github.com/microsoft/frameworkcontroller/pkg/validation"
+	"io/ioutil"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/fields"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/apimachinery/pkg/util/errors"
+	"k8s.io/apimachinery/pkg/watch"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/tools/clientcmd"
+	"k8s.io/klog"
+	"net/http"
+	"os"
+	"sigs.k8s.io/yaml"
+	"strings"
+)
+
+// FrameworkController Extension: FrameworkGateway
+//
+// Best Practice:
+// It is usually deployed as a Service in front of the ApiServer, for the
+// clients, such as schedulers and web portals, which cannot or should not talk
+// to the ApiServer directly, so that they only need to manage the Frameworks by
+// a simple REST API, without the k8s credentials and client libraries.
+//
+// Usage:
+// It serves below REST API at ${GATEWAY_LISTEN_ADDRESS}, and the request and
+// response Body is the Framework in JSON, and the request Body can also be in
+// YAML:
+//   POST   /v1/namespaces/{FrameworkNamespace}/frameworks
+//     Submit the Framework in the request Body, which is rejected if it is
+//     invalid, see validation.Validate.
+//   GET    /v1/namespaces/{FrameworkNamespace}/frameworks[?labelSelector=]
+//     List the Frameworks as the FrameworkList.
+//   GET    /v1/namespaces/{FrameworkNamespace}/frameworks/{FrameworkName}
+//     Get the Framework.
+//   POST   /v1/namespaces/{FrameworkNamespace}/frameworks/{FrameworkName}/stop
+//     Stop the Framework, see Stop Framework in the user manual.
+//   DELETE /v1/namespaces/{FrameworkNamespace}/frameworks/{FrameworkName}
+//     Delete the Framework by the Foreground Deletion.
+//   GET    /v1/namespaces/{FrameworkNamespace}/frameworks/{FrameworkName}/watch
+//     Stream the Framework status changes as the newline delimited JSON
+//     StatusEvents, until the Framework is deleted or the client disconnects.
+// The failure response Body is the JSON ErrorResponse with the same HTTP
+// status code as the ApiServer.
+// The request Body larger than MaxRequestBodyBytes is rejected.
+//
+// The caller can also specify:
+//   ${GATEWAY_AUTH_TOKEN}: The bearer token which the clients should provide in
+//     the Authorization header. It is required, unless the
+//     ${GATEWAY_AUTH_DISABLED} is true.
+//   ${GATEWAY_AUTH_DISABLED}: Whether to explicitly serve without
+//     authentication, which is only safe if the gateway is not exposed to
+//     untrusted clients, since anyone who can reach it can create Pods by the
+//     gateway's credentials.
+//   ${GATEWAY_NAMESPACES}: The comma separated namespaces which the clients can
+//     access. Empty means all namespaces which the gateway can access.
+type FrameworkGateway struct {
+	kConfig *rest.Config
+	gConfig *Config
+
+	fClient frameworkClient.Interface
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// Constants
+///////////////////////////////////////////////////////////////////////////////////////
+const (
+	ComponentName = "frameworkgateway"
+	APIPathPrefix = "/v1/namespaces/"
+	HealthzPath   = "/healthz"
+
+	EnvNameGatewayListenAddress = "GATEWAY_LISTEN_ADDRESS"
+	EnvNameGatewayAuthToken     = "GATEWAY_AUTH_TOKEN"
+	EnvNameGatewayAuthDisabled  = "GATEWAY_AUTH_DISABLED"
+	EnvNameGatewayNamespaces    = "GATEWAY_NAMESPACES"
+
+	// The same as the default max request body size of the ApiServer.
+	MaxRequestBodyBytes = 3 * 1024 * 1024
+)
+
+// The element of the watch response stream.
+type StatusEvent struct {
+	// ADDED, MODIFIED or DELETED.
+	Type   watch.EventType     `json:"type"`
+	Name   string              `json:"name"`
+	Status *ci.FrameworkStatus `json:"status"`
+}
+
+type ErrorResponse struct {
+	Code    int    `json:"code"`
+	Message string `json:"message"`
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// Config
+///////////////////////////////////////////////////////////////////////////////////////
+type Config struct {
+	// See the same fields in pkg/apis/frameworkcontroller/v1/config.go
+	KubeApiServerAddress string `yaml:"kubeApiServerAddress"`
+	KubeConfigFilePath   string `yaml:"kubeConfigFilePath"`
+
+	ListenAddress string `yaml:"listenAddress"`
+	// Not logged.
+	AuthToken    string   `yaml:"-"`
+	AuthDisabled bool     `yaml:"authDisabled"`
+	Namespaces   []string `yaml:"namespaces"`
+}
+
+func newConfig() *Config {
+	c := Config{}
+
+	// Setting and Defaulting
+	c.KubeApiServerAddress = ci.EnvValueKubeApiServerAddress
+	c.KubeConfigFilePath = ci.EnvValueKubeConfigFilePath
+	if c.KubeConfigFilePath == "" {
+		if _, err := os.Stat(ci.DefaultKubeConfigFilePath); err == nil {
+			c.KubeConfigFilePath = ci.DefaultKubeConfigFilePath
+		}
+	}
+
+	c.ListenAddress = os.Getenv(EnvNameGatewayListenAddress)
+	if c.ListenAddress == "" {
+		c.ListenAddress = ":8080"
+	}
+	c.AuthToken = os.Getenv(EnvNameGatewayAuthToken)
+	c.AuthDisabled = strings.ToLower(os.Getenv(EnvNameGatewayAuthDisabled)) == "true"
+	for _, namespace := range strings.Split(os.Getenv(EnvNameGatewayNamespaces), ",") {
+		namespace = strings.TrimSpace(namespace)
+		if namespace != "" {
+			c.Namespaces = append(c.Namespaces, namespace)
+		}
+	}
+
+	// Validation
+	errPrefix := "Validation Failed: "
+	if c.AuthToken == "" && !c.AuthDisabled {
+		panic(fmt.Errorf(errPrefix+
+			"${%v} should not be empty, unless ${%v} is true to explicitly "+
+			"serve without authentication",
+			EnvNameGatewayAuthToken, EnvNameGatewayAuthDisabled))
+	}
+
+	return &c
+}
+
+func buildKubeConfig(gConfig *Config) *rest.Config {
+	kConfig, err := clientcmd.BuildConfigFromFlags(
+		gConfig.KubeApiServerAddress, gConfig.KubeConfigFilePath)
+	if err != nil {
+		panic(fmt.Errorf("Failed to build KubeConfig, please ensure "+
+			"${KUBE_APISERVER_ADDRESS} or ${KUBECONFIG} or ${HOME}/.kube/config or "+
+			"${KUBERNETES_SERVICE_HOST}:${KUBERNETES_SERVICE_PORT} is valid: "+
+			"Error: %v", err))
+	}
+	return kConfig
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// Methods
+///////////////////////////////////////////////////////////////////////////////////////
+func NewFrameworkGateway() *FrameworkGateway {
+	klog.Infof("Initializing %v", ComponentName)
+
+	gConfig := newConfig()
+	klog.Infof("With Config: \n%v", common.ToYaml(gConfig))
+	kConfig := buildKubeConfig(gConfig)
+	_, fClient := internal.CreateClients(kConfig)
+
+	return &FrameworkGateway{
+		kConfig: kConfig,
+		gConfig: gConfig,
+		fClient: fClient,
+	}
+}
+
+func (g *FrameworkGateway) Run() {
+	klog.Infof("Running %v at %v", ComponentName, g.gConfig.ListenAddress)
+
+	mux := http.NewServeMux()
+	mux.HandleFunc(HealthzPath, func(w http.ResponseWriter, r *http.Request) {
+		w.WriteHeader(http.StatusOK)
+	})
+	mux.HandleFunc(APIPathPrefix, g.serveAPI)
+
+	panic(fmt.Errorf("Failed to serve %v: %v", ComponentName,
+		http.ListenAndServe(g.gConfig.ListenAddress, mux)))
+}
+
+func (g *FrameworkGateway) serveAPI(w http.ResponseWriter, r *http.Request) {
+	if g.gConfig.AuthToken != "" && subtle.ConstantTimeCompare(
+		[]byte(r.Header.Get("Authorization")),
+		[]byte("Bearer "+g.gConfig.AuthToken)) != 1 {
+		writeError(w, http.StatusUnauthorized, "Invalid bearer token")
+		return
+	}
+
+	// {FrameworkNamespace}/frameworks[/{FrameworkName}[/{Action}]]
+	parts := strings.Split(
+		strings.Trim(strings.TrimPrefix(r.URL.Path, APIPathPrefix), "/"), "/")
+	if len(parts) < 2 || len(parts) > 4 || parts[1] != ci.FrameworkPlural {
+		writeError(w, http.StatusNotFound, "Unknown path "+r.URL.Path)
+		return
+	}
+	namespace := parts[0]
+	if !g.isNamespaceAllowed(namespace) {
+		writeError(w, http.StatusForbidden, fmt.Sprintf(
+			"Namespace %v is not allowed", namespace))
+		return
+	}
+
+	name, action := "", ""
+	if len(parts) > 2 {
+		name = parts[2]
+	}
+	if len(parts) > 3 {
+		action = parts[3]
+	}
+	klog.Infof("[%v]: Serving %v %v", r.RemoteAddr, r.Method, r.URL.Path)
+
+	switch {
+	case name == "" && r.Method == http.MethodPost:
+		g.submitFramework(w, r, namespace)
+	case name == "" && r.Method == http.MethodGet:
+		g.listFrameworks(w, r, namespace)
+	case name != "" && action == "" && r.Method == http.MethodGet:
+		g.getFramework(w, namespace, name)
+	case name != "" && action == "" && r.Method == http.MethodDelete:
+		g.deleteFramework(w, namespace, name)
+	case name != "" && action == "stop" && r.Method == http.MethodPost:
+		g.stopFramework(w, namespace, name)
+	case name != "" && action == "watch" && r.Method == http.MethodGet:
+		g.watchFramework(w, r, namespace, name)
+	default:
+		writeError(w, http.StatusMethodNotAllowed, fmt.Sprintf(
+			"Unknown method %v for path %v", r.Method, r.URL.Path))
+	}
+}
+
+func (g *FrameworkGateway) isNamespaceAllowed(namespace string) bool {
+	if len(g.gConfig.Namespaces) == 0 {
+		return true
+	}
+	for _, allowed := range g.gConfig.Namespaces {
+		if namespace == allowed {
+			return true
+		}
+	}
+	return false
+}
+
+func (g *FrameworkGateway) submitFramework(
+	w http.ResponseWriter, r *http.Request, namespace string) {
+	body, err := ioutil.ReadAll(http.MaxBytesReader(w, r.Body, MaxRequestBodyBytes))
+	if err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf(
+			"Failed to read request body within %v bytes: %v", MaxRequestBodyBytes, err))
+		return
+	}
+	f := &ci.Framework{}
+	if err := yaml.Unmarshal(body, f); err != nil {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf(
+			"Failed to parse Framework: %v", err))
+		return
+	}
+	if f.Namespace != "" && f.Namespace != namespace {
+		writeError(w, http.StatusBadRequest, fmt.Sprintf(
+			"Framework namespace %v does not match the path namespace %v",
+			f.Namespace, namespace))
+		return
+	}
+	if errs := validation.Validate(f); len(errs) > 0 {
+		writeError(w, http.StatusUnprocessableEntity, fmt.Sprintf(
+			"Framework is invalid: %v", errors.NewAggregate(errs)))
+		return
+	}
+
+	f, err = g.fClient.FrameworkcontrollerV1().Frameworks(namespace).Create(f)
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	klog.Infof("[%v]: Succeeded to submit Framework", f.Key())
+	writeObject(w, http.StatusCreated, f)
+}
+
+func (g *FrameworkGateway) listFrameworks(
+	w http.ResponseWriter, r *http.Request, namespace string) {
+	fl, err := g.fClient.FrameworkcontrollerV1().Frameworks(namespace).List(
+		meta.ListOptions{LabelSelector: r.URL.Query().Get("labelSelector")})
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeObject(w, http.StatusOK, fl)
+}
+
+func (g *FrameworkGateway) getFramework(
+	w http.ResponseWriter, namespace string, name string) {
+	f, err := g.fClient.FrameworkcontrollerV1().Frameworks(namespace).Get(
+		name, meta.GetOptions{})
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	writeObject(w, http.StatusOK, f)
+}
+
+func (g *FrameworkGateway) stopFramework(
+	w http.ResponseWriter, namespace string, name string) {
+	patch := fmt.Sprintf(`{"spec":{"executionType":"%v"}}`, ci.ExecutionStop)
+	f, err := g.fClient.FrameworkcontrollerV1().Frameworks(namespace).Patch(
+		name, types.MergePatchType, []byte(patch))
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	klog.Infof("[%v]: Succeeded to stop Framework", f.Key())
+	writeObject(w, http.StatusOK, f)
+}
+
+func (g *FrameworkGateway) deleteFramework(
+	w http.ResponseWriter, namespace string, name string) {
+	err := g.fClient.FrameworkcontrollerV1().Frameworks(namespace).Delete(
+		name, &meta.DeleteOptions{
+			PropagationPolicy: common.PtrDeletionPropagation(meta.DeletePropagationForeground),
+		})
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	klog.Infof("[%v/%v]: Succeeded to delete Framework", namespace, name)
+	w.WriteHeader(http.StatusAccepted)
+}
+
+func (g *FrameworkGateway) watchFramework(
+	w http.ResponseWriter, r *http.Request, namespace string, name string) {
+	flusher, ok := w.(http.Flusher)
+	if !ok {
+		writeError(w, http.StatusInternalServerError, "Streaming is not supported")
+		return
+	}
+
+	watcher, err := g.fClient.FrameworkcontrollerV1().Frameworks(namespace).Watch(
+		meta.ListOptions{
+			FieldSelector: fields.OneTermEqualSelector("metadata.name", name).String(),
+		})
+	if err != nil {
+		writeAPIError(w, err)
+		return
+	}
+	defer watcher.Stop()
+
+	w.Header().Set("Content-Type", "application/x-ndjson")
+	w.WriteHeader(http.StatusOK)
+	flusher.Flush()
+
+	encoder := json.NewEncoder(w)
+	for {
+		select {
+		case <-r.Context().Done():
+			return
+		case event, ok := <-watcher.ResultChan():
+			if !ok {
+				// The watch is closed by the ApiServer, such as timeout, so the
+				// client should watch again.
+				return
+			}
+			f, isFramework := event.Object.(*ci.Framework)
+			if !isFramework {
+				continue
+			}
+			if err := encoder.Encode(&StatusEvent{
+				Type: event.Type, Name: f.Name, Status: f.Status}); err != nil {
+				return
+			}
+			flusher.Flush()
+			if event.Type == watch.Deleted {
+				return
+			}
+		}
+	}
+}
+
+func writeObject(w http.ResponseWriter, code int, obj interface{}) {
+	w.Header().Set("Content-Type", "application/json")
+	w.WriteHeader(code)
+	if err := json.NewEncoder(w).Encode(obj); err != nil {
+		klog.Warningf("Failed to write response: %v", err)
+	}
+}
+
+func writeError(w http.ResponseWriter, code int, message string) {
+	writeObject(w, code, &ErrorResponse{Code: code, Message: message})
+}
+
+func writeAPIError(w http.ResponseWriter, err error) {
+	code := http.StatusInternalServerError
+	if apiStatus, ok := err.(apiErrors.APIStatus); ok && apiStatus.Status().Code != 0 {
+		code = int(apiStatus.Status().Code)
+	}
+	writeError(w, code, err.Error())
+}
@@ -0,0 +1,121 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package internal
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"io"
+	"k8s.io/client-go/rest"
+	"k8s.io/client-go/transport"
+	"k8s.io/klog"
+	"math/rand"
+	"net/http"
+	"time"
+)
+
+// Wrap the RoundTripper of the kConfig to inject the faults into the requests
+// to the ApiServer, i.e. fail the writes with the transient errors and delay
+// the watch events.
+// See FaultInjectionConfig.
+func EnableFaultInjection(kConfig *rest.Config, fiConfig *ci.FaultInjectionConfig) {
+	kConfig.WrapTransport = transport.Wrappers(kConfig.WrapTransport,
+		func(rt http.RoundTripper) http.RoundTripper {
+			return &faultInjectionRoundTripper{
+				delegate:            rt,
+				writeFailurePercent: *fiConfig.WriteFailurePercent,
+				watchEventMaxDelay: time.Duration(
+					*fiConfig.WatchEventMaxDelayMs) * time.Millisecond,
+			}
+		})
+}
+
+type faultInjectionRoundTripper struct {
+	delegate            http.RoundTripper
+	writeFailurePercent int32
+	watchEventMaxDelay  time.Duration
+}
+
+func (rt *faultInjectionRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+		return rt.roundTripWrite(req)
+	}
+
+	resp, err := rt.delegate.RoundTrip(req)
+	if err != nil || rt.watchEventMaxDelay <= 0 {
+		return resp, err
+	}
+	if watch := req.URL.Query().Get("watch"); watch == "true" || watch == "1" {
+		resp.Body = &delayedReadCloser{
+			delegate: resp.Body, maxDelay: rt.watchEventMaxDelay}
+	}
+	return resp, err
+}
+
+func (rt *faultInjectionRoundTripper) roundTripWrite(req *http.Request) (*http.Response, error) {
+	roll := rand.Int31n(100)
+	if roll >= rt.writeFailurePercent {
+		return rt.delegate.RoundTrip(req)
+	}
+
+	if roll%2 == 0 {
+		klog.Warningf("FaultInjection: Failed %v %v before it is sent",
+			req.Method, req.URL.Path)
+		return nil, fmt.Errorf(
+			"FaultInjection: Injected transient failure before %v %v is sent",
+			req.Method, req.URL.Path)
+	}
+
+	resp, err := rt.delegate.RoundTrip(req)
+	if err != nil {
+		return resp, err
+	}
+	resp.Body.Close()
+	klog.Warningf("FaultInjection: Failed %v %v after it is sent with status %v",
+		req.Method, req.URL.Path, resp.StatusCode)
+	return nil, fmt.Errorf(
+		"FaultInjection: Injected transient failure after %v %v is sent",
+		req.Method, req.URL.Path)
+}
+
+// Delay each read of the watch response, so that the watch events are still
+// delivered in order, but later.
+type delayedReadCloser struct {
+	delegate io.ReadCloser
+	maxDelay time.Duration
+}
+
+func (r *delayedReadCloser) Read(p []byte) (int, error) {
+	// Delay after the read, otherwise the delay may elapse while the watch is
+	// idle.
+	n, err := r.delegate.Read(p)
+	if n > 0 {
+		time.Sleep(time.Duration(rand.Int63n(int64(r.maxDelay))))
+	}
+	return n, err
+}
+
+func (r *delayedReadCloser) Close() error {
+	return r.delegate.Close()
+}
@@ -0,0 +1,319 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package internal
+
+import (
+	"bytes"
+	"crypto/rand"
+	"encoding/hex"
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"io/ioutil"
+	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/klog"
+	"net/http"
+	"strings"
+	"sync"
+	"time"
+)
+
+// Tracer records the spans of the sync pipeline and exports them to the
+// OpenTelemetry collector in the OTLP JSON encoding, so that no OpenTelemetry
+// library is needed.
+// See https://opentelemetry.io/docs/specs/otlp/#otlphttp
+//
+// The spans are keyed by the Framework key, instead of the context.Context:
+// 1. StartSpan with a key starts a child span of the current active span of
+//    the key, or a new trace if the key has no active span.
+// 2. The started span becomes the current active span of the key until it is
+//    ended.
+// So, it relies on that the same key will not be synced concurrently.
+//
+// All methods are safe to be called on a nil Tracer and a nil Span, which are
+// used if the tracing is disabled.
+type Tracer struct {
+	tConfig *ci.TracingConfig
+	client  *http.Client
+	url     string
+
+	// Framework key -> current active *Span
+	activeSpans sync.Map
+	endedSpans  chan *Span
+}
+
+type Span struct {
+	tracer *Tracer
+	key    string
+	parent *Span
+
+	traceID    string
+	spanID     string
+	name       string
+	startTime  time.Time
+	endTime    time.Time
+	attributes map[string]string
+	err        error
+}
+
+// Return nil if the tracing is disabled.
+func NewTracer(tConfig *ci.TracingConfig) *Tracer {
+	if *tConfig.OTLPEndpoint == "" {
+		return nil
+	}
+
+	return &Tracer{
+		tConfig:    tConfig,
+		client:     &http.Client{Timeout: common.SecToDuration(tConfig.ExportTimeoutSec)},
+		url:        strings.TrimSuffix(*tConfig.OTLPEndpoint, "/") + "/v1/traces",
+		endedSpans: make(chan *Span, *tConfig.BufferSize),
+	}
+}
+
+func (t *Tracer) Run(stopCh <-chan struct{}) {
+	if t == nil {
+		return
+	}
+
+	wait.Until(t.export, common.SecToDuration(t.tConfig.ExportIntervalSec), stopCh)
+	// Best effort to export the remaining spans.
+	t.export()
+}
+
+func (t *Tracer) StartSpan(
+	key string, name string, attributes map[string]string) *Span {
+	if t == nil {
+		return nil
+	}
+
+	s := &Span{
+		tracer:     t,
+		key:        key,
+		spanID:     newTraceHexID(8),
+		name:       name,
+		startTime:  time.Now(),
+		attributes: map[string]string{"framework.key": key},
+	}
+	for k, v := range attributes {
+		s.attributes[k] = v
+	}
+
+	if parent, ok := t.activeSpans.Load(key); ok {
+		s.parent = parent.(*Span)
+		s.traceID = s.parent.traceID
+		// Inherit the Framework identity attributes, such as the
+		// framework.attempt_id, so that all spans can be filtered by them.
+		for k, v := range s.parent.attributes {
+			if _, exists := s.attributes[k]; !exists && strings.HasPrefix(k, "framework.") {
+				s.attributes[k] = v
+			}
+		}
+	} else {
+		s.traceID = newTraceHexID(16)
+	}
+	t.activeSpans.Store(key, s)
+
+	return s
+}
+
+func (s *Span) SetAttribute(k string, v interface{}) {
+	if s == nil {
+		return
+	}
+
+	s.attributes[k] = fmt.Sprint(v)
+}
+
+// The span is recorded as failed if err is not nil.
+func (s *Span) End(err error) {
+	if s == nil {
+		return
+	}
+
+	s.endTime = time.Now()
+	s.err = err
+
+	if s.parent != nil {
+		s.tracer.activeSpans.Store(s.key, s.parent)
+	} else {
+		s.tracer.activeSpans.Delete(s.key)
+	}
+
+	select {
+	case s.tracer.endedSpans <- s:
+	default:
+		klog.Warningf("Tracer: Dropped span %v: Buffer is full", s.name)
+	}
+}
+
+func (t *Tracer) export() {
+	spans := []*Span{}
+batch:
+	for {
+		select {
+		case s := <-t.endedSpans:
+			spans = append(spans, s)
+		default:
+			break batch
+		}
+	}
+	if len(spans) == 0 {
+		return
+	}
+
+	err := t.post(spans)
+	if err != nil {
+		klog.Warningf("Tracer: Dropped %v spans: %v", len(spans), err)
+	}
+}
+
+func (t *Tracer) post(spans []*Span) error {
+	req, err := http.NewRequest(http.MethodPost, t.url,
+		bytes.NewReader([]byte(common.ToJson(t.newOTLPRequest(spans)))))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json")
+
+	resp, err := t.client.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode < 200 || resp.StatusCode >= 300 {
+		body, _ := ioutil.ReadAll(resp.Body)
+		return fmt.Errorf("OTLP endpoint returns unexpected response: %v: %v",
+			resp.Status, string(body))
+	}
+	return nil
+}
+
+func newTraceHexID(byteNum int) string {
+	b := make([]byte, byteNum)
+	_, err := rand.Read(b)
+	if err != nil {
+		panic(fmt.Errorf("Failed to generate trace ID: %v", err))
+	}
+	return hex.EncodeToString(b)
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// OTLP JSON Encoding
+// See https://github.com/open-telemetry/opentelemetry-proto/blob/main/opentelemetry/proto/trace/v1/trace.proto
+///////////////////////////////////////////////////////////////////////////////////////
+const (
+	otlpSpanKindInternal = 1
+	otlpStatusCodeOk     = 1
+	otlpStatusCodeError  = 2
+)
+
+type otlpRequest struct {
+	ResourceSpans []otlpResourceSpans `json:"resourceSpans"`
+}
+
+type otlpResourceSpans struct {
+	Resource   otlpResource     `json:"resource"`
+	ScopeSpans []otlpScopeSpans `json:"scopeSpans"`
+}
+
+type otlpResource struct {
+	Attributes []otlpKeyValue `json:"attributes"`
+}
+
+type otlpScopeSpans struct {
+	Scope otlpScope  `json:"scope"`
+	Spans []otlpSpan `json:"spans"`
+}
+
+type otlpScope struct {
+	Name string `json:"name"`
+}
+
+type otlpSpan struct {
+	TraceID           string         `json:"traceId"`
+	SpanID            string         `json:"spanId"`
+	ParentSpanID      string         `json:"parentSpanId,omitempty"`
+	Name              string         `json:"name"`
+	Kind              int            `json:"kind"`
+	StartTimeUnixNano string         `json:"startTimeUnixNano"`
+	EndTimeUnixNano   string         `json:"endTimeUnixNano"`
+	Attributes        []otlpKeyValue `json:"attributes"`
+	Status            otlpStatus     `json:"status"`
+}
+
+type otlpKeyValue struct {
+	Key   string       `json:"key"`
+	Value otlpAnyValue `json:"value"`
+}
+
+type otlpAnyValue struct {
+	StringValue string `json:"stringValue"`
+}
+
+type otlpStatus struct {
+	Code    int    `json:"code"`
+	Message string `json:"message,omitempty"`
+}
+
+func (t *Tracer) newOTLPRequest(spans []*Span) *otlpRequest {
+	otlpSpans := []otlpSpan{}
+	for _, s := range spans {
+		os := otlpSpan{
+			TraceID:           s.traceID,
+			SpanID:            s.spanID,
+			Name:              s.name,
+			Kind:              otlpSpanKindInternal,
+			StartTimeUnixNano: fmt.Sprint(s.startTime.UnixNano()),
+			EndTimeUnixNano:   fmt.Sprint(s.endTime.UnixNano()),
+			Attributes:        []otlpKeyValue{},
+			Status:            otlpStatus{Code: otlpStatusCodeOk},
+		}
+		if s.parent != nil {
+			os.ParentSpanID = s.parent.spanID
+		}
+		for k, v := range s.attributes {
+			os.Attributes = append(os.Attributes,
+				otlpKeyValue{Key: k, Value: otlpAnyValue{StringValue: v}})
+		}
+		if s.err != nil {
+			os.Status = otlpStatus{Code: otlpStatusCodeError, Message: s.err.Error()}
+		}
+		otlpSpans = append(otlpSpans, os)
+	}
+
+	return &otlpRequest{
+		ResourceSpans: []otlpResourceSpans{{
+			Resource: otlpResource{
+				Attributes: []otlpKeyValue{{
+					Key:   "service.name",
+					Value: otlpAnyValue{StringValue: *t.tConfig.ServiceName},
+				}},
+			},
+			ScopeSpans: []otlpScopeSpans{{
+				Scope: otlpScope{Name: ci.ComponentName},
+				Spans: otlpSpans,
+			}},
+		}},
+	}
+}
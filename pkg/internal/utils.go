@@ -31,20 +31,40 @@ import (
 	apiExtensions "k8s.io/apiextensions-apiserver/pkg/apis/apiextensions/v1beta1"
 	apiClient "k8s.io/apiextensions-apiserver/pkg/client/clientset/clientset"
 	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	apiMeta "k8s.io/apimachinery/pkg/api/meta"
 	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/runtime"
 	"k8s.io/apimachinery/pkg/util/wait"
+	"k8s.io/apimachinery/pkg/watch"
 	kubeClient "k8s.io/client-go/kubernetes"
 	"k8s.io/client-go/rest"
 	"k8s.io/client-go/tools/cache"
+	"k8s.io/client-go/transport"
 	"k8s.io/klog"
+	"net/http"
 	"reflect"
 	"strings"
 	"time"
 )
 
+const (
+	ContentTypeProtobuf = "application/vnd.kubernetes.protobuf"
+
+	// Annotation which is larger than it will be stripped from the local cache,
+	// such as the kubectl.kubernetes.io/last-applied-configuration, unless it is
+	// consumed by FrameworkController, see ObjectTransformer.
+	LocalCacheAnnotationMaxBytes = 1024
+)
+
 func CreateClients(kConfig *rest.Config) (
 	kubeClient.Interface, frameworkClient.Interface) {
-	kClient, err := kubeClient.NewForConfig(kConfig)
+	// Only k8s built-in objects support protobuf, so only use it for KubeClient
+	// to reduce the serialization cost of the heavy Pod list and watch.
+	kubeConfig := rest.CopyConfig(kConfig)
+	kubeConfig.AcceptContentTypes = strings.Join([]string{
+		ContentTypeProtobuf, runtime.ContentTypeJSON}, ",")
+	kubeConfig.ContentType = ContentTypeProtobuf
+	kClient, err := kubeClient.NewForConfig(kubeConfig)
 	if err != nil {
 		panic(fmt.Errorf("Failed to create KubeClient: %v", err))
 	}
@@ -57,6 +77,40 @@ func CreateClients(kConfig *rest.Config) (
 	return kClient, fClient
 }
 
+// Wrap the RoundTripper of the kConfig to send all the write requests as server
+// side dry-run requests, so that they are validated and responded by the
+// ApiServer but never persisted, and log them as the intended writes.
+func EnableDryRun(kConfig *rest.Config) {
+	kConfig.WrapTransport = transport.Wrappers(kConfig.WrapTransport,
+		func(rt http.RoundTripper) http.RoundTripper {
+			return &dryRunRoundTripper{delegate: rt}
+		})
+}
+
+type dryRunRoundTripper struct {
+	delegate http.RoundTripper
+}
+
+func (rt *dryRunRoundTripper) RoundTrip(req *http.Request) (*http.Response, error) {
+	switch req.Method {
+	case http.MethodPost, http.MethodPut, http.MethodPatch, http.MethodDelete:
+	default:
+		return rt.delegate.RoundTrip(req)
+	}
+
+	klog.Infof("DryRun: %v %v", req.Method, req.URL.Path)
+
+	// RoundTripper should not modify the original request.
+	dryRunReq := new(http.Request)
+	*dryRunReq = *req
+	dryRunURL := *req.URL
+	query := dryRunURL.Query()
+	query.Set("dryRun", meta.DryRunAll)
+	dryRunURL.RawQuery = query.Encode()
+	dryRunReq.URL = &dryRunURL
+	return rt.delegate.RoundTrip(dryRunReq)
+}
+
 func PutCRD(
 	config *rest.Config, crd *apiExtensions.CustomResourceDefinition,
 	establishedCheckIntervalSec *int64, establishedCheckTimeoutSec *int64) {
@@ -140,6 +194,121 @@ func isCRDEstablished(crd *apiExtensions.CustomResourceDefinition) bool {
 	return false
 }
 
+// obj should come from ScheduledFramework SharedIndexInformer, otherwise may panic.
+func ToScheduledFramework(obj interface{}) *ci.ScheduledFramework {
+	sf, ok := obj.(*ci.ScheduledFramework)
+
+	if !ok {
+		deletedFinalStateUnknown, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			panic(fmt.Errorf(
+				"Failed to convert obj to ScheduledFramework or DeletedFinalStateUnknown: %#v",
+				obj))
+		}
+
+		sf, ok = deletedFinalStateUnknown.Obj.(*ci.ScheduledFramework)
+		if !ok {
+			panic(fmt.Errorf(
+				"Failed to convert DeletedFinalStateUnknown.Obj to ScheduledFramework: %#v",
+				deletedFinalStateUnknown))
+		}
+	}
+
+	return sf
+}
+
+// obj should come from FrameworkGroup SharedIndexInformer, otherwise may panic.
+func ToFrameworkGroup(obj interface{}) *ci.FrameworkGroup {
+	g, ok := obj.(*ci.FrameworkGroup)
+
+	if !ok {
+		deletedFinalStateUnknown, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			panic(fmt.Errorf(
+				"Failed to convert obj to FrameworkGroup or DeletedFinalStateUnknown: %#v",
+				obj))
+		}
+
+		g, ok = deletedFinalStateUnknown.Obj.(*ci.FrameworkGroup)
+		if !ok {
+			panic(fmt.Errorf(
+				"Failed to convert DeletedFinalStateUnknown.Obj to FrameworkGroup: %#v",
+				deletedFinalStateUnknown))
+		}
+	}
+
+	return g
+}
+
+// obj should come from Queue SharedIndexInformer, otherwise may panic.
+func ToQueue(obj interface{}) *ci.Queue {
+	q, ok := obj.(*ci.Queue)
+
+	if !ok {
+		deletedFinalStateUnknown, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			panic(fmt.Errorf(
+				"Failed to convert obj to Queue or DeletedFinalStateUnknown: %#v",
+				obj))
+		}
+
+		q, ok = deletedFinalStateUnknown.Obj.(*ci.Queue)
+		if !ok {
+			panic(fmt.Errorf(
+				"Failed to convert DeletedFinalStateUnknown.Obj to Queue: %#v",
+				deletedFinalStateUnknown))
+		}
+	}
+
+	return q
+}
+
+// obj should come from FrameworkQuota SharedIndexInformer, otherwise may panic.
+func ToFrameworkQuota(obj interface{}) *ci.FrameworkQuota {
+	fq, ok := obj.(*ci.FrameworkQuota)
+
+	if !ok {
+		deletedFinalStateUnknown, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			panic(fmt.Errorf(
+				"Failed to convert obj to FrameworkQuota or DeletedFinalStateUnknown: %#v",
+				obj))
+		}
+
+		fq, ok = deletedFinalStateUnknown.Obj.(*ci.FrameworkQuota)
+		if !ok {
+			panic(fmt.Errorf(
+				"Failed to convert DeletedFinalStateUnknown.Obj to FrameworkQuota: %#v",
+				deletedFinalStateUnknown))
+		}
+	}
+
+	return fq
+}
+
+// obj should come from TaskRoleScale SharedIndexInformer, otherwise may panic.
+func ToTaskRoleScale(obj interface{}) *ci.TaskRoleScale {
+	ts, ok := obj.(*ci.TaskRoleScale)
+
+	if !ok {
+		deletedFinalStateUnknown, ok := obj.(cache.DeletedFinalStateUnknown)
+		if !ok {
+			panic(fmt.Errorf(
+				"Failed to convert obj to TaskRoleScale or DeletedFinalStateUnknown: %#v",
+				obj))
+		}
+
+		ts, ok = deletedFinalStateUnknown.Obj.(*ci.TaskRoleScale)
+		if !ok {
+			panic(fmt.Errorf(
+				"Failed to convert DeletedFinalStateUnknown.Obj to TaskRoleScale: %#v",
+				deletedFinalStateUnknown))
+		}
+	}
+
+	return ts
+}
+
 // obj should come from Framework SharedIndexInformer, otherwise may panic.
 func ToFramework(obj interface{}) *ci.Framework {
 	f, ok := obj.(*ci.Framework)
@@ -209,6 +378,170 @@ func ToPod(obj interface{}) *core.Pod {
 	return pod
 }
 
+// ListWatch all ConfigMaps through the typed client instead of its RESTClient,
+// so that it also works with the fake clientset.
+func NewConfigMapListWatch(kClient kubeClient.Interface) *cache.ListWatch {
+	return &cache.ListWatch{
+		ListFunc: func(options meta.ListOptions) (runtime.Object, error) {
+			return kClient.CoreV1().ConfigMaps(core.NamespaceAll).List(options)
+		},
+		WatchFunc: func(options meta.ListOptions) (watch.Interface, error) {
+			return kClient.CoreV1().ConfigMaps(core.NamespaceAll).Watch(options)
+		},
+	}
+}
+
+// ListWatch all Pods through the typed client instead of its RESTClient,
+// so that it also works with the fake clientset.
+func NewPodListWatch(kClient kubeClient.Interface) *cache.ListWatch {
+	return &cache.ListWatch{
+		ListFunc: func(options meta.ListOptions) (runtime.Object, error) {
+			return kClient.CoreV1().Pods(core.NamespaceAll).List(options)
+		},
+		WatchFunc: func(options meta.ListOptions) (watch.Interface, error) {
+			return kClient.CoreV1().Pods(core.NamespaceAll).Watch(options)
+		},
+	}
+}
+
+func NewNodeListWatch(kClient kubeClient.Interface) *cache.ListWatch {
+	return &cache.ListWatch{
+		ListFunc: func(options meta.ListOptions) (runtime.Object, error) {
+			return kClient.CoreV1().Nodes().List(options)
+		},
+		WatchFunc: func(options meta.ListOptions) (watch.Interface, error) {
+			return kClient.CoreV1().Nodes().Watch(options)
+		},
+	}
+}
+
+// Transform the object in place before it is stored in the local cache.
+type ObjectTransformFunc func(obj runtime.Object)
+
+// Wrap the ListWatch to transform every listed and watched object before it is
+// delivered to the Informer, so that the transformed object is stored in the
+// local cache instead of the original one.
+func NewTransformedListWatch(
+	lw *cache.ListWatch, transform ObjectTransformFunc) *cache.ListWatch {
+	return &cache.ListWatch{
+		ListFunc: func(options meta.ListOptions) (runtime.Object, error) {
+			list, err := lw.ListFunc(options)
+			if err != nil {
+				return list, err
+			}
+
+			err = apiMeta.EachListItem(list, func(obj runtime.Object) error {
+				transform(obj)
+				return nil
+			})
+			return list, err
+		},
+		WatchFunc: func(options meta.ListOptions) (watch.Interface, error) {
+			w, err := lw.WatchFunc(options)
+			if err != nil {
+				return w, err
+			}
+
+			return watch.Filter(w, func(in watch.Event) (watch.Event, bool) {
+				if in.Type != watch.Error {
+					transform(in.Object)
+				}
+				return in, true
+			}), nil
+		},
+	}
+}
+
+// ObjectTransformer strips the object fields which are never used by
+// FrameworkController, before the object is stored in the local cache.
+// The annotations consumed by FrameworkController are always kept, no matter
+// how large they are, i.e. the ones with the AnnotationKeyPrefix, which are
+// created by FrameworkController itself or the TaskAttempt, and the ones
+// specified in the Config, such as the PodDeviceIDAnnotationKeys.
+type ObjectTransformer struct {
+	keptAnnotationKeys map[string]bool
+}
+
+func NewObjectTransformer(cConfig *ci.Config) *ObjectTransformer {
+	keptAnnotationKeys := map[string]bool{}
+	for _, key := range cConfig.PodDeviceIDAnnotationKeys {
+		keptAnnotationKeys[key] = true
+	}
+	for _, key := range cConfig.SpotInterruption.NodeAnnotationKeys {
+		keptAnnotationKeys[key] = true
+	}
+	return &ObjectTransformer{keptAnnotationKeys: keptAnnotationKeys}
+}
+
+// Strip the Pod fields which are never used by FrameworkController.
+func (t *ObjectTransformer) TransformPod(obj runtime.Object) {
+	pod, ok := obj.(*core.Pod)
+	if !ok {
+		return
+	}
+
+	t.transformObjectMeta(&pod.ObjectMeta)
+	transformContainerStatuses(pod.Status.InitContainerStatuses)
+	transformContainerStatuses(pod.Status.ContainerStatuses)
+}
+
+// Strip the ConfigMap fields which are never used by FrameworkController.
+// The ConfigMap data, such as the hostfile written by FrameworkController, is
+// only consumed by the Pods, and is never read from the local cache.
+func (t *ObjectTransformer) TransformConfigMap(obj runtime.Object) {
+	cm, ok := obj.(*core.ConfigMap)
+	if !ok {
+		return
+	}
+
+	t.transformObjectMeta(&cm.ObjectMeta)
+	cm.Data = nil
+	cm.BinaryData = nil
+}
+
+// Strip the Node fields which are never used by FrameworkController.
+// Only the Node conditions are used.
+func (t *ObjectTransformer) TransformNode(obj runtime.Object) {
+	node, ok := obj.(*core.Node)
+	if !ok {
+		return
+	}
+
+	t.transformObjectMeta(&node.ObjectMeta)
+	node.Status.Images = nil
+	node.Status.VolumesInUse = nil
+	node.Status.VolumesAttached = nil
+}
+
+func (t *ObjectTransformer) transformObjectMeta(objectMeta *meta.ObjectMeta) {
+	objectMeta.ManagedFields = nil
+	for key, value := range objectMeta.Annotations {
+		if len(value) <= LocalCacheAnnotationMaxBytes ||
+			strings.HasPrefix(key, ci.AnnotationKeyPrefix) ||
+			t.keptAnnotationKeys[key] {
+			continue
+		}
+
+		klog.Infof(
+			"[%v/%v]: Stripped %v bytes Annotation %v from local cache",
+			objectMeta.Namespace, objectMeta.Name, len(value), key)
+		delete(objectMeta.Annotations, key)
+	}
+}
+
+func transformContainerStatuses(containerStatuses []core.ContainerStatus) {
+	for i := range containerStatuses {
+		// Only the last termination is used for the ContainerCompletionStatus.
+		containerStatuses[i].LastTerminationState.Running = nil
+		containerStatuses[i].LastTerminationState.Waiting = nil
+		if lastTerm := containerStatuses[i].LastTerminationState.Terminated; lastTerm != nil {
+			lastTerm.ContainerID = ""
+		}
+		containerStatuses[i].Image = ""
+		containerStatuses[i].ImageID = ""
+	}
+}
+
 func GetPodDeletionStartTime(pod *core.Pod) *meta.Time {
 	if pod.DeletionTimestamp == nil {
 		return nil
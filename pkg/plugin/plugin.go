@@ -0,0 +1,556 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package plugin
+
+import (
+	"bufio"
+	"encoding/json"
+	"flag"
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	frameworkClient "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned"
+	"github.com/microsoft/frameworkcontroller/pkg/internal"
+	"io"
+	core "k8s.io/api/core/v1"
+	"k8s.io/apimachinery/pkg/api/resource"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/apis/meta/v1/unstructured"
+	"k8s.io/apimachinery/pkg/runtime/schema"
+	"k8s.io/apimachinery/pkg/types"
+	"k8s.io/client-go/dynamic"
+	kubeClient "k8s.io/client-go/kubernetes"
+	"k8s.io/client-go/tools/clientcmd"
+	"os"
+	"sort"
+	"strconv"
+	"sync"
+	"text/tabwriter"
+	"time"
+)
+
+// FrameworkController Extension: kubectl-framework
+//
+// Best Practice:
+// It is installed as a kubectl plugin, i.e. the binary kubectl-framework is put
+// into the ${PATH}, so that the users can manage the Frameworks by the familiar
+// kubectl, without crafting the patches in the user manual.
+//
+// Usage:
+//   kubectl framework describe     {FrameworkName}
+//     Show the Framework status and a task table per TaskRole.
+//   kubectl framework stop         {FrameworkName}
+//     Stop the Framework, see Stop Framework in the user manual.
+//   kubectl framework restart-task {FrameworkName} {TaskRoleName} {TaskIndex}
+//     Restart the current TaskAttempt of the Task, see Restart Task in the user
+//     manual.
+//   kubectl framework logs         {FrameworkName} [--role=] [--index=]
+//                                  [--container=] [--tail=] [--follow]
+//     Aggregate the logs of the current TaskAttempts, prefixed by the Task.
+//   kubectl framework top          {FrameworkName}
+//     Show the CPU and memory usage of the current TaskAttempts, which requires
+//     the metrics-server.
+// All subcommands also accept the --namespace (-n), --kubeconfig and --context.
+type Plugin struct {
+	namespace string
+	kClient   kubeClient.Interface
+	fClient   frameworkClient.Interface
+	dClient   dynamic.Interface
+	out       io.Writer
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// Constants
+///////////////////////////////////////////////////////////////////////////////////////
+const (
+	ComponentName = "kubectl-framework"
+)
+
+var PodMetricsGroupVersionResource = schema.GroupVersionResource{
+	Group: "metrics.k8s.io", Version: "v1beta1", Resource: "pods"}
+
+type command struct {
+	usage string
+	// Register the command specific flags into the returned options, which is
+	// then passed to run.
+	flags func(fs *flag.FlagSet) interface{}
+	run   func(p *Plugin, opts interface{}, args []string) error
+}
+
+type logsOptions struct {
+	taskRoleName string
+	taskIndex    int
+	container    string
+	tail         int64
+	follow       bool
+}
+
+var commands = map[string]*command{
+	"describe": {
+		usage: "describe {FrameworkName}",
+		run:   (*Plugin).describe,
+	},
+	"stop": {
+		usage: "stop {FrameworkName}",
+		run:   (*Plugin).stop,
+	},
+	"restart-task": {
+		usage: "restart-task {FrameworkName} {TaskRoleName} {TaskIndex}",
+		run:   (*Plugin).restartTask,
+	},
+	"logs": {
+		usage: "logs {FrameworkName} [--role=] [--index=] [--container=] [--tail=] [--follow]",
+		flags: func(fs *flag.FlagSet) interface{} {
+			o := &logsOptions{}
+			fs.StringVar(&o.taskRoleName, "role", "", "Only the Tasks of the TaskRole")
+			fs.IntVar(&o.taskIndex, "index", -1, "Only the Task with the TaskIndex, together with --role")
+			fs.StringVar(&o.container, "container", "", "The container, default to the first one")
+			fs.Int64Var(&o.tail, "tail", -1, "The number of recent lines per Task, default to all")
+			fs.BoolVar(&o.follow, "follow", false, "Stream the logs")
+			return o
+		},
+		run: (*Plugin).logs,
+	},
+	"top": {
+		usage: "top {FrameworkName}",
+		run:   (*Plugin).top,
+	},
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// Methods
+///////////////////////////////////////////////////////////////////////////////////////
+
+// Run the subcommand in the args, i.e. os.Args[1:], and return the exit code.
+func Run(args []string) int {
+	if len(args) < 1 || commands[args[0]] == nil {
+		printUsage()
+		return 2
+	}
+	name := args[0]
+	cmd := commands[name]
+
+	fs := flag.NewFlagSet(ComponentName+" "+name, flag.ContinueOnError)
+	namespace := fs.String("namespace", "", "The namespace of the Framework")
+	fs.StringVar(namespace, "n", "", "Shorthand for --namespace")
+	kubeConfig := fs.String("kubeconfig", "", "The kubeconfig file path")
+	kubeContext := fs.String("context", "", "The kubeconfig context")
+	var opts interface{}
+	if cmd.flags != nil {
+		opts = cmd.flags(fs)
+	}
+	positionalArgs, err := parseInterspersed(fs, args[1:])
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Usage: kubectl framework %v\n", cmd.usage)
+		return 2
+	}
+
+	p, err := newPlugin(*namespace, *kubeConfig, *kubeContext)
+	if err == nil {
+		err = cmd.run(p, opts, positionalArgs)
+	}
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "Error: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+func printUsage() {
+	names := []string{}
+	for name := range commands {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	fmt.Fprintf(os.Stderr, "Usage:\n")
+	for _, name := range names {
+		fmt.Fprintf(os.Stderr, "  kubectl framework %v\n", commands[name].usage)
+	}
+}
+
+// The flag package stops parsing at the first positional arg, but kubectl
+// allows the flags after it, such as kubectl framework logs myfw -n ns.
+func parseInterspersed(fs *flag.FlagSet, args []string) ([]string, error) {
+	positionalArgs := []string{}
+	for {
+		if err := fs.Parse(args); err != nil {
+			return nil, err
+		}
+		if fs.NArg() == 0 {
+			return positionalArgs, nil
+		}
+		positionalArgs = append(positionalArgs, fs.Arg(0))
+		args = fs.Args()[1:]
+	}
+}
+
+func newPlugin(namespace, kubeConfig, kubeContext string) (*Plugin, error) {
+	loadingRules := clientcmd.NewDefaultClientConfigLoadingRules()
+	loadingRules.ExplicitPath = kubeConfig
+	clientConfig := clientcmd.NewNonInteractiveDeferredLoadingClientConfig(
+		loadingRules, &clientcmd.ConfigOverrides{CurrentContext: kubeContext})
+
+	kConfig, err := clientConfig.ClientConfig()
+	if err != nil {
+		return nil, fmt.Errorf("Failed to build KubeConfig: %v", err)
+	}
+	if namespace == "" {
+		namespace, _, err = clientConfig.Namespace()
+		if err != nil {
+			return nil, fmt.Errorf("Failed to get namespace from KubeConfig: %v", err)
+		}
+	}
+
+	kClient, fClient := internal.CreateClients(kConfig)
+	dClient, err := dynamic.NewForConfig(kConfig)
+	if err != nil {
+		return nil, fmt.Errorf("Failed to create DynamicClient: %v", err)
+	}
+
+	return &Plugin{
+		namespace: namespace,
+		kClient:   kClient,
+		fClient:   fClient,
+		dClient:   dClient,
+		out:       os.Stdout,
+	}, nil
+}
+
+func requireArgs(args []string, names ...string) error {
+	if len(args) != len(names) {
+		return fmt.Errorf("Expect args %v, but got %v", names, args)
+	}
+	return nil
+}
+
+// Get the Framework with its Status decompressed if it is compressed.
+func (p *Plugin) getFramework(name string) (*ci.Framework, error) {
+	f, err := p.fClient.FrameworkcontrollerV1().Frameworks(p.namespace).
+		Get(name, meta.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Decompress(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+// The current Task of each TaskRole, in the order of the Spec.
+type taskRef struct {
+	taskRoleName string
+	status       *ci.TaskStatus
+}
+
+func (t *taskRef) String() string {
+	return fmt.Sprintf("%v-%v", t.taskRoleName, t.status.Index)
+}
+
+func getTasks(f *ci.Framework, taskRoleName string, taskIndex int32) []*taskRef {
+	tasks := []*taskRef{}
+	if f.Status == nil {
+		return tasks
+	}
+	for _, taskRoleStatus := range f.TaskRoleStatuses() {
+		if taskRoleName != "" && taskRoleStatus.Name != taskRoleName {
+			continue
+		}
+		for _, taskStatus := range taskRoleStatus.TaskStatuses {
+			if taskIndex >= 0 && taskStatus.Index != taskIndex {
+				continue
+			}
+			tasks = append(tasks, &taskRef{
+				taskRoleName: taskRoleStatus.Name, status: taskStatus})
+		}
+	}
+	return tasks
+}
+
+func formatAge(t *meta.Time) string {
+	if t == nil || t.IsZero() {
+		return "<none>"
+	}
+	return time.Since(t.Time).Round(time.Second).String()
+}
+
+func formatString(s *string) string {
+	if s == nil || *s == "" {
+		return "<none>"
+	}
+	return *s
+}
+
+func formatCompletionStatus(cs *ci.CompletionStatus) string {
+	if cs == nil {
+		return "<none>"
+	}
+	return fmt.Sprintf("%v(%v) %v", cs.Phrase, cs.Code, cs.Type.Name)
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// Subcommands
+///////////////////////////////////////////////////////////////////////////////////////
+func (p *Plugin) describe(opts interface{}, args []string) error {
+	if err := requireArgs(args, "FrameworkName"); err != nil {
+		return err
+	}
+	f, err := p.getFramework(args[0])
+	if err != nil {
+		return err
+	}
+
+	w := tabwriter.NewWriter(p.out, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "Name:\t%v\n", f.Name)
+	fmt.Fprintf(w, "Namespace:\t%v\n", f.Namespace)
+	fmt.Fprintf(w, "ExecutionType:\t%v\n", f.Spec.ExecutionType)
+	if f.Status == nil {
+		fmt.Fprintf(w, "State:\t<none>\n")
+		return w.Flush()
+	}
+	fmt.Fprintf(w, "State:\t%v\n", f.Status.State)
+	fmt.Fprintf(w, "Phase:\t%v\n", f.GetPhase())
+	fmt.Fprintf(w, "Age:\t%v\n", formatAge(&f.Status.StartTime))
+	fmt.Fprintf(w, "AttemptID:\t%v\n", f.Status.AttemptStatus.ID)
+	fmt.Fprintf(w, "RetriedCount:\t%v\n", f.Status.RetryPolicyStatus.TotalRetriedCount)
+	if f.Status.Progress != nil {
+		fmt.Fprintf(w, "Progress:\t%v%%\n", f.Status.Progress.CompletionPercentage)
+	}
+	if cs := f.Status.AttemptStatus.CompletionStatus; cs != nil {
+		fmt.Fprintf(w, "Completion:\t%v\n", formatCompletionStatus(cs.CompletionStatus))
+		fmt.Fprintf(w, "Diagnostics:\t%v\n", cs.Diagnostics)
+	}
+
+	for _, taskRoleStatus := range f.TaskRoleStatuses() {
+		fmt.Fprintf(w, "\nTaskRole %v:\n", taskRoleStatus.Name)
+		fmt.Fprintf(w, "INDEX\tSTATE\tATTEMPT\tPOD\tNODE\tIP\tAGE\tCOMPLETION\n")
+		for _, t := range getTasks(f, taskRoleStatus.Name, -1) {
+			as := t.status.AttemptStatus
+			completion := "<none>"
+			if as.CompletionStatus != nil {
+				completion = formatCompletionStatus(as.CompletionStatus.CompletionStatus)
+			}
+			fmt.Fprintf(w, "%v\t%v\t%v\t%v\t%v\t%v\t%v\t%v\n",
+				t.status.Index, t.status.State, as.ID, as.PodName,
+				formatString(as.PodNodeName), formatString(as.PodIP),
+				formatAge(&as.StartTime), completion)
+		}
+	}
+	return w.Flush()
+}
+
+func (p *Plugin) stop(opts interface{}, args []string) error {
+	if err := requireArgs(args, "FrameworkName"); err != nil {
+		return err
+	}
+
+	patch := fmt.Sprintf(`{"spec":{"executionType":"%v"}}`, ci.ExecutionStop)
+	f, err := p.fClient.FrameworkcontrollerV1().Frameworks(p.namespace).Patch(
+		args[0], types.MergePatchType, []byte(patch))
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(p.out, "framework %v stop requested\n", f.Name)
+	return nil
+}
+
+func (p *Plugin) restartTask(opts interface{}, args []string) error {
+	if err := requireArgs(args, "FrameworkName", "TaskRoleName", "TaskIndex"); err != nil {
+		return err
+	}
+	taskRoleName := args[1]
+	taskIndex, err := strconv.ParseInt(args[2], 10, 32)
+	if err != nil {
+		return fmt.Errorf("Failed to parse TaskIndex %v: %v", args[2], err)
+	}
+
+	f, err := p.getFramework(args[0])
+	if err != nil {
+		return err
+	}
+	taskRoleIndex := -1
+	for i, taskRoleSpec := range f.Spec.TaskRoles {
+		if taskRoleSpec.Name == taskRoleName {
+			taskRoleIndex = i
+		}
+	}
+	if taskRoleIndex < 0 {
+		return fmt.Errorf("TaskRole %v is not found in Framework %v", taskRoleName, f.Key())
+	}
+	tasks := getTasks(f, taskRoleName, int32(taskIndex))
+	if len(tasks) == 0 {
+		return fmt.Errorf("Task %v-%v is not found in Framework %v status",
+			taskRoleName, taskIndex, f.Key())
+	}
+	attemptID := tasks[0].status.AttemptStatus.ID
+
+	// Replace the stale request of the same Task, and keep the others.
+	requests := []ci.TaskRestartRequest{}
+	for _, request := range f.Spec.TaskRoles[taskRoleIndex].Task.RestartRequests {
+		if request.Index != int32(taskIndex) {
+			requests = append(requests, request)
+		}
+	}
+	requests = append(requests,
+		ci.TaskRestartRequest{Index: int32(taskIndex), AttemptID: attemptID})
+
+	// Test the ResourceVersion to avoid overriding the concurrent requests.
+	patch, err := json.Marshal([]map[string]interface{}{
+		{"op": "test", "path": "/metadata/resourceVersion", "value": f.ResourceVersion},
+		{"op": "add",
+			"path":  fmt.Sprintf("/spec/taskRoles/%v/task/restartRequests", taskRoleIndex),
+			"value": requests},
+	})
+	if err != nil {
+		return err
+	}
+	_, err = p.fClient.FrameworkcontrollerV1().Frameworks(p.namespace).Patch(
+		f.Name, types.JSONPatchType, patch)
+	if err != nil {
+		return err
+	}
+	fmt.Fprintf(p.out, "task %v-%v attempt %v restart requested\n",
+		taskRoleName, taskIndex, attemptID)
+	return nil
+}
+
+func (p *Plugin) logs(opts interface{}, args []string) error {
+	if err := requireArgs(args, "FrameworkName"); err != nil {
+		return err
+	}
+	o := opts.(*logsOptions)
+
+	f, err := p.getFramework(args[0])
+	if err != nil {
+		return err
+	}
+
+	logOptions := &core.PodLogOptions{Container: o.container, Follow: o.follow}
+	if o.tail >= 0 {
+		logOptions.TailLines = &o.tail
+	}
+
+	// Stream all Tasks concurrently, so that the followed logs are interleaved,
+	// but each line is written atomically.
+	outLock := &sync.Mutex{}
+	errs := make(chan error, 1)
+	wg := &sync.WaitGroup{}
+	for _, t := range getTasks(f, o.taskRoleName, int32(o.taskIndex)) {
+		if t.status.AttemptStatus.PodUID == nil {
+			continue
+		}
+		wg.Add(1)
+		go func(t *taskRef) {
+			defer wg.Done()
+			err := p.streamLogs(t, logOptions, outLock)
+			if err != nil {
+				select {
+				case errs <- fmt.Errorf("[%v]: %v", t, err):
+				default:
+				}
+			}
+		}(t)
+	}
+	wg.Wait()
+
+	select {
+	case err := <-errs:
+		return err
+	default:
+		return nil
+	}
+}
+
+func (p *Plugin) streamLogs(
+	t *taskRef, logOptions *core.PodLogOptions, outLock *sync.Mutex) error {
+	stream, err := p.kClient.CoreV1().Pods(p.namespace).GetLogs(
+		t.status.AttemptStatus.PodName, logOptions).Stream()
+	if err != nil {
+		return err
+	}
+	defer stream.Close()
+
+	scanner := bufio.NewScanner(stream)
+	scanner.Buffer(make([]byte, 64*1024), 1024*1024)
+	for scanner.Scan() {
+		outLock.Lock()
+		fmt.Fprintf(p.out, "[%v] %v\n", t, scanner.Text())
+		outLock.Unlock()
+	}
+	return scanner.Err()
+}
+
+func (p *Plugin) top(opts interface{}, args []string) error {
+	if err := requireArgs(args, "FrameworkName"); err != nil {
+		return err
+	}
+	f, err := p.getFramework(args[0])
+	if err != nil {
+		return err
+	}
+
+	metricsList, err := p.dClient.Resource(PodMetricsGroupVersionResource).
+		Namespace(p.namespace).List(meta.ListOptions{
+		LabelSelector: ci.LabelKeyFrameworkName + "=" + f.Name})
+	if err != nil {
+		return fmt.Errorf("Failed to list PodMetrics, ensure the metrics-server "+
+			"is installed: %v", err)
+	}
+
+	type usage struct {
+		cpu    resource.Quantity
+		memory resource.Quantity
+	}
+	podUsages := map[string]*usage{}
+	for _, item := range metricsList.Items {
+		u := &usage{}
+		containers, _, _ := unstructured.NestedSlice(item.Object, "containers")
+		for _, container := range containers {
+			c, ok := container.(map[string]interface{})
+			if !ok {
+				continue
+			}
+			cpu, _, _ := unstructured.NestedString(c, "usage", "cpu")
+			if q, err := resource.ParseQuantity(cpu); err == nil {
+				u.cpu.Add(q)
+			}
+			memory, _, _ := unstructured.NestedString(c, "usage", "memory")
+			if q, err := resource.ParseQuantity(memory); err == nil {
+				u.memory.Add(q)
+			}
+		}
+		podUsages[item.GetName()] = u
+	}
+
+	w := tabwriter.NewWriter(p.out, 0, 8, 2, ' ', 0)
+	fmt.Fprintf(w, "TASKROLE\tINDEX\tPOD\tCPU(cores)\tMEMORY(bytes)\n")
+	for _, t := range getTasks(f, "", -1) {
+		u, ok := podUsages[t.status.AttemptStatus.PodName]
+		if !ok {
+			continue
+		}
+		fmt.Fprintf(w, "%v\t%v\t%v\t%vm\t%vMi\n",
+			t.taskRoleName, t.status.Index, t.status.AttemptStatus.PodName,
+			u.cpu.MilliValue(), u.memory.Value()/(1024*1024))
+	}
+	return w.Flush()
+}
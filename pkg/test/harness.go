@@ -0,0 +1,400 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package test
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	frameworkClient "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned"
+	frameworkFake "github.com/microsoft/frameworkcontroller/pkg/client/clientset/versioned/fake"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	"github.com/microsoft/frameworkcontroller/pkg/controller"
+	"github.com/microsoft/frameworkcontroller/pkg/internal"
+	core "k8s.io/api/core/v1"
+	apiErrors "k8s.io/apimachinery/pkg/api/errors"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/labels"
+	"k8s.io/apimachinery/pkg/runtime"
+	"k8s.io/apimachinery/pkg/util/clock"
+	"k8s.io/apimachinery/pkg/util/wait"
+	dynamicFake "k8s.io/client-go/dynamic/fake"
+	kubeClient "k8s.io/client-go/kubernetes"
+	kubeFake "k8s.io/client-go/kubernetes/fake"
+	kubeTesting "k8s.io/client-go/testing"
+	"reflect"
+	"time"
+)
+
+// Harness runs a FrameworkController against the fake clientsets and a fake
+// clock, so that the Framework state machine can be tested without a real
+// cluster, such as in a table-driven test:
+//   h := test.NewHarness(nil)
+//   defer h.Stop()
+//   f, _ := h.CreateFramework(f)
+//   h.Sync(f)                                 // Pods are created
+//   h.CompletePod(f.Namespace, podName, 1)    // Pod failed with exit code 1
+//   h.Sync(f)                                 // Framework is retried or failed
+//   h.Step(time.Minute)                       // Retry delay passed
+//   h.Sync(f)
+// The Harness never starts the controller workers, instead, the test drives
+// each sync explicitly by Sync, so the result is deterministic.
+// Only one Harness can be running at a time, since the clock is process wide.
+
+const (
+	// Timeout to wait for the local caches catching up with the fake clientsets.
+	CacheSyncTimeout  = 10 * time.Second
+	cacheSyncInterval = 10 * time.Millisecond
+)
+
+type Harness struct {
+	Controller      *controller.FrameworkController
+	Config          *ci.Config
+	KubeClient      *kubeFake.Clientset
+	FrameworkClient *frameworkFake.Clientset
+	DynamicClient   *dynamicFake.FakeDynamicClient
+	Clock           *clock.FakeClock
+
+	originalClock clock.Clock
+	stopCh        chan struct{}
+}
+
+// NewHarness creates and starts a Harness with the given Config, or the default
+// Config if it is nil, and the given initial objects.
+// The Frameworks in the objects are put into the FrameworkClient, and the
+// others are put into the KubeClient.
+// Note the Config.PodFailureSpec is appended to the process wide
+// CompletionCodeInfos only by the first Harness created in the process, so it
+// is ignored for the later ones.
+func NewHarness(cConfig *ci.Config, objects ...runtime.Object) *Harness {
+	if cConfig == nil {
+		cConfig = ci.NewConfigFromYaml("")
+	}
+
+	fObjects := []runtime.Object{}
+	kObjects := []runtime.Object{}
+	for _, obj := range objects {
+		if _, ok := obj.(*ci.Framework); ok {
+			fObjects = append(fObjects, obj)
+		} else {
+			kObjects = append(kObjects, obj)
+		}
+	}
+
+	h := &Harness{
+		Config:          cConfig,
+		KubeClient:      kubeFake.NewSimpleClientset(kObjects...),
+		FrameworkClient: frameworkFake.NewSimpleClientset(fObjects...),
+		DynamicClient:   dynamicFake.NewSimpleDynamicClient(runtime.NewScheme()),
+		Clock:           clock.NewFakeClock(time.Now()),
+		originalClock:   common.Clock,
+		stopCh:          make(chan struct{}),
+	}
+	common.Clock = h.Clock
+
+	// Simulate the ApiServer defaulting, since the fake clientset does not.
+	h.KubeClient.PrependReactor("create", "pods",
+		func(action kubeTesting.Action) (bool, runtime.Object, error) {
+			pod := action.(kubeTesting.CreateAction).GetObject().(*core.Pod)
+			if pod.Status.Phase == "" {
+				pod.Status.Phase = core.PodPending
+			}
+			return false, nil, nil
+		})
+
+	h.Controller = controller.NewFrameworkControllerWithClients(
+		cConfig, nil, h.KubeClient, h.FrameworkClient, h.DynamicClient)
+	h.Controller.RunInformers(h.stopCh)
+	return h
+}
+
+// Stop the informers and restore the clock.
+func (h *Harness) Stop() {
+	close(h.stopCh)
+	common.Clock = h.originalClock
+}
+
+// Step the fake clock, such as to pass the retry delay or the timeouts.
+func (h *Harness) Step(d time.Duration) {
+	h.Clock.Step(d)
+}
+
+// Sync the Framework once after the local caches caught up with all the
+// previous changes, and then wait for the changes made by the sync itself.
+func (h *Harness) Sync(f *ci.Framework) error {
+	if err := h.WaitForCacheSync(); err != nil {
+		return err
+	}
+
+	syncErr := h.Controller.SyncFramework(f.Key())
+
+	if err := h.WaitForCacheSync(); err != nil {
+		return err
+	}
+	return syncErr
+}
+
+// Sync the Framework until its state is the expected one, or the maxSyncs is
+// reached.
+func (h *Harness) SyncUntil(
+	f *ci.Framework, state ci.FrameworkState, maxSyncs int) (*ci.Framework, error) {
+	for i := 0; i < maxSyncs; i++ {
+		if err := h.Sync(f); err != nil {
+			return nil, err
+		}
+
+		latestF, err := h.GetFramework(f.Namespace, f.Name)
+		if err != nil {
+			return nil, err
+		}
+		if latestF.Status != nil && latestF.Status.State == state {
+			return latestF, nil
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"[%v]: Framework is not in state %v after %v syncs", f.Key(), state, maxSyncs)
+}
+
+// Wait until the local caches of the controller are the same as the objects in
+// the fake clientsets, i.e. all the informer events have been delivered.
+func (h *Harness) WaitForCacheSync() error {
+	var lastErr error
+	err := wait.PollImmediate(cacheSyncInterval, CacheSyncTimeout,
+		func() (bool, error) {
+			lastErr = h.checkCacheSynced()
+			return lastErr == nil, nil
+		})
+	if err != nil {
+		return fmt.Errorf("Failed to WaitForCacheSync: %v", lastErr)
+	}
+	return nil
+}
+
+func (h *Harness) checkCacheSynced() error {
+	fList, err := h.FrameworkClient.FrameworkcontrollerV1().
+		Frameworks(core.NamespaceAll).List(meta.ListOptions{})
+	if err != nil {
+		return err
+	}
+	cachedFs, err := h.Controller.FrameworkLister().List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	if len(fList.Items) != len(cachedFs) {
+		return fmt.Errorf("Framework number %v != cached Framework number %v",
+			len(fList.Items), len(cachedFs))
+	}
+	for i := range fList.Items {
+		f := &fList.Items[i]
+		cachedF, err := h.Controller.FrameworkLister().Frameworks(f.Namespace).Get(f.Name)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(f, cachedF) {
+			return fmt.Errorf("[%v]: Framework is not synced to cache", f.Key())
+		}
+	}
+
+	podList, err := h.KubeClient.CoreV1().Pods(core.NamespaceAll).List(meta.ListOptions{})
+	if err != nil {
+		return err
+	}
+	cachedPods, err := h.Controller.PodLister().List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	if len(podList.Items) != len(cachedPods) {
+		return fmt.Errorf("Pod number %v != cached Pod number %v",
+			len(podList.Items), len(cachedPods))
+	}
+	for i := range podList.Items {
+		pod := &podList.Items[i]
+		if *h.Config.LocalCacheObjectTransform {
+			internal.NewObjectTransformer(h.Config).TransformPod(pod)
+		}
+		cachedPod, err := h.Controller.PodLister().Pods(pod.Namespace).Get(pod.Name)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(pod, cachedPod) {
+			return fmt.Errorf("[%v/%v]: Pod is not synced to cache",
+				pod.Namespace, pod.Name)
+		}
+	}
+
+	cmList, err := h.KubeClient.CoreV1().ConfigMaps(core.NamespaceAll).List(meta.ListOptions{})
+	if err != nil {
+		return err
+	}
+	cachedCMs, err := h.Controller.ConfigMapLister().List(labels.Everything())
+	if err != nil {
+		return err
+	}
+	if len(cmList.Items) != len(cachedCMs) {
+		return fmt.Errorf("ConfigMap number %v != cached ConfigMap number %v",
+			len(cmList.Items), len(cachedCMs))
+	}
+	for i := range cmList.Items {
+		cm := &cmList.Items[i]
+		if *h.Config.LocalCacheObjectTransform {
+			internal.NewObjectTransformer(h.Config).TransformConfigMap(cm)
+		}
+		cachedCM, err := h.Controller.ConfigMapLister().ConfigMaps(cm.Namespace).Get(cm.Name)
+		if err != nil {
+			return err
+		}
+		if !reflect.DeepEqual(cm, cachedCM) {
+			return fmt.Errorf("[%v/%v]: ConfigMap is not synced to cache",
+				cm.Namespace, cm.Name)
+		}
+	}
+
+	return nil
+}
+
+///////////////////////////////////////////////////////////////////////////////////////
+// Object Operations
+///////////////////////////////////////////////////////////////////////////////////////
+func (h *Harness) fClient() frameworkClient.Interface {
+	return h.FrameworkClient
+}
+
+func (h *Harness) kClient() kubeClient.Interface {
+	return h.KubeClient
+}
+
+func (h *Harness) CreateFramework(f *ci.Framework) (*ci.Framework, error) {
+	return h.fClient().FrameworkcontrollerV1().Frameworks(f.Namespace).Create(f)
+}
+
+// Get the Framework with its Status decompressed if it is compressed.
+func (h *Harness) GetFramework(namespace, name string) (*ci.Framework, error) {
+	f, err := h.fClient().FrameworkcontrollerV1().Frameworks(namespace).
+		Get(name, meta.GetOptions{})
+	if err != nil {
+		return nil, err
+	}
+	if err := f.Decompress(); err != nil {
+		return nil, err
+	}
+	return f, nil
+}
+
+func (h *Harness) DeleteFramework(namespace, name string) error {
+	return h.fClient().FrameworkcontrollerV1().Frameworks(namespace).
+		Delete(name, &meta.DeleteOptions{})
+}
+
+func (h *Harness) GetPod(namespace, name string) (*core.Pod, error) {
+	return h.kClient().CoreV1().Pods(namespace).Get(name, meta.GetOptions{})
+}
+
+func (h *Harness) ListPods(namespace string) ([]core.Pod, error) {
+	podList, err := h.kClient().CoreV1().Pods(namespace).List(meta.ListOptions{})
+	if err != nil {
+		return nil, err
+	}
+	return podList.Items, nil
+}
+
+// Delete the Pod immediately, such as to simulate the Pod is evicted and then
+// garbage collected.
+func (h *Harness) DeletePod(namespace, name string) error {
+	err := h.kClient().CoreV1().Pods(namespace).Delete(name, &meta.DeleteOptions{})
+	if apiErrors.IsNotFound(err) {
+		return nil
+	}
+	return err
+}
+
+// Update the Pod.Status by the mutate func, such as to simulate the kubelet.
+func (h *Harness) UpdatePodStatus(
+	namespace, name string, mutate func(status *core.PodStatus)) (*core.Pod, error) {
+	pod, err := h.GetPod(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+	mutate(&pod.Status)
+	return h.kClient().CoreV1().Pods(namespace).UpdateStatus(pod)
+}
+
+// Simulate the Pod is bound to a node and all its containers are running.
+func (h *Harness) RunPod(namespace, name string) (*core.Pod, error) {
+	pod, err := h.GetPod(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	now := common.Now()
+	return h.UpdatePodStatus(namespace, name, func(status *core.PodStatus) {
+		status.Phase = core.PodRunning
+		status.HostIP = "127.0.0.1"
+		status.PodIP = "127.0.0.1"
+		status.StartTime = &now
+		status.ContainerStatuses = nil
+		for _, container := range pod.Spec.Containers {
+			status.ContainerStatuses = append(status.ContainerStatuses,
+				core.ContainerStatus{
+					Name:  container.Name,
+					Ready: true,
+					State: core.ContainerState{
+						Running: &core.ContainerStateRunning{StartedAt: now},
+					},
+				})
+		}
+	})
+}
+
+// Simulate all the Pod containers are terminated with the exitCode, so the Pod
+// is Succeeded if the exitCode is 0, otherwise Failed.
+func (h *Harness) CompletePod(
+	namespace, name string, exitCode int32) (*core.Pod, error) {
+	pod, err := h.GetPod(namespace, name)
+	if err != nil {
+		return nil, err
+	}
+
+	now := common.Now()
+	return h.UpdatePodStatus(namespace, name, func(status *core.PodStatus) {
+		status.Phase = core.PodSucceeded
+		reason := "Completed"
+		if exitCode != 0 {
+			status.Phase = core.PodFailed
+			reason = "Error"
+		}
+		status.ContainerStatuses = nil
+		for _, container := range pod.Spec.Containers {
+			status.ContainerStatuses = append(status.ContainerStatuses,
+				core.ContainerStatus{
+					Name: container.Name,
+					State: core.ContainerState{
+						Terminated: &core.ContainerStateTerminated{
+							ExitCode:   exitCode,
+							Reason:     reason,
+							FinishedAt: now,
+						},
+					},
+				})
+		}
+	})
+}
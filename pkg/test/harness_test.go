@@ -0,0 +1,147 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package test
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"testing"
+	"time"
+)
+
+func newTestFramework(maxRetryCount int32) *ci.Framework {
+	return &ci.Framework{
+		ObjectMeta: meta.ObjectMeta{Namespace: "default", Name: "f"},
+		Spec: ci.FrameworkSpec{
+			ExecutionType: ci.ExecutionStart,
+			TaskRoles: []*ci.TaskRoleSpec{{
+				Name:       "worker",
+				TaskNumber: 1,
+				FrameworkAttemptCompletionPolicy: ci.CompletionPolicySpec{
+					MinFailedTaskCount:    1,
+					MinSucceededTaskCount: 1,
+				},
+				Task: ci.TaskSpec{
+					RetryPolicy: ci.RetryPolicySpec{MaxRetryCount: maxRetryCount},
+					Pod: core.PodTemplateSpec{Spec: core.PodSpec{
+						Containers: []core.Container{{Name: "main", Image: "busybox"}},
+					}},
+				},
+			}},
+		},
+	}
+}
+
+// Sync the Framework until the Pod of the TaskAttempt is created.
+func syncUntilPodCreated(
+	h *Harness, f *ci.Framework, taskAttemptID int32, maxSyncs int) (*ci.TaskStatus, error) {
+	for i := 0; i < maxSyncs; i++ {
+		if err := h.Sync(f); err != nil {
+			return nil, err
+		}
+
+		latestF, err := h.GetFramework(f.Namespace, f.Name)
+		if err != nil {
+			return nil, err
+		}
+		if latestF.Status == nil || len(latestF.TaskRoleStatuses()) == 0 {
+			continue
+		}
+		taskStatus := latestF.TaskStatus("worker", 0)
+		if taskStatus.TaskAttemptID() == taskAttemptID &&
+			taskStatus.State == ci.TaskAttemptPreparing {
+			return taskStatus, nil
+		}
+	}
+
+	return nil, fmt.Errorf(
+		"[%v]: Pod of TaskAttempt %v is not created after %v syncs",
+		f.Key(), taskAttemptID, maxSyncs)
+}
+
+func TestSyncFramework(t *testing.T) {
+	cases := []struct {
+		name          string
+		maxRetryCount int32
+		exitCodes     []int32
+		wantType      ci.CompletionTypeName
+		wantRetried   int32
+	}{
+		{"Succeeded", 0, []int32{0}, ci.CompletionTypeNameSucceeded, 0},
+		{"Failed", 0, []int32{1}, ci.CompletionTypeNameFailed, 0},
+		{"SucceededAfterRetried", 1, []int32{1, 0}, ci.CompletionTypeNameSucceeded, 1},
+	}
+
+	for _, c := range cases {
+		t.Run(c.name, func(t *testing.T) {
+			h := NewHarness(nil)
+			defer h.Stop()
+
+			f, err := h.CreateFramework(newTestFramework(c.maxRetryCount))
+			if err != nil {
+				t.Fatal(err)
+			}
+
+			for i, exitCode := range c.exitCodes {
+				taskStatus, err := syncUntilPodCreated(h, f, int32(i), 10)
+				if err != nil {
+					t.Fatal(err)
+				}
+
+				if _, err := h.RunPod(f.Namespace, taskStatus.PodName()); err != nil {
+					t.Fatal(err)
+				}
+				if err := h.Sync(f); err != nil {
+					t.Fatal(err)
+				}
+				if _, err := h.CompletePod(
+					f.Namespace, taskStatus.PodName(), exitCode); err != nil {
+					t.Fatal(err)
+				}
+				if err := h.Sync(f); err != nil {
+					t.Fatal(err)
+				}
+				// Pass the retry delay, if any.
+				h.Step(time.Hour)
+			}
+
+			f, err = h.SyncUntil(f, ci.FrameworkCompleted, 10)
+			if err != nil {
+				t.Fatal(err)
+			}
+			if got := f.CompletionType().Name; got != c.wantType {
+				t.Errorf("got Framework CompletionType %v, want %v", got, c.wantType)
+			}
+			taskStatus := f.TaskStatus("worker", 0)
+			if got := taskStatus.RetryPolicyStatus.TotalRetriedCount; got != c.wantRetried {
+				t.Errorf("got Task TotalRetriedCount %v, want %v", got, c.wantRetried)
+			}
+			// The Framework is not retried.
+			if got := f.FrameworkAttemptID(); got != 0 {
+				t.Errorf("got FrameworkAttemptID %v, want 0", got)
+			}
+		})
+	}
+}
@@ -0,0 +1,502 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package validation
+
+import (
+	"fmt"
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"regexp"
+)
+
+// Validate the Framework against the constraints of the Framework CRD
+// validation and the implicit assumptions of FrameworkController, so that the
+// invalid Framework can be rejected before it is submitted, such as in the CI
+// pipelines and the admission webhooks:
+//   for _, err := range validation.Validate(f) {
+//     fmt.Println(err) // spec.taskRoles[1].name: Duplicate value: "worker"
+//   }
+// Each returned error is a *field.Error with the path of the invalid field.
+
+const MaxTaskNumber = 10000
+
+var namingConventionRegex = regexp.MustCompile(ci.NamingConvention)
+
+// Validate returns all the errors found in the Framework, or empty if it is
+// valid.
+func Validate(f *ci.Framework) []error {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateName(field.NewPath("metadata", "name"), f.Name)...)
+	allErrs = append(allErrs, validateSpec(field.NewPath("spec"), &f.Spec)...)
+
+	errs := []error{}
+	for _, err := range allErrs {
+		errs = append(errs, err)
+	}
+	return errs
+}
+
+func validateName(path *field.Path, name string) field.ErrorList {
+	if !namingConventionRegex.MatchString(name) {
+		return field.ErrorList{field.Invalid(path, name,
+			fmt.Sprintf("should match %v", ci.NamingConvention))}
+	}
+	return nil
+}
+
+func validateMin(path *field.Path, value int64, min int64) field.ErrorList {
+	if value < min {
+		return field.ErrorList{field.Invalid(path, value,
+			fmt.Sprintf("should not be less than %v", min))}
+	}
+	return nil
+}
+
+func validatePtrMin(path *field.Path, value *int64, min int64) field.ErrorList {
+	if value == nil {
+		return nil
+	}
+	return validateMin(path, *value, min)
+}
+
+func validatePercent(path *field.Path, value int64) field.ErrorList {
+	if value < 0 || value > 100 {
+		return field.ErrorList{field.Invalid(path, value,
+			"should be in range [0, 100]")}
+	}
+	return nil
+}
+
+func validateDeletionPropagation(
+	path *field.Path, policy *meta.DeletionPropagation) field.ErrorList {
+	if policy == nil || ci.IsValidDeletionPropagation(*policy) {
+		return nil
+	}
+	validPolicies := []string{}
+	for _, validPolicy := range ci.ValidDeletionPropagations {
+		validPolicies = append(validPolicies, string(validPolicy))
+	}
+	return field.ErrorList{field.NotSupported(path, *policy, validPolicies)}
+}
+
+func validateSpec(path *field.Path, spec *ci.FrameworkSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+
+	switch spec.ExecutionType {
+	case ci.ExecutionStart, ci.ExecutionStop, ci.ExecutionSuspend:
+	default:
+		allErrs = append(allErrs, field.NotSupported(
+			path.Child("executionType"), spec.ExecutionType, []string{
+				string(ci.ExecutionStart), string(ci.ExecutionStop),
+				string(ci.ExecutionSuspend)}))
+	}
+	allErrs = append(allErrs, validateRetryPolicy(
+		path.Child("retryPolicy"), &spec.RetryPolicy)...)
+	if spec.RetryBudget != nil {
+		budgetPath := path.Child("retryBudget")
+		if spec.RetryBudget.MaxTaskRetryCount != nil {
+			allErrs = append(allErrs, validateMin(
+				budgetPath.Child("maxTaskRetryCount"),
+				int64(*spec.RetryBudget.MaxTaskRetryCount), 0)...)
+		}
+		if spec.RetryBudget.MaxRetriedTaskPercent != nil {
+			allErrs = append(allErrs, validatePercent(
+				budgetPath.Child("maxRetriedTaskPercent"),
+				int64(*spec.RetryBudget.MaxRetriedTaskPercent))...)
+		}
+	}
+	allErrs = append(allErrs, validatePtrMin(
+		path.Child("attemptSchedulingTimeoutSec"), spec.AttemptSchedulingTimeoutSec, 1)...)
+	allErrs = append(allErrs, validatePtrMin(
+		path.Child("succeededRetainSec"), spec.SucceededRetainSec, 0)...)
+	allErrs = append(allErrs, validatePtrMin(
+		path.Child("failedRetainSec"), spec.FailedRetainSec, 0)...)
+	allErrs = append(allErrs, validateDeletionPropagation(
+		path.Child("frameworkDeletionPropagationPolicy"),
+		spec.FrameworkDeletionPropagationPolicy)...)
+	allErrs = append(allErrs, validateDeletionPropagation(
+		path.Child("configMapDeletionPropagationPolicy"),
+		spec.ConfigMapDeletionPropagationPolicy)...)
+	allErrs = append(allErrs, validatePtrMin(
+		path.Child("debugRetainFailedPodSec"), spec.DebugRetainFailedPodSec, 0)...)
+	if spec.RestartAttemptID != nil {
+		allErrs = append(allErrs, validateMin(
+			path.Child("restartAttemptID"), int64(*spec.RestartAttemptID), 0)...)
+	}
+
+	if spec.Hooks != nil {
+		hooksPath := path.Child("hooks")
+		allErrs = append(allErrs, validateHook(
+			hooksPath.Child("preAttempt"), spec.Hooks.PreAttempt)...)
+		allErrs = append(allErrs, validateHook(
+			hooksPath.Child("postAttempt"), spec.Hooks.PostAttempt)...)
+	}
+
+	taskRolesPath := path.Child("taskRoles")
+	if len(spec.TaskRoles) == 0 {
+		allErrs = append(allErrs, field.Required(taskRolesPath,
+			"should have at least one TaskRole"))
+	}
+	taskRoleNames := map[string]bool{}
+	for i, taskRole := range spec.TaskRoles {
+		taskRolePath := taskRolesPath.Index(i)
+		if taskRoleNames[taskRole.Name] {
+			allErrs = append(allErrs, field.Duplicate(
+				taskRolePath.Child("name"), taskRole.Name))
+		}
+		taskRoleNames[taskRole.Name] = true
+		allErrs = append(allErrs, validateTaskRole(taskRolePath, taskRole)...)
+		allErrs = append(allErrs, validateAntiAffinity(
+			taskRolePath.Child("antiAffinity"), taskRole, spec.Colocation)...)
+	}
+	allErrs = append(allErrs, validateDependencies(taskRolesPath, spec.TaskRoles)...)
+
+	return allErrs
+}
+
+func validateHook(path *field.Path, hook *ci.HookSpec) field.ErrorList {
+	if hook == nil {
+		return nil
+	}
+	allErrs := field.ErrorList{}
+
+	if (hook.Pod == nil) == (hook.Webhook == nil) {
+		allErrs = append(allErrs, field.Invalid(path, "",
+			"should specify exactly one of pod and webhook"))
+	}
+	if hook.Pod != nil {
+		podPath := path.Child("pod")
+		allErrs = append(allErrs, validatePodTemplate(podPath, hook.Pod)...)
+		if hook.Pod.Spec.RestartPolicy == core.RestartPolicyAlways {
+			allErrs = append(allErrs, field.NotSupported(
+				podPath.Child("spec", "restartPolicy"), hook.Pod.Spec.RestartPolicy,
+				[]string{string(core.RestartPolicyOnFailure), string(core.RestartPolicyNever)}))
+		}
+	}
+	if hook.Webhook != nil && hook.Webhook.URL == "" {
+		allErrs = append(allErrs, field.Required(
+			path.Child("webhook", "url"), ""))
+	}
+	allErrs = append(allErrs, validatePtrMin(
+		path.Child("timeoutSec"), hook.TimeoutSec, 1)...)
+	switch hook.FailurePolicy {
+	case "", ci.HookFailurePolicyFail, ci.HookFailurePolicyIgnore:
+	default:
+		allErrs = append(allErrs, field.NotSupported(
+			path.Child("failurePolicy"), hook.FailurePolicy, []string{
+				string(ci.HookFailurePolicyFail), string(ci.HookFailurePolicyIgnore)}))
+	}
+
+	return allErrs
+}
+
+func validateRetryPolicy(path *field.Path, rp *ci.RetryPolicySpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateMin(
+		path.Child("maxRetryCount"), int64(rp.MaxRetryCount), ci.ExtendedUnlimitedValue)...)
+
+	if rp.BackoffPolicy != nil {
+		backoffPath := path.Child("backoffPolicy")
+		switch rp.BackoffPolicy.Type {
+		case "", ci.BackoffFixed, ci.BackoffExponential:
+		default:
+			allErrs = append(allErrs, field.NotSupported(
+				backoffPath.Child("type"), rp.BackoffPolicy.Type, []string{
+					string(ci.BackoffFixed), string(ci.BackoffExponential)}))
+		}
+		allErrs = append(allErrs, validateMin(
+			backoffPath.Child("baseDelaySec"), rp.BackoffPolicy.BaseDelaySec, 0)...)
+		allErrs = append(allErrs, validateMin(
+			backoffPath.Child("maxDelaySec"), rp.BackoffPolicy.MaxDelaySec, 0)...)
+		if rp.BackoffPolicy.MaxDelaySec > 0 &&
+			rp.BackoffPolicy.MaxDelaySec < rp.BackoffPolicy.BaseDelaySec {
+			allErrs = append(allErrs, field.Invalid(
+				backoffPath.Child("maxDelaySec"), rp.BackoffPolicy.MaxDelaySec,
+				"should not be less than baseDelaySec"))
+		}
+		allErrs = append(allErrs, validatePercent(
+			backoffPath.Child("jitterPercent"), int64(rp.BackoffPolicy.JitterPercent))...)
+	}
+
+	for i, override := range rp.CompletionOverrides {
+		overridePath := path.Child("completionOverrides").Index(i)
+		switch override.TypeName {
+		case "", ci.CompletionTypeNameSucceeded, ci.CompletionTypeNameFailed:
+		default:
+			allErrs = append(allErrs, field.NotSupported(
+				overridePath.Child("typeName"), override.TypeName, []string{
+					string(ci.CompletionTypeNameSucceeded),
+					string(ci.CompletionTypeNameFailed)}))
+		}
+		if override.MinCode != nil && override.MaxCode != nil &&
+			*override.MinCode > *override.MaxCode {
+			allErrs = append(allErrs, field.Invalid(
+				overridePath.Child("maxCode"), *override.MaxCode,
+				"should not be less than minCode"))
+		}
+		allErrs = append(allErrs, validateMin(
+			overridePath.Child("maxRetryCount"), int64(override.MaxRetryCount), ci.UnlimitedValue)...)
+		allErrs = append(allErrs, validateMin(
+			overridePath.Child("delaySec"), override.DelaySec, 0)...)
+	}
+
+	return allErrs
+}
+
+func validateTaskRole(path *field.Path, taskRole *ci.TaskRoleSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	allErrs = append(allErrs, validateName(path.Child("name"), taskRole.Name)...)
+
+	if taskRole.TaskNumber < 0 || taskRole.TaskNumber > MaxTaskNumber {
+		allErrs = append(allErrs, field.Invalid(
+			path.Child("taskNumber"), taskRole.TaskNumber,
+			fmt.Sprintf("should be in range [0, %v]", MaxTaskNumber)))
+	}
+	allErrs = append(allErrs, validateMin(
+		path.Child("minTaskNumber"), int64(taskRole.MinTaskNumber), 0)...)
+	allErrs = append(allErrs, validateMin(
+		path.Child("portNumber"), int64(taskRole.PortNumber), 0)...)
+
+	// The completion policy cannot be satisfied if it requires more Tasks than
+	// the TaskNumber, unless the TaskRole is scaled up later.
+	policyPath := path.Child("frameworkAttemptCompletionPolicy")
+	policy := taskRole.FrameworkAttemptCompletionPolicy
+	for _, name := range []string{"minFailedTaskCount", "minSucceededTaskCount"} {
+		count := policy.MinFailedTaskCount
+		if name == "minSucceededTaskCount" {
+			count = policy.MinSucceededTaskCount
+		}
+		if count < ci.UnlimitedValue {
+			allErrs = append(allErrs, field.Invalid(policyPath.Child(name), count,
+				fmt.Sprintf("should not be less than %v", ci.UnlimitedValue)))
+		} else if count > taskRole.TaskNumber {
+			allErrs = append(allErrs, field.Invalid(policyPath.Child(name), count,
+				fmt.Sprintf("should not be greater than taskNumber %v, "+
+					"otherwise it can never be satisfied", taskRole.TaskNumber)))
+		}
+	}
+
+	taskPath := path.Child("task")
+	allErrs = append(allErrs, validateRetryPolicy(
+		taskPath.Child("retryPolicy"), &taskRole.Task.RetryPolicy)...)
+	allErrs = append(allErrs, validatePtrMin(
+		taskPath.Child("podGracefulDeletionTimeoutSec"),
+		taskRole.Task.PodGracefulDeletionTimeoutSec, 0)...)
+	allErrs = append(allErrs, validatePtrMin(
+		taskPath.Child("attemptMaxRunDurationSec"),
+		taskRole.Task.AttemptMaxRunDurationSec, 1)...)
+	allErrs = append(allErrs, validatePtrMin(
+		taskPath.Child("heartbeatTimeoutSec"),
+		taskRole.Task.HeartbeatTimeoutSec, 1)...)
+	allErrs = append(allErrs, validatePodTemplate(
+		taskPath.Child("pod"), &taskRole.Task.Pod)...)
+
+	if taskRole.CompletionContainer != "" {
+		found := false
+		for _, container := range taskRole.Task.Pod.Spec.Containers {
+			if container.Name == taskRole.CompletionContainer {
+				found = true
+			}
+		}
+		if !found {
+			allErrs = append(allErrs, field.NotFound(
+				path.Child("completionContainer"), taskRole.CompletionContainer))
+		}
+	}
+
+	for i, ref := range taskRole.SecretRefs {
+		refPath := path.Child("secretRefs").Index(i)
+		if ref.Name == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("name"), ""))
+		}
+		if ref.Key == "" {
+			allErrs = append(allErrs, field.Required(refPath.Child("key"), ""))
+		}
+		if (ref.EnvName == "") == (ref.MountPath == "") {
+			allErrs = append(allErrs, field.Invalid(refPath, "",
+				"should specify exactly one of envName and mountPath"))
+		}
+	}
+
+	if hived := taskRole.HiveD; hived != nil {
+		hivedPath := path.Child("hived")
+		if hived.VirtualCluster == "" {
+			allErrs = append(allErrs, field.Required(
+				hivedPath.Child("virtualCluster"), ""))
+		}
+		allErrs = append(allErrs, validateMin(
+			hivedPath.Child("priority"), int64(hived.Priority), -1)...)
+		allErrs = append(allErrs, validateMin(
+			hivedPath.Child("leafCellNumber"), int64(hived.LeafCellNumber), 0)...)
+	}
+
+	for i, constraint := range taskRole.TopologySpreadConstraints {
+		constraintPath := path.Child("topologySpreadConstraints").Index(i)
+		allErrs = append(allErrs, validateMin(
+			constraintPath.Child("maxSkew"), int64(constraint.MaxSkew), 0)...)
+		if constraint.TopologyKey == "" {
+			allErrs = append(allErrs, field.Required(
+				constraintPath.Child("topologyKey"), ""))
+		}
+		switch constraint.WhenUnsatisfiable {
+		case "", ci.UnsatisfiableDoNotSchedule, ci.UnsatisfiableScheduleAnyway:
+		default:
+			allErrs = append(allErrs, field.NotSupported(
+				constraintPath.Child("whenUnsatisfiable"), constraint.WhenUnsatisfiable,
+				[]string{string(ci.UnsatisfiableDoNotSchedule),
+					string(ci.UnsatisfiableScheduleAnyway)}))
+		}
+	}
+
+	for i, override := range taskRole.TaskOverrides {
+		overridePath := path.Child("taskOverrides").Index(i)
+		allErrs = append(allErrs, validateMin(
+			overridePath.Child("minTaskIndex"), int64(override.MinTaskIndex), 0)...)
+		if override.MaxTaskIndex < override.MinTaskIndex {
+			allErrs = append(allErrs, field.Invalid(
+				overridePath.Child("maxTaskIndex"), override.MaxTaskIndex,
+				"should not be less than minTaskIndex"))
+		}
+	}
+
+	return allErrs
+}
+
+// The required AntiAffinity can never be satisfied together with the required
+// Colocation in the same topology domain, if the TaskRole has multiple Tasks.
+func validateAntiAffinity(
+	path *field.Path, taskRole *ci.TaskRoleSpec,
+	colocation *ci.ColocationSpec) field.ErrorList {
+	antiAffinity := taskRole.AntiAffinity
+	if antiAffinity == nil || !antiAffinity.Required ||
+		colocation == nil || !colocation.Required || taskRole.TaskNumber <= 1 {
+		return nil
+	}
+	antiAffinityKey, colocationKey := antiAffinity.TopologyKey, colocation.TopologyKey
+	if antiAffinityKey == "" {
+		antiAffinityKey = core.LabelHostname
+	}
+	if colocationKey == "" {
+		colocationKey = core.LabelHostname
+	}
+	if antiAffinityKey != colocationKey {
+		return nil
+	}
+	if len(colocation.TaskRoles) > 0 {
+		found := false
+		for _, name := range colocation.TaskRoles {
+			if name == taskRole.Name {
+				found = true
+				break
+			}
+		}
+		if !found {
+			return nil
+		}
+	}
+	return field.ErrorList{field.Invalid(path.Child("required"), true,
+		"should not be required together with the required colocation in the "+
+			"same topology domain, otherwise it can never be satisfied")}
+}
+
+// The Pod template may be completed by the TaskOverrides, so only the sanity
+// of the containers is checked.
+func validatePodTemplate(path *field.Path, pod *core.PodTemplateSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	containersPath := path.Child("spec", "containers")
+	if len(pod.Spec.Containers) == 0 {
+		allErrs = append(allErrs, field.Required(containersPath,
+			"should have at least one container"))
+	}
+
+	containerNames := map[string]bool{}
+	for i, container := range pod.Spec.Containers {
+		namePath := containersPath.Index(i).Child("name")
+		if container.Name == "" {
+			allErrs = append(allErrs, field.Required(namePath, ""))
+		} else if containerNames[container.Name] {
+			allErrs = append(allErrs, field.Duplicate(namePath, container.Name))
+		}
+		containerNames[container.Name] = true
+	}
+
+	return allErrs
+}
+
+// All the dependencies should be on other existing TaskRoles, and there should
+// be no cyclic dependency, otherwise the Tasks will never be created.
+func validateDependencies(
+	path *field.Path, taskRoles []*ci.TaskRoleSpec) field.ErrorList {
+	allErrs := field.ErrorList{}
+	taskRoleIndexes := map[string]int{}
+	for i, taskRole := range taskRoles {
+		taskRoleIndexes[taskRole.Name] = i
+	}
+
+	pendingDepNumbers := map[string]int{}
+	downstreams := map[string][]string{}
+	for i, taskRole := range taskRoles {
+		for j, dep := range taskRole.DependsOn {
+			depPath := path.Index(i).Child("dependsOn").Index(j)
+			if _, ok := taskRoleIndexes[dep]; !ok {
+				allErrs = append(allErrs, field.NotFound(depPath, dep))
+			} else if dep == taskRole.Name {
+				allErrs = append(allErrs, field.Invalid(depPath, dep,
+					"should not depend on itself"))
+			} else {
+				pendingDepNumbers[taskRole.Name]++
+				downstreams[dep] = append(downstreams[dep], taskRole.Name)
+			}
+		}
+	}
+
+	// Kahn's algorithm: the dependencies are acyclic iff all TaskRoles can be
+	// sorted topologically.
+	readyNames := []string{}
+	for _, taskRole := range taskRoles {
+		if pendingDepNumbers[taskRole.Name] == 0 {
+			readyNames = append(readyNames, taskRole.Name)
+		}
+	}
+	for len(readyNames) > 0 {
+		name := readyNames[0]
+		readyNames = readyNames[1:]
+		for _, downstream := range downstreams[name] {
+			pendingDepNumbers[downstream]--
+			if pendingDepNumbers[downstream] == 0 {
+				readyNames = append(readyNames, downstream)
+			}
+		}
+	}
+	for _, taskRole := range taskRoles {
+		if pendingDepNumbers[taskRole.Name] > 0 {
+			allErrs = append(allErrs, field.Invalid(
+				path.Index(taskRoleIndexes[taskRole.Name]).Child("dependsOn"),
+				taskRole.DependsOn, "should not be cyclic"))
+		}
+	}
+
+	return allErrs
+}
@@ -0,0 +1,124 @@
+// MIT License
+//
+// Copyright (c) Microsoft Corporation. All rights reserved.
+//
+// Permission is hereby granted, free of charge, to any person obtaining a copy
+// of this software and associated documentation files (the "Software"), to deal
+// in the Software without restriction, including without limitation the rights
+// to use, copy, modify, merge, publish, distribute, sublicense, and/or sell
+// copies of the Software, and to permit persons to whom the Software is
+// furnished to do so, subject to the following conditions:
+//
+// The above copyright notice and this permission notice shall be included in all
+// copies or substantial portions of the Software.
+//
+// THE SOFTWARE IS PROVIDED "AS IS", WITHOUT WARRANTY OF ANY KIND, EXPRESS OR
+// IMPLIED, INCLUDING BUT NOT LIMITED TO THE WARRANTIES OF MERCHANTABILITY,
+// FITNESS FOR A PARTICULAR PURPOSE AND NONINFRINGEMENT. IN NO EVENT SHALL THE
+// AUTHORS OR COPYRIGHT HOLDERS BE LIABLE FOR ANY CLAIM, DAMAGES OR OTHER
+// LIABILITY, WHETHER IN AN ACTION OF CONTRACT, TORT OR OTHERWISE, ARISING FROM,
+// OUT OF OR IN CONNECTION WITH THE SOFTWARE OR THE USE OR OTHER DEALINGS IN THE
+// SOFTWARE
+
+package validation
+
+import (
+	ci "github.com/microsoft/frameworkcontroller/pkg/apis/frameworkcontroller/v1"
+	"github.com/microsoft/frameworkcontroller/pkg/common"
+	core "k8s.io/api/core/v1"
+	meta "k8s.io/apimachinery/pkg/apis/meta/v1"
+	"k8s.io/apimachinery/pkg/util/validation/field"
+	"reflect"
+	"testing"
+)
+
+func newValidFramework() *ci.Framework {
+	newTaskRole := func(name string) *ci.TaskRoleSpec {
+		return &ci.TaskRoleSpec{
+			Name:       name,
+			TaskNumber: 2,
+			Task: ci.TaskSpec{Pod: core.PodTemplateSpec{Spec: core.PodSpec{
+				Containers: []core.Container{{Name: "main", Image: "busybox"}},
+			}}},
+		}
+	}
+	return &ci.Framework{
+		ObjectMeta: meta.ObjectMeta{Name: "f"},
+		Spec: ci.FrameworkSpec{
+			ExecutionType: ci.ExecutionStart,
+			TaskRoles:     []*ci.TaskRoleSpec{newTaskRole("ps"), newTaskRole("worker")},
+		},
+	}
+}
+
+func TestValidate(t *testing.T) {
+	cases := []struct {
+		name   string
+		mutate func(f *ci.Framework)
+		// The paths of the invalid fields in order.
+		want []string
+	}{
+		{"Valid", func(f *ci.Framework) {}, []string{}},
+		{"InvalidName", func(f *ci.Framework) {
+			f.Name = "F_1"
+		}, []string{"metadata.name"}},
+		{"InvalidExecutionType", func(f *ci.Framework) {
+			f.Spec.ExecutionType = "Pause"
+		}, []string{"spec.executionType"}},
+		{"NoTaskRole", func(f *ci.Framework) {
+			f.Spec.TaskRoles = nil
+		}, []string{"spec.taskRoles"}},
+		{"DuplicateTaskRole", func(f *ci.Framework) {
+			f.Spec.TaskRoles[1].Name = "ps"
+		}, []string{"spec.taskRoles[1].name"}},
+		{"InvalidTaskNumber", func(f *ci.Framework) {
+			f.Spec.TaskRoles[0].TaskNumber = MaxTaskNumber + 1
+		}, []string{"spec.taskRoles[0].taskNumber"}},
+		{"UnsatisfiableCompletionPolicy", func(f *ci.Framework) {
+			f.Spec.TaskRoles[0].FrameworkAttemptCompletionPolicy.MinSucceededTaskCount = 3
+		}, []string{"spec.taskRoles[0].frameworkAttemptCompletionPolicy.minSucceededTaskCount"}},
+		{"InvalidMaxRetryCount", func(f *ci.Framework) {
+			f.Spec.TaskRoles[1].Task.RetryPolicy.MaxRetryCount = -3
+		}, []string{"spec.taskRoles[1].task.retryPolicy.maxRetryCount"}},
+		{"InvalidBackoffPolicy", func(f *ci.Framework) {
+			f.Spec.RetryPolicy.BackoffPolicy = &ci.BackoffPolicySpec{
+				Type: ci.BackoffExponential, BaseDelaySec: 10, MaxDelaySec: 5,
+				JitterPercent: 101}
+		}, []string{
+			"spec.retryPolicy.backoffPolicy.maxDelaySec",
+			"spec.retryPolicy.backoffPolicy.jitterPercent",
+		}},
+		{"NoContainer", func(f *ci.Framework) {
+			f.Spec.TaskRoles[0].Task.Pod.Spec.Containers = nil
+		}, []string{"spec.taskRoles[0].task.pod.spec.containers"}},
+		{"CompletionContainerNotFound", func(f *ci.Framework) {
+			f.Spec.TaskRoles[0].CompletionContainer = "sidecar"
+		}, []string{"spec.taskRoles[0].completionContainer"}},
+		{"HookWithBothPodAndWebhook", func(f *ci.Framework) {
+			f.Spec.Hooks = &ci.FrameworkHooksSpec{PreAttempt: &ci.HookSpec{
+				Pod:        &f.Spec.TaskRoles[0].Task.Pod,
+				Webhook:    &ci.HookWebhookSpec{URL: "http://hook"},
+				TimeoutSec: common.PtrInt64(0),
+			}}
+		}, []string{"spec.hooks.preAttempt", "spec.hooks.preAttempt.timeoutSec"}},
+		{"DependencyNotFound", func(f *ci.Framework) {
+			f.Spec.TaskRoles[1].DependsOn = []string{"chief"}
+		}, []string{"spec.taskRoles[1].dependsOn[0]"}},
+		{"CyclicDependency", func(f *ci.Framework) {
+			f.Spec.TaskRoles[0].DependsOn = []string{"worker"}
+			f.Spec.TaskRoles[1].DependsOn = []string{"ps"}
+		}, []string{"spec.taskRoles[0].dependsOn", "spec.taskRoles[1].dependsOn"}},
+	}
+
+	for _, c := range cases {
+		f := newValidFramework()
+		c.mutate(f)
+		got := []string{}
+		for _, err := range Validate(f) {
+			got = append(got, err.(*field.Error).Field)
+		}
+		if !reflect.DeepEqual(got, c.want) {
+			t.Errorf("%v: got invalid fields %v, want %v", c.name, got, c.want)
+		}
+	}
+}